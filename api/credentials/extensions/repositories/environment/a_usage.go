@@ -0,0 +1,48 @@
+package environment
+
+import (
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to provide credentials given by environment
+variables, for example secrets passed to a CI pipeline. It supports two
+ways to describe credentials.
+
+Environment variables following the naming scheme
+<code>&lt;prefix>&lt;HOST>_&lt;PROPERTY></code> (default prefix
+<code>` + DEFAULT_PREFIX + `</code>) describe credentials for a host.
+<code>&lt;PROPERTY></code> is the upper case form of a credential property,
+with an underscore separating the words of the property name (for example
+<code>USERNAME</code>, <code>IDENTITY_TOKEN</code> or
+<code>AWS_ACCESS_KEY_ID</code>). The following properties are supported:
+` + listformat.FormatList("", Properties...) + `
+In <code>&lt;HOST></code> a single underscore stands for a dot, a double
+underscore for a dash and a triple underscore for the colon separating
+a port. For example, <code>OCM_CRED_GHCR_IO_PASSWORD</code> provides the
+property <code>password</code> for the host <code>ghcr.io</code> and
+<code>OCM_CRED_REGISTRY___5000_USERNAME</code> the property
+<code>username</code> for the host <code>registry:5000</code>.
+These credentials are provided for all configured consumer types with the
+given hostname and can be accessed by the hostname as credential name.
+
+Additionally, the repository specification may explicitly map environment
+variables to credential properties for dedicated consumer identities.
+
+If enabled, the described credentials will be automatically assigned to
+appropriate consumer ids using the identity matchers of the consumer types.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"prefix", "*string*(optional): the prefix for environment variables following the naming scheme",
+	"consumerTypes", "*[]string*(optional): the consumer types credentials are provided for (default: all registered consumer types)",
+	"mappings", "*[]mapping*(optional): explicit mappings of environment variables to consumer identities",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation (default: true)",
+}) + `
+A mapping has the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"name", "*string*(optional): the name used to access the credentials from the repository",
+	"identity", "*map[string]string*: the consumer identity",
+	"variables", "*map[string]string*: the mapping of credential property names to environment variable names",
+})
@@ -0,0 +1,61 @@
+package environment
+
+import (
+	"strings"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+type ConsumerProvider struct {
+	types    []string
+	hosts    map[string]common.Properties
+	mappings []mapping
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(id cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	for host, props := range p.hosts {
+		for _, t := range p.types {
+			id := hostIdentity(t, host)
+			if m(req, cur, id) {
+				creds = cpi.NewCredentials(props)
+				cur = id
+			}
+		}
+	}
+	for _, e := range p.mappings {
+		if m(req, cur, e.id) {
+			creds = cpi.NewCredentials(e.props)
+			cur = e.id
+		}
+	}
+	return creds, cur
+}
+
+// hostIdentity provides the consumer identity for a host
+// with an optional port.
+func hostIdentity(typ, host string) cpi.ConsumerIdentity {
+	if h, port, ok := strings.Cut(host, ":"); ok {
+		return cpi.NewConsumerIdentity(typ, hostpath.ID_HOSTNAME, h, hostpath.ID_PORT, port)
+	}
+	return cpi.NewConsumerIdentity(typ, hostpath.ID_HOSTNAME, host)
+}
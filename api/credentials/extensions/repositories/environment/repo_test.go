@@ -0,0 +1,153 @@
+package environment_test
+
+import (
+	"encoding/json"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	me "ocm.software/ocm/api/credentials/extensions/repositories/environment"
+	s3identity "ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	helmidentity "ocm.software/ocm/api/tech/helm/identity"
+	ociidentity "ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("environment credentials", func() {
+	var ctx credentials.Context
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+
+		GinkgoT().Setenv("OCM_CRED_GHCR_IO_USERNAME", "ocm")
+		GinkgoT().Setenv("OCM_CRED_GHCR_IO_PASSWORD", "ghcr-secret")
+		GinkgoT().Setenv("OCM_CRED_MY__REGISTRY_ACME_ORG_IDENTITY_TOKEN", "acme-token")
+		GinkgoT().Setenv("OCM_CRED_S3_ACME_ORG_AWS_ACCESS_KEY_ID", "key")
+		GinkgoT().Setenv("OCM_CRED_S3_ACME_ORG_AWS_SECRET_ACCESS_KEY", "secret")
+		GinkgoT().Setenv("OCM_CRED_REGISTRY___5000_USERNAME", "local")
+		GinkgoT().Setenv("OCM_CRED_REGISTRY___5000_PASSWORD", "local-secret")
+		GinkgoT().Setenv("CI_HELM_USER", "helm")
+		GinkgoT().Setenv("CI_HELM_PASSWORD", "helm-secret")
+	})
+
+	Context("naming scheme", func() {
+		props := me.Properties
+
+		It("provides variable names", func() {
+			Expect(me.VariableName("username")).To(Equal("USERNAME"))
+			Expect(me.VariableName("identityToken")).To(Equal("IDENTITY_TOKEN"))
+			Expect(me.VariableName("awsAccessKeyID")).To(Equal("AWS_ACCESS_KEY_ID"))
+		})
+
+		It("parses variable names", func() {
+			host, prop := me.ParseVariable("GHCR_IO_USERNAME", props)
+			Expect(host).To(Equal("ghcr.io"))
+			Expect(prop).To(Equal("username"))
+
+			host, prop = me.ParseVariable("MY__REGISTRY_ACME_ORG_IDENTITY_TOKEN", props)
+			Expect(host).To(Equal("my-registry.acme.org"))
+			Expect(prop).To(Equal("identityToken"))
+
+			host, prop = me.ParseVariable("S3_AWS_COM_AWS_ACCESS_KEY_ID", props)
+			Expect(host).To(Equal("s3.aws.com"))
+			Expect(prop).To(Equal("awsAccessKeyID"))
+
+			host, prop = me.ParseVariable("REGISTRY_ACME_ORG___5000_PASSWORD", props)
+			Expect(host).To(Equal("registry.acme.org:5000"))
+			Expect(prop).To(Equal("password"))
+
+			host, prop = me.ParseVariable("MY__REGISTRY___5000_TOKEN", props)
+			Expect(host).To(Equal("my-registry:5000"))
+			Expect(prop).To(Equal("token"))
+
+			host, _ = me.ParseVariable("GHCR_IO_UNKNOWN", props)
+			Expect(host).To(Equal(""))
+			host, _ = me.ParseVariable("USERNAME", props)
+			Expect(host).To(Equal(""))
+		})
+	})
+
+	It("serializes repo spec", func() {
+		spec := me.NewRepositorySpec("", ociidentity.CONSUMER_TYPE).WithMapping(
+			credentials.NewConsumerIdentity(helmidentity.CONSUMER_TYPE, helmidentity.ID_HOSTNAME, "charts.acme.org"),
+			map[string]string{"username": "CI_HELM_USER"}, "helm")
+		data := Must(json.Marshal(spec))
+		Expect(string(data)).To(Equal(`{"type":"Environment","consumerTypes":["OCIRegistry"],"mappings":[{"name":"helm","identity":{"hostname":"charts.acme.org","type":"HelmChartRepository"},"variables":{"username":"CI_HELM_USER"}}]}`))
+
+		spec2 := Must(ctx.RepositorySpecForConfig(data, nil))
+		Expect(spec2).To(Equal(spec))
+	})
+
+	It("retrieves credentials by host and name", func() {
+		spec := me.NewRepositorySpec("").WithMapping(
+			credentials.NewConsumerIdentity(helmidentity.CONSUMER_TYPE, helmidentity.ID_HOSTNAME, "charts.acme.org"),
+			map[string]string{"username": "CI_HELM_USER", "password": "CI_HELM_PASSWORD"}, "helm")
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(repo.LookupCredentials("ghcr.io"))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"username": "ocm",
+			"password": "ghcr-secret",
+		}))
+		creds = Must(repo.LookupCredentials("helm"))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"username": "helm",
+			"password": "helm-secret",
+		}))
+		Expect(repo.ExistsCredentials("other.org")).To(BeFalse())
+	})
+
+	It("propagates credentials for all consumer types", func() {
+		Must(ctx.RepositoryForSpec(me.NewRepositorySpec("")))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, ociidentity.GetConsumerId("ghcr.io/open-component-model/ocm", ""), ociidentity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"username": "ocm",
+			"password": "ghcr-secret",
+		}))
+
+		creds = Must(credentials.CredentialsForConsumer(ctx, credentials.NewConsumerIdentity(helmidentity.CONSUMER_TYPE, helmidentity.ID_HOSTNAME, "my-registry.acme.org"), helmidentity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"identityToken": "acme-token",
+		}))
+
+		creds = Must(credentials.CredentialsForConsumer(ctx, s3identity.GetConsumerId("s3.acme.org", "bucket", "key", ""), s3identity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			s3identity.ATTR_AWS_ACCESS_KEY_ID:     "key",
+			s3identity.ATTR_AWS_SECRET_ACCESS_KEY: "secret",
+		}))
+	})
+
+	It("propagates credentials for hosts with port", func() {
+		repo := Must(ctx.RepositoryForSpec(me.NewRepositorySpec("")))
+
+		props := common.Properties{
+			"username": "local",
+			"password": "local-secret",
+		}
+		Expect(Must(repo.LookupCredentials("registry:5000")).Properties()).To(Equal(props))
+		creds := Must(credentials.CredentialsForConsumer(ctx, ociidentity.GetConsumerId("registry:5000/ocm", ""), ociidentity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(props))
+	})
+
+	It("restricts consumer types", func() {
+		Must(ctx.RepositoryForSpec(me.NewRepositorySpec("", ociidentity.CONSUMER_TYPE)))
+
+		Expect(credentials.CredentialsForConsumer(ctx, ociidentity.GetConsumerId("ghcr.io/ocm", ""), ociidentity.IdentityMatcher)).NotTo(BeNil())
+		Expect(credentials.CredentialsForConsumer(ctx, credentials.NewConsumerIdentity(helmidentity.CONSUMER_TYPE, helmidentity.ID_HOSTNAME, "ghcr.io"), helmidentity.IdentityMatcher)).To(BeNil())
+	})
+
+	It("propagates mapped credentials", func() {
+		Must(ctx.RepositoryForSpec(me.NewRepositorySpec("NONE_").WithMapping(
+			credentials.NewConsumerIdentity(helmidentity.CONSUMER_TYPE, helmidentity.ID_HOSTNAME, "charts.acme.org", helmidentity.ID_PORT, "8443"),
+			map[string]string{"username": "CI_HELM_USER", "password": "CI_HELM_PASSWORD", "certificate": "CI_UNKNOWN"})))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, credentials.NewConsumerIdentity(helmidentity.CONSUMER_TYPE, helmidentity.ID_HOSTNAME, "charts.acme.org", helmidentity.ID_PORT, "8443"), helmidentity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"username": "helm",
+			"password": "helm-secret",
+		}))
+	})
+})
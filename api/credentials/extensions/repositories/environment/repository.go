@@ -0,0 +1,212 @@
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+type Repository struct {
+	lock      sync.RWMutex
+	ctx       cpi.Context
+	spec      *RepositorySpec
+	propagate bool
+
+	hosts    map[string]common.Properties
+	mappings []mapping
+}
+
+type mapping struct {
+	name  string
+	id    cpi.ConsumerIdentity
+	props common.Properties
+}
+
+func NewRepository(ctx cpi.Context, spec *RepositorySpec) (*Repository, error) {
+	r := &Repository{
+		ctx:       datacontext.InternalContextRef(ctx),
+		spec:      spec,
+		propagate: utils.AsBool(spec.PropagateConsumerIdentity, true),
+	}
+	err := r.Read(true)
+	return r, err
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	err := r.Read(false)
+	if err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.lookup(name) != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	err := r.Read(false)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	props := r.lookup(name)
+	if props == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return cpi.NewCredentials(props), nil
+}
+
+func (r *Repository) lookup(name string) common.Properties {
+	for _, m := range r.mappings {
+		if m.name == name {
+			return m.props
+		}
+	}
+	return r.hosts[name]
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+// Read evaluates the environment according to the repository specification.
+func (r *Repository) Read(force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !force && r.hosts != nil {
+		return nil
+	}
+
+	types := r.spec.ConsumerTypes
+	if len(types) == 0 {
+		for _, i := range r.ctx.ConsumerIdentityMatchers().List() {
+			if i.IsConsumerType() {
+				types = append(types, i.Type)
+			}
+		}
+	}
+
+	hosts := map[string]common.Properties{}
+	prefix := r.spec.GetPrefix()
+	for _, e := range os.Environ() {
+		name, value, ok := strings.Cut(e, "=")
+		if !ok || value == "" || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		host, prop := ParseVariable(name[len(prefix):], Properties)
+		if host == "" {
+			continue
+		}
+		if hosts[host] == nil {
+			hosts[host] = common.Properties{}
+		}
+		hosts[host][prop] = value
+	}
+
+	var mappings []mapping
+	for i, m := range r.spec.Mappings {
+		if len(m.Identity) == 0 {
+			return errors.ErrInvalid("mapping", "identity", fmt.Sprintf("%d", i))
+		}
+		props := common.Properties{}
+		for p, v := range m.Variables {
+			props.SetNonEmptyValue(p, os.Getenv(v))
+		}
+		if len(props) > 0 {
+			mappings = append(mappings, mapping{m.Name, m.Identity, props})
+		}
+	}
+
+	if r.propagate {
+		data, err := json.Marshal(r.spec)
+		if err != nil {
+			return err
+		}
+		id := cpi.ProviderIdentity(PROVIDER + "/" + digest.FromBytes(data).Encoded())
+		r.ctx.RegisterConsumerProvider(id, &ConsumerProvider{types: types, hosts: hosts, mappings: mappings})
+	}
+	r.hosts = hosts
+	r.mappings = mappings
+	return nil
+}
+
+// Properties is the list of credential property names
+// supported by the naming scheme for host credentials.
+var Properties = []string{
+	cpi.ATTR_USERNAME,
+	cpi.ATTR_PASSWORD,
+	cpi.ATTR_EMAIL,
+	cpi.ATTR_TOKEN,
+	cpi.ATTR_IDENTITY_TOKEN,
+	cpi.ATTR_CERTIFICATE_AUTHORITY,
+	cpi.ATTR_CERTIFICATE,
+	cpi.ATTR_PRIVATE_KEY,
+
+	// S3
+	"awsAccessKeyID",
+	"awsSecretAccessKey",
+
+	// Azure
+	"accountKey",
+	"sasToken",
+	"clientId",
+	"clientSecret",
+	"tenantId",
+}
+
+// VariableName provides the environment variable name
+// part for a credential property name.
+func VariableName(prop string) string {
+	var b strings.Builder
+	var last rune
+	for i, c := range prop {
+		if i > 0 && unicode.IsUpper(c) && !unicode.IsUpper(last) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(c))
+		last = c
+	}
+	return b.String()
+}
+
+// ParseVariable splits an environment variable name without prefix
+// into the host and the credential property name.
+// In the host part, a triple underscore stands for a colon separating
+// the port, a double underscore for a dash and a single underscore
+// for a dot.
+// The property part must match one of the given property names.
+// If there are multiple matches, the longest one is used.
+// If no property matches, an empty host is returned.
+func ParseVariable(name string, props []string) (string, string) {
+	prop := ""
+	suffix := ""
+	for _, p := range props {
+		s := "_" + VariableName(p)
+		if len(s) > len(suffix) && len(name) > len(s) && strings.HasSuffix(name, s) {
+			prop, suffix = p, s
+		}
+	}
+	if prop == "" {
+		return "", ""
+	}
+	host := strings.ToLower(name[:len(name)-len(suffix)])
+	host = strings.ReplaceAll(host, "___", ":")
+	host = strings.ReplaceAll(host, "__", "-")
+	host = strings.ReplaceAll(host, "_", ".")
+	return host, prop
+}
@@ -0,0 +1,89 @@
+package environment
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Type   = "Environment"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+// DEFAULT_PREFIX is the default prefix of environment variables
+// describing credentials for hosts.
+const DEFAULT_PREFIX = "OCM_CRED_"
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes a credential repository based on
+// environment variables.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	// Prefix is the prefix of environment variables following the
+	// naming scheme <prefix><host>_<property>. If not set, DEFAULT_PREFIX is used.
+	Prefix string `json:"prefix,omitempty"`
+	// ConsumerTypes restricts the consumer types credentials described by
+	// the naming scheme are provided for. If not set, all registered
+	// consumer types are used.
+	ConsumerTypes []string `json:"consumerTypes,omitempty"`
+	// Mappings explicitly map environment variables to credential properties
+	// for dedicated consumer identities.
+	Mappings                  []Mapping `json:"mappings,omitempty"`
+	PropagateConsumerIdentity *bool     `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// Mapping describes the credential properties for a consumer identity
+// taken from environment variables.
+type Mapping struct {
+	// Name is an optional name used to access the credentials
+	// from the repository.
+	Name     string               `json:"name,omitempty"`
+	Identity cpi.ConsumerIdentity `json:"identity"`
+	// Variables maps credential property names to environment variable names.
+	Variables map[string]string `json:"variables"`
+}
+
+// NewRepositorySpec creates a new environment RepositorySpec.
+func NewRepositorySpec(prefix string, types ...string) *RepositorySpec {
+	return &RepositorySpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Prefix:              prefix,
+		ConsumerTypes:       types,
+	}
+}
+
+func (s RepositorySpec) WithMapping(id cpi.ConsumerIdentity, vars map[string]string, name ...string) *RepositorySpec {
+	m := Mapping{
+		Identity:  id,
+		Variables: vars,
+	}
+	if len(name) > 0 {
+		m.Name = name[0]
+	}
+	s.Mappings = append(append([]Mapping{}, s.Mappings...), m)
+	return &s
+}
+
+func (s RepositorySpec) WithConsumerPropagation(propagate bool) *RepositorySpec {
+	s.PropagateConsumerIdentity = &propagate
+	return &s
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) GetPrefix() string {
+	if a.Prefix == "" {
+		return DEFAULT_PREFIX
+	}
+	return a.Prefix
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	return NewRepository(ctx, a)
+}
@@ -0,0 +1,50 @@
+package oidc
+
+import (
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to provide short-lived credentials for OCI
+registries accepting OIDC-federated tokens, for example, in CI pipelines
+using a workload identity instead of long-lived passwords.
+
+An OIDC token is taken from the configured token source and exchanged at a
+token endpoint following the OAuth 2.0 token exchange (RFC 8693). The
+endpoint must return a JSON document with the fields <code>access_token</code>
+(or <code>token</code>) and optionally <code>expires_in</code>. If no expiry
+is returned, the expiry of the token is used, if it is a JWT, or a default
+lifetime of one minute. The exchanged token is cached and transparently
+exchanged again shortly before it expires.
+
+If a username is configured, the token is provided as password together with
+this username. Otherwise, it is provided as <code>accessToken</code> property,
+which is directly used as bearer token for the registry access.
+
+The credentials are provided for the <code>OCIRegistry</code> consumer ids
+of the configured registries and can be accessed by the registry locator as
+credential name. If enabled, they will be automatically assigned to
+appropriate consumer ids.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"source", "*source*: the source of the OIDC token",
+	"tokenEndpoint", "*string*: the URL of the token exchange endpoint",
+	"audience", "*string*(optional): the audience requested for the exchanged token",
+	"scope", "*string*(optional): the scope requested for the exchanged token",
+	"username", "*string*(optional): the username provided together with the exchanged token",
+	"registries", "*[]string*: the OCI registry locators (<code>host[:port][/prefix]</code>) the credentials are provided for",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation (default: true)",
+}) + `
+A token source has the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"type", "*string*: the source type, one of <code>" + SOURCE_FILE + "</code>, <code>" + SOURCE_ENV + "</code> or <code>" + SOURCE_GITHUB + "</code>",
+	"path", "*string*: the path of the token file for type <code>" + SOURCE_FILE + "</code> (read on every exchange)",
+	"variable", "*string*: the environment variable containing the token for type <code>" + SOURCE_ENV + "</code>",
+	"audience", "*string*(optional): the audience requested from the GitHub Actions ID token endpoint for type <code>" + SOURCE_GITHUB + "</code>",
+}) + `
+For type <code>` + SOURCE_GITHUB + `</code> the token is requested from the endpoint given by the
+environment variables <code>` + ENV_GITHUB_TOKEN_REQUEST_URL + `</code> and
+<code>` + ENV_GITHUB_TOKEN_REQUEST_TOKEN + `</code> (requires the <code>id-token: write</code> permission).
+`
@@ -0,0 +1,38 @@
+package oidc
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+type ConsumerProvider struct {
+	ids    []cpi.ConsumerIdentity
+	source cpi.CredentialsSource
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(id cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	for _, id := range p.ids {
+		if m(req, cur, id) {
+			creds = p.source
+			cur = id
+		}
+	}
+	return creds, cur
+}
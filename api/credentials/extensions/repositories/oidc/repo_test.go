@@ -0,0 +1,186 @@
+package oidc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/credentials"
+	me "ocm.software/ocm/api/credentials/extensions/repositories/oidc"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	OIDC_TOKEN      = "eyJhbGciOiJSUzI1NiJ9.oidc.signature"
+	GITHUB_TOKEN    = "github-request-token"
+	GITHUB_AUDIENCE = "ghcr.io"
+)
+
+// fakeIssuer provides a GitHub Actions ID token endpoint
+// and a token exchange endpoint.
+type fakeIssuer struct {
+	lock      sync.Mutex
+	ttl       int
+	exchanges int
+	audience  string
+}
+
+func (f *fakeIssuer) Exchanges() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.exchanges
+}
+
+func (f *fakeIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/github":
+		if r.Header.Get("Authorization") != "Bearer "+GITHUB_TOKEN {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.audience = r.URL.Query().Get("audience")
+		json.NewEncoder(w).Encode(map[string]interface{}{"value": OIDC_TOKEN})
+	case "/token":
+		if r.ParseForm() != nil ||
+			r.PostForm.Get("grant_type") != me.GRANT_TYPE_TOKEN_EXCHANGE ||
+			r.PostForm.Get("subject_token") != OIDC_TOKEN {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		f.exchanges++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":      "registry-token-" + strconv.Itoa(f.exchanges),
+			"issued_token_type": me.TOKEN_TYPE_ACCESS_TOKEN,
+			"expires_in":        f.ttl,
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("oidc token exchange", func() {
+	var ctx credentials.Context
+	var issuer *fakeIssuer
+	var server *httptest.Server
+	var tokenfile string
+
+	consumer := identity.GetConsumerId("ghcr.io/acme", "repo")
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+		issuer = &fakeIssuer{ttl: 3600}
+		server = httptest.NewServer(issuer)
+
+		tokenfile = filepath.Join(GinkgoT().TempDir(), "token")
+		MustBeSuccessful(os.WriteFile(tokenfile, []byte(OIDC_TOKEN+"\n"), 0o600))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("provides exchanged token for registry consumers", func() {
+		spec := me.NewRepositorySpec(server.URL+"/token", me.FileTokenSource(tokenfile), "ghcr.io/acme").WithUsername("oidc")
+		Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, consumer, identity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			credentials.ATTR_USERNAME: "oidc",
+			credentials.ATTR_PASSWORD: "registry-token-1",
+		}))
+
+		creds = Must(credentials.CredentialsForConsumer(ctx, consumer, identity.IdentityMatcher))
+		Expect(creds.GetProperty(credentials.ATTR_PASSWORD)).To(Equal("registry-token-1"))
+		Expect(issuer.Exchanges()).To(Equal(1))
+
+		Expect(credentials.CredentialsForConsumer(ctx, identity.GetConsumerId("ghcr.io/other", "repo"), identity.IdentityMatcher)).To(BeNil())
+	})
+
+	It("reads the token file from the filesystem of the context", func() {
+		fs := memoryfs.New()
+		MustBeSuccessful(fs.MkdirAll("/var/run", 0o700))
+		MustBeSuccessful(vfs.WriteFile(fs, "/var/run/token", []byte(OIDC_TOKEN+"\n"), 0o600))
+		vfsattr.Set(ctx, fs)
+
+		spec := me.NewRepositorySpec(server.URL+"/token", me.FileTokenSource("/var/run/token"), "ghcr.io")
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(repo.LookupCredentials("ghcr.io"))
+		Expect(creds.GetProperty(identity.ATTR_ACCESS_TOKEN)).To(Equal("registry-token-1"))
+	})
+
+	It("refreshes expired tokens", func() {
+		issuer.ttl = 1
+		spec := me.NewRepositorySpec(server.URL+"/token", me.EnvTokenSource("OIDC_TOKEN"), "ghcr.io")
+		GinkgoT().Setenv("OIDC_TOKEN", OIDC_TOKEN)
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(repo.LookupCredentials("ghcr.io"))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			identity.ATTR_ACCESS_TOKEN: "registry-token-1",
+		}))
+
+		time.Sleep(time.Second)
+		creds = Must(credentials.CredentialsForConsumer(ctx, consumer, identity.IdentityMatcher))
+		Expect(creds.GetProperty(identity.ATTR_ACCESS_TOKEN)).To(Equal("registry-token-2"))
+		Expect(issuer.Exchanges()).To(Equal(2))
+	})
+
+	It("uses the GitHub Actions ID token", func() {
+		GinkgoT().Setenv(me.ENV_GITHUB_TOKEN_REQUEST_URL, server.URL+"/github?api-version=2.0")
+		GinkgoT().Setenv(me.ENV_GITHUB_TOKEN_REQUEST_TOKEN, GITHUB_TOKEN)
+		spec := me.NewRepositorySpec(server.URL+"/token", me.GitHubTokenSource(GITHUB_AUDIENCE), "ghcr.io")
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		creds := Must(repo.LookupCredentials("ghcr.io"))
+		Expect(creds.GetProperty(identity.ATTR_ACCESS_TOKEN)).To(Equal("registry-token-1"))
+		Expect(issuer.audience).To(Equal(GITHUB_AUDIENCE))
+	})
+
+	It("reports failed exchanges", func() {
+		MustBeSuccessful(os.WriteFile(tokenfile, []byte("invalid"), 0o600))
+		spec := me.NewRepositorySpec(server.URL+"/token", me.FileTokenSource(tokenfile), "ghcr.io")
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		ExpectError(repo.LookupCredentials("ghcr.io")).To(MatchError(ContainSubstring("invalid_grant")))
+		ExpectError(repo.LookupCredentials("other.io")).To(MatchError(ContainSubstring("other.io")))
+	})
+
+	It("validates the specification", func() {
+		ExpectError(ctx.RepositoryForSpec(me.NewRepositorySpec(server.URL, me.FileTokenSource("")))).To(MatchError(`field "path" required for token source file`))
+		ExpectError(ctx.RepositoryForSpec(me.NewRepositorySpec(server.URL, me.GitHubTokenSource("")))).To(MatchError(`field "registries" required for ` + me.Type))
+	})
+
+	It("deserializes the specification", func() {
+		data := `
+type: OIDCTokenExchange
+tokenEndpoint: https://auth.acme.org/token
+source:
+  type: github
+  audience: acme
+registries:
+  - ghcr.io/acme
+`
+		spec := Must(ctx.RepositorySpecForConfig([]byte(data), nil))
+		Expect(spec).To(Equal(me.NewRepositorySpec("https://auth.acme.org/token", me.GitHubTokenSource("acme"), "ghcr.io/acme")))
+	})
+})
@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/tech/oci/identity"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// DEFAULT_TIMEOUT is the timeout used for requests to the
+// OIDC token source and the token endpoint.
+const DEFAULT_TIMEOUT = 30 * time.Second
+
+type Repository struct {
+	lock      sync.Mutex
+	ctx       cpi.Context
+	spec      *RepositorySpec
+	propagate bool
+	client    *http.Client
+
+	token    *Token
+	lifetime time.Duration
+}
+
+func NewRepository(ctx cpi.Context, spec *RepositorySpec) (*Repository, error) {
+	if spec.TokenEndpoint == "" {
+		return nil, errors.ErrRequired("field", "tokenEndpoint", Type)
+	}
+	if err := spec.Source.Validate(); err != nil {
+		return nil, err
+	}
+	if len(spec.Registries) == 0 {
+		return nil, errors.ErrRequired("field", "registries", Type)
+	}
+	r := &Repository{
+		ctx:       datacontext.InternalContextRef(ctx),
+		spec:      spec,
+		propagate: utils.AsBool(spec.PropagateConsumerIdentity, true),
+		client:    &http.Client{Timeout: DEFAULT_TIMEOUT},
+	}
+	if r.propagate {
+		data, err := json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+		var ids []cpi.ConsumerIdentity
+		for _, reg := range spec.Registries {
+			ids = append(ids, identity.GetConsumerId(reg, ""))
+		}
+		id := cpi.ProviderIdentity(PROVIDER + "/" + digest.FromBytes(data).Encoded())
+		r.ctx.RegisterConsumerProvider(id, &ConsumerProvider{ids: ids, source: &credentialsSource{r}})
+	}
+	return r, nil
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	return slices.Contains(r.spec.Registries, name), nil
+}
+
+// LookupCredentials provides the actual credentials for
+// one of the configured registries.
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	if !slices.Contains(r.spec.Registries, name) {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return r.Credentials()
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+// Credentials provides credentials based on the actual exchanged token.
+// The token is cached and exchanged again shortly before it expires.
+func (r *Repository) Credentials() (cpi.Credentials, error) {
+	token, err := r.getToken()
+	if err != nil {
+		return nil, err
+	}
+	props := common.Properties{}
+	if r.spec.Username != "" {
+		props[cpi.ATTR_USERNAME] = r.spec.Username
+		props[cpi.ATTR_PASSWORD] = token
+	} else {
+		props[identity.ATTR_ACCESS_TOKEN] = token
+	}
+	return cpi.NewCredentials(props), nil
+}
+
+func (r *Repository) getToken() (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.token.Valid(time.Now(), r.lifetime) {
+		return r.token.Value, nil
+	}
+
+	ctx := context.Background()
+	subject, err := r.spec.Source.GetToken(ctx, vfsattr.Get(r.ctx), r.client)
+	if err != nil {
+		return "", err
+	}
+	token, lifetime, err := Exchange(ctx, r.client, r.spec.TokenEndpoint, subject, r.spec.Audience, r.spec.Scope)
+	if err != nil {
+		return "", err
+	}
+	r.token = token
+	r.lifetime = lifetime
+	return token.Value, nil
+}
+
+// credentialsSource provides the actual credentials of the
+// repository whenever credentials are requested.
+type credentialsSource struct {
+	repo *Repository
+}
+
+var _ cpi.CredentialsSource = (*credentialsSource)(nil)
+
+func (s *credentialsSource) Credentials(cpi.Context, ...cpi.CredentialsSource) (cpi.Credentials, error) {
+	return s.repo.Credentials()
+}
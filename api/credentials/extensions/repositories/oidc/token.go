@@ -0,0 +1,242 @@
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/utils"
+)
+
+const (
+	SOURCE_FILE   = "file"
+	SOURCE_ENV    = "env"
+	SOURCE_GITHUB = "github"
+)
+
+const (
+	// ENV_GITHUB_TOKEN_REQUEST_URL is the environment variable provided
+	// by GitHub Actions containing the URL of the ID token endpoint.
+	ENV_GITHUB_TOKEN_REQUEST_URL = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	// ENV_GITHUB_TOKEN_REQUEST_TOKEN is the environment variable provided
+	// by GitHub Actions containing the bearer token for the ID token endpoint.
+	ENV_GITHUB_TOKEN_REQUEST_TOKEN = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+const (
+	GRANT_TYPE_TOKEN_EXCHANGE = "urn:ietf:params:oauth:grant-type:token-exchange"
+	TOKEN_TYPE_JWT            = "urn:ietf:params:oauth:token-type:jwt"
+	TOKEN_TYPE_ACCESS_TOKEN   = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// DEFAULT_LIFETIME is the lifetime assumed for an exchanged token
+// if neither the token endpoint nor the token itself provide an expiry.
+const DEFAULT_LIFETIME = time.Minute
+
+// MAX_REFRESH_MARGIN is the maximum time before the expiry of an exchanged
+// token, a new token is requested.
+const MAX_REFRESH_MARGIN = 30 * time.Second
+
+func (s *TokenSource) Validate() error {
+	switch s.Type {
+	case SOURCE_FILE:
+		if s.Path == "" {
+			return errors.ErrRequired("field", "path", "token source "+s.Type)
+		}
+	case SOURCE_ENV:
+		if s.Variable == "" {
+			return errors.ErrRequired("field", "variable", "token source "+s.Type)
+		}
+	case SOURCE_GITHUB:
+	case "":
+		return errors.ErrRequired("token source type")
+	default:
+		return errors.ErrNotSupported("token source type", s.Type)
+	}
+	return nil
+}
+
+// GetToken provides the actual OIDC token of the source.
+// Token files are read from the given filesystem on every call,
+// because they may be rotated.
+func (s *TokenSource) GetToken(ctx context.Context, fs vfs.FileSystem, client *http.Client) (string, error) {
+	switch s.Type {
+	case SOURCE_FILE:
+		data, err := utils.ReadFile(s.Path, fs)
+		if err != nil {
+			return "", errors.Wrapf(err, "cannot read OIDC token file %q", s.Path)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case SOURCE_ENV:
+		token := os.Getenv(s.Variable)
+		if token == "" {
+			return "", errors.Newf("environment variable %q for OIDC token not set", s.Variable)
+		}
+		return strings.TrimSpace(token), nil
+	case SOURCE_GITHUB:
+		return s.getGitHubToken(ctx, client)
+	default:
+		return "", errors.ErrNotSupported("token source type", s.Type)
+	}
+}
+
+func (s *TokenSource) getGitHubToken(ctx context.Context, client *http.Client) (string, error) {
+	u := os.Getenv(ENV_GITHUB_TOKEN_REQUEST_URL)
+	t := os.Getenv(ENV_GITHUB_TOKEN_REQUEST_TOKEN)
+	if u == "" || t == "" {
+		return "", errors.Newf("GitHub Actions ID token endpoint not available (missing id-token permission?)")
+	}
+	if s.Audience != "" {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid GitHub Actions ID token URL")
+		}
+		q := parsed.Query()
+		q.Set("audience", s.Audience)
+		parsed.RawQuery = q.Encode()
+		u = parsed.String()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+t)
+	req.Header.Set("Accept", "application/json")
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	err = doJSON(client, req, &result)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot get GitHub Actions ID token")
+	}
+	if result.Value == "" {
+		return "", errors.Newf("no GitHub Actions ID token returned")
+	}
+	return result.Value, nil
+}
+
+// Token is an exchanged token.
+type Token struct {
+	Value  string
+	Expiry time.Time
+}
+
+// Valid reports whether the token can still be used at the given time.
+// A token is refreshed ahead of its expiry.
+func (t *Token) Valid(now time.Time, lifetime time.Duration) bool {
+	if t == nil {
+		return false
+	}
+	margin := lifetime / 10
+	if margin > MAX_REFRESH_MARGIN {
+		margin = MAX_REFRESH_MARGIN
+	}
+	return now.Add(margin).Before(t.Expiry)
+}
+
+// Exchange exchanges an OIDC token for a token of the token endpoint
+// following the OAuth 2.0 token exchange (RFC 8693).
+func Exchange(ctx context.Context, client *http.Client, endpoint, subject, audience, scope string) (*Token, time.Duration, error) {
+	form := url.Values{}
+	form.Set("grant_type", GRANT_TYPE_TOKEN_EXCHANGE)
+	form.Set("subject_token", subject)
+	form.Set("subject_token_type", TOKEN_TYPE_JWT)
+	form.Set("requested_token_type", TOKEN_TYPE_ACCESS_TOKEN)
+	if audience != "" {
+		form.Set("audience", audience)
+	}
+	if scope != "" {
+		form.Set("scope", scope)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		Token       string `json:"token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	now := time.Now()
+	err = doJSON(client, req, &result)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "token exchange at %s failed", endpoint)
+	}
+	t := &Token{Value: result.AccessToken}
+	if t.Value == "" {
+		t.Value = result.Token
+	}
+	if t.Value == "" {
+		return nil, 0, errors.Newf("no token returned by %s", endpoint)
+	}
+
+	lifetime := time.Duration(result.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		if exp := jwtExpiry(t.Value); !exp.IsZero() {
+			lifetime = exp.Sub(now)
+		}
+	}
+	if lifetime <= 0 {
+		lifetime = DEFAULT_LIFETIME
+	}
+	t.Expiry = now.Add(lifetime)
+	return t, lifetime, nil
+}
+
+// jwtExpiry provides the expiry of a JWT without verifying it.
+// If the token is no JWT or has no expiry, the zero time is returned.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(data, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+func doJSON(client *http.Client, req *http.Request, result interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(data))
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		if msg == "" {
+			return errors.Newf("status %s", resp.Status)
+		}
+		return errors.Newf("status %s: %s", resp.Status, msg)
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Type   = "OIDCTokenExchange"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes a credential repository providing short-lived
+// registry tokens exchanged for an OIDC token of a workload identity.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	// Source describes where to get the OIDC token from.
+	Source TokenSource `json:"source"`
+	// TokenEndpoint is the URL of the token exchange endpoint.
+	TokenEndpoint string `json:"tokenEndpoint"`
+	// Audience is the optional audience requested for the exchanged token.
+	Audience string `json:"audience,omitempty"`
+	// Scope is the optional scope requested for the exchanged token.
+	Scope string `json:"scope,omitempty"`
+	// Username is the username provided together with the exchanged token.
+	// If not set, the token is provided as identity token.
+	Username string `json:"username,omitempty"`
+	// Registries are the OCI registry locators (host[:port][/prefix])
+	// the exchanged token is provided for.
+	Registries                []string `json:"registries"`
+	PropagateConsumerIdentity *bool    `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// TokenSource describes the source of an OIDC token.
+type TokenSource struct {
+	// Type is the source type (file, env or github).
+	Type string `json:"type"`
+	// Path is the path of the token file for source type file.
+	Path string `json:"path,omitempty"`
+	// Variable is the environment variable for source type env.
+	Variable string `json:"variable,omitempty"`
+	// Audience is the audience requested from the GitHub Actions
+	// token endpoint for source type github.
+	Audience string `json:"audience,omitempty"`
+}
+
+// FileTokenSource describes an OIDC token read from a file, for example,
+// a projected service account token.
+func FileTokenSource(path string) TokenSource {
+	return TokenSource{Type: SOURCE_FILE, Path: path}
+}
+
+// EnvTokenSource describes an OIDC token given by an environment variable.
+func EnvTokenSource(name string) TokenSource {
+	return TokenSource{Type: SOURCE_ENV, Variable: name}
+}
+
+// GitHubTokenSource describes an OIDC token requested from the
+// GitHub Actions ID token endpoint.
+func GitHubTokenSource(audience string) TokenSource {
+	return TokenSource{Type: SOURCE_GITHUB, Audience: audience}
+}
+
+// NewRepositorySpec creates a new OIDC token exchange RepositorySpec.
+func NewRepositorySpec(endpoint string, src TokenSource, registries ...string) *RepositorySpec {
+	return &RepositorySpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Source:              src,
+		TokenEndpoint:       endpoint,
+		Registries:          registries,
+	}
+}
+
+func (s RepositorySpec) WithUsername(name string) *RepositorySpec {
+	s.Username = name
+	return &s
+}
+
+func (s RepositorySpec) WithAudience(audience string) *RepositorySpec {
+	s.Audience = audience
+	return &s
+}
+
+func (s RepositorySpec) WithScope(scope string) *RepositorySpec {
+	s.Scope = scope
+	return &s
+}
+
+func (s RepositorySpec) WithConsumerPropagation(propagate bool) *RepositorySpec {
+	s.PropagateConsumerIdentity = &propagate
+	return &s
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	return NewRepository(ctx, a)
+}
@@ -0,0 +1,53 @@
+package sops
+
+import (
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to access credentials stored in a
+<a href="https://getsops.io">SOPS</a> encrypted YAML or JSON file. This way
+credentials can be kept in a version control system without exposing
+plain secrets.
+
+Only a subset of the SOPS file format is supported:
+- the data key must be encrypted for age recipients. Other key management
+  services, key groups and Shamir secret sharing are not supported.
+- values must be encrypted with <code>AES256_GCM</code>.
+- the file must contain a message authentication code, which is always
+  verified.
+
+The decrypted document has the following structure:
+
+<pre>
+consumers:
+  - identity:
+      type: OCIRegistry
+      hostname: ghcr.io
+    credentials:
+      username: ocm
+      password: secret
+credentials:
+  mycreds:
+    token: secret
+</pre>
+
+The field <code>consumers</code> maps consumer identities to credential
+properties. If enabled, those credentials are automatically assigned to
+matching consumer ids using the identity matchers of the consumer types.
+The field <code>credentials</code> describes named credentials, which can
+be used as credential source with the repository.
+
+The age identities used to decrypt the file are taken from the configured
+key file, the environment variable <code>SOPS_AGE_KEY</code> (identities),
+the file described by the environment variable <code>SOPS_AGE_KEY_FILE</code>
+and the SOPS default key file <code>sops/age/keys.txt</code> in the user
+configuration directory.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"credentialsFile", "*string*: the file path to a SOPS encrypted credentials file",
+	"ageKeyFile", "*string*(optional): the file path to a file containing age identities",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation",
+})
@@ -0,0 +1,38 @@
+package sops
+
+import (
+	"sync"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+)
+
+const ATTR_REPOS = "ocm.software/ocm/api/credentials/extensions/repositories/sops"
+
+type Repositories struct {
+	lock  sync.Mutex
+	repos map[string]*Repository
+}
+
+func newRepositories(datacontext.Context) interface{} {
+	return &Repositories{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Repositories) GetRepository(ctx cpi.Context, path, keyfile string, propagate bool) (*Repository, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := path + ":" + keyfile
+	repo := r.repos[key]
+	if repo == nil {
+		var err error
+		repo, err = NewRepository(ctx, path, keyfile, propagate)
+		if err != nil {
+			return nil, err
+		}
+		r.repos[key] = repo
+	}
+	return repo, nil
+}
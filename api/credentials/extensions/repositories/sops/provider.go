@@ -0,0 +1,37 @@
+package sops
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+type ConsumerProvider struct {
+	consumers []ConsumerSpec
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(id cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	for _, c := range p.consumers {
+		if m(req, cur, c.Identity) {
+			creds = cpi.NewCredentials(c.Credentials)
+			cur = c.Identity
+		}
+	}
+	return creds, cur
+}
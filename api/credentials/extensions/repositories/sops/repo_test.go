@@ -0,0 +1,134 @@
+package sops_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"filippo.io/age"
+	. "github.com/mandelsoft/goutils/testutils"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	local "ocm.software/ocm/api/credentials/extensions/repositories/sops"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	ociidentity "ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("sops credentials", func() {
+	var DefaultContext credentials.Context
+
+	BeforeEach(func() {
+		DefaultContext = credentials.New()
+	})
+
+	specdata := "{\"type\":\"SOPSCredentials\",\"credentialsFile\":\"testdata/credentials.enc.yaml\",\"ageKeyFile\":\"testdata/keys.txt\"}"
+
+	It("serializes repo spec", func() {
+		spec := local.NewRepositorySpec("testdata/credentials.enc.yaml").WithAgeKeyFile("testdata/keys.txt")
+		data := Must(json.Marshal(spec))
+		Expect(data).To(Equal([]byte(specdata)))
+	})
+
+	It("deserializes repo spec", func() {
+		spec := Must(DefaultContext.RepositorySpecForConfig([]byte(specdata), nil))
+		Expect(reflect.TypeOf(spec).String()).To(Equal("*sops.RepositorySpec"))
+		Expect(spec.(*local.RepositorySpec).CredentialsFile).To(Equal("testdata/credentials.enc.yaml"))
+	})
+
+	// The test files are encrypted with sops 3.13.3 for the age key in testdata/keys.txt:
+	//   sops encrypt --age <recipient> --encrypted-regex '^credentials$' credentials.yaml
+	DescribeTable("retrieves named credentials", func(file string) {
+		spec := local.NewRepositorySpec(file).WithAgeKeyFile("testdata/keys.txt")
+		repo := Must(DefaultContext.RepositoryForSpec(spec))
+
+		creds := Must(repo.LookupCredentials("mycreds"))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"token":    "my-token",
+			"insecure": "true",
+		}))
+		Expect(repo.ExistsCredentials("other")).To(BeFalse())
+	},
+		Entry("yaml", "testdata/credentials.enc.yaml"),
+		Entry("json", "testdata/credentials.enc.json"),
+		Entry("fully encrypted yaml", "testdata/credentials.full.enc.yaml"),
+	)
+
+	DescribeTable("propagates credentials to consumer identities", func(file string) {
+		Must(DefaultContext.RepositoryForSpec(local.NewRepositorySpec(file).WithAgeKeyFile("testdata/keys.txt")))
+
+		creds := Must(credentials.CredentialsForConsumer(DefaultContext, credentials.ConsumerIdentity{
+			credentials.ID_TYPE:       ociidentity.CONSUMER_TYPE,
+			ociidentity.ID_HOSTNAME:   "ghcr.io",
+			ociidentity.ID_PATHPREFIX: "open-component-model/ocm",
+		}, ociidentity.IdentityMatcher))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"username": "ocm",
+			"password": "ghcr-secret",
+		}))
+
+		creds = Must(credentials.CredentialsForConsumer(DefaultContext, credentials.ConsumerIdentity{
+			credentials.ID_TYPE:     "HelmChartRepository",
+			ociidentity.ID_HOSTNAME: "charts.acme.org",
+			ociidentity.ID_PORT:     "8443",
+		}))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			"username": "helm",
+			"password": "helm-secret",
+		}))
+	},
+		Entry("yaml", "testdata/credentials.enc.yaml"),
+		Entry("fully encrypted yaml", "testdata/credentials.full.enc.yaml"),
+	)
+
+	It("detects modified files", func() {
+		data := Must(os.ReadFile("testdata/credentials.enc.yaml"))
+		file := filepath.Join(GinkgoT().TempDir(), "credentials.enc.yaml")
+		MustBeSuccessful(os.WriteFile(file, []byte(strings.ReplaceAll(string(data), "charts.acme.org", "charts.evil.org")), 0o600))
+
+		spec := local.NewRepositorySpec(file).WithAgeKeyFile("testdata/keys.txt")
+		ExpectError(DefaultContext.RepositoryForSpec(spec)).To(MatchError(ContainSubstring("message authentication code mismatch")))
+	})
+
+	It("fails for unknown identity", func() {
+		id := Must(age.GenerateX25519Identity())
+		data := Must(os.ReadFile("testdata/credentials.enc.yaml"))
+		ExpectError(local.Decrypt(data, id)).To(MatchError(ContainSubstring("cannot decrypt data key")))
+	})
+
+	It("reads files from the context filesystem", func() {
+		fs := memoryfs.New()
+		MustBeSuccessful(fs.MkdirAll("/sops", 0o700))
+		MustBeSuccessful(vfs.WriteFile(fs, "/sops/credentials.enc.yaml", Must(os.ReadFile("testdata/credentials.enc.yaml")), 0o600))
+		MustBeSuccessful(vfs.WriteFile(fs, "/sops/keys.txt", Must(os.ReadFile("testdata/keys.txt")), 0o600))
+		vfsattr.Set(DefaultContext, fs)
+
+		spec := local.NewRepositorySpec("/sops/credentials.enc.yaml").WithAgeKeyFile("/sops/keys.txt")
+		repo := Must(DefaultContext.RepositoryForSpec(spec))
+		Expect(repo.ExistsCredentials("mycreds")).To(BeTrue())
+	})
+
+	It("requires a message authentication code", func() {
+		id := Must(age.ParseIdentities(Must(os.Open("testdata/keys.txt"))))
+		data := Must(os.ReadFile("testdata/credentials.enc.yaml"))
+		data = regexp.MustCompile(`(?m)^    mac: .*\n`).ReplaceAll(data, nil)
+		ExpectError(local.Decrypt(data, id...)).To(MatchError(ContainSubstring("no message authentication code found")))
+	})
+
+	DescribeTable("rejects key groups", func(meta string) {
+		id := Must(age.ParseIdentities(Must(os.Open("testdata/keys.txt"))))
+		data := Must(os.ReadFile("testdata/credentials.enc.yaml"))
+		data = []byte(strings.Replace(string(data), "\nsops:\n", "\nsops:\n"+meta, 1))
+		ExpectError(local.Decrypt(data, id...)).To(MatchError(ContainSubstring("sops key groups")))
+	},
+		Entry("key groups", "    key_groups:\n        - age: []\n"),
+		Entry("shamir threshold", "    shamir_threshold: 2\n"),
+	)
+})
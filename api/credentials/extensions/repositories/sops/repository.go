@@ -0,0 +1,101 @@
+package sops
+
+import (
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/utils"
+)
+
+type Repository struct {
+	lock      sync.RWMutex
+	ctx       cpi.Context
+	fs        vfs.FileSystem
+	propagate bool
+	path      string
+	keyfile   string
+	doc       *Document
+}
+
+func NewRepository(ctx cpi.Context, path, keyfile string, propagate bool) (*Repository, error) {
+	if path == "" {
+		return nil, errors.ErrRequired("credentials file")
+	}
+	r := &Repository{
+		ctx:       datacontext.InternalContextRef(ctx),
+		fs:        vfsattr.Get(ctx),
+		propagate: propagate,
+		path:      path,
+		keyfile:   keyfile,
+	}
+	err := r.Read(true)
+	return r, err
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	err := r.Read(false)
+	if err != nil {
+		return false, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	_, ok := r.doc.Credentials[name]
+	return ok, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	err := r.Read(false)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	props, ok := r.doc.Credentials[name]
+	if !ok {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return cpi.NewCredentials(props), nil
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+func (r *Repository) Read(force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !force && r.doc != nil {
+		return nil
+	}
+
+	path, err := utils.ResolvePath(r.path)
+	if err != nil {
+		return errors.Wrapf(err, "cannot resolve path %q", r.path)
+	}
+	data, err := vfs.ReadFile(r.fs, path)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read credentials file %q", path)
+	}
+	ids, err := AgeIdentities(r.fs, r.keyfile)
+	if err != nil {
+		return err
+	}
+	doc, err := Decrypt(data, ids...)
+	if err != nil {
+		return errors.Wrapf(err, "credentials file %q", path)
+	}
+	if r.propagate {
+		r.ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+path), &ConsumerProvider{doc.Consumers})
+	}
+	r.doc = doc
+	return nil
+}
@@ -0,0 +1,352 @@
+package sops
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"gopkg.in/yaml.v3"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	// ENV_AGE_KEY is the environment variable used by SOPS
+	// to provide age identities.
+	ENV_AGE_KEY = "SOPS_AGE_KEY"
+	// ENV_AGE_KEY_FILE is the environment variable used by SOPS
+	// to provide the path of a file containing age identities.
+	ENV_AGE_KEY_FILE = "SOPS_AGE_KEY_FILE"
+
+	// DEFAULT_AGE_KEY_FILE is the path of the SOPS default key file
+	// relative to the user configuration directory.
+	DEFAULT_AGE_KEY_FILE = "sops/age/keys.txt"
+
+	// METADATA_KEY is the top-level field of an encrypted file
+	// containing the SOPS metadata.
+	METADATA_KEY = "sops"
+)
+
+// Document describes the content of a decrypted credentials file.
+type Document struct {
+	Consumers   []ConsumerSpec               `json:"consumers,omitempty"`
+	Credentials map[string]common.Properties `json:"credentials,omitempty"`
+}
+
+// ConsumerSpec describes the credentials for a consumer identity.
+type ConsumerSpec struct {
+	Identity    cpi.ConsumerIdentity `json:"identity"`
+	Credentials common.Properties    `json:"credentials"`
+}
+
+type metadata struct {
+	Age []struct {
+		Recipient string `yaml:"recipient"`
+		Enc       string `yaml:"enc"`
+	} `yaml:"age"`
+	KeyGroups        []interface{} `yaml:"key_groups"`
+	ShamirThreshold  int           `yaml:"shamir_threshold"`
+	LastModified     string        `yaml:"lastmodified"`
+	MAC              string        `yaml:"mac"`
+	MACOnlyEncrypted bool          `yaml:"mac_only_encrypted"`
+}
+
+var encrypted = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.*),tag:(.*),type:(.*)\]$`)
+
+// AgeIdentities provides the age identities used to decrypt SOPS files.
+// Files are read from the given filesystem.
+// If a key file is given, it must exist. Additionally, identities are taken
+// from the environment and the SOPS default key file, if present.
+func AgeIdentities(fs vfs.FileSystem, keyfile string) ([]age.Identity, error) {
+	var result []age.Identity
+
+	add := func(src string, r io.Reader) error {
+		ids, err := age.ParseIdentities(r)
+		if err != nil {
+			return errors.Wrapf(err, "age identities from %s", src)
+		}
+		result = append(result, ids...)
+		return nil
+	}
+	addFile := func(path string, optional bool) error {
+		path, err := utils.ResolvePath(path)
+		if err != nil {
+			return errors.Wrapf(err, "cannot resolve path %q", path)
+		}
+		data, err := vfs.ReadFile(fs, path)
+		if err != nil {
+			if optional && vfs.IsErrNotExist(err) {
+				return nil
+			}
+			return errors.Wrapf(err, "cannot read age key file %q", path)
+		}
+		return add(path, bytes.NewReader(data))
+	}
+
+	if keyfile != "" {
+		if err := addFile(keyfile, false); err != nil {
+			return nil, err
+		}
+	}
+	if key := os.Getenv(ENV_AGE_KEY); key != "" {
+		if err := add(ENV_AGE_KEY, strings.NewReader(key)); err != nil {
+			return nil, err
+		}
+	}
+	if path := os.Getenv(ENV_AGE_KEY_FILE); path != "" {
+		if err := addFile(path, false); err != nil {
+			return nil, err
+		}
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		if err := addFile(filepath.Join(dir, DEFAULT_AGE_KEY_FILE), true); err != nil {
+			return nil, err
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.Newf("no age identity found")
+	}
+	return result, nil
+}
+
+// Decrypt decrypts a SOPS encrypted YAML or JSON credentials file
+// using the given age identities. The integrity of the file
+// is verified using the message authentication code of the file.
+//
+// Only a subset of the SOPS file format is supported: the data key
+// must be encrypted for age recipients listed directly in the metadata
+// and values must be encrypted with AES256_GCM. Files using key groups
+// (and therefore Shamir secret sharing) are rejected, as are files
+// without a message authentication code.
+func Decrypt(data []byte, identities ...age.Identity) (*Document, error) {
+	var root yaml.Node
+	err := yaml.Unmarshal(data, &root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid credentials file")
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) != 1 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.Newf("credentials file must contain a map")
+	}
+	doc := root.Content[0]
+
+	var meta *metadata
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == METADATA_KEY {
+			meta = &metadata{}
+			if err := doc.Content[i+1].Decode(meta); err != nil {
+				return nil, errors.Wrapf(err, "invalid sops metadata")
+			}
+		}
+	}
+	if meta == nil {
+		return nil, errors.Newf("no sops metadata found, file is not encrypted")
+	}
+
+	key, err := meta.dataKey(identities)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &decrypter{
+		key:              key,
+		hash:             sha512.New(),
+		macOnlyEncrypted: meta.MACOnlyEncrypted,
+	}
+	tree, err := d.value(doc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.MAC == "" {
+		return nil, errors.Newf("no message authentication code found")
+	}
+	mac, _, err := d.decrypt(meta.MAC, meta.LastModified)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot decrypt message authentication code")
+	}
+	if subtle.ConstantTimeCompare(mac, []byte(fmt.Sprintf("%X", d.hash.Sum(nil)))) != 1 {
+		return nil, errors.Newf("message authentication code mismatch, file has been tampered with")
+	}
+
+	data, err = json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	var result Document
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid credentials file")
+	}
+	return &result, nil
+}
+
+func (m *metadata) dataKey(identities []age.Identity) ([]byte, error) {
+	if len(m.KeyGroups) > 0 || m.ShamirThreshold > 0 {
+		return nil, errors.ErrNotSupported("sops key groups")
+	}
+	if len(m.Age) == 0 {
+		return nil, errors.Newf("no age recipients found")
+	}
+	var errs []error
+	for _, r := range m.Age {
+		rd, err := age.Decrypt(armor.NewReader(strings.NewReader(r.Enc)), identities...)
+		if err == nil {
+			var key []byte
+			key, err = io.ReadAll(rd)
+			if err == nil {
+				return key, nil
+			}
+		}
+		errs = append(errs, errors.Wrapf(err, "recipient %s", r.Recipient))
+	}
+	return nil, errors.Wrapf(errors.Join(errs...), "cannot decrypt data key")
+}
+
+type decrypter struct {
+	key              []byte
+	hash             hash.Hash
+	macOnlyEncrypted bool
+}
+
+// value converts the given node into a generic value with
+// decrypted string leaves. The path is the list of map keys
+// used by SOPS as additional data for the encryption of a value.
+func (d *decrypter) value(n *yaml.Node, path []string) (interface{}, error) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		m := map[string]interface{}{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k := n.Content[i].Value
+			if len(path) == 0 && k == METADATA_KEY {
+				continue
+			}
+			v, err := d.value(n.Content[i+1], append(path[:len(path):len(path)], k))
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		l := []interface{}{}
+		for _, e := range n.Content {
+			v, err := d.value(e, path)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, nil
+	case yaml.AliasNode:
+		return d.value(n.Alias, path)
+	case yaml.ScalarNode:
+		return d.scalar(n, path)
+	default:
+		return nil, errors.Newf("unexpected yaml node at %s", strings.Join(path, "."))
+	}
+}
+
+func (d *decrypter) scalar(n *yaml.Node, path []string) (interface{}, error) {
+	if n.Tag == "!!str" && encrypted.MatchString(n.Value) {
+		plain, typ, err := d.decrypt(n.Value, strings.Join(path, ":")+":")
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decrypt value of %s", strings.Join(path, "."))
+		}
+		d.hash.Write(plain)
+		if typ == "bool" {
+			b, err := strconv.ParseBool(string(plain))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value of %s", strings.Join(path, "."))
+			}
+			return strconv.FormatBool(b), nil
+		}
+		return string(plain), nil
+	}
+	if !d.macOnlyEncrypted {
+		d.hash.Write(plainBytes(n))
+	}
+	if n.Tag == "!!null" {
+		return nil, nil
+	}
+	return n.Value, nil
+}
+
+// decrypt decrypts a single value using the given additional data.
+// It returns the plain value and its SOPS value type.
+func (d *decrypter) decrypt(value string, aad string) ([]byte, string, error) {
+	m := encrypted.FindStringSubmatch(value)
+	if m == nil {
+		return nil, "", errors.Newf("invalid encrypted value")
+	}
+	data, err := base64.StdEncoding.DecodeString(m[1])
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "invalid data")
+	}
+	iv, err := base64.StdEncoding.DecodeString(m[2])
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "invalid iv")
+	}
+	tag, err := base64.StdEncoding.DecodeString(m[3])
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "invalid tag")
+	}
+	block, err := aes.NewCipher(d.key)
+	if err != nil {
+		return nil, "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, "", err
+	}
+	plain, err := gcm.Open(nil, iv, append(data, tag...), []byte(aad))
+	if err != nil {
+		return nil, "", err
+	}
+	return plain, m[4], nil
+}
+
+// plainBytes provides the representation of an unencrypted value
+// used by SOPS to calculate the message authentication code.
+func plainBytes(n *yaml.Node) []byte {
+	switch n.Tag {
+	case "!!bool":
+		var b bool
+		if n.Decode(&b) == nil {
+			if b {
+				return []byte("True")
+			}
+			return []byte("False")
+		}
+	case "!!int":
+		var i int
+		if n.Decode(&i) == nil {
+			return []byte(strconv.Itoa(i))
+		}
+	case "!!float":
+		var f float64
+		if n.Decode(&f) == nil {
+			return []byte(strconv.FormatFloat(f, 'f', -1, 64))
+		}
+	case "!!null":
+		return nil
+	}
+	return []byte(n.Value)
+}
@@ -0,0 +1,66 @@
+package sops
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/generics"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Type   = "SOPSCredentials"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes a credential repository based on a SOPS encrypted
+// credentials file.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	CredentialsFile             string `json:"credentialsFile"`
+	AgeKeyFile                  string `json:"ageKeyFile,omitempty"`
+	PropagateConsumerIdentity   *bool  `json:"propagateConsumerIdentity,omitempty"`
+}
+
+func (s RepositorySpec) WithConsumerPropagation(propagate bool) *RepositorySpec {
+	s.PropagateConsumerIdentity = &propagate
+	return &s
+}
+
+func (s RepositorySpec) WithAgeKeyFile(path string) *RepositorySpec {
+	s.AgeKeyFile = path
+	return &s
+}
+
+// NewRepositorySpec creates a new SOPS RepositorySpec.
+func NewRepositorySpec(path string, prop ...bool) *RepositorySpec {
+	var p *bool
+	if len(prop) > 0 {
+		p = generics.Pointer(utils.Optional(prop...))
+	}
+	return &RepositorySpec{
+		ObjectVersionedType:       runtime.NewVersionedTypedObject(Type),
+		CredentialsFile:           path,
+		PropagateConsumerIdentity: p,
+	}
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Repositories", r)
+	}
+	return repos.GetRepository(ctx, a.CredentialsFile, a.AgeKeyFile, utils.AsBool(a.PropagateConsumerIdentity, true))
+}
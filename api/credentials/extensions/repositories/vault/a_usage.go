@@ -50,7 +50,13 @@ The repository specification supports the following fields:
 	"path", "*string* (optional): the path prefix used to lookup secrets",
 	"secrets", "*[]string* (optional): list of secrets",
 	"propagateConsumerIdentity", "*bool*(optional): evaluate metadata for consumer id propagation",
+	"refreshInterval", "*string* (optional): duration after which the secrets are read again (default: never)",
 }) + `
 If the secrets list is empty, all secret entries found in the given path
 is read.
+
+Vault tokens obtained by a login are renewed if they are renewable,
+otherwise a new login is done before they expire. Token files used
+for the <code>kubernetes</code> or <code>jwt</code> auth methods are read
+again for every login, to support rotated service account tokens.
 `
@@ -2,11 +2,14 @@ package vault
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/vault-client-go"
 	"github.com/hashicorp/vault-client-go/schema"
 	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
 
 	"ocm.software/ocm/api/credentials/cpi"
 	"ocm.software/ocm/api/credentials/extensions/repositories/vault/identity"
@@ -19,6 +22,15 @@ type AuthMethod interface {
 	GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error)
 }
 
+// FileSystemAuthMethod is an optional interface for an AuthMethod
+// reading files, like token files. If implemented, it is used instead
+// of GetToken to read the files from the virtual filesystem of the
+// credential context.
+type FileSystemAuthMethod interface {
+	AuthMethod
+	GetTokenFromFileSystem(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials, fs vfs.FileSystem) (string, error)
+}
+
 type AuthMethods struct {
 	lock    sync.Mutex
 	methods map[string]AuthMethod
@@ -62,6 +74,8 @@ func RegisterAuthMethod(m AuthMethod) {
 func init() {
 	RegisterAuthMethod(&approle{})
 	RegisterAuthMethod(&token{})
+	RegisterAuthMethod(&kubernetes{})
+	RegisterAuthMethod(&jwt{})
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -120,3 +134,124 @@ func (a *token) Validate(creds cpi.Credentials) error {
 func (a *token) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
 	return creds.GetProperty(identity.ATTR_TOKEN), nil
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+type kubernetes struct{}
+
+var _ FileSystemAuthMethod = (*kubernetes)(nil)
+
+func (a *kubernetes) GetName() string {
+	return identity.AUTH_KUBERNETES
+}
+
+func (a *kubernetes) Validate(creds cpi.Credentials) error {
+	if !creds.ExistsProperty(identity.ATTR_ROLE) {
+		return errors.ErrRequired("credential property", identity.ATTR_ROLE, a.GetName())
+	}
+	return nil
+}
+
+func (a *kubernetes) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	return a.GetTokenFromFileSystem(ctx, client, ns, creds, osfs.New())
+}
+
+func (a *kubernetes) GetTokenFromFileSystem(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials, fs vfs.FileSystem) (string, error) {
+	jwt, err := getJWT(fs, creds, identity.KUBERNETES_TOKEN_FILE)
+	if err != nil {
+		return "", err
+	}
+	req := schema.KubernetesLoginRequest{
+		Jwt:  jwt,
+		Role: creds.GetProperty(identity.ATTR_ROLE),
+	}
+	resp, err := client.Auth.KubernetesLogin(
+		ctx,
+		req,
+		vault.WithNamespace(ns),
+		vault.WithMountPath(authPath(creds, a.GetName())),
+	)
+	if err != nil {
+		return "", err
+	}
+	if resp.Auth == nil {
+		return "", errors.Newf("no auth info returned by %s login", a.GetName())
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type jwt struct{}
+
+var _ FileSystemAuthMethod = (*jwt)(nil)
+
+func (a *jwt) GetName() string {
+	return identity.AUTH_JWT
+}
+
+func (a *jwt) Validate(creds cpi.Credentials) error {
+	if !creds.ExistsProperty(identity.ATTR_ROLE) {
+		return errors.ErrRequired("credential property", identity.ATTR_ROLE, a.GetName())
+	}
+	if !creds.ExistsProperty(identity.ATTR_JWT) && !creds.ExistsProperty(identity.ATTR_JWTFILE) {
+		return errors.ErrRequired("credential property", identity.ATTR_JWT+" or "+identity.ATTR_JWTFILE, a.GetName())
+	}
+	return nil
+}
+
+func (a *jwt) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	return a.GetTokenFromFileSystem(ctx, client, ns, creds, osfs.New())
+}
+
+func (a *jwt) GetTokenFromFileSystem(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials, fs vfs.FileSystem) (string, error) {
+	jwt, err := getJWT(fs, creds, "")
+	if err != nil {
+		return "", err
+	}
+	req := schema.JwtLoginRequest{
+		Jwt:  jwt,
+		Role: creds.GetProperty(identity.ATTR_ROLE),
+	}
+	resp, err := client.Auth.JwtLogin(
+		ctx,
+		req,
+		vault.WithNamespace(ns),
+		vault.WithMountPath(authPath(creds, a.GetName())),
+	)
+	if err != nil {
+		return "", err
+	}
+	if resp.Auth == nil {
+		return "", errors.Newf("no auth info returned by %s login", a.GetName())
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+// getJWT provides the JWT given by the credentials. Token files
+// are read on every login, because projected service account tokens
+// are rotated.
+func getJWT(fs vfs.FileSystem, creds cpi.Credentials, def string) (string, error) {
+	if creds.ExistsProperty(identity.ATTR_JWT) {
+		return creds.GetProperty(identity.ATTR_JWT), nil
+	}
+	file := creds.GetProperty(identity.ATTR_JWTFILE)
+	if file == "" {
+		file = def
+	}
+	if file == "" {
+		return "", errors.ErrRequired("credential property", identity.ATTR_JWT)
+	}
+	data, err := utils.ReadFile(file, fs)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot read JWT file %q", file)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func authPath(creds cpi.Credentials, def string) string {
+	if p := creds.GetProperty(identity.ATTR_AUTHPATH); p != "" {
+		return p
+	}
+	return def
+}
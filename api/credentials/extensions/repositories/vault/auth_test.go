@@ -0,0 +1,275 @@
+package vault_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/credentials"
+	me "ocm.software/ocm/api/credentials/extensions/repositories/vault"
+	"ocm.software/ocm/api/credentials/extensions/repositories/vault/identity"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	JWT_ROLE  = "ocm"
+	JWT_TOKEN = "eyJhbGciOiJSUzI1NiJ9.test.signature"
+	JWT_MOUNT = "secret"
+	JWT_PATH  = "mysecrets/repo1"
+)
+
+// fakeVault is a minimal stand-in for a vault dev server providing
+// the kubernetes and jwt login endpoints, token lookup and renewal
+// and a kv v2 secret engine mounted at secret.
+type fakeVault struct {
+	lock    sync.Mutex
+	ttl     int
+	logins  map[string]int
+	renewed int
+	tokens  map[string]bool
+	secrets map[string]map[string]interface{}
+}
+
+func newFakeVault(ttl int) *fakeVault {
+	return &fakeVault{
+		ttl:     ttl,
+		logins:  map[string]int{},
+		tokens:  map[string]bool{},
+		secrets: map[string]map[string]interface{}{},
+	}
+}
+
+func (v *fakeVault) Logins(mount string) int {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.logins[mount]
+}
+
+func (v *fakeVault) Renewed() int {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.renewed
+}
+
+func (v *fakeVault) Revoke() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.tokens = map[string]bool{}
+}
+
+func (v *fakeVault) reply(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (v *fakeVault) auth(token string) map[string]interface{} {
+	return map[string]interface{}{
+		"data": nil,
+		"auth": map[string]interface{}{
+			"client_token":   token,
+			"lease_duration": v.ttl,
+			"renewable":      true,
+		},
+	}
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	p := r.URL.Path
+	if strings.HasPrefix(p, "/v1/auth/") && strings.HasSuffix(p, "/login") {
+		mount := strings.TrimSuffix(strings.TrimPrefix(p, "/v1/auth/"), "/login")
+		var req struct {
+			Jwt  string `json:"jwt"`
+			Role string `json:"role"`
+		}
+		if json.NewDecoder(r.Body).Decode(&req) != nil || req.Jwt != JWT_TOKEN || req.Role != JWT_ROLE {
+			v.reply(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"invalid jwt or role"}})
+			return
+		}
+		v.logins[mount]++
+		token := mount + "-token-" + string(rune('0'+v.logins[mount]))
+		v.tokens[token] = true
+		v.reply(w, http.StatusOK, v.auth(token))
+		return
+	}
+
+	token := r.Header.Get("X-Vault-Token")
+	if !v.tokens[token] {
+		v.reply(w, http.StatusForbidden, map[string]interface{}{"errors": []string{"permission denied"}})
+		return
+	}
+
+	switch {
+	case p == "/v1/auth/token/lookup-self":
+		v.reply(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"ttl": v.ttl, "renewable": true}})
+	case p == "/v1/auth/token/renew-self":
+		v.renewed++
+		v.reply(w, http.StatusOK, v.auth(token))
+	case strings.HasPrefix(p, "/v1/secret/metadata/"):
+		prefix := strings.TrimSuffix(strings.TrimPrefix(p, "/v1/secret/metadata/"), "/") + "/"
+		var keys []string
+		for k := range v.secrets {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, strings.TrimPrefix(k, prefix))
+			}
+		}
+		v.reply(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	case strings.HasPrefix(p, "/v1/secret/data/"):
+		data, ok := v.secrets[strings.TrimPrefix(p, "/v1/secret/data/")]
+		if !ok {
+			v.reply(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		v.reply(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"data": data, "metadata": map[string]interface{}{}}})
+	default:
+		v.reply(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+	}
+}
+
+var _ = Describe("vault auth methods", func() {
+	var ctx credentials.Context
+	var vault *fakeVault
+	var server *httptest.Server
+	var jwtfile string
+
+	data := map[string]interface{}{
+		"password1": "ocm-password-1",
+	}
+
+	setCredentials := func(props common.Properties) {
+		id := Must(identity.GetConsumerId(server.URL, "", JWT_MOUNT, JWT_PATH))
+		ctx.SetCredentialsForConsumer(id, credentials.NewCredentials(props))
+	}
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+		vault = newFakeVault(3600)
+		vault.secrets[JWT_PATH+"/mysecret"] = data
+		server = httptest.NewServer(vault)
+
+		jwtfile = filepath.Join(GinkgoT().TempDir(), "token")
+		MustBeSuccessful(os.WriteFile(jwtfile, []byte(JWT_TOKEN+"\n"), 0o600))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("authenticates with kubernetes service account token file", func() {
+		setCredentials(common.Properties{
+			identity.ATTR_AUTHMETH: identity.AUTH_KUBERNETES,
+			identity.ATTR_ROLE:     JWT_ROLE,
+			identity.ATTR_JWTFILE:  jwtfile,
+		})
+		spec := me.NewRepositorySpec(server.URL, me.WithMountPath(JWT_MOUNT), me.WithPath(JWT_PATH))
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		c := Must(repo.LookupCredentials("mysecret"))
+		Expect(c.Properties()).To(YAMLEqual(data))
+		Expect(vault.Logins(identity.AUTH_KUBERNETES)).To(Equal(1))
+	})
+
+	It("authenticates with jwt using a dedicated auth mount path", func() {
+		setCredentials(common.Properties{
+			identity.ATTR_AUTHMETH: identity.AUTH_JWT,
+			identity.ATTR_ROLE:     JWT_ROLE,
+			identity.ATTR_JWT:      JWT_TOKEN,
+			identity.ATTR_AUTHPATH: "oidc",
+		})
+		spec := me.NewRepositorySpec(server.URL, me.WithMountPath(JWT_MOUNT), me.WithPath(JWT_PATH))
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		c := Must(repo.LookupCredentials("mysecret"))
+		Expect(c.Properties()).To(YAMLEqual(data))
+		Expect(vault.Logins("oidc")).To(Equal(1))
+	})
+
+	It("rejects jwt auth without token", func() {
+		setCredentials(common.Properties{
+			identity.ATTR_AUTHMETH: identity.AUTH_JWT,
+			identity.ATTR_ROLE:     JWT_ROLE,
+		})
+		spec := me.NewRepositorySpec(server.URL, me.WithMountPath(JWT_MOUNT), me.WithPath(JWT_PATH))
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		ExpectError(repo.LookupCredentials("mysecret")).To(MatchError(`credential property "jwt or jwtfile" required for jwt`))
+	})
+
+	It("renews tokens and logs in again after revocation", func() {
+		vault.ttl = 1
+		setCredentials(common.Properties{
+			identity.ATTR_AUTHMETH: identity.AUTH_KUBERNETES,
+			identity.ATTR_ROLE:     JWT_ROLE,
+			identity.ATTR_JWTFILE:  jwtfile,
+		})
+		spec := me.NewRepositorySpec(server.URL, me.WithMountPath(JWT_MOUNT), me.WithPath(JWT_PATH), me.WithRefreshInterval(time.Millisecond))
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		Expect(repo.LookupCredentials("mysecret")).NotTo(BeNil())
+		Expect(vault.Logins(identity.AUTH_KUBERNETES)).To(Equal(1))
+
+		time.Sleep(800 * time.Millisecond)
+		Expect(repo.LookupCredentials("mysecret")).NotTo(BeNil())
+		Expect(vault.Renewed()).To(Equal(1))
+		Expect(vault.Logins(identity.AUTH_KUBERNETES)).To(Equal(1))
+
+		vault.Revoke()
+		time.Sleep(10 * time.Millisecond)
+		Expect(repo.LookupCredentials("mysecret")).NotTo(BeNil())
+		Expect(vault.Logins(identity.AUTH_KUBERNETES)).To(Equal(2))
+	})
+
+	It("logs in again for configured secrets after revocation", func() {
+		setCredentials(common.Properties{
+			identity.ATTR_AUTHMETH: identity.AUTH_JWT,
+			identity.ATTR_ROLE:     JWT_ROLE,
+			identity.ATTR_JWT:      JWT_TOKEN,
+		})
+		spec := me.NewRepositorySpec(server.URL, me.WithMountPath(JWT_MOUNT), me.WithPath(JWT_PATH), me.WithSecrets("mysecret"), me.WithRefreshInterval(time.Millisecond))
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		Expect(repo.LookupCredentials("mysecret")).NotTo(BeNil())
+		Expect(vault.Logins(identity.AUTH_JWT)).To(Equal(1))
+
+		vault.Revoke()
+		time.Sleep(10 * time.Millisecond)
+		c := Must(repo.LookupCredentials("mysecret"))
+		Expect(c.Properties()).To(YAMLEqual(data))
+		Expect(vault.Logins(identity.AUTH_JWT)).To(Equal(2))
+	})
+
+	It("reads the token file from the filesystem of the context", func() {
+		fs := memoryfs.New()
+		MustBeSuccessful(fs.MkdirAll("/var/run", 0o700))
+		MustBeSuccessful(vfs.WriteFile(fs, "/var/run/token", []byte(JWT_TOKEN+"\n"), 0o600))
+		vfsattr.Set(ctx, fs)
+
+		setCredentials(common.Properties{
+			identity.ATTR_AUTHMETH: identity.AUTH_JWT,
+			identity.ATTR_ROLE:     JWT_ROLE,
+			identity.ATTR_JWTFILE:  "/var/run/token",
+		})
+		spec := me.NewRepositorySpec(server.URL, me.WithMountPath(JWT_MOUNT), me.WithPath(JWT_PATH))
+		repo := Must(ctx.RepositoryForSpec(spec))
+
+		c := Must(repo.LookupCredentials("mysecret"))
+		Expect(c.Properties()).To(YAMLEqual(data))
+		Expect(vault.Logins(identity.AUTH_JWT)).To(Equal(1))
+	})
+})
@@ -30,13 +30,23 @@ const (
 	ATTR_TOKEN    = cpi.ATTR_TOKEN
 	ATTR_ROLEID   = "roleid"
 	ATTR_SECRETID = "secretid"
+	ATTR_ROLE     = "role"
+	ATTR_JWT      = "jwt"
+	ATTR_JWTFILE  = "jwtfile"
+	ATTR_AUTHPATH = "authpath"
 )
 
 const (
-	AUTH_APPROLE = "approle"
-	AUTH_TOKEN   = "token"
+	AUTH_APPROLE    = "approle"
+	AUTH_TOKEN      = "token"
+	AUTH_KUBERNETES = "kubernetes"
+	AUTH_JWT        = "jwt"
 )
 
+// KUBERNETES_TOKEN_FILE is the default location of the projected
+// service account token used by the kubernetes auth method.
+const KUBERNETES_TOKEN_FILE = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
 var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
 
 func IdentityMatcher(request, cur, id cpi.ConsumerIdentity) bool {
@@ -55,6 +65,10 @@ func init() {
 		ATTR_TOKEN, "vault token",
 		ATTR_ROLEID, "app-role role id",
 		ATTR_SECRETID, "app-role secret id",
+		ATTR_ROLE, "role used for kubernetes and jwt auth",
+		ATTR_JWT, "JWT used for kubernetes and jwt auth",
+		ATTR_JWTFILE, "file containing the JWT used for kubernetes and jwt auth (default for kubernetes: <code>" + KUBERNETES_TOKEN_FILE + "</code>)",
+		ATTR_AUTHPATH, "(optional) mount path of the auth method (default: name of the auth method)",
 	})
 	ids := listformat.FormatListElements("", listformat.StringElementDescriptionList{
 		ID_HOSTNAME, "vault server host",
@@ -71,7 +85,8 @@ This matcher matches credentials for a HashiCorp vault instance.
 It uses the following identity attributes:
 `+ids,
 		attrs+`
-The only supported auth methods, so far, are <code>token</code> and <code>approle</code>.
+The supported auth methods are <code>token</code>, <code>approle</code>,
+<code>kubernetes</code> and <code>jwt</code>.
 `)
 }
 
@@ -2,6 +2,7 @@ package vault
 
 import (
 	"slices"
+	"time"
 
 	"github.com/mandelsoft/goutils/optionutils"
 
@@ -16,6 +17,7 @@ type Options struct {
 	Path                     string   `json:"path,omitempty"`
 	Secrets                  []string `json:"secrets,omitempty"`
 	PropgateConsumerIdentity bool     `json:"propagateConsumerIdentity,omitempty"`
+	RefreshInterval          string   `json:"refreshInterval,omitempty"`
 }
 
 var _ Option = (*Options)(nil)
@@ -34,6 +36,9 @@ func (o *Options) ApplyTo(opts *Options) {
 		opts.Secrets = slices.Clone(o.Secrets)
 	}
 	opts.PropgateConsumerIdentity = o.PropgateConsumerIdentity
+	if o.RefreshInterval != "" {
+		opts.RefreshInterval = o.RefreshInterval
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -95,3 +100,17 @@ func (o pr) ApplyTo(opts *Options) {
 func WithPropagation(b ...bool) Option {
 	return pr(utils.OptionalDefaultedBool(true, b...))
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ri time.Duration
+
+func (o ri) ApplyTo(opts *Options) {
+	opts.RefreshInterval = time.Duration(o).String()
+}
+
+// WithRefreshInterval configures the interval after which the
+// secrets are read again from the vault.
+func WithRefreshInterval(d time.Duration) Option {
+	return ri(d)
+}
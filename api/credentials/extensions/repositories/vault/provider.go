@@ -11,11 +11,13 @@ import (
 	"time"
 
 	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
 	"github.com/mandelsoft/goutils/errors"
 
 	"ocm.software/ocm/api/credentials/cpi"
 	"ocm.software/ocm/api/credentials/extensions/repositories/vault/identity"
 	"ocm.software/ocm/api/credentials/internal"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
 	common "ocm.software/ocm/api/utils/misc"
 )
 
@@ -48,8 +50,9 @@ type ConsumerProvider struct {
 	lock       sync.Mutex
 	repository *Repository
 	cache      *credentialCache
+	session    session
 
-	updated bool
+	updated time.Time
 }
 
 var (
@@ -81,7 +84,7 @@ func (p *ConsumerProvider) GetIdentityMatcher() string {
 }
 
 func (p *ConsumerProvider) update(ectx cpi.EvaluationContext) error {
-	if p.updated {
+	if !p.updated.IsZero() && (p.repository.refresh == 0 || time.Since(p.updated) < p.repository.refresh) {
 		return nil
 	}
 	credsrc, err := cpi.GetCredentialsForConsumer(p.repository.ctx, ectx, p.repository.id, identity.IdentityMatcher)
@@ -98,25 +101,27 @@ func (p *ConsumerProvider) update(ectx cpi.EvaluationContext) error {
 	}
 
 	ctx := context.Background()
+	fs := vfsattr.Get(p.repository.ctx)
 
-	client, err := vault.New(
-		vault.WithAddress(p.repository.spec.ServerURL),
-		vault.WithRequestTimeout(30*time.Second),
-	)
+	client, err := p.session.get(ctx, fs, p.repository.spec, creds)
 	if err != nil {
 		return err
 	}
 
-	// vault.WithMountPath("piper/PIPELINE-GROUP-4953/PIPELINE-25042/appRoleCredentials"),
-	token, err := p.getToken(ctx, client, creds)
-	if err != nil {
-		return err
-	}
-
-	if err := client.SetToken(token); err != nil {
-		return err
-	}
-	if err := client.SetNamespace(p.repository.spec.Namespace); err != nil {
+	// request executes a vault request. If it is rejected, the token
+	// might have been revoked or expired, and the request is retried
+	// once after a new login.
+	relogin := false
+	request := func(f func(client *vault.Client) error) error {
+		err := f(client)
+		if !relogin && isForbidden(err) {
+			relogin = true
+			p.session.invalidate()
+			client, err = p.session.get(ctx, fs, p.repository.spec, creds)
+			if err == nil {
+				err = f(client)
+			}
+		}
 		return err
 	}
 
@@ -125,8 +130,12 @@ func (p *ConsumerProvider) update(ectx cpi.EvaluationContext) error {
 	// TODO: support for pure path based access for other secret engine types
 	secrets := slices.Clone(p.repository.spec.Secrets)
 	if len(secrets) == 0 {
-		s, err := client.Secrets.KvV2List(ctx, p.repository.spec.Path,
-			vault.WithMountPath(p.repository.spec.MountPath))
+		var s *vault.Response[schema.StandardListResponse]
+		err := request(func(client *vault.Client) (err error) {
+			s, err = client.Secrets.KvV2List(ctx, p.repository.spec.Path,
+				vault.WithMountPath(p.repository.spec.MountPath))
+			return err
+		})
 		if err != nil {
 			p.error(err, "error listing secrets", "")
 			return err
@@ -139,7 +148,12 @@ func (p *ConsumerProvider) update(ectx cpi.EvaluationContext) error {
 	}
 	for i := 0; i < len(secrets); i++ {
 		n := secrets[i]
-		creds, id, list, err := p.read(ctx, client, n)
+		var creds, id common.Properties
+		var list []string
+		err := request(func(client *vault.Client) (err error) {
+			creds, id, list, err = p.read(ctx, client, n)
+			return err
+		})
 		p.error(err, "error reading vault secret", n)
 		if err == nil {
 			for _, a := range list {
@@ -159,7 +173,7 @@ func (p *ConsumerProvider) update(ectx cpi.EvaluationContext) error {
 		}
 	}
 	p.cache = cache
-	p.updated = true
+	p.updated = time.Now()
 	return nil
 }
 
@@ -175,11 +189,6 @@ func (p *ConsumerProvider) validateCreds(creds cpi.Credentials) error {
 	return meth.Validate(creds)
 }
 
-func (p *ConsumerProvider) getToken(ctx context.Context, client *vault.Client, creds cpi.Credentials) (string, error) {
-	m := creds.GetProperty(identity.ATTR_AUTHMETH)
-	return methods.Get(m).GetToken(ctx, client, p.repository.spec.Namespace, creds)
-}
-
 func (p *ConsumerProvider) error(err error, msg string, secret string, keypairs ...interface{}) {
 	if err == nil {
 		return
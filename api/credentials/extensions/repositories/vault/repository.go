@@ -1,6 +1,8 @@
 package vault
 
 import (
+	"time"
+
 	"github.com/mandelsoft/goutils/errors"
 
 	"ocm.software/ocm/api/credentials/cpi"
@@ -12,6 +14,7 @@ type Repository struct {
 	ctx      cpi.Context
 	spec     *RepositorySpec
 	id       cpi.ConsumerIdentity
+	refresh  time.Duration
 	provider *ConsumerProvider
 }
 
@@ -33,6 +36,12 @@ func NewRepository(ctx cpi.Context, spec *RepositorySpec) (*Repository, error) {
 	if spec.ServerURL == "" {
 		return nil, errors.ErrInvalid("server url")
 	}
+	if spec.RefreshInterval != "" {
+		r.refresh, err = time.ParseDuration(spec.RefreshInterval)
+		if err != nil {
+			return nil, errors.ErrInvalidWrap(err, "refresh interval", spec.RefreshInterval)
+		}
+	}
 	r.provider, err = NewConsumerProvider(r)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,136 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/vault/identity"
+)
+
+// session keeps an authenticated vault client together with the
+// lease information of its token. Tokens are renewed if they
+// are renewable, otherwise a new login is done before they expire.
+type session struct {
+	client    *vault.Client
+	creds     cpi.Credentials
+	ttl       time.Duration
+	expiry    time.Time
+	renewable bool
+}
+
+func (s *session) invalidate() {
+	s.client = nil
+}
+
+func (s *session) get(ctx context.Context, fs vfs.FileSystem, spec *RepositorySpec, creds cpi.Credentials) (*vault.Client, error) {
+	if s.client != nil && s.creds != nil && s.creds.Properties().Equals(creds.Properties()) {
+		if s.valid() {
+			return s.client, nil
+		}
+		if s.renewable && time.Now().Before(s.expiry) {
+			err := s.renew(ctx, spec)
+			if err == nil {
+				return s.client, nil
+			}
+			log.Info("token renewal failed, trying new login", "server", spec.ServerURL, "error", err.Error())
+		}
+	}
+	err := s.login(ctx, fs, spec, creds)
+	return s.client, err
+}
+
+// valid checks whether the token is valid for at least
+// a third of its lease duration.
+func (s *session) valid() bool {
+	return s.expiry.IsZero() || time.Now().Add(s.ttl/3).Before(s.expiry)
+}
+
+func (s *session) login(ctx context.Context, fs vfs.FileSystem, spec *RepositorySpec, creds cpi.Credentials) error {
+	s.client = nil
+	client, err := vault.New(
+		vault.WithAddress(spec.ServerURL),
+		vault.WithRequestTimeout(30*time.Second),
+	)
+	if err != nil {
+		return err
+	}
+
+	var token string
+	m := methods.Get(creds.GetProperty(identity.ATTR_AUTHMETH))
+	if f, ok := m.(FileSystemAuthMethod); ok {
+		token, err = f.GetTokenFromFileSystem(ctx, client, spec.Namespace, creds, fs)
+	} else {
+		token, err = m.GetToken(ctx, client, spec.Namespace, creds)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := client.SetToken(token); err != nil {
+		return err
+	}
+	if err := client.SetNamespace(spec.Namespace); err != nil {
+		return err
+	}
+
+	s.client = client
+	s.creds = creds
+	s.ttl, s.renewable = 0, false
+	s.expiry = time.Time{}
+
+	resp, err := client.Auth.TokenLookUpSelf(ctx)
+	if err != nil {
+		// the token may not be allowed to look up itself,
+		// it is used until vault rejects it.
+		log.Debug("cannot lookup token", "server", spec.ServerURL, "error", err.Error())
+		return nil
+	}
+	s.setLease(resp.Data["ttl"], resp.Data["renewable"] == true)
+	return nil
+}
+
+func (s *session) renew(ctx context.Context, spec *RepositorySpec) error {
+	resp, err := s.client.Auth.TokenRenewSelf(ctx, schema.TokenRenewSelfRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.Auth == nil {
+		return errors.Newf("no auth info for renewed token")
+	}
+	log.Debug("token renewed", "server", spec.ServerURL, "ttl", resp.Auth.LeaseDuration)
+	s.setLease(resp.Auth.LeaseDuration, resp.Auth.Renewable)
+	return nil
+}
+
+func (s *session) setLease(ttl interface{}, renewable bool) {
+	var secs int64
+	switch v := ttl.(type) {
+	case json.Number:
+		secs, _ = v.Int64()
+	case float64:
+		secs = int64(v)
+	case int:
+		secs = int64(v)
+	}
+	s.renewable = renewable
+	if secs <= 0 {
+		s.ttl = 0
+		s.expiry = time.Time{}
+		return
+	}
+	s.ttl = time.Duration(secs) * time.Second
+	s.expiry = time.Now().Add(s.ttl)
+}
+
+func isForbidden(err error) bool {
+	var v *vault.ResponseError
+	return errors.As(err, &v) && v.StatusCode == http.StatusForbidden
+}
@@ -43,6 +43,11 @@ type (
 	IdentityMatcherRegistry  = internal.IdentityMatcherRegistry
 )
 
+type (
+	MatchProvenance = internal.MatchProvenance
+	MatchCandidate  = internal.MatchCandidate
+)
+
 type (
 	GenericRepositorySpec  = internal.GenericRepositorySpec
 	GenericCredentialsSpec = internal.GenericCredentialsSpec
@@ -123,6 +128,20 @@ func RequiredCredentialsForConsumer(ctx ContextProvider, id ConsumerIdentity, ma
 	return internal.CredentialsForConsumer(ctx, id, true, matchers...)
 }
 
+// ExplainCredentialsForConsumer evaluates the credentials source for a consumer
+// like CredentialsForConsumer and additionally provides the provenance of
+// the match: all matching consumer identities, their providers and the
+// finally used one.
+func ExplainCredentialsForConsumer(ctx ContextProvider, id ConsumerIdentity, matchers ...IdentityMatcher) (*MatchProvenance, CredentialsSource, error) {
+	return internal.ExplainCredentialsForConsumer(ctx.CredentialsContext(), id, matchers...)
+}
+
+// DescribeCredentialsSource provides a short description of the origin
+// of a credentials source.
+func DescribeCredentialsSource(ctx ContextProvider, src CredentialsSource) string {
+	return internal.DescribeCredentialsSource(ctx.CredentialsContext(), src)
+}
+
 var (
 	CompleteMatch = internal.CompleteMatch
 	NoMatch       = internal.NoMatch
@@ -94,11 +94,17 @@ func (c *_consumers) Get(id ConsumerIdentity) (CredentialsSource, bool) {
 // Match matches a given request (pattern) against configured
 // identities.
 func (c *_consumers) Match(ectx EvaluationContext, pattern ConsumerIdentity, cur ConsumerIdentity, m IdentityMatcher) (CredentialsSource, ConsumerIdentity) {
+	return c.match(nil, pattern, cur, m)
+}
+
+func (c *_consumers) match(prov *MatchProvenance, pattern ConsumerIdentity, cur ConsumerIdentity, m IdentityMatcher) (CredentialsSource, ConsumerIdentity) {
 	var found *_consumer
-	for _, s := range c.data {
-		if m(pattern, cur, s.identity) {
+	// use a stable order to get reproducible results for equally good matches.
+	for _, s := range maputils.OrderedValues(c.data) {
+		if prov.matcher(s.providerId, m)(pattern, cur, s.identity) {
 			found = s
 			cur = s.identity
+			prov.setSource(s.providerId, s.credentials)
 		}
 	}
 	if found != nil {
@@ -152,7 +158,7 @@ type consumerProviderRegistry struct {
 	lock      sync.RWMutex
 	explicit  *_consumers
 	providers map[ProviderIdentity]ConsumerProvider
-	ordered   []ConsumerProvider
+	ordered   []ProviderIdentity
 }
 
 func newConsumerProviderRegistry() *consumerProviderRegistry {
@@ -171,9 +177,15 @@ func (p *consumerProviderRegistry) Register(id ProviderIdentity, c ConsumerProvi
 
 	p.unregister(id)
 	p.providers[id] = c
-	p.ordered = maputils.OrderedValues(p.providers)
-	sort.Slice(p.ordered, func(a, b int) bool {
-		return priority(p.ordered[a]) < priority(p.ordered[b])
+	p.order()
+}
+
+// order provides the evaluation order of the providers,
+// ordered by priority and provider identity.
+func (p *consumerProviderRegistry) order() {
+	p.ordered = maputils.OrderedKeys(p.providers)
+	sort.SliceStable(p.ordered, func(a, b int) bool {
+		return priority(p.providers[p.ordered[a]]) < priority(p.providers[p.ordered[b]])
 	})
 }
 
@@ -187,10 +199,7 @@ func (p *consumerProviderRegistry) unregister(id ProviderIdentity) {
 	p.explicit.Unregister(id)
 	if _, ok := p.providers[id]; ok {
 		delete(p.providers, id)
-		p.ordered = maputils.OrderedValues(p.providers)
-		sort.Slice(p.ordered, func(a, b int) bool {
-			return priority(p.ordered[a]) < priority(p.ordered[b])
-		})
+		p.order()
 	} else {
 		for _, sub := range p.providers {
 			sub.Unregister(id)
@@ -255,15 +264,24 @@ func (p *consumerProviderRegistry) catchedMatch(ectx EvaluationContext, sub Cons
 }
 
 func (p *consumerProviderRegistry) Match(ectx EvaluationContext, pattern ConsumerIdentity, cur ConsumerIdentity, m IdentityMatcher) (CredentialsSource, ConsumerIdentity) {
+	return p.match(ectx, nil, pattern, cur, m)
+}
+
+// match matches the given pattern against the consumers of all
+// providers. If a provenance recorder is given, the matching
+// consumer identities are recorded.
+func (p *consumerProviderRegistry) match(ectx EvaluationContext, prov *MatchProvenance, pattern ConsumerIdentity, cur ConsumerIdentity, m IdentityMatcher) (CredentialsSource, ConsumerIdentity) {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
-	credsrc, cur := p.explicit.Match(ectx, pattern, cur, m)
-	for _, sub := range p.providers {
+	credsrc, cur := p.explicit.match(prov, pattern, cur, m)
+	for _, pid := range p.ordered {
+		sub := p.providers[pid]
 		var f CredentialsSource
-		f, cur = p.catchedMatch(ectx, sub, pattern, cur, m)
+		f, cur = p.catchedMatch(ectx, sub, pattern, cur, prov.matcher(pid, m))
 		if f != nil {
 			credsrc = f
+			prov.setSource(pid, f)
 		}
 	}
 	// If this is the case, we are in a situation where we have excluded all providers (since they are all in the stack).
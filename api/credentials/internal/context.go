@@ -259,14 +259,19 @@ func (c *_context) getCredentialsForConsumer(ectx EvaluationContext, identity Co
 		ectx = &evaluationContext{}
 	}
 	m := c.defaultMatcher(identity, matchers...)
+	prov, ectx := provenanceFor(ectx, identity, c.matcherName(identity, matchers...))
 	var credsrc CredentialsSource
 	if m == nil {
 		credsrc, _ = c.consumerProviders.Get(identity)
 	} else {
-		credsrc, _ = c.consumerProviders.Match(ectx, identity, nil, m)
+		credsrc, _ = c.consumerProviders.match(ectx, prov, identity, nil, m)
 	}
 	if credsrc == nil {
 		credsrc, _ = c.consumerProviders.Get(emptyIdentity)
+		if credsrc != nil && prov != nil {
+			prov.Default = true
+			prov.Winner = nil
+		}
 	}
 	if credsrc == nil {
 		return nil, ErrUnknownConsumer(identity.String())
@@ -282,6 +287,19 @@ func (c *_context) defaultMatcher(id ConsumerIdentity, matchers ...IdentityMatch
 	return mergeMatcher(def, andMatcher, matchers)
 }
 
+// matcherName describes the identity matcher used by defaultMatcher.
+func (c *_context) matcherName(id ConsumerIdentity, matchers ...IdentityMatcher) string {
+	for _, m := range matchers {
+		if m != nil {
+			return "explicit"
+		}
+	}
+	if c.consumerIdentityMatchers.Get(id.Type()) != nil {
+		return id.Type()
+	}
+	return "partial"
+}
+
 func (c *_context) SetCredentialsForConsumer(identity ConsumerIdentity, creds CredentialsSource) {
 	c.Update()
 	c.consumerProviders.Set(identity, "", creds)
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modern-go/reflect2"
+)
+
+// MatchCandidate describes a configured consumer identity
+// matching a requested consumer identity.
+type MatchCandidate struct {
+	// Provider is the identity of the consumer provider offering the
+	// consumer identity. It is empty for consumers configured
+	// directly at the credentials context.
+	Provider ProviderIdentity
+	// Identity is the configured consumer identity.
+	Identity ConsumerIdentity
+	// Exact indicates a complete match of the requested identity.
+	Exact bool
+	// Score is the number of attributes of the candidate identity,
+	// which are all matched by the identity matcher. Identity matchers
+	// typically prefer candidates with a higher score.
+	Score int
+	// Selected indicates that the candidate has been preferred
+	// by the identity matcher over the previously found candidates.
+	Selected bool
+	// Source is the credentials source offered for a selected candidate.
+	Source CredentialsSource
+}
+
+// MatchProvenance records the evaluation of a credential
+// request for a consumer identity.
+type MatchProvenance struct {
+	// Requested is the requested consumer identity.
+	Requested ConsumerIdentity
+	// Matcher describes the used identity matcher.
+	Matcher string
+	// Candidates lists all matching consumer identities in
+	// evaluation order. Consumer identities configured directly at the
+	// context are evaluated first, ordered by their identity,
+	// followed by the consumer identities of the providers, ordered by
+	// provider priority and provider identity.
+	Candidates []*MatchCandidate
+	// Winner is the candidate finally used to provide credentials.
+	Winner *MatchCandidate
+	// Default indicates that the credentials configured for the
+	// empty consumer identity are used.
+	Default bool
+}
+
+func (p *MatchProvenance) add(pid ProviderIdentity, id ConsumerIdentity, selected bool) *MatchCandidate {
+	c := &MatchCandidate{
+		Provider: pid,
+		Identity: id.Copy(),
+		Exact:    id.Equals(p.Requested),
+		Score:    len(id),
+		Selected: selected,
+	}
+	p.Candidates = append(p.Candidates, c)
+	return c
+}
+
+// setSource assigns the credentials source found by a provider
+// to the last candidate selected for this provider.
+func (p *MatchProvenance) setSource(pid ProviderIdentity, src CredentialsSource) {
+	if p == nil || src == nil {
+		return
+	}
+	for i := len(p.Candidates) - 1; i >= 0; i-- {
+		c := p.Candidates[i]
+		if c.Provider == pid && c.Selected {
+			c.Source = src
+			p.Winner = c
+			return
+		}
+	}
+}
+
+// matcher wraps an identity matcher to record the
+// matching identities of the given provider.
+func (p *MatchProvenance) matcher(pid ProviderIdentity, m IdentityMatcher) IdentityMatcher {
+	if p == nil {
+		return m
+	}
+	return func(pattern, cur, id ConsumerIdentity) bool {
+		if m(pattern, cur, id) {
+			p.add(pid, id, true)
+			return true
+		}
+		if cur != nil && m(pattern, nil, id) {
+			p.add(pid, id, false)
+		}
+		return false
+	}
+}
+
+// provenanceFor provides the provenance recorder for the top-level
+// evaluation of a credential request. Nested credential requests
+// (for example, for credentials required to access a credential
+// repository) are not recorded.
+func provenanceFor(ectx EvaluationContext, identity ConsumerIdentity, matcher string) (*MatchProvenance, EvaluationContext) {
+	p := GetEvaluationContextFor[*MatchProvenance](ectx)
+	if p == nil {
+		return nil, ectx
+	}
+	p.Requested = identity.Copy()
+	if p.Requested == nil {
+		p.Requested = ConsumerIdentity{}
+	}
+	p.Matcher = matcher
+	return p, SetEvaluationContextFor(ectx, (*MatchProvenance)(nil))
+}
+
+// ExplainCredentialsForConsumer evaluates a credential request for a consumer
+// identity like GetCredentialsForConsumer and records the provenance
+// of the found credentials source. The provenance is returned even if
+// the evaluation fails.
+func ExplainCredentialsForConsumer(ctx Context, identity ConsumerIdentity, matchers ...IdentityMatcher) (*MatchProvenance, CredentialsSource, error) {
+	p := &MatchProvenance{}
+	ectx := SetEvaluationContextFor(&evaluationContext{}, p)
+	src, err := ctx.getCredentialsForConsumer(ectx, identity, matchers...)
+	return p, src, err
+}
+
+// DescribeCredentialsSource provides a short description of
+// the origin of a credentials source.
+func DescribeCredentialsSource(ctx Context, src CredentialsSource) string {
+	if reflect2.IsNil(src) {
+		return ""
+	}
+	switch s := src.(type) {
+	case CredentialsChain:
+		var list []string
+		for _, e := range s {
+			list = append(list, DescribeCredentialsSource(ctx, e))
+		}
+		return strings.Join(list, ", ")
+	case CredentialsSpec:
+		repo := s.GetRepositorySpec(ctx)
+		if reflect2.IsNil(repo) {
+			return s.GetCredentialsName()
+		}
+		if s.GetCredentialsName() == "" {
+			return repo.GetType()
+		}
+		return fmt.Sprintf("%s[%s]", repo.GetType(), s.GetCredentialsName())
+	case Credentials:
+		return "direct"
+	default:
+		return fmt.Sprintf("%T", src)
+	}
+}
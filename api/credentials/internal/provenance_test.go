@@ -0,0 +1,82 @@
+package internal_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/extensions/repositories/memory"
+	"ocm.software/ocm/api/tech/oci/identity"
+)
+
+var _ = Describe("credential provenance", func() {
+	var ctx credentials.Context
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+
+		ctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME, "ghcr.io"),
+			credentials.CredentialsFromList("username", "host"))
+		ctx.SetCredentialsForConsumerWithProvider("acme.org/test", credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME, "ghcr.io", identity.ID_PATHPREFIX, "ocm"),
+			credentials.NewCredentialsSpec("cred", memory.NewRepositorySpec("test")))
+		ctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME, "docker.io"),
+			credentials.CredentialsFromList("username", "docker"))
+	})
+
+	It("records candidates and winner", func() {
+		id := credentials.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io", identity.ID_PATHPREFIX, "ocm/repo")
+		prov, src := Must2(credentials.ExplainCredentialsForConsumer(ctx, id))
+
+		Expect(prov.Requested).To(Equal(id))
+		Expect(prov.Matcher).To(Equal(identity.CONSUMER_TYPE))
+		Expect(prov.Default).To(BeFalse())
+		Expect(len(prov.Candidates)).To(Equal(2))
+		Expect(prov.Winner).NotTo(BeNil())
+		Expect(prov.Winner.Selected).To(BeTrue())
+		Expect(prov.Winner.Exact).To(BeFalse())
+		Expect(string(prov.Winner.Provider)).To(Equal("acme.org/test"))
+		Expect(prov.Winner.Identity[identity.ID_PATHPREFIX]).To(Equal("ocm"))
+		Expect(prov.Winner.Source).To(BeIdenticalTo(src))
+		Expect(credentials.DescribeCredentialsSource(ctx, src)).To(Equal("Memory[cred]"))
+
+		Expect(prov.Candidates[0]).To(BeIdenticalTo(prov.Winner))
+		Expect(prov.Candidates[0].Score).To(Equal(3))
+		Expect(prov.Candidates[1].Selected).To(BeFalse())
+		Expect(prov.Candidates[1].Score).To(Equal(2))
+	})
+
+	It("records candidates in a stable order", func() {
+		id := credentials.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io", identity.ID_PATHPREFIX, "ocm/repo")
+		first, _ := Must2(credentials.ExplainCredentialsForConsumer(ctx, id))
+		for i := 0; i < 10; i++ {
+			prov, _ := Must2(credentials.ExplainCredentialsForConsumer(ctx, id))
+			Expect(len(prov.Candidates)).To(Equal(len(first.Candidates)))
+			for j, c := range prov.Candidates {
+				Expect(c.Identity).To(Equal(first.Candidates[j].Identity))
+				Expect(c.Selected).To(Equal(first.Candidates[j].Selected))
+			}
+		}
+	})
+
+	It("records exact matches", func() {
+		id := credentials.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "docker.io")
+		prov, src := Must2(credentials.ExplainCredentialsForConsumer(ctx, id))
+
+		Expect(len(prov.Candidates)).To(Equal(1))
+		Expect(prov.Winner.Exact).To(BeTrue())
+		Expect(string(prov.Winner.Provider)).To(Equal(""))
+		Expect(credentials.DescribeCredentialsSource(ctx, src)).To(Equal("direct"))
+	})
+
+	It("records failed matches", func() {
+		id := credentials.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "gcr.io")
+		prov, _, err := credentials.ExplainCredentialsForConsumer(ctx, id)
+		Expect(err).To(HaveOccurred())
+		Expect(prov.Candidates).To(BeEmpty())
+		Expect(prov.Winner).To(BeNil())
+	})
+})
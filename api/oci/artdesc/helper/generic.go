@@ -44,19 +44,23 @@ func (g *GenericDescriptor) IsManifest() bool {
 
 func (g *GenericDescriptor) AsManifest() *ociv1.Manifest {
 	return &ociv1.Manifest{
-		Versioned:   g.Versioned,
-		MediaType:   g.MediaType,
-		Config:      g.Config,
-		Layers:      g.Layers,
-		Annotations: g.Annotations,
+		Versioned:    g.Versioned,
+		MediaType:    g.MediaType,
+		ArtifactType: g.ArtifactType,
+		Config:       g.Config,
+		Layers:       g.Layers,
+		Subject:      g.Subject,
+		Annotations:  g.Annotations,
 	}
 }
 
 func (g *GenericDescriptor) AsIndex() *ociv1.Index {
 	return &ociv1.Index{
-		Versioned:   g.Versioned,
-		MediaType:   g.MediaType,
-		Manifests:   g.Manifests,
-		Annotations: g.Annotations,
+		Versioned:    g.Versioned,
+		MediaType:    g.MediaType,
+		ArtifactType: g.ArtifactType,
+		Manifests:    g.Manifests,
+		Subject:      g.Subject,
+		Annotations:  g.Annotations,
 	}
 }
@@ -0,0 +1,96 @@
+package artdesc
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+)
+
+// ReferrersTag provides the tag used by the referrers tag schema
+// (https://github.com/opencontainers/distribution-spec/blob/main/spec.md#referrers-tag-schema)
+// to store the referrers index for artifacts without a registry
+// supporting the referrers API.
+func ReferrersTag(d digest.Digest) string {
+	alg := d.Algorithm().String()
+	if len(alg) > 32 {
+		alg = alg[:32]
+	}
+	ref := d.Encoded()
+	if len(ref) > 64 {
+		ref = ref[:64]
+	}
+	return alg + "-" + ref
+}
+
+// GetSubject returns the subject of the artifact, if it refers
+// to another artifact.
+func (d *Artifact) GetSubject() *Descriptor {
+	switch {
+	case d.manifest != nil:
+		return d.manifest.Subject
+	case d.index != nil:
+		return d.index.Subject
+	}
+	return nil
+}
+
+// SetSubject sets the subject of the artifact, which
+// declares it as referrer of the given artifact.
+func (d *Artifact) SetSubject(subject *Descriptor) error {
+	switch {
+	case d.manifest != nil:
+		d.manifest.Subject = subject
+	case d.index != nil:
+		d.index.Subject = subject
+	default:
+		return errors.ErrInvalid("artifact")
+	}
+	return nil
+}
+
+// GetArtifactType returns the artifact type of the artifact.
+// For manifests without explicit artifact type, the media type of the
+// config blob is used, according to the referrers API.
+func (d *Artifact) GetArtifactType() string {
+	switch {
+	case d.manifest != nil:
+		if d.manifest.ArtifactType != "" {
+			return d.manifest.ArtifactType
+		}
+		return d.manifest.Config.MediaType
+	case d.index != nil:
+		return d.index.ArtifactType
+	}
+	return ""
+}
+
+// ReferrerDescriptor provides the descriptor for a referrer, as it is used
+// in a referrers index. It includes the artifact type and the annotations
+// of the artifact.
+func ReferrerDescriptor(blob blobaccess.BlobAccess, art *Artifact) *Descriptor {
+	d := DefaultBlobDescriptor(blob)
+	d.ArtifactType = art.GetArtifactType()
+	switch {
+	case art.manifest != nil:
+		d.Annotations = art.manifest.Annotations
+	case art.index != nil:
+		d.Annotations = art.index.Annotations
+	}
+	return d
+}
+
+// FilterReferrers filters a list of referrer descriptors by an artifact type.
+// An empty artifact type matches all referrers.
+func FilterReferrers(list []Descriptor, artifactType string) []Descriptor {
+	if artifactType == "" {
+		return list
+	}
+	var result []Descriptor
+	for _, d := range list {
+		if d.ArtifactType == artifactType {
+			result = append(result, d)
+		}
+	}
+	return result
+}
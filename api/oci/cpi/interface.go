@@ -32,6 +32,7 @@ type (
 	Artifact                         = internal.Artifact
 	ArtifactSource                   = internal.ArtifactSource
 	ArtifactSink                     = internal.ArtifactSink
+	ReferrersSink                    = internal.ReferrersSink
 	BlobSource                       = internal.BlobSource
 	BlobSink                         = internal.BlobSink
 	NamespaceLister                  = internal.NamespaceLister
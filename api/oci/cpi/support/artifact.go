@@ -216,6 +216,47 @@ func (a *ArtifactAccessImpl) AddArtifact(art cpi.Artifact, platform *artdesc.Pla
 	return blob, nil
 }
 
+func (a *ArtifactAccessImpl) Referrers(artifactType string) ([]cpi.Descriptor, error) {
+	return a.container.ListReferrers(a.Digest(), artifactType)
+}
+
+func (a *ArtifactAccessImpl) GetReferrer(digest digest.Digest) (cpi.ArtifactAccess, error) {
+	art, err := a.container.GetArtifact("@" + digest.String())
+	if err != nil {
+		return nil, err
+	}
+	if d := art.GetDescriptor(); d == nil || d.GetSubject() == nil || d.GetSubject().Digest != a.Digest() {
+		art.Close()
+		return nil, errors.ErrNotFound(cpi.KIND_OCIARTIFACT, digest.String(), "referrers of "+a.Digest().String())
+	}
+	return art, nil
+}
+
+func (a *ArtifactAccessImpl) AddReferrer(art cpi.Artifact, tags ...string) (cpi.BlobAccess, error) {
+	if a.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	d := art.Artifact()
+	if d == nil || !d.IsValid() {
+		return nil, errors.ErrInvalid(cpi.KIND_OCIARTIFACT)
+	}
+	subject := d.GetSubject()
+	if subject == nil {
+		blob, err := a.Blob()
+		if err != nil {
+			return nil, err
+		}
+		err = d.SetSubject(artdesc.DefaultBlobDescriptor(blob))
+		blob.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else if subject.Digest != a.Digest() {
+		return nil, errors.Newf("artifact refers to %s instead of %s", subject.Digest, a.Digest())
+	}
+	return a.container.AddReferrer(art, tags...)
+}
+
 func (a *ArtifactAccessImpl) AddLayer(blob cpi.BlobAccess, d *cpi.Descriptor) (int, error) {
 	if a.IsReadOnly() {
 		return -1, accessio.ErrReadOnly
@@ -50,6 +50,9 @@ type NamespaceAccessImpl interface {
 	// GetBlobDescriptor(digest digest.Digest) *cpi.Descriptor
 	IsReadOnly() bool
 
+	ListReferrers(digest digest.Digest, artifactType string) ([]cpi.Descriptor, error)
+	AddReferrer(artifact cpi.Artifact, tags ...string) (blobaccess.BlobAccess, error)
+
 	WithContainer(container NamespaceContainer) NamespaceAccessImpl
 }
 
@@ -0,0 +1,95 @@
+package support
+
+import (
+	"slices"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+)
+
+// ReferrersContainer is an optional interface for a NamespaceContainer
+// natively supporting the referrers API. Such a container is responsible
+// for maintaining the referrers of added artifacts with a subject field.
+// For all other containers, the referrers explicitly added with AddReferrer
+// are maintained according to the referrers tag schema as index tagged
+// with artdesc.ReferrersTag. Artifacts added with AddArtifact are never
+// registered, to avoid unexpected tags in such namespaces.
+type ReferrersContainer interface {
+	ListReferrers(digest digest.Digest, artifactType string) ([]cpi.Descriptor, error)
+}
+
+func (i *namespaceAccessImpl) ListReferrers(digest digest.Digest, artifactType string) ([]cpi.Descriptor, error) {
+	if c, ok := i.NamespaceContainer.(ReferrersContainer); ok {
+		return c.ListReferrers(digest, artifactType)
+	}
+	idx, err := i.getReferrersIndex(digest)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+	return artdesc.FilterReferrers(idx.Manifests, artifactType), nil
+}
+
+func (i *namespaceAccessImpl) getReferrersIndex(digest digest.Digest) (*artdesc.Index, error) {
+	tag := artdesc.ReferrersTag(digest)
+	ok, err := i.NamespaceContainer.HasArtifact(tag)
+	if err != nil || !ok {
+		return nil, err
+	}
+	art, err := i.NamespaceContainer.GetArtifact(i, tag)
+	if err != nil {
+		return nil, err
+	}
+	defer art.Close()
+	if !art.IsIndex() {
+		return nil, errors.ErrInvalid("referrers index", tag)
+	}
+	return art.Index()
+}
+
+// AddReferrer adds an artifact with a subject field and registers it
+// as referrer of its subject.
+func (i *namespaceAccessImpl) AddReferrer(artifact cpi.Artifact, tags ...string) (blobaccess.BlobAccess, error) {
+	blob, err := i.NamespaceContainer.AddArtifact(artifact, tags...)
+	if err != nil {
+		return nil, err
+	}
+	return blob, i.addReferrer(artifact, blob)
+}
+
+// addReferrer updates the referrers index according to the referrers
+// tag schema for an added artifact with a subject field.
+func (i *namespaceAccessImpl) addReferrer(artifact cpi.Artifact, blob blobaccess.BlobAccess) error {
+	if _, ok := i.NamespaceContainer.(ReferrersContainer); ok {
+		return nil
+	}
+	art := artifact.Artifact()
+	if art == nil || art.GetSubject() == nil {
+		return nil
+	}
+	subject := art.GetSubject().Digest
+
+	old, err := i.getReferrersIndex(subject)
+	if err != nil {
+		return errors.Wrapf(err, "referrers index for %s", subject)
+	}
+	idx := artdesc.NewIndex()
+	if old != nil {
+		for _, d := range old.Manifests {
+			if d.Digest == blob.Digest() {
+				return nil
+			}
+		}
+		idx.Manifests = slices.Clone(old.Manifests)
+	}
+	idx.AddManifest(artdesc.ReferrerDescriptor(blob, art))
+
+	b, err := i.NamespaceContainer.AddArtifact(idx, artdesc.ReferrersTag(subject))
+	if err != nil {
+		return errors.Wrapf(err, "referrers index for %s", subject)
+	}
+	return b.Close()
+}
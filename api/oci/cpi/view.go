@@ -162,7 +162,10 @@ type namespaceAccessView struct {
 	impl NamespaceAccessImpl
 }
 
-var _ NamespaceAccess = (*namespaceAccessView)(nil)
+var (
+	_ NamespaceAccess        = (*namespaceAccessView)(nil)
+	_ internal.ReferrersSink = (*namespaceAccessView)(nil)
+)
 
 func GetNamespaceAccessImplementation(n NamespaceAccess) (NamespaceAccessImpl, error) {
 	if v, ok := n.(*namespaceAccessView); ok {
@@ -224,6 +227,18 @@ func (n *namespaceAccessView) AddArtifact(a internal.Artifact, tags ...string) (
 	return acc, err
 }
 
+func (n *namespaceAccessView) AddReferrer(a internal.Artifact, tags ...string) (acc internal.BlobAccess, err error) {
+	err = n.Execute(func() error {
+		if r, ok := n.impl.(internal.ReferrersSink); ok {
+			acc, err = r.AddReferrer(a, tags...)
+		} else {
+			acc, err = n.impl.AddArtifact(a, tags...)
+		}
+		return err
+	})
+	return acc, err
+}
+
 func (n *namespaceAccessView) AddTags(digest digest.Digest, tags ...string) error {
 	return n.Execute(func() error {
 		return n.impl.AddTags(digest, tags...)
@@ -395,3 +410,27 @@ func (a *artifactAccessView) AddLayer(access internal.BlobAccess, descriptor *ar
 	})
 	return index, err
 }
+
+func (a *artifactAccessView) Referrers(artifactType string) (list []artdesc.Descriptor, err error) {
+	err = a.Execute(func() error {
+		list, err = a.impl.Referrers(artifactType)
+		return err
+	})
+	return list, err
+}
+
+func (a *artifactAccessView) GetReferrer(digest digest.Digest) (acc internal.ArtifactAccess, err error) {
+	err = a.Execute(func() error {
+		acc, err = a.impl.GetReferrer(digest)
+		return err
+	})
+	return acc, err
+}
+
+func (a *artifactAccessView) AddReferrer(art internal.Artifact, tags ...string) (acc internal.BlobAccess, err error) {
+	err = a.Execute(func() error {
+		acc, err = a.impl.AddReferrer(art, tags...)
+		return err
+	})
+	return acc, err
+}
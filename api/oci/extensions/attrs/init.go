@@ -2,4 +2,5 @@ package attrs
 
 import (
 	_ "ocm.software/ocm/api/oci/extensions/attrs/cacheattr"
+	_ "ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 )
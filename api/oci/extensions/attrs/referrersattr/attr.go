@@ -0,0 +1,121 @@
+package referrersattr
+
+import (
+	"fmt"
+
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	ATTR_KEY   = "ocm.software/oci/referrers"
+	ATTR_SHORT = "ocireferrers"
+)
+
+func init() {
+	datacontext.RegisterAttributeType(ATTR_KEY, AttributeType{}, ATTR_SHORT)
+}
+
+type AttributeType struct{}
+
+func (a AttributeType) Name() string {
+	return ATTR_KEY
+}
+
+func (a AttributeType) Description() string {
+	return `
+*bool|[]string*
+Transfer the artifacts referring to transferred OCI artifacts
+(OCI 1.1 referrers, for example signatures or SBOM attestations), also.
+The value may be a boolean, or a list of artifact types to restrict
+the transferred referrers.
+`
+}
+
+// Attribute describes the referrers to be transferred.
+// An empty list of artifact types transfers all referrers.
+type Attribute struct {
+	ArtifactTypes []string
+}
+
+func (a AttributeType) Encode(v interface{}, marshaller runtime.Marshaler) ([]byte, error) {
+	switch t := v.(type) {
+	case bool:
+		return marshaller.Marshal(t)
+	case []string:
+		return marshaller.Marshal(t)
+	case *Attribute:
+		if t == nil {
+			return marshaller.Marshal(false)
+		}
+		if len(t.ArtifactTypes) == 0 {
+			return marshaller.Marshal(true)
+		}
+		return marshaller.Marshal(t.ArtifactTypes)
+	default:
+		return nil, fmt.Errorf("boolean or list of artifact types required")
+	}
+}
+
+func (a AttributeType) Decode(data []byte, unmarshaller runtime.Unmarshaler) (interface{}, error) {
+	var flag bool
+	if err := unmarshaller.Unmarshal(data, &flag); err == nil {
+		if !flag {
+			return (*Attribute)(nil), nil
+		}
+		return &Attribute{}, nil
+	}
+	var types []string
+	if err := unmarshaller.Unmarshal(data, &types); err != nil {
+		return nil, fmt.Errorf("boolean or list of artifact types required: %w", err)
+	}
+	return &Attribute{ArtifactTypes: types}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Get returns the referrers setting of the context,
+// or nil if referrers should not be transferred.
+func Get(ctx datacontext.Context) *Attribute {
+	a := ctx.GetAttributes().GetAttribute(ATTR_KEY)
+	switch t := a.(type) {
+	case *Attribute:
+		return t
+	case bool:
+		if t {
+			return &Attribute{}
+		}
+	case []string:
+		return &Attribute{ArtifactTypes: t}
+	}
+	return nil
+}
+
+func Set(ctx datacontext.Context, attr *Attribute) error {
+	return ctx.GetAttributes().SetAttribute(ATTR_KEY, attr)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Consumer is an optional interface for objects used by an operation,
+// like access methods, accepting an operation specific referrers setting.
+// It overrides the setting of the context attribute.
+type Consumer interface {
+	SetReferrers(attr *Attribute)
+}
+
+// Provider is an optional interface for objects used by an operation,
+// which provide the effective referrers setting for this operation.
+type Provider interface {
+	GetReferrers() *Attribute
+}
+
+// For returns the referrers setting for an object used by an
+// operation. If the object is no Provider, the setting of
+// the context is used.
+func For(ctx datacontext.Context, o interface{}) *Attribute {
+	if p, ok := o.(Provider); ok {
+		return p.GetReferrers()
+	}
+	return Get(ctx)
+}
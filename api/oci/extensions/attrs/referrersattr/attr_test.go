@@ -0,0 +1,47 @@
+package referrersattr_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/config"
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/oci"
+	me "ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+var _ = Describe("attribute", func() {
+	var ctx ocm.Context
+	var cfgctx config.Context
+
+	BeforeEach(func() {
+		cfgctx = config.WithSharedAttributes(datacontext.New(nil)).New()
+		credctx := credentials.WithConfigs(cfgctx).New()
+		ocictx := oci.WithCredentials(credctx).New()
+		ctx = ocm.WithOCIRepositories(ocictx).New()
+	})
+
+	It("local setting", func() {
+		Expect(me.Get(ctx)).To(BeNil())
+		MustBeSuccessful(me.Set(ctx, &me.Attribute{ArtifactTypes: []string{"application/spdx+json"}}))
+		Expect(me.Get(ctx)).To(Equal(&me.Attribute{ArtifactTypes: []string{"application/spdx+json"}}))
+	})
+
+	It("parses boolean", func() {
+		Expect(me.AttributeType{}.Decode([]byte("true"), runtime.DefaultYAMLEncoding)).To(Equal(&me.Attribute{}))
+		Expect(me.AttributeType{}.Decode([]byte("false"), runtime.DefaultYAMLEncoding)).To(BeNil())
+	})
+
+	It("parses artifact types", func() {
+		Expect(me.AttributeType{}.Decode([]byte("[application/spdx+json]"), runtime.DefaultYAMLEncoding)).To(Equal(&me.Attribute{ArtifactTypes: []string{"application/spdx+json"}}))
+	})
+
+	It("sets from config", func() {
+		MustBeSuccessful(ctx.GetAttributes().SetEncodedAttribute(me.ATTR_SHORT, []byte("true"), runtime.DefaultYAMLEncoding))
+		Expect(me.Get(ctx)).To(Equal(&me.Attribute{}))
+	})
+})
@@ -71,6 +71,13 @@ func (a *ArtifactSet) Close() error { // why???
 	return a.NamespaceAccess.Close()
 }
 
+func (a *ArtifactSet) AddReferrer(art cpi.Artifact, tags ...string) (cpi.BlobAccess, error) {
+	if r, ok := a.NamespaceAccess.(cpi.ReferrersSink); ok {
+		return r.AddReferrer(art, tags...)
+	}
+	return a.NamespaceAccess.AddArtifact(art, tags...)
+}
+
 func (a *ArtifactSet) GetBlobData(digest digest.Digest) (int64, blobaccess.DataAccess, error) {
 	return a.container.GetBlobData(digest)
 }
@@ -88,6 +88,17 @@ func SynthesizeArtifactBlobWithFilter(ns cpi.NamespaceAccess, ref string, filter
 }
 
 func SynthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, refs []string, filter ...filters.Filter) (ArtifactBlob, error) {
+	return synthesizeArtifactBlobForArtifact(art, refs, false, nil, filter...)
+}
+
+// SynthesizeArtifactBlobWithReferrers synthesizes an artifact blob for an artifact
+// incorporating the artifacts referring to it, like signatures or SBOMs.
+// If artifact types are given, only referrers with those types are incorporated.
+func SynthesizeArtifactBlobWithReferrers(art cpi.ArtifactAccess, refs []string, artifactTypes []string, filter ...filters.Filter) (ArtifactBlob, error) {
+	return synthesizeArtifactBlobForArtifact(art, refs, true, artifactTypes, filter...)
+}
+
+func synthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, refs []string, referrers bool, artifactTypes []string, filter ...filters.Filter) (ArtifactBlob, error) {
 	blob, err := art.Blob()
 	if err != nil {
 		return nil, err
@@ -109,6 +120,12 @@ func SynthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, refs []string, fi
 		if err != nil {
 			return "", fmt.Errorf("failed to transfer artifact: %w", err)
 		}
+		if referrers {
+			err = transfer.TransferReferrers(art, set, artifactTypes...)
+			if err != nil {
+				return "", fmt.Errorf("failed to transfer referrers: %w", err)
+			}
+		}
 		set.Annotate(MAINARTIFACT_ANNOTATION, dig.String())
 		return blob.MimeType(), nil
 	})
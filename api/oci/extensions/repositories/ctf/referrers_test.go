@@ -0,0 +1,157 @@
+package ctf_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/oci/extensions/repositories/ctf/testhelper"
+
+	"github.com/mandelsoft/goutils/finalizer"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/oci/tools/transfer"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	NAMESPACE      = "acme.org/image"
+	TYPE_SIGNATURE = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	TYPE_SBOM      = "application/spdx+json"
+)
+
+func addReferrer(art cpi.ArtifactAccess, n cpi.NamespaceAccess, artifactType, data string) *cpi.Descriptor {
+	config := blobaccess.ForData(ociv1.MediaTypeEmptyJSON, ociv1.DescriptorEmptyJSON.Data)
+	MustBeSuccessful(n.AddBlob(config))
+	layer := blobaccess.ForString(mime.MIME_OCTET, data)
+	MustBeSuccessful(n.AddBlob(layer))
+
+	m := artdesc.NewManifest()
+	m.ArtifactType = artifactType
+	m.Config = *artdesc.DefaultBlobDescriptor(config)
+	m.Layers = append(m.Layers, *artdesc.DefaultBlobDescriptor(layer))
+
+	blob := Must(art.AddReferrer(m))
+	defer Close(blob, "referrer")
+	return artdesc.DefaultBlobDescriptor(blob)
+}
+
+var _ = Describe("ctf referrers", func() {
+	var tempfs vfs.FileSystem
+
+	BeforeEach(func() {
+		tempfs = Must(osfs.NewTempFileSystem())
+	})
+
+	AfterEach(func() {
+		vfs.Cleanup(tempfs)
+	})
+
+	It("stores and lists referrers", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		r := Must(ctf.Create(oci.DefaultContext(), accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs), accessobj.FormatDirectory))
+		finalize.Close(r, "repo")
+		n := Must(r.LookupNamespace(NAMESPACE))
+		finalize.Close(n, "namespace")
+		DefaultManifestFill(n)
+
+		art := Must(n.GetArtifact(TAG))
+		finalize.Close(art, "artifact")
+		Expect(art.Referrers("")).To(BeEmpty())
+
+		sig := addReferrer(art, n, TYPE_SIGNATURE, "signature")
+		sbom := addReferrer(art, n, TYPE_SBOM, "sbom")
+
+		list := Must(art.Referrers(""))
+		Expect(list).To(HaveLen(2))
+		Expect(list[0].Digest).To(Equal(sig.Digest))
+		Expect(list[0].ArtifactType).To(Equal(TYPE_SIGNATURE))
+		Expect(list[1].Digest).To(Equal(sbom.Digest))
+		Expect(list[1].ArtifactType).To(Equal(TYPE_SBOM))
+
+		list = Must(art.Referrers(TYPE_SBOM))
+		Expect(list).To(HaveLen(1))
+		Expect(list[0].Digest).To(Equal(sbom.Digest))
+
+		ref := Must(art.GetReferrer(sig.Digest))
+		defer Close(ref, "referrer")
+		Expect(ref.GetDescriptor().GetSubject().Digest).To(Equal(art.Digest()))
+		Expect(ref.GetDescriptor().GetArtifactType()).To(Equal(TYPE_SIGNATURE))
+
+		Expect(n.HasArtifact(artdesc.ReferrersTag(art.Digest()))).To(BeTrue())
+		ExpectError(art.GetReferrer(art.Digest())).To(HaveOccurred())
+	})
+
+	It("does not register plain artifacts with a subject as referrers", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		r := Must(ctf.Create(oci.DefaultContext(), accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs), accessobj.FormatDirectory))
+		finalize.Close(r, "repo")
+		n := Must(r.LookupNamespace(NAMESPACE))
+		finalize.Close(n, "namespace")
+		DefaultManifestFill(n)
+
+		art := Must(n.GetArtifact(TAG))
+		finalize.Close(art, "artifact")
+		blob := Must(art.Blob())
+		finalize.Close(blob, "artifact blob")
+
+		config := blobaccess.ForData(ociv1.MediaTypeEmptyJSON, ociv1.DescriptorEmptyJSON.Data)
+		MustBeSuccessful(n.AddBlob(config))
+		m := artdesc.NewManifest()
+		m.ArtifactType = TYPE_SIGNATURE
+		m.Config = *artdesc.DefaultBlobDescriptor(config)
+		m.Subject = artdesc.DefaultBlobDescriptor(blob)
+		MustBeSuccessful(Must(n.AddArtifact(m)).Close())
+
+		Expect(n.HasArtifact(artdesc.ReferrersTag(art.Digest()))).To(BeFalse())
+		Expect(n.ListTags()).To(ConsistOf(TAG))
+		Expect(art.Referrers("")).To(BeEmpty())
+	})
+
+	It("transfers referrers", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		r := Must(ctf.Create(oci.DefaultContext(), accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs), accessobj.FormatDirectory))
+		finalize.Close(r, "repo")
+		n := Must(r.LookupNamespace(NAMESPACE))
+		finalize.Close(n, "namespace")
+		DefaultManifestFill(n)
+		art := Must(n.GetArtifact(TAG))
+		finalize.Close(art, "artifact")
+		sig := addReferrer(art, n, TYPE_SIGNATURE, "signature")
+		addReferrer(art, n, TYPE_SBOM, "sbom")
+
+		t := Must(ctf.Create(oci.DefaultContext(), accessobj.ACC_CREATE, "target", 0o700, accessio.PathFileSystem(tempfs), accessobj.FormatDirectory))
+		tn := Must(t.LookupNamespace(NAMESPACE))
+		MustBeSuccessful(transfer.TransferArtifactWithReferrers(art, tn, []string{TYPE_SIGNATURE}, TAG))
+		MustBeSuccessful(tn.Close())
+		MustBeSuccessful(t.Close())
+
+		t = Must(ctf.Open(oci.DefaultContext(), accessobj.ACC_READONLY, "target", 0, accessio.PathFileSystem(tempfs)))
+		finalize.Close(t, "target")
+		tart := Must(t.LookupArtifact(NAMESPACE, TAG))
+		finalize.Close(tart, "target artifact")
+
+		list := Must(tart.Referrers(""))
+		Expect(list).To(HaveLen(1))
+		Expect(list[0].Digest).To(Equal(sig.Digest))
+		ref := Must(tart.GetReferrer(sig.Digest))
+		finalize.Close(ref, "target referrer")
+		blob := Must(ref.GetBlob(ref.ManifestAccess().GetDescriptor().Layers[0].Digest))
+		finalize.Close(blob, "signature blob")
+		Expect(blob.Get()).To(Equal([]byte("signature")))
+	})
+})
@@ -30,7 +30,10 @@ type NamespaceContainer struct {
 	checked  bool
 }
 
-var _ support.NamespaceContainer = (*NamespaceContainer)(nil)
+var (
+	_ support.NamespaceContainer = (*NamespaceContainer)(nil)
+	_ support.ReferrersContainer = (*NamespaceContainer)(nil)
+)
 
 func NewNamespace(repo *RepositoryImpl, name string) (cpi.NamespaceAccess, error) {
 	ref := repo.GetRef(name, "")
@@ -161,6 +164,23 @@ func (n *NamespaceContainer) GetArtifact(i support.NamespaceAccessImpl, vers str
 	return support.NewArtifactForBlob(i, blobaccess.ForDataAccess(desc.Digest, desc.Size, desc.MediaType, acc))
 }
 
+// ListReferrers lists the referrers of an artifact using the referrers API.
+// Registries without support for the referrers API are handled by oras
+// according to the referrers tag schema, which is also maintained by oras
+// when pushing manifests with a subject field.
+func (n *NamespaceContainer) ListReferrers(digest digest.Digest, artifactType string) ([]cpi.Descriptor, error) {
+	ref := n.repo.GetRef(n.impl.GetNamespace(), digest.String())
+	n.repo.GetContext().Logger().Debug("list referrers", "ref", ref, "artifactType", artifactType)
+	_, desc, err := n.resolver.Resolve(context.Background(), ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, errors.ErrNotFound(cpi.KIND_OCIARTIFACT, ref, n.impl.GetNamespace())
+		}
+		return nil, err
+	}
+	return n.lister.Referrers(dummyContext, desc, artifactType)
+}
+
 func (n *NamespaceContainer) HasArtifact(vers string) (bool, error) {
 	ref := n.repo.GetRef(n.impl.GetNamespace(), vers)
 	n.repo.GetContext().Logger().Debug("check artifact", "ref", ref)
@@ -132,6 +132,38 @@ func (r *RepositoryImpl) getCreds(comp string) (credentials.Credentials, error)
 	return identity.GetCredentials(r.GetContext(), r.info.Locator, comp)
 }
 
+// authCredential maps OCM credentials to the credentials used
+// for the registry authorization.
+func authCredential(creds credentials.Credentials) auth.Credential {
+	if creds == nil {
+		return auth.EmptyCredential
+	}
+	username := creds.GetProperty(credentials.ATTR_USERNAME)
+	password := creds.GetProperty(credentials.ATTR_PASSWORD)
+	token := creds.GetProperty(credentials.ATTR_IDENTITY_TOKEN)
+
+	// If ATTR_PASSWORD was not set but there IS a username defined we do have an ATTR_IDENTITY_TOKEN set,
+	// we have to provide that token through the `Password` field for authentication.
+	if password == "" && token != "" && username != "" {
+		password = token
+	}
+
+	authCreds := auth.Credential{
+		Username: username,
+		Password: password,
+		// ATTR_ACCESS_TOKEN is a registry access token, which is directly used as bearer token.
+		AccessToken: creds.GetProperty(identity.ATTR_ACCESS_TOKEN),
+	}
+
+	// If there was NO username set ( for example, docker login, azure login, etc... ) but the token
+	// IS set we are dealing with a RefreshToken. RefreshTokens CANNOT be used together with a username.
+	// There are checks for that resulting in a "The operation is unsupported" error.
+	if token != "" && username == "" {
+		authCreds.RefreshToken = token
+	}
+	return authCreds
+}
+
 func (r *RepositoryImpl) getResolver(comp string) (oras.Resolver, error) {
 	creds, err := r.getCreds(comp)
 	if err != nil {
@@ -144,31 +176,6 @@ func (r *RepositoryImpl) getResolver(comp string) (oras.Resolver, error) {
 		logger.Trace("no credentials")
 	}
 
-	authCreds := auth.Credential{}
-	if creds != nil {
-		username := creds.GetProperty(credentials.ATTR_USERNAME)
-		password := creds.GetProperty(credentials.ATTR_PASSWORD)
-		token := creds.GetProperty(credentials.ATTR_IDENTITY_TOKEN)
-
-		// If ATTR_PASSWORD was not set but there IS a username defined we do have an ATTR_IDENTITY_TOKEN set,
-		// we have to provide that token through the `Password` field for authentication.
-		if password == "" && token != "" && username != "" {
-			password = token
-		}
-
-		authCreds = auth.Credential{
-			Username: username,
-			Password: password,
-		}
-
-		// If there was NO username set ( for example, docker login, azure login, etc... ) but the token
-		// IS set we are dealing with a RefreshToken. RefreshTokens CANNOT be used together with a username.
-		// There are checks for that resulting in a "The operation is unsupported" error.
-		if token != "" && username == "" {
-			authCreds.RefreshToken = token
-		}
-	}
-
 	if creds != nil && r.transport.TLSClientConfig != nil {
 		c := creds.GetProperty(credentials.ATTR_CERTIFICATE_AUTHORITY)
 		if c != "" {
@@ -190,7 +197,13 @@ func (r *RepositoryImpl) getResolver(comp string) (oras.Resolver, error) {
 		Cache:  auth.NewCache(),
 		Credential: auth.CredentialFunc(func(ctx context.Context, hostport string) (auth.Credential, error) {
 			if strings.Contains(hostport, r.info.HostPort()) {
-				return authCreds, nil
+				// credentials are resolved for every authorization, because
+				// they might be short-lived, for example exchanged tokens.
+				creds, err := r.getCreds(comp)
+				if err != nil && !errors.IsErrUnknownKind(err, credentials.KIND_CONSUMER) {
+					return auth.EmptyCredential, err
+				}
+				return authCredential(creds), nil
 			}
 			logger.Warn("no credentials for host", "host", hostport)
 			return auth.EmptyCredential, nil
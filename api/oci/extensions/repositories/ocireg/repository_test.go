@@ -8,6 +8,7 @@ import (
 
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/tech/oci/identity"
 	common "ocm.software/ocm/api/utils/misc"
 )
 
@@ -55,3 +56,44 @@ ABC=
 		transport.TLSClientConfig.RootCAs.AppendCertsFromPEM([]byte(c))
 	})
 }
+
+func TestAuthCredential(t *testing.T) {
+	t.Run("username and password", func(t *testing.T) {
+		cred := authCredential(credentials.NewCredentials(common.Properties{
+			credentials.ATTR_USERNAME: "user",
+			credentials.ATTR_PASSWORD: "pass",
+		}))
+		assert.Equal(t, "user", cred.Username)
+		assert.Equal(t, "pass", cred.Password)
+		assert.Empty(t, cred.RefreshToken)
+		assert.Empty(t, cred.AccessToken)
+	})
+
+	t.Run("identity token is used as refresh token", func(t *testing.T) {
+		cred := authCredential(credentials.NewCredentials(common.Properties{
+			credentials.ATTR_IDENTITY_TOKEN: "refresh",
+		}))
+		assert.Equal(t, "refresh", cred.RefreshToken)
+		assert.Empty(t, cred.AccessToken)
+	})
+
+	t.Run("access token is used as access token", func(t *testing.T) {
+		cred := authCredential(credentials.NewCredentials(common.Properties{
+			identity.ATTR_ACCESS_TOKEN: "access",
+		}))
+		assert.Equal(t, "access", cred.AccessToken)
+		assert.Empty(t, cred.RefreshToken)
+	})
+
+	t.Run("generic token is not used as access token", func(t *testing.T) {
+		cred := authCredential(credentials.NewCredentials(common.Properties{
+			credentials.ATTR_USERNAME: "user",
+			credentials.ATTR_PASSWORD: "pass",
+			credentials.ATTR_TOKEN:    "other",
+		}))
+		assert.Equal(t, "user", cred.Username)
+		assert.Equal(t, "pass", cred.Password)
+		assert.Empty(t, cred.AccessToken)
+		assert.Empty(t, cred.RefreshToken)
+	})
+}
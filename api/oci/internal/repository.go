@@ -56,6 +56,17 @@ type ArtifactSink interface {
 	AddTags(digest digest.Digest, tags ...string) error
 }
 
+// ReferrersSink is an optional interface for an ArtifactSink, which
+// is able to register added artifacts as referrers of their subject.
+// This is required for sinks without native support for the
+// referrers API, which maintain the referrers according to the
+// referrers tag schema only for artifacts explicitly added as referrers.
+type ReferrersSink interface {
+	// AddReferrer adds an artifact with a subject field and registers
+	// it as referrer of its subject.
+	AddReferrer(a Artifact, tags ...string) (BlobAccess, error)
+}
+
 type ArtifactSource interface {
 	GetArtifact(version string) (ArtifactAccess, error)
 	GetBlobData(digest digest.Digest) (int64, DataAccess, error)
@@ -97,6 +108,16 @@ type ArtifactAccessImpl interface {
 
 	NewArtifact(...Artifact) (ArtifactAccess, error)
 
+	// Referrers lists the descriptors of the artifacts referring to this
+	// artifact by their subject field. If an artifact type is given,
+	// only referrers with this artifact type are listed.
+	Referrers(artifactType string) ([]artdesc.Descriptor, error)
+	// GetReferrer provides access to a referrer of this artifact.
+	GetReferrer(digest digest.Digest) (ArtifactAccess, error)
+	// AddReferrer adds an artifact referring to this artifact.
+	// If the artifact has no subject, it is set to this artifact.
+	AddReferrer(art Artifact, tags ...string) (BlobAccess, error)
+
 	io.Closer
 }
 
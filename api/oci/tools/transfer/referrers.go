@@ -0,0 +1,90 @@
+package transfer
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/set"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+// TransferArtifactWithReferrers transfers an artifact together with
+// the artifacts referring to it, like signatures or SBOMs.
+// If artifact types are given, only referrers with those types
+// are transferred.
+func TransferArtifactWithReferrers(art cpi.ArtifactAccess, sink cpi.ArtifactSink, artifactTypes []string, tags ...string) error {
+	err := TransferArtifact(art, sink, tags...)
+	if err != nil {
+		return err
+	}
+	return TransferReferrers(art, sink, artifactTypes...)
+}
+
+// TransferReferrers transfers the artifacts referring to the given artifact
+// to the given sink. Referrers of transferred referrers are transferred, also.
+// If artifact types are given, only referrers with those types are transferred.
+// The referrers are added as referrers to sinks implementing
+// cpi.ReferrersSink, otherwise the sink is responsible for maintaining
+// the referrers information for the transferred artifacts.
+func TransferReferrers(art cpi.ArtifactAccess, sink cpi.ArtifactSink, artifactTypes ...string) error {
+	return transferReferrers(art, sink, set.New[digest.Digest](), artifactTypes)
+}
+
+func transferReferrers(art cpi.ArtifactAccess, sink cpi.ArtifactSink, visited set.Set[digest.Digest], artifactTypes []string) error {
+	if visited.Contains(art.Digest()) {
+		return nil
+	}
+	visited.Add(art.Digest())
+
+	list, err := referrers(art, artifactTypes)
+	if err != nil {
+		return errors.Wrapf(err, "listing referrers of %s", art.Digest())
+	}
+	for _, d := range list {
+		logging.Logger().Debug("transfer referrer", "subject", art.Digest(), "digest", d.Digest, "artifactType", d.ArtifactType)
+		ref, err := art.GetReferrer(d.Digest)
+		if err != nil {
+			return errors.Wrapf(err, "getting referrer %s", d.Digest)
+		}
+		err = TransferArtifact(ref, referrersSink{sink})
+		if err == nil {
+			err = transferReferrers(ref, sink, visited, artifactTypes)
+		}
+		ref.Close()
+		if err != nil {
+			return errors.Wrapf(err, "transferring referrer %s", d.Digest)
+		}
+	}
+	return nil
+}
+
+func referrers(art cpi.ArtifactAccess, artifactTypes []string) ([]cpi.Descriptor, error) {
+	if len(artifactTypes) == 0 {
+		return art.Referrers("")
+	}
+	var result []cpi.Descriptor
+	for _, t := range artifactTypes {
+		list, err := art.Referrers(t)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, list...)
+	}
+	return result, nil
+}
+
+// referrersSink adds the transferred artifacts with a subject field
+// as referrers, if supported by the sink.
+type referrersSink struct {
+	cpi.ArtifactSink
+}
+
+func (s referrersSink) AddArtifact(art cpi.Artifact, tags ...string) (cpi.BlobAccess, error) {
+	if r, ok := s.ArtifactSink.(cpi.ReferrersSink); ok {
+		if d := art.Artifact(); d != nil && d.GetSubject() != nil {
+			return r.AddReferrer(art, tags...)
+		}
+	}
+	return s.ArtifactSink.AddArtifact(art, tags...)
+}
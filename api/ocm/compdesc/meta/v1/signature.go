@@ -17,6 +17,11 @@ const (
 	// NoDigest used in digest field for hashAlgorithm and value (in combination with ExcludeFromSignature for normalisationAlgorithm)
 	// to indicate the resource content should not be part of the signature.
 	NoDigest = "NO-DIGEST"
+
+	// CountersignatureNormalisation is the normalisation algorithm used for
+	// the digest of a countersignature. It describes the canonical (rfc8785)
+	// JSON representation of the countersigned signature.
+	CountersignatureNormalisation = "ocmSignature/v1"
 )
 
 // Signatures is a list of signatures.
@@ -76,6 +81,30 @@ func (s Signatures) GetByName(name string) *Signature {
 	return nil
 }
 
+// GetCountersigned follows the countersignature chain starting at the
+// signature with the given name and returns the chain of signatures
+// up to the signature covering the component descriptor (last element).
+func (s Signatures) GetCountersigned(name string) ([]*Signature, error) {
+	var chain []*Signature
+
+	visited := map[string]bool{}
+	for {
+		sig := s.GetByName(name)
+		if sig == nil {
+			return nil, fmt.Errorf("signature %q not found", name)
+		}
+		if visited[name] {
+			return nil, fmt.Errorf("countersignature cycle for signature %q", name)
+		}
+		visited[name] = true
+		chain = append(chain, sig)
+		if sig.Countersigns == "" {
+			return chain, nil
+		}
+		name = sig.Countersigns
+	}
+}
+
 func (s *Signatures) Set(sig Signature) {
 	if idx := s.GetIndex(sig.Name); idx < 0 {
 		*s = append(*s, sig)
@@ -182,6 +211,10 @@ type Signature struct {
 	Digest    DigestSpec     `json:"digest"`
 	Signature SignatureSpec  `json:"signature"`
 	Timestamp *TimestampSpec `json:"timestamp,omitempty"`
+	// Countersigns is the name of another signature covered
+	// by this signature. If set, the digest is calculated on the
+	// countersigned signature instead of the component descriptor.
+	Countersigns string `json:"countersigns,omitempty"`
 }
 
 // Copy provides a copy of the signature data.
@@ -193,6 +226,12 @@ func (s *Signature) Copy() *Signature {
 	return &r
 }
 
+// IsCountersignature reports whether the signature covers
+// another signature instead of the component descriptor.
+func (s *Signature) IsCountersignature() bool {
+	return s != nil && s.Countersigns != ""
+}
+
 // ConvertToSigning converts a cd signature to a signing signature.
 func (s *Signature) ConvertToSigning() *signing.Signature {
 	return s.Signature.ConvertToSigning()
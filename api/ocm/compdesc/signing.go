@@ -2,9 +2,11 @@ package compdesc
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"hash"
 
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/general"
 
@@ -66,6 +68,31 @@ func Hash(cd *ComponentDescriptor, normAlgo string, hash hash.Hash) (string, err
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// NormalizeSignature provides the normalized form of a signature
+// used to calculate the digest of a countersignature
+// (normalisation algorithm metav1.CountersignatureNormalisation).
+func NormalizeSignature(sig *metav1.Signature) ([]byte, error) {
+	data, err := json.Marshal(sig)
+	if err != nil {
+		return nil, err
+	}
+	return jsoncanonicalizer.Transform(data)
+}
+
+// SignatureDigest calculates the digest of a signature
+// covered by a countersignature.
+func SignatureDigest(sig *metav1.Signature, hash hash.Hash) (string, error) {
+	normalized, err := NormalizeSignature(sig)
+	if err != nil {
+		return "", fmt.Errorf("failed normalising signature %q: %w", sig.Name, err)
+	}
+	hash.Reset()
+	if _, err = hash.Write(normalized); err != nil {
+		return "", fmt.Errorf("failed hashing normalised signature %q: %w", sig.Name, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 type CompDescDigest struct {
 	normAlgo   string
 	hashAlgo   string
@@ -78,7 +78,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _ResourcesComponentDescriptorOcmV3SchemaYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x19\x5f\x6f\xdb\xb6\xf3\xdd\x9f\xe2\x80\x04\x90\xdd\x44\x76\xe2\xa2\x0f\xd5\x4b\x50\xb4\x2f\x3f\xfc\xb6\x75\x58\x8b\x3d\x2c\xf5\x02\x46\x3a\xd9\x4c\x25\x52\x23\x69\x27\x5e\x9b\xef\x3e\x90\x14\x25\x4a\x96\x1c\xcb\x49\x1f\x86\xbd\x24\xe2\xf1\xee\x78\xbc\xff\x47\x9f\xd2\x24\x82\x60\xa5\x54\x21\xa3\xd9\x6c\x49\x44\x82\x0c\xc5\x34\xce\xf8\x3a\x99\xc9\x78\x85\x39\x91\xb3\x98\xe7\x05\x67\xc8\x54\x98\xa0\x8c\x05\x2d\x14\x17\x21\x8f\xf3\x70\xf3\x9a\x64\xc5\x8a\x5c\x06\xa3\x53\x8b\xeb\xf1\xba\x93\x9c\x85\x16\x3a\xe5\x62\x39\x4b\x04\x49\xd5\x6c\x7e\x31\xbf\x08\x2f\xe7\x25\xeb\x60\xe4\x18\x52\xce\x22\x08\x3e\xbe\xff\x19\xde\xbb\xc3\xe0\x43\x75\x18\x6c\x5e\x83\xa3\x38\x4d\x30\x95\xd1\x08\x20\x47\x45\xf4\x7f\x00\xb5\x2d\x30\x82\x80\xdf\xde\x61\xac\x02\x03\x6a\xf2\xad\x2e\x00\x1b\x14\x92\x72\x66\x88\x13\xa2\x88\xc5\x16\xf8\xd7\x9a\x0a\x4c\x2c\x3b\x80\x10\x02\x46\x72\x0c\xea\x65\x49\x67\x21\x24\x49\xa8\xe6\x4c\xb2\x5f\x05\x2f\x50\x28\x8a\x32\x82\x94\x64\x12\xcd\x7e\x51\x43\x4b\x0e\x9a\x9b\xfb\x06\x38\x15\x98\x46\x10\x9c\xcc\xcc\x5d\x6a\xf5\xfe\xe2\x9d\x59\x1e\xd8\x4b\x24\x30\x23\x0f\x98\x7c\xc2\x7c\x83\xc2\x11\x65\xe4\x16\x33\xd9\x4b\x63\xb7\x1d\x72\x21\xf8\x86\x26\x28\x7a\xd1\x1d\x82\x23\x88\x05\x12\x7d\xed\xcf\xd4\xbf\x8c\x55\xbe\x54\x82\xb2\x65\x05\x4c\xb9\xc8\x89\x8a\x20\x21\x0a\x43\x45\x73\x1c\x19\x83\x89\x25\xf6\x5a\x6c\x57\x69\x24\x5b\x72\x41\xd5\x2a\xaf\x0f\x2b\x88\x52\x28\xb4\x49\xff\xbc\x26\xe1\xdf\x0b\xfd\xe7\x22\x7c\x3b\xbb\x09\x17\x67\xa7\x95\x9c\x9c\xa5\x74\x19\xc1\x37\x78\x3c\xc0\x5c\xbe\xce\x4a\xb1\x88\x10\x64\x6b\xb9\x51\x85\x79\x25\x50\x97\x3a\x03\xc7\xa2\xf7\x62\x07\x38\x17\xc9\xd6\xd8\xa7\x85\xa6\xeb\x74\x68\xdb\x50\x47\xf0\xed\xb1\xcf\x73\x3c\xa5\x6d\xae\x2f\xc2\xb7\x9e\xaa\x24\x5d\x32\xca\x96\x6d\xfe\xc1\x2d\xe7\x19\x12\xe6\xd0\x3c\xcb\x75\xe8\xc1\xec\x3e\x1d\x19\x23\x6d\x19\xcf\xd3\x1b\x0a\xb3\x37\xb2\x4c\x72\xf2\xf0\x13\xb2\xa5\x5a\x45\x30\x7f\xf3\x66\xd4\x69\xf7\xd0\x1a\x7e\xf1\x6a\x7c\x3d\x5d\xb4\x40\x93\x57\x0e\xf6\x6d\x7e\xfe\x38\x9e\x35\xb6\x6f\x3a\x48\x6e\x34\xcd\x44\x6b\x65\x04\x40\x13\x64\x8a\xaa\xed\x3b\xa5\x04\xbd\x5d\x2b\xfc\x3f\x6e\xad\xa8\x39\x65\x95\x5c\x5d\x52\xe9\xc3\xc7\xd7\xe1\xcd\x99\x13\xc4\x01\x27\x57\x96\x75\x23\x66\x2d\xcf\x13\x50\xe4\x2b\x32\x48\x05\xcf\x41\x9a\x0d\x9d\x2d\x81\xb0\x04\x48\x72\xb7\x96\x0a\x13\x50\x1c\x48\x96\xf1\x7b\x20\x0c\x78\x61\xf5\x0b\x19\x92\x84\xb2\x25\x04\x9b\xe0\x1c\x72\x72\xa7\x53\x32\xcb\xb6\xe7\x86\xd4\xac\xa7\x39\x65\x25\xd4\x9d\xb5\xa2\x12\x72\x24\x4c\x82\x5a\x21\xa4\x5c\x73\xd5\x4c\xac\xfa\x25\x10\x81\xfa\x28\xed\x53\x34\x69\xca\x2b\x9d\xc0\x97\xd3\xf9\xf4\xb5\xff\x1d\xa6\x9c\x9f\xdd\x12\x51\xc2\x36\x3e\xc2\xa6\x0b\xe3\x72\x3a\x77\x5f\x15\x9a\x87\x5f\x7d\x36\xc8\x7c\x65\x6f\x16\x57\xe3\x8b\xef\xd7\x97\xe1\xdb\xc5\x97\xe4\xd5\x64\x7c\x15\x7d\x99\xfa\x80\xc9\x55\x37\x28\x1c\x8f\xaf\xa2\x1a\xf8\xfd\x4b\x62\x6c\xf4\x2e\xfc\x23\x5c\xe8\xc8\x70\xdf\x8e\xe5\x81\xc8\x13\x77\xe2\xd9\xd8\xdf\x38\x33\x4c\x1a\x10\x83\x59\x46\x5f\xcb\xf3\xbb\x5c\xef\xa9\x64\xb9\xd5\x71\x24\x75\xa6\x6b\x85\x64\x97\x13\x07\xf0\x68\x9d\xb0\xe0\x92\x2a\x2e\xb6\xef\x39\x53\xf8\xa0\x86\x24\x2e\x8d\xd5\x97\xa8\x0c\x87\x76\x22\xf1\x6e\x47\xe2\x18\xa5\x3c\xb0\x62\xdf\x12\x89\x06\x4b\x97\x92\x92\x14\x25\x8c\xf5\x0a\x1f\x14\x32\x9d\xe2\xe4\xe4\x09\x41\x47\x00\x92\xaf\x45\x8c\x1f\x30\xa5\xcc\x64\xa6\x01\xb7\xd5\x99\xb7\x5a\x94\x59\xb5\x5a\x6b\x0e\xd5\xc2\xca\x37\x20\x81\x37\xf2\x5d\x47\x4a\xed\xb4\x5f\x89\x8c\x0f\x4a\x90\xff\x95\x08\xbd\x49\x79\x87\xc3\xb3\x1a\x8b\xbd\xb6\xb5\xc0\x41\xbd\x87\xef\x0b\x1d\xc8\x76\xdb\xd8\x2f\xa1\x4b\x94\xea\x53\x81\xf1\x00\xcb\xad\x88\x5c\xbd\x73\xdd\x43\x6d\x4f\xdd\x94\x64\x54\x9a\x26\x66\x77\xdb\xd4\xd1\x23\xfb\xbb\xc6\x81\x7b\xab\x75\xb7\x10\x07\x14\xf8\x6e\x8c\x11\x80\x6e\xaf\xa4\x22\x79\xd1\x56\x92\xd5\x51\x8f\xc4\xfb\x98\x96\xa0\x23\xdb\x3c\xdd\x53\x10\xb5\x16\x38\xd0\x68\x64\x8f\x45\xf4\x2a\xc7\x84\x92\xcf\x2e\xec\x86\xdb\xa8\xa3\x9d\x1c\xa8\x6c\x0b\xaa\xe4\xa8\xb1\x9a\xb9\xeb\xf3\x0a\x2d\x92\x4d\x60\x3c\x35\xc5\xb6\x52\x0b\x78\xed\x5e\x97\x3d\x2b\xc4\x63\x53\x95\x0d\x99\x6a\x59\xf1\x7b\x91\xd9\xa5\x43\x21\xf6\xbc\xde\x60\xae\x23\xd8\xef\x3a\xbd\x1b\x76\xd0\x34\x7c\x28\xf0\x1c\xd2\x38\x7a\x2f\x59\x23\x14\x4c\xfa\x60\xa8\x3b\xa8\x0f\xc7\x24\x91\x3e\x9d\x1e\xd3\xa2\xb7\x73\x6e\x07\xce\x33\xd3\xfa\x00\x23\x54\x6a\xa9\x46\x6d\xab\x9f\xfe\xda\x3c\xb0\x36\xb6\x1c\x50\x60\x59\x84\xed\x29\x2f\xe2\x86\x3f\x6c\x84\x1e\xec\xcc\xad\xdb\xed\x54\x49\x6f\x98\x84\xf6\x40\xd9\x71\x40\xdb\x61\x6d\x13\x23\xe2\xdf\x30\x3d\xb0\x75\x22\x20\x30\x45\x81\x2c\x46\x33\x39\xc0\xb8\x7e\xbf\xc9\x78\x4c\xb2\x49\xd9\x14\x1d\xfb\x98\xe1\x7c\xf0\x13\x66\x18\x2b\xde\xff\x80\xd0\xeb\xac\x07\xf6\x0a\xa6\x5b\x2d\xaf\x72\xec\xe5\xab\xbb\x1f\x3a\x8c\x77\xba\xd2\xf3\x5f\x80\x3a\x46\xdf\x43\xfd\x78\x5f\x03\x09\x27\x40\x62\xb5\x26\x59\xb6\x8d\xea\x33\x42\x53\x78\xee\x67\x20\x0b\x8c\x29\xc9\xb4\x93\x2a\x41\x63\x2d\xb2\xfc\xb7\xf4\x9c\x83\x1a\xca\x76\xd8\x72\x86\x1f\x53\x3f\xcc\x42\xa7\x38\xb6\xce\xb2\xa0\xb1\xb1\x3f\x55\x56\xf1\xfd\xf4\x08\xb1\x6f\x84\x71\x6c\xe4\xd0\x17\x47\x38\x31\xf4\x26\x72\x6b\x2e\xe7\xe5\x2c\xbf\x96\x0a\x72\xa2\xe2\x95\xe7\xe8\x72\x27\x21\x7b\x73\x9b\x59\x6a\x7d\xab\xca\x99\x0d\xc8\xb5\xdc\xdd\xde\xfb\x1f\x99\x61\x6c\x9e\x7d\x76\x0e\xb7\x6c\xea\x02\x61\x95\xfd\xa4\xfe\x90\xad\xf3\x08\xae\x03\x63\xea\xe0\x1c\x02\x3d\xe8\x0a\x46\xb2\x60\x71\x4c\x48\x1c\x38\x63\xfd\xe8\xf8\x69\x3e\x33\x0f\x7d\x1f\x7d\x99\x76\xf5\xb8\x51\x55\x17\xde\x7d\x2d\x63\x33\xdc\x4d\xaa\x4d\x69\x6c\x6c\xed\x9a\xfe\x98\x33\x85\x4c\xe9\xa5\x57\x8a\x9c\xff\xaa\x63\xef\x58\x26\x81\x67\xfb\x69\x2b\xb1\xd5\x1e\x5b\x96\xd0\x67\x9f\x50\x71\x6a\x77\x4b\x2f\xc0\x79\x57\xfa\x51\xcb\x48\xbe\x67\xe9\x14\x57\xd0\xdf\xeb\x22\x1e\x42\xf0\x95\xb2\xa4\xfc\xf4\x7f\x0f\x0a\xad\x31\x83\x51\x53\xf1\x35\x79\xef\xcb\x75\x19\xc1\x10\xf0\x38\x9f\xb6\x7e\x4c\xab\x7e\x2b\x3b\xb7\xdb\x92\xa7\xea\x9e\x08\xac\x37\x40\x87\xb9\x96\xa9\x97\x7f\xcc\x99\x54\x11\x04\x55\xe3\xee\xdd\xc7\xdd\xc0\x12\xef\x3c\xd1\xdb\xab\xed\x3c\xfe\x1d\xf5\xc3\xc7\x0e\x17\x5d\xa4\xe2\xb5\x10\xc8\x54\xb6\x3d\x87\x7b\x04\xce\xb2\x6d\xf9\x68\x6d\x0a\x15\x67\xd8\x08\xa7\xb6\x27\x96\x0d\x75\x35\xf7\x1d\x25\x57\x45\x1d\xb4\x26\xbf\xa3\xb8\x75\xcf\x48\xc1\x3f\x01\x00\x00\xff\xff\x5a\x12\x6d\xad\x32\x1d\x00\x00")
+var _ResourcesComponentDescriptorOcmV3SchemaYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x59\x5b\x6f\xdb\xb6\x17\x7f\xd7\xa7\x38\x40\x02\xd0\x6e\x22\x3b\x49\xd1\x87\xea\x25\x28\xda\x97\x3f\xfe\xdb\x3a\xac\xc5\x1e\x96\x7a\x01\x23\x1d\xd9\x4c\x25\xd2\x23\x69\x27\x5e\x9b\xef\x3e\x90\x12\xa9\x8b\x29\xc7\x76\x5b\x60\x43\x81\x46\x3a\x3c\x17\x9e\xdf\xb9\xf0\x50\x3e\x65\x59\x02\x64\xa1\xf5\x52\x25\xd3\xe9\x9c\xca\x0c\x39\xca\x49\x5a\x88\x55\x36\x55\xe9\x02\x4b\xaa\xa6\xa9\x28\x97\x82\x23\xd7\x71\x86\x2a\x95\x6c\xa9\x85\x8c\x45\x5a\xc6\xeb\x97\xb4\x58\x2e\xe8\x25\x89\x4e\x2b\xde\x96\xae\x7b\x25\x78\x5c\x51\x27\x42\xce\xa7\x99\xa4\xb9\x9e\x5e\x5d\x5c\x5d\xc4\x97\x57\xb5\x6a\x12\x39\x85\x4c\xf0\x04\xc8\xfb\xb7\x3f\xc3\x5b\x67\x0c\xde\x79\x63\xb0\x7e\x09\x4e\xe2\x34\xc3\x5c\x25\x11\x40\x89\x9a\x9a\xbf\x00\x7a\xb3\xc4\x04\x88\xb8\xbb\xc7\x54\x13\x4b\xea\xea\xf5\x0e\xc0\x1a\xa5\x62\x82\x5b\xe1\x8c\x6a\x5a\x71\x4b\xfc\x6b\xc5\x24\x66\x95\x3a\x80\x18\x08\xa7\x25\x92\xe6\xb5\x96\xab\x28\x34\xcb\x98\x66\x82\xd3\xe2\x57\x29\x96\x28\x35\x43\x95\x40\x4e\x0b\x85\x76\x7d\xd9\x50\x6b\x0d\x46\x9b\x7b\x06\x38\x95\x98\x27\x40\x4e\xa6\xd6\x97\x06\xde\x5f\x5a\x36\x6b\x83\x83\x42\x12\x0b\xfa\x88\xd9\x07\x2c\xd7\x28\x9d\x50\x41\xef\xb0\x50\x83\x32\xd5\xb2\x63\x5e\x4a\xb1\x66\x19\xca\x41\x76\xc7\xe0\x04\x52\x89\xd4\xb8\xfd\x91\xb5\x9d\xa9\xc0\x57\x5a\x32\x3e\xf7\xc4\x5c\xc8\x92\xea\x04\x32\xaa\x31\xd6\xac\xc4\xc8\x06\x4c\xce\x71\x30\x62\xdb\xa0\xd1\x62\x2e\x24\xd3\x8b\xb2\x31\xb6\xa4\x5a\xa3\x34\x21\xfd\xf3\x86\xc6\x7f\xcf\xcc\x7f\x17\xf1\xeb\xe9\x6d\x3c\x3b\x3b\xf5\xfb\x14\x3c\x67\xf3\x04\xbe\xc0\xd3\x1e\xe1\x6a\x63\x56\x6f\x8b\x4a\x49\x37\x95\x36\xa6\xb1\xf4\x1b\x0a\xc1\x49\x9c\x8a\x41\xc7\xf6\x48\x2e\x5a\xac\x70\x08\x05\x4e\x9f\x41\xdb\x4a\x27\xf0\xe5\x69\x28\x73\x5a\xa0\xad\x6f\x2e\xe2\xd7\x2d\xa8\x14\x9b\x73\xc6\xe7\x7d\xfd\xe4\x4e\x88\x02\x29\x77\x6c\xad\xc8\x05\x70\xb0\xab\xcf\x57\x46\x04\xd0\xc9\xf4\x0e\x60\x95\x47\x95\x92\x92\x3e\xfe\x84\x7c\xae\x17\x09\x5c\xbd\x7a\x15\x05\xe3\x1e\x57\x81\x9f\xbd\x18\xdd\x4c\x66\x3d\xd2\xf8\x85\xa3\x7d\xb9\x3a\x7f\x1a\x4d\x3b\xcb\xb7\x01\x91\x5b\x23\x33\x36\xa8\x44\x00\x2c\x43\xae\x99\xde\xbc\xd1\x5a\xb2\xbb\x95\xc6\xff\xe3\xa6\xda\x6a\xc9\xb8\xdf\x57\x68\x57\x06\xda\xd1\x4d\x7c\x7b\xe6\x36\xe2\x88\xe3\xeb\x4a\x75\xa7\x66\x2b\x9d\x27\xa0\xe9\x67\xe4\x90\x4b\x51\x82\xb2\x0b\xa6\x5b\x02\xe5\x19\xd0\xec\x7e\xa5\x34\x66\xa0\x05\xd0\xa2\x10\x0f\x40\x39\x08\xdb\xd3\x68\x01\x05\xd2\x8c\xf1\x39\x90\x35\x39\x87\x92\xde\x9b\x96\xcc\x8b\xcd\xb9\x15\xb5\xef\x93\x92\xf1\x9a\xea\x6c\x2d\x98\x82\x12\x29\x57\xa0\x17\x08\xb9\x30\x5a\x8d\x92\x0a\x7e\x05\x54\xa2\x31\x05\x6b\x5a\xb0\xac\xbb\xdf\x3a\x21\x4f\xe0\x72\x72\x35\x79\xd9\x7e\x8e\x73\x21\xce\xee\xa8\xac\x69\xeb\x36\xc3\x3a\xc4\x71\x39\xb9\x72\x4f\xf5\xdf\x75\xf3\xe0\xd7\xd6\x97\x1d\xb1\x36\xd8\xeb\xd9\xf5\xe8\xe2\xeb\xcd\x65\xfc\x7a\xf6\x29\x7b\x31\x1e\x5d\x27\x9f\x26\x6d\xc2\xf8\x3a\x4c\x8a\x47\xa3\xeb\xa4\x21\x7e\xfd\x94\xd9\x18\xbd\x89\xff\x88\x67\xa6\x32\xdc\xb3\x53\xb9\x27\xf3\xd8\x59\x3c\x1b\xb5\x17\xce\x0c\x69\xd2\xa1\x58\xce\x53\x12\xca\xfc\x50\xea\x3d\xd7\x2c\x37\xe6\xc4\x50\xa6\xd3\xf5\x4a\x32\x94\xc4\x04\x9e\xaa\x24\x5c\x0a\xc5\xb4\x90\x9b\xb7\x82\x6b\x7c\xd4\x87\x34\x2e\xc3\x35\xd4\xa8\xcc\x9a\x7b\x0e\x79\x47\xd3\x14\x95\x1a\xb4\xd6\x3d\xb1\xef\xa8\x42\xcb\x05\xb9\x90\xb5\x28\x2a\x18\x99\x37\x7c\xd4\xc8\xcd\x29\xae\xc6\xcf\x6c\x34\x02\x50\x62\x25\x53\x7c\x87\x39\xe3\xf6\x10\x38\xc0\x5b\xd3\x79\xfd\x4b\xdd\x55\xfd\xbb\xd1\xe0\x5f\xaa\xfd\x1d\xd0\xc0\x3d\x2e\x03\x2d\x35\x18\xbf\x5a\x07\x3e\x6a\x49\xff\x57\x33\x24\x7b\x6b\x20\x43\xc7\x43\x4f\xb0\x53\xf4\x64\x9f\xd8\x1e\x31\x7b\xb4\x73\x21\xc0\x5c\x2d\xdb\x9a\xc8\xd8\x1c\x95\xfe\xb0\xc4\xf4\x80\xc8\x2d\xa8\x5a\xbc\x71\xd3\x83\xa7\x72\x33\x94\x14\x4c\xd9\x21\x66\x7b\xd9\x9e\xa3\x7b\x0c\x0c\xa1\x18\x77\x0c\xf6\x81\xea\x9c\xd6\xe1\x4d\xec\x14\xb1\x1b\x1b\xe0\x88\x00\xcc\x78\xa5\x34\x2d\x97\x7d\x90\xaa\xea\x1a\xd8\xf1\x2e\xa5\x35\xe9\xc8\x31\xcf\xcc\x14\x54\xaf\x24\x1e\x18\x34\x3f\xee\x05\x22\x62\xe2\x53\x62\xc6\xe8\xc7\xcd\xf2\xd8\x18\x05\xc6\xc9\x03\xc1\x76\xc3\x50\xbd\x8f\x86\xab\xdb\xbb\x3e\x2e\xb0\x62\xb2\xa0\x81\xc8\xed\x61\xeb\x61\xa9\x4c\x90\xb0\x89\x36\x7e\xc7\xb6\xaa\xaa\x64\xfc\xab\xd7\x77\x24\x6e\xcf\x0e\xa0\x95\xbd\xc1\x62\x6e\x2a\xd8\xf9\xdc\xf3\x30\x20\xe3\x39\xda\x62\x3e\xd1\x07\xc5\x3a\xa5\xe0\xc4\x52\xb1\xe2\xda\x74\xef\x39\x57\xbb\x42\x66\xfc\xdc\x0e\x56\x2a\xd6\x28\x31\x83\xbb\x4d\x35\x3d\x35\x2b\xa3\x96\x62\x4b\x19\x93\x30\x4a\x11\x00\x47\x33\xc8\xbd\x3b\xa6\x97\x0d\x85\xf6\x88\x40\x6d\xb5\xfe\x00\xcf\x37\x9e\x2e\x07\xe4\x82\x87\xc5\xdf\xf8\x2b\x7c\xd4\xb1\xe0\xd4\xee\x85\xc0\x32\xbc\x12\xeb\x59\xc0\x92\xd5\x77\xa9\x86\x1f\x76\x93\x3f\xb8\xa6\x7a\xde\x35\x92\xdb\x77\xda\xad\x7b\x6d\xc0\x40\x3f\x61\xed\x59\xac\x64\xfa\x1b\xe6\x83\xe1\xe9\x96\x14\x05\x89\x39\x4a\xe4\x29\xda\x0b\x0c\x8c\x3c\x3a\x71\x21\x52\x5a\x8c\xeb\xd9\xec\xd8\x6f\x2a\x2e\x07\x3f\x60\x81\xa9\x16\xf2\xf0\x64\xdd\x73\x64\x89\xa0\x71\xe5\x58\xe7\xbd\xef\xfb\x7e\x13\x08\xa6\xd2\xb7\x7f\x88\xea\xa8\x1d\xf4\x3c\x68\x7c\xd7\x1c\x0b\x27\x40\x53\xbd\xa2\x45\xb1\x49\x1a\x1b\xb1\x61\x82\x87\x29\xa8\x25\xa6\x8c\x16\x20\xd1\xf4\x9a\xd4\x6c\x59\x0d\xd9\xfe\xb7\x8d\xbe\x07\xcd\xb5\xfd\xb2\x15\x1c\xdf\xd7\x05\xe3\x22\x58\x03\xc7\x57\x45\xe1\xa4\xcc\xbf\x78\x57\x89\x47\xad\xfa\x7e\xfe\x26\xb3\xeb\x26\xe5\xd4\xa8\x7d\xf3\xd0\xe5\x1b\x9c\xd8\x9b\x98\xad\xdc\x46\xcb\x79\xfd\x49\x61\xa5\x34\x94\x54\xa7\x8b\x26\xf8\x44\x39\xbc\x43\xd7\x47\xab\xdb\x74\x3e\xed\x93\xd9\x92\xdc\xe4\xbf\x4f\xf3\xed\xa6\xe0\x50\x80\xfe\x83\x57\xa9\xaa\xcf\xaa\x2d\xae\x03\x7b\x78\xa5\xc6\xf1\x3b\xb0\x9f\xb1\x0d\x80\x7c\x55\x26\x70\x43\x6c\xa8\xc9\x39\x10\x73\xdf\x96\x9c\x16\x64\x76\x4c\x49\xec\x79\xd5\xfb\xd1\xf5\xd3\xfd\xda\x1d\xa8\x9a\x9d\xa5\x70\x78\xa3\xdd\x23\x55\xf7\x84\xd1\x1c\xbc\xbb\x46\xc6\x6e\xb9\xdb\x56\x9b\xb3\xd4\xde\x2f\xdd\x38\x9b\x9a\x2f\x3d\x5c\x9b\xd7\xd6\x51\xe4\xf2\x57\x1f\xeb\x63\xdd\x04\xb6\xdc\x3c\x34\x4f\x7b\x8d\xcd\x49\xfa\x23\xf4\x9b\x2d\x78\x4d\x8d\xea\xef\xb4\x77\x89\xdb\xbb\x8f\x7a\x41\x6a\x67\x96\x69\x71\x4b\xf6\x7b\x73\x88\xc7\x40\x3e\x33\x9e\xd5\x8f\xed\x9f\xa5\xe2\x2a\x98\x24\xea\x02\xdf\x88\x27\xd1\x40\x6a\xd5\x15\x0c\x44\xa4\xe5\xa4\xf7\x9b\x9e\xff\xc9\xee\xbc\x5a\x56\x22\xd7\x0f\x54\x62\xb3\x00\xa6\xcc\xcd\x9e\x06\xf5\xa7\x82\x2b\x9d\x00\xf1\x83\x7b\xcb\x1f\xe7\x41\x12\x05\xd0\x32\x8b\x24\xf4\x0d\x52\x75\x6c\xb5\x62\xd0\xc1\x7f\x0b\xfb\x9e\x16\x02\x27\x90\xae\xa4\x44\xae\xcd\x87\xf0\x07\x04\xc1\x8b\x4d\xfd\xed\xdc\x1e\x54\x82\x63\xa7\x9c\x7a\x1a\x55\x3d\x50\xfb\x2b\xdd\x51\xfb\xf2\xd2\xa4\x77\xf3\x3b\x4a\x5b\xf8\x8e\x44\xfe\x19\x00\xfb\xbe\x94\x84\xb9\x1d\x00\x00")
 
 func ResourcesComponentDescriptorOcmV3SchemaYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -78,7 +78,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _ResourcesComponentDescriptorV2SchemaYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x59\x4b\x6f\x1b\x37\x10\xbe\xeb\x57\x0c\x60\x03\x94\x62\xaf\x64\xab\xc8\x21\xba\x18\x41\x72\x29\xfa\x48\x91\x04\x3d\xd4\x51\x0d\x7a\x77\x24\xd1\xdd\x25\xb7\x24\xa5\x58\x4d\xfc\xdf\x0b\x92\xcb\x7d\x72\xf5\x70\xe2\xa6\x45\x7d\x48\x96\xb3\xc3\x99\xe1\xcc\x37\x0f\xae\x4e\x59\x32\x03\xb2\xd2\x3a\x57\xb3\xc9\x64\x49\x65\x82\x1c\xe5\x38\x4e\xc5\x3a\x99\xa8\x78\x85\x19\x55\x93\x58\x64\xb9\xe0\xc8\x75\x94\xa0\x8a\x25\xcb\xb5\x90\xd1\x66\x4a\x06\xa7\x8e\xa3\x26\xe1\x4e\x09\x1e\x39\xea\x58\xc8\xe5\x24\x91\x74\xa1\x27\xd3\x8b\xe9\x45\x74\x39\x2d\x04\x92\x81\x17\xc3\x04\x9f\x01\x79\x93\x23\x87\x57\x5e\x07\xfc\x24\x12\x4c\x61\x33\x05\xcf\x7d\x9a\xe0\x42\xcd\x06\x00\x19\x6a\x6a\xfe\x07\xd0\xdb\x1c\x67\x40\xc4\xed\x1d\xc6\x9a\x58\x52\x53\x66\x69\x32\x54\x26\xdb\xfd\x09\xd5\xd4\x6d\x90\xf8\xe7\x9a\x49\x4c\x9c\x44\x80\x08\x88\xd3\xf8\x2b\x4a\xc5\x04\x77\x5c\xb9\x14\x39\x4a\xcd\x50\x79\xbe\x06\x93\x27\x96\x26\x29\x2d\x19\x5f\x92\x81\x35\x57\x2e\xb1\xd7\xde\xae\x60\x9a\x2e\x85\x64\x7a\x95\x55\x42\x73\xaa\x35\x4a\x73\xa0\xdf\xaf\x69\xf4\xd7\xdc\xfc\x73\x11\xbd\x98\xdc\x44\xf3\xb3\x53\x52\xb0\xc5\x82\x2f\xd8\x72\x06\x9f\xe0\xc1\x52\x68\x92\x30\xe3\x06\x9a\xfe\x52\xe9\x80\x05\x4d\x15\x0e\x00\x52\x7a\x8b\x69\xaf\x55\x01\xa7\x70\x9a\x21\xa9\x96\x1b\x9a\xae\xb1\xef\x08\x86\xb7\xd7\x25\x8e\x68\xf7\xcf\xe0\xd3\x83\x5f\xb7\x1d\x59\x3b\xf3\xe6\xfa\x22\x7a\x51\x3b\xa9\x62\x4b\xce\xf8\xb2\xa3\xe1\x56\x88\x14\x29\xf7\x6c\x35\xc7\x9b\xbf\x53\x89\x8b\x19\x90\x93\x89\x05\xd2\xc4\xbe\xb5\x01\x2a\x41\xf2\x73\x69\x76\xc0\xe4\x8c\xde\xff\x88\x7c\xa9\x57\x33\x98\x3e\x7f\x3e\x08\x86\x25\x72\x71\x99\x3f\x1b\x5e\x8f\xe7\x2d\xd2\xe8\x99\xa7\x7d\x9a\x9e\x3f\x0c\x27\x8d\xd7\x37\x81\x2d\x37\x66\xcf\xc8\x9c\x7a\x00\xc0\x12\xe4\x9a\xe9\xed\x4b\xad\x25\xbb\x5d\x6b\xfc\x01\xb7\xce\xd4\x8c\xf1\xd2\xae\x90\x55\x46\xf9\xf0\x3a\xba\x39\xf3\x86\x78\xe2\xe8\xca\x89\x96\x98\xd2\x7b\x4c\xde\x61\xb6\x41\xe9\x64\x9e\x80\xa6\x7f\x20\x87\x85\x14\x19\x28\xfb\xc2\xa4\x31\x50\x9e\x00\x4d\xee\xd6\x4a\x63\x02\x5a\x00\x4d\x53\xf1\x11\x28\x07\x91\x3b\xa4\x41\x8a\x34\x61\x7c\x09\x64\x43\xce\x21\xa3\x77\x42\x46\x82\xa7\xdb\x73\xbb\xd5\xae\xc7\x19\xe3\x05\xd5\xeb\x5a\x31\x05\x19\x52\xae\x40\xaf\x10\x16\xc2\x48\x35\x42\x9c\xfb\x15\x50\x89\x46\x95\xc1\x0c\x4b\x9a\xf6\x2a\x6f\xf0\xe5\x78\x3a\xfe\xae\xfe\x1c\x2d\x84\x38\xbb\xa5\xb2\xa0\x6d\xea\x0c\x9b\x10\xc7\xe5\x78\xea\x9f\x4a\xb6\x1a\x7f\xf9\xd8\xd8\x56\x77\xf6\x66\x7e\x35\xbc\xf8\x7c\x7d\x19\xbd\x98\x7f\x48\x9e\x8d\x86\x57\xb3\x0f\xe3\x3a\x61\x74\x15\x26\x45\xc3\xe1\xd5\xac\x22\x7e\xfe\x90\xd8\x18\xbd\x8c\x7e\x8b\xe6\x06\xf9\xfe\xd9\x8b\x3c\x90\x79\xe4\x35\x9e\x0d\xeb\x2f\xce\xac\x90\x06\xc5\x72\x16\xd9\xd5\xad\x5f\x1d\xe8\xed\xab\x65\x5b\x93\x47\xca\x14\xa2\x56\xca\x85\x40\x4c\xe0\xc1\x81\x30\x17\x8a\x69\x21\xb7\xaf\x04\xd7\x78\xaf\x8f\x29\x4d\x86\xab\xaf\x14\x59\x09\x3b\xaa\x33\x8d\x63\x54\xea\xc0\x76\x72\x4b\x15\x5a\x2e\x58\x08\x59\x6c\x45\x05\x43\xb3\xc2\x7b\x8d\xdc\x94\x30\x35\xda\x63\xe8\x00\x40\x89\xb5\x8c\xf1\x35\x2e\x18\xb7\x35\xfa\x88\xd3\x9a\xda\x5a\x2e\x8a\xaa\x59\xae\x8d\x84\x72\xe1\xec\x7b\x7c\x89\xee\x94\xcc\x60\xfc\x0a\x66\xbc\xd7\x92\x7e\x5f\x30\xf4\x16\xdd\x8e\x04\xd2\x57\xfe\x5b\x1b\x1b\x49\x4f\x0e\x89\xad\x23\xda\x36\xa7\x3a\x4c\x54\x4a\xba\xad\xce\xc9\x34\x66\x35\xa6\x8e\x76\x2b\xc5\xb3\xd7\x11\x13\x62\x76\xef\x6d\x98\x13\xb6\x44\xa5\xdf\xe5\x18\x1f\x11\xe0\x15\x55\xab\x97\x7e\x06\xa8\xc2\x2e\x64\x46\x53\xa6\xa8\x81\x4b\xf7\xb5\x6d\xa7\x3d\xa1\x6e\x08\x6c\xbb\xc2\xb9\xcb\x83\x22\xa8\x64\xe7\x16\xd7\xc7\xc3\x1c\x03\x00\xcd\x32\x54\x9a\x66\x79\xdb\x09\xce\x07\x3d\x16\xef\x12\x5a\x90\x58\x17\xbe\x0d\x06\x30\x29\x9a\x51\x3d\x83\x84\x6a\x8c\x0c\xbf\x4d\x3c\xb6\xe4\x54\xaf\x25\x1e\x19\x14\xba\xc3\xe3\x66\x95\x61\xc2\xe8\x7b\x9f\x7d\x07\x0d\x75\x47\x3a\xd3\x91\x4a\x3d\x15\x57\xb3\x44\xbd\x5f\xa1\x63\x72\x75\x4a\x2c\x6c\x4f\x2d\x8f\x0d\xb5\xb9\x2d\x14\xaf\x92\xf1\xb1\x15\xc9\x41\xbe\x5c\x96\xf2\x0e\x18\x49\x0f\x2d\x53\x0d\x87\x38\x7d\xbd\x75\xa3\xca\xc0\xfa\xf0\x58\x3b\x61\x60\x4f\x03\x23\xa4\x06\x38\x0b\xe4\xde\x6d\x0d\xa8\xdb\xf4\xe7\x68\x06\xa5\xd7\x8f\x29\x02\xa5\x4f\x1f\xe1\x92\x4e\x2d\x0d\xf0\x7c\x61\xb9\x3e\xc2\xeb\xa5\x1f\xca\xab\x9d\x73\x48\x7f\xcf\x3d\xa2\xe7\xed\xf3\x4e\xcb\xae\xc6\xa4\xff\x45\xad\xe7\x68\xd8\x49\x2c\x7a\x7e\xfd\xf0\xe6\x4f\x70\x7c\xb3\xa8\xf7\x9e\xa8\xa7\x49\x41\xa8\x51\x05\x74\xb7\x51\x47\x42\xb2\xf9\x3a\x4d\xdd\x24\x22\xe3\xb7\xb8\x38\x70\xfe\xa1\x20\x71\x81\x12\x79\x8c\x76\xfc\x87\x61\xf5\x4d\x20\x15\x31\x4d\x47\xc5\x64\x43\x1e\x99\xee\x1e\x70\xef\x30\xc5\x58\x0b\x79\x3c\x32\xdb\x0d\xff\xab\xbb\xb7\x31\x0b\x84\x7d\x5a\x3a\xe5\xad\xf7\xd6\x63\xfd\x5b\x4a\x3a\xf4\x5a\x1e\x44\xb8\xb9\xae\xd7\x3f\x66\x1c\x1f\x97\xc0\x15\x39\xe0\x9a\xa0\xf2\x5d\x83\x26\x9c\x00\x8d\xf5\x9a\xa6\xe9\x76\x56\xe9\x88\x6c\xe7\xfa\x38\x01\x95\x63\xcc\x68\x6a\x72\x47\x4b\x16\x1b\x93\xd5\x7f\x65\x36\xfd\xe6\x38\x74\xc4\x76\x9d\xea\x37\xa3\xbe\xcb\xbd\xd8\x5d\xd4\xcb\x82\xb6\xff\x12\xb3\xeb\x12\xe5\xc5\xa8\x83\x3f\x3c\x15\x48\x86\x13\xbb\xdf\x96\x9d\x4a\xca\x79\xf1\x35\x61\xad\x34\x64\x54\xc7\xab\x5a\x0a\xa9\xce\x75\xa9\x76\x73\xb4\x4b\x13\x49\x5d\xa6\x89\x25\xf9\x69\x3e\x9c\x17\xff\x93\x5b\x94\x6b\x12\x4f\x89\x66\xa7\x61\x2f\x9c\x7d\x80\xf6\xfa\x1c\xf9\x3a\x9b\xc1\x35\xb1\xf0\x20\xe7\x40\xcc\xf5\x5c\x72\x9a\x92\xf9\xbf\x2b\x41\x3b\xb7\xc9\xbe\xeb\xa4\x7b\xf9\x74\xf9\x5c\xe6\xc9\xc1\xbd\xea\xe8\xe6\xd4\x68\x42\x45\xc2\xb5\x3e\xfb\xa8\xda\xcb\x5c\x8a\x0d\x4b\x2a\xa0\x46\x40\x1a\xb5\xa2\xd9\xf0\xca\x5e\xab\x1a\xf2\x1b\x3b\xbe\xd9\xb8\x18\x4b\xb4\xb8\x7d\x1f\xb8\xb6\x5e\x7b\xf0\x9e\x17\x41\x9b\x97\x0c\xdd\x2b\xac\xa3\x77\xdd\xf6\x84\x38\xee\x28\xdb\x8b\x69\x1f\xb8\x7f\xfe\xdb\x4c\x11\xed\xa7\x2c\x54\xad\xa6\xb7\xd7\x19\x01\x80\x3e\xa1\x79\x5d\x6d\x07\xd4\xd4\xa7\xf7\x5a\x77\x58\xd8\x61\x56\xab\xf4\xd4\xeb\x4a\x04\x24\x43\xf7\xcb\x5d\x3d\xf7\xc9\xa0\x99\xd9\xd5\x2f\x84\x9d\x1f\x7d\xdc\xe6\x56\xb1\xeb\x73\x22\xa9\x7f\x11\x29\xce\xd8\xf0\x4d\x9f\x5f\xf6\x81\xb6\x14\x4a\xda\x82\x7c\x64\xea\xb7\xb8\xaf\xa9\x39\x7c\x17\x0f\x9b\xf1\x77\x00\x00\x00\xff\xff\xe4\xe4\x24\xa2\x16\x1e\x00\x00")
+var _ResourcesComponentDescriptorV2SchemaYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x59\x5f\x6f\xdb\x38\x12\x7f\xd7\xa7\x18\x20\x01\x68\x37\x91\x9d\xf8\xd0\x87\xea\x25\x28\xda\x97\xc3\xfd\xe9\xa1\x2d\xee\xe1\x52\x5f\xc0\x48\x63\x9b\x59\x89\xf4\x92\xb4\x1b\x6f\x9b\xef\xbe\x20\x25\x52\xff\x28\xc7\xf6\x36\xd8\x2e\x52\x34\xd2\x70\x38\x33\xfc\xcd\x6f\x86\xa4\x72\xce\xb2\x04\xc8\x4a\xeb\xb5\x4a\xa6\xd3\x25\x95\x19\x72\x94\x93\x34\x17\x9b\x6c\xaa\xd2\x15\x16\x54\x4d\x53\x51\xac\x05\x47\xae\xe3\x0c\x55\x2a\xd9\x5a\x0b\x19\x6f\x67\x24\x3a\x2f\x35\x1a\x16\x1e\x94\xe0\x71\x29\x9d\x08\xb9\x9c\x66\x92\x2e\xf4\x74\x76\x35\xbb\x8a\xaf\x67\x95\x41\x12\x39\x33\x4c\xf0\x04\xc8\x87\x35\x72\x78\xe7\x7c\xc0\xbf\x44\x86\x39\x6c\x67\xe0\xb4\xcf\x33\x5c\xa8\x24\x02\x28\x50\x53\xf3\x1b\x40\xef\xd6\x98\x00\x11\xf7\x0f\x98\x6a\x62\x45\x6d\x9b\x3e\x64\x2f\x17\xd2\xce\xcf\xa8\xa6\xe5\x04\x89\xbf\x6e\x98\xc4\xac\xb4\x08\x10\x03\x29\x3d\xfe\x17\xa5\x62\x82\x97\x5a\x6b\x29\xd6\x28\x35\x43\xe5\xf4\x5a\x4a\x4e\xe8\x43\x52\x5a\x32\xbe\x24\x91\x0d\x57\x2e\x71\x30\xde\xbe\x61\x9a\x2f\x85\x64\x7a\x55\xd4\x46\xd7\x54\x6b\x94\x66\x41\xff\xbf\xa5\xf1\x6f\x73\xf3\xdf\x55\xfc\x66\x7a\x17\xcf\x2f\xce\x49\xa5\x96\x0a\xbe\x60\xcb\x04\xbe\xc1\x93\x95\xd0\x2c\x63\x9a\x09\x4e\xf3\xff\xd4\x3e\x60\x41\x73\x85\x11\x40\x4e\xef\x31\x1f\x8c\x2a\x00\x0a\xa7\x05\x3a\x57\x31\x90\x2d\xcd\x37\x38\xb4\x04\xa3\xeb\x9e\x7b\x90\x18\x11\x80\x9d\x9f\xc0\xb7\xa7\x4a\x6d\xdb\x05\xb2\xb1\xe6\xed\xed\x55\xfc\xa6\xb1\x52\xc5\x96\x9c\xf1\x65\xcf\xc3\xbd\x10\x39\xd2\x2a\x63\x2d\xe0\xcd\xbf\x73\x89\x8b\x04\xc8\xd9\xd4\x12\x69\x6a\x47\x6d\x82\x3c\x49\xfe\xed\xc3\x0e\x84\x5c\xd0\xc7\x7f\x22\x5f\xea\x55\x02\xb3\xd7\xaf\xa3\x60\x5a\xe2\x32\x2f\xf3\x57\xa3\xdb\xc9\xbc\x23\x1a\xbf\x72\xb2\x6f\xb3\xcb\xa7\xd1\xb4\x35\x7c\x17\x98\x72\x67\xe6\x8c\xcd\xaa\x23\x00\x96\x21\xd7\x4c\xef\xde\x6a\x2d\xd9\xfd\x46\xe3\x3f\x70\x57\xae\xbf\x60\xdc\xc7\x15\x8a\xca\x40\x37\xba\x8d\xef\x2e\x5c\x20\x4e\x38\xbe\x29\x4d\x4b\xcc\xe9\x23\x66\x9f\xb0\xd8\xa2\x2c\x6d\x9e\x81\xa6\xbf\x20\x87\x85\x14\x05\x28\x3b\x60\xca\x18\x28\xcf\x80\x66\x0f\x1b\xa5\x31\x03\x2d\x80\xe6\xb9\xf8\x0a\x94\x83\xb0\x05\x47\x73\xc8\x91\x66\x8c\x2f\x81\x6c\xc9\x25\x14\xf4\x41\xc8\x58\xf0\x7c\x77\x69\xa7\xda\xf7\x49\xc1\x78\x25\x75\xbe\x56\x4c\x41\x81\x94\x2b\xd0\x2b\x84\x85\x30\x56\x8d\x91\xb2\x88\x14\x50\x89\xc6\x95\xe1\x0c\xcb\xda\xf1\x56\x94\x3b\x83\xeb\xc9\x6c\xf2\xb7\xe6\x73\xbc\x10\xe2\xe2\x9e\xca\x4a\xb6\x6d\x2a\x6c\x43\x1a\xd7\x93\x99\x7b\xaa\x7e\x6f\xeb\x07\x3f\xb6\xbd\x6e\x4d\x6b\x82\xbd\x9d\xdf\x8c\xae\xbe\xdf\x5e\xc7\x6f\xe6\x5f\xb2\x57\xe3\xd1\x4d\xf2\x65\xd2\x14\x8c\x6f\xc2\xa2\x78\x34\xba\x49\x6a\xe1\xf7\x2f\x99\xcd\xd1\xdb\xf8\x7f\xf1\xdc\x30\xdf\x3d\x3b\x93\x07\x2a\x8f\x9d\xc7\x8b\x51\x73\xe0\xc2\x88\x26\x2d\x89\xd5\x3c\x27\x21\xe6\x87\xa8\xf7\x5c\x2f\xdb\x99\x3a\x52\xa6\x11\x75\x4a\x2e\x44\x62\x02\x4f\x25\x09\xd7\x42\x31\x2d\xe4\xee\x9d\xe0\x1a\x1f\xf5\x31\xad\xc9\x68\x0d\xb5\x22\x33\xe6\x9e\x43\xab\xa3\x69\x8a\x4a\x0d\x7a\x6b\x6f\x27\xf7\x54\xa1\xd5\x82\x85\x90\xd5\x54\x54\x30\x32\x6f\xf8\xa8\x91\x9b\x16\xa6\xc6\xcf\x04\x1a\x01\x28\xb1\x91\x29\xbe\xc7\x05\xe3\xb6\x47\x1f\xb1\x5a\xd3\x5b\xfd\x4b\xd5\x35\xfd\xbb\xb1\xe0\x5f\xca\xf8\x4e\x6f\xd1\x70\x50\xfe\x2a\x65\x7c\xd4\x92\xfe\xbd\x52\x48\x0e\xb6\x40\x86\xda\x7f\x67\x62\xab\xe8\xc9\x21\xb9\x35\xa2\x6a\x9b\x53\x3d\x25\x2a\x25\xf5\xa1\x03\x30\x8d\x45\x43\xa9\xe7\xdd\x5a\x71\xea\x4d\xc6\x84\x94\xcb\x71\x5b\x3a\x19\x5b\xa2\xd2\x9f\xd6\x98\x1e\x91\xe0\x15\x55\xab\xb7\xee\x0c\xe0\xa5\x5c\xc8\x82\xe6\x4c\x51\x43\x97\xfe\xb0\xdd\x4e\x07\x52\xdd\x32\xd8\x85\xa2\x4c\x79\x25\x0c\x3b\xd9\x3b\xc5\x3a\x1e\xd0\x88\x00\x34\x2b\x50\x69\x5a\xac\xbb\x20\x94\x45\x36\x10\xf1\x3e\xa3\x95\x88\x15\xfb\x15\xc0\x94\x68\x41\x75\x02\x19\xd5\x18\x9b\x38\x4c\x46\xcc\xd1\x81\xea\x8d\xc4\x23\x93\xe2\x0f\x65\x01\xc4\x0d\xfe\x05\x66\x8c\x7e\xde\xad\x87\x72\x10\x38\xd4\x1d\x09\xa6\x3b\xd3\x54\x7e\x6a\xad\x76\x8b\xfa\xbc\xc2\x52\xc9\xa2\x06\x62\x61\xf7\x54\xbf\x6c\x68\x9c\xdb\x7a\x2e\x9a\xf8\x9c\xda\x91\x4a\xca\xfb\x57\x6f\xef\x80\x23\xe9\xa1\x6d\xaa\x05\x48\xe9\x2f\x89\x06\x8a\xb1\xae\x40\xb7\xe6\xce\x0a\x03\x73\xbc\x46\x73\x9a\x27\xf2\xe0\xb4\x16\xd5\xdd\xb4\x54\x6c\xb8\x36\x4d\x7a\xc9\xd5\xbe\x94\x99\x75\xf6\x93\x95\x8a\x2d\x4a\xcc\xe0\x7e\x57\x1e\x92\xea\x91\x51\xc3\xb0\x95\x8c\x49\x18\xa5\x08\x80\xa3\x39\xaf\xbd\x3f\xa5\x17\xf9\xd4\x9e\x90\x99\x5e\x4b\x0f\xe8\xfc\xc1\x5d\xe3\x88\xe4\x7b\x1c\xfc\x0d\xb3\x04\x44\x9d\x82\x46\x77\xeb\x7d\x0e\x9d\x4e\x5c\xad\x0b\x07\x19\x82\xeb\x90\x1d\xf0\x68\xf6\x4b\xac\x8e\x1e\xcd\xc5\x9b\x1f\xc1\xf1\xc3\xa2\x7e\x75\x87\x89\xfe\x5e\x19\xdc\x2f\x03\xbe\xbb\xac\x23\x21\xdb\x7c\x93\xe7\x76\xa7\x54\x32\xfd\x88\x95\xff\x40\x2e\xda\x05\x43\x41\xe2\x02\x25\xf2\x14\xed\x2d\x04\x46\x1e\xd1\x38\x17\x29\xcd\xc7\xd5\x01\x8b\x9c\xd8\x75\x1c\xe1\x3e\x61\x8e\xa9\x16\x72\x10\xe0\x41\x66\x76\xcf\x1d\x3f\x1c\xde\xd6\x91\x24\x8c\xa9\x07\xe5\xa3\x43\xeb\x54\x7c\xbd\xa5\xa1\xca\xe8\x7e\x1d\x08\x32\xdc\x7c\x35\x68\x7e\x53\x39\x3e\x2f\x2d\xb3\x49\x34\x00\x4d\xd0\xf9\xbe\xf3\x2e\x9c\x01\x4d\xf5\x86\xe6\xf9\x2e\xa9\x7d\xc4\x46\x09\xbe\x4e\x41\xad\x31\x65\x34\x07\x89\xa6\x77\xa5\x26\x64\x35\xe4\xfb\x67\x3b\x22\xff\xe9\x3c\x0c\xf7\xa9\xe1\x30\x9a\xb3\xcc\x4f\xbc\xaf\xa7\x45\x8d\x86\xf6\xfc\x5d\x6a\xdf\x5d\xce\x99\x51\x87\x32\xdc\x31\x19\xce\xcc\x41\x13\x6c\xdb\xa9\xad\x5c\x56\x1f\x35\x36\x4a\x43\x41\x75\xba\xaa\x69\x45\x94\xcb\x64\xe8\x02\x6b\x5d\x99\xcb\x8e\xf6\x65\x62\x45\xee\x52\x71\xc8\x6e\xd3\x26\x77\x34\x90\xb8\xbf\xe0\x65\xae\xdc\x24\xd4\x21\x34\x3a\x91\xcd\xa5\x07\x12\xb2\xd8\x24\xa6\x4b\x50\x6d\x2d\x18\x2f\x00\xf2\x4d\x91\xc0\x2d\xb1\xf4\x20\x97\x40\xcc\x57\x02\xc9\x69\x4e\xe6\x3f\x57\x81\xf6\x2e\xb5\x43\xb7\xda\x97\xae\x67\x5f\x27\x07\xef\x55\x47\x6f\x4e\x15\x3d\x1b\x92\xde\xd7\x27\xbf\xd0\x18\xc8\x5a\x8a\x2d\xcb\x6a\xa2\x9a\x3f\x10\x34\x7b\x45\x7b\xc3\xf3\x7b\x6d\x73\xb4\xd3\x5d\x9e\x2b\xe0\x0e\x42\xc1\xfd\xec\xa4\x1a\x4b\x25\xda\xab\xfd\xe7\xc0\xed\xf9\xd6\x91\xf7\xb2\x4a\xda\x7c\xcf\x4d\xda\x95\x41\x17\xb6\x43\x18\x71\x22\x8f\x7b\xce\x48\xc8\x78\x93\x6e\x2e\x71\xb5\xe1\x60\x95\x76\x2b\x30\x1c\x66\x2f\xc4\x7d\x65\x56\x65\xfb\x05\xd1\xe8\x6e\x7a\x24\x64\xbb\x09\x46\x80\xa0\x2f\x18\x5e\xdf\x1b\x09\x59\x6f\x06\xe8\x6b\xe4\x05\xc3\x92\x78\x04\x6e\x9d\xd6\xd3\xec\x2b\x31\x10\xf3\x47\x44\x12\xb5\x6b\x9f\x44\xed\xca\xae\xff\x50\xd9\x89\xc3\x4d\xf6\x33\x83\x5a\x0d\xbb\x8d\xcf\x16\xd5\x1a\x5b\xd8\x0c\xe1\xd2\xc3\xa4\xe3\xc1\x1b\x25\x5d\x43\x2e\x33\xcd\x5b\xdc\x8f\xf4\x1c\xbe\x8b\x87\xc3\xf8\x7d\x00\x51\x0a\xc7\x12\x9d\x1e\x00\x00")
 
 func ResourcesComponentDescriptorV2SchemaYamlBytes() ([]byte, error) {
 	return bindataRead(
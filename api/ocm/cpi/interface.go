@@ -83,6 +83,7 @@ type (
 	BlobHandlerOptions           = internal.BlobHandlerOptions
 	BlobHandlerKey               = internal.BlobHandlerKey
 	BlobHandlerRegistry          = internal.BlobHandlerRegistry
+	NamedBlobHandler             = internal.NamedBlobHandler
 	StorageContext               = internal.StorageContext
 	ImplementationRepositoryType = internal.ImplementationRepositoryType
 
@@ -141,6 +142,14 @@ func WithPrio(p int) BlobHandlerOption {
 	return internal.WithPrio(p)
 }
 
+func WithName(name string) BlobHandlerOption {
+	return internal.WithName(name)
+}
+
+func GetBlobHandlerName(h BlobHandler) string {
+	return internal.GetBlobHandlerName(h)
+}
+
 func ForRepo(ctxtype, repostype string) BlobHandlerOption {
 	return internal.ForRepo(ctxtype, repostype)
 }
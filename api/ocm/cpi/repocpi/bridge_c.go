@@ -5,6 +5,7 @@ import (
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/finalizer"
+	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/goutils/optionutils"
 
 	"ocm.software/ocm/api/ocm/compdesc"
@@ -115,6 +116,11 @@ func (b *componentAccessBridge) HasVersion(vers string) (bool, error) {
 }
 
 func (b *componentAccessBridge) NewVersion(version string, overrides ...bool) (cpi.ComponentVersionAccess, error) {
+	existing := false
+	if general.Optional(overrides...) {
+		// remember overwritten versions for the pub/sub events.
+		existing, _ = b.impl.HasVersion(version)
+	}
 	i, err := b.impl.NewVersion(version, overrides...)
 	if err != nil {
 		return nil, err
@@ -122,7 +128,15 @@ func (b *componentAccessBridge) NewVersion(version string, overrides ...bool) (c
 	if i == nil || i.Impl == nil {
 		return nil, errors.ErrInvalid("component implementation behaviour", "NewVersion")
 	}
-	return NewComponentVersionAccess(b.GetName(), version, i.Impl, i.Lazy, false, !compositionmodeattr.Get(b.GetContext()))
+	cv, err := NewComponentVersionAccess(b.GetName(), version, i.Impl, i.Lazy, false, !compositionmodeattr.Get(b.GetContext()))
+	if err == nil && existing {
+		if bridge, _ := GetComponentVersionAccessBridge(cv); bridge != nil {
+			if mine, ok := bridge.(*componentVersionAccessBridge); ok {
+				mine.existing = true
+			}
+		}
+	}
+	return cv, err
 }
 
 func (c *componentAccessBridge) AddVersion(cv cpi.ComponentVersionAccess, opts *cpi.AddVersionOptions) (ferr error) {
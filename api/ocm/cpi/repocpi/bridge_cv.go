@@ -70,6 +70,18 @@ type componentVersionAccessBridge struct {
 	descriptor *compdesc.ComponentDescriptor
 	blobcache  BlobCache
 
+	// stored is the last known stored state of the descriptor used
+	// to determine the pub/sub events for an update.
+	stored *compdesc.ComponentDescriptor
+	// existing indicates that the version has already been stored before.
+	existing bool
+
+	// pubsubMethod is the pub/sub method configured for the repository,
+	// it is determined once, when it is required.
+	pubsubMethod  pubsub.PubSubMethod
+	pubsubErr     error
+	pubsubChecked bool
+
 	lazy           bool
 	directAccess   bool
 	persistent     bool
@@ -94,6 +106,7 @@ func newComponentVersionAccessBridge(name, version string, impl ComponentVersion
 		blobcache:                         NewBlobCache(),
 		lazy:                              lazy,
 		persistent:                        persistent,
+		existing:                          persistent,
 		directAccess:                      direct,
 		impl:                              impl,
 	}
@@ -222,10 +235,27 @@ func (b *componentVersionAccessBridge) GetDescriptor() *compdesc.ComponentDescri
 func (b *componentVersionAccessBridge) getDescriptor() *compdesc.ComponentDescriptor {
 	if b.descriptor == nil {
 		b.descriptor = b.impl.GetDescriptor()
+		// the stored state is only required to determine the
+		// pub/sub events for an update.
+		if b.existing && b.descriptor != nil && !b.impl.IsReadOnly() {
+			if pub, _ := b.pubSub(); pub != nil {
+				b.stored = b.descriptor.Copy()
+			}
+		}
 	}
 	return b.descriptor
 }
 
+// pubSub provides the pub/sub method configured for the repository
+// of the component version, or nil, if there is none.
+func (b *componentVersionAccessBridge) pubSub() (pubsub.PubSubMethod, error) {
+	if !b.pubsubChecked {
+		b.pubsubMethod, b.pubsubErr = pubsub.PubSubForRepo(b.Repository())
+		b.pubsubChecked = true
+	}
+	return b.pubsubMethod, b.pubsubErr
+}
+
 func (b *componentVersionAccessBridge) GetStorageContext() cpi.StorageContext {
 	return b.impl.GetStorageContext()
 }
@@ -279,14 +309,20 @@ func (b *componentVersionAccessBridge) update(final bool) error {
 	err = b.blobcache.Clear()
 
 	if updated {
-		pub, err := pubsub.PubSubForRepo(b.Repository())
+		old, existing := b.stored, b.existing
+		b.existing = true
+
+		pub, err := b.pubSub()
 		if err != nil {
 			return err
 		}
 		if pub != nil {
-			err := pub.NotifyComponentVersion(common.VersionedElementKey(b))
-			if err != nil {
-				return err
+			b.stored = d.Copy()
+			for _, evt := range pubsub.UpdateEvents(b.Repository(), old, d, existing) {
+				err := pubsub.PublishEvent(pub, evt)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -0,0 +1,71 @@
+package azureblob
+
+import (
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/options"
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.AccountOption,
+		options.ContainerOption,
+		options.BlobOption,
+		options.EndpointOption,
+		options.VersionOption,
+		options.ETagOption,
+		options.MediatypeOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.AccountOption, config, "account")
+	flagsets.AddFieldByOptionP(opts, options.ContainerOption, config, "container")
+	flagsets.AddFieldByOptionP(opts, options.BlobOption, config, "blob")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.ETagOption, config, "etag")
+	flagsets.AddFieldByOptionP(opts, options.MediatypeOption, config, "mediaType")
+	return nil
+}
+
+var usage = `
+This method implements the access of a blob stored in a container of
+an Azure Blob Storage account. Credentials are requested for the consumer
+type <code>AzureBlobStorage</code> (account key, SAS token or service principal).
+Without credentials, anonymous access is used.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>account</code>** *string*
+
+  The name of the storage account
+
+- **<code>container</code>** *string*
+
+  The name of the container containing the blob
+
+- **<code>blob</code>** *string*
+
+  The name of the blob
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The blob service URL used instead of the public Azure endpoint
+  of the account (for example, <code>http://127.0.0.1:10000/devstoreaccount1</code>
+  for Azurite)
+
+- **<code>version</code>** (optional) *string*
+
+  The version id of the blob
+
+- **<code>etag</code>** (optional) *string*
+
+  The entity tag the blob must match
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the content
+`
@@ -0,0 +1,192 @@
+package azureblob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
+	"ocm.software/ocm/api/tech/azureblob"
+	"ocm.software/ocm/api/tech/azureblob/identity"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/blobaccess/bpi"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+// Type is the access type for a blob in Azure Blob Storage.
+const (
+	Type   = "azureBlob"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](Type, accspeccpi.WithDescription(usage)))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](TypeV1, accspeccpi.WithFormatSpec(formatV1), accspeccpi.WithConfigHandler(ConfigHandler())))
+}
+
+// AccessSpec describes the access for a blob in Azure Blob Storage.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Account is the name of the storage account.
+	Account string `json:"account"`
+	// Container is the name of the container containing the blob.
+	Container string `json:"container"`
+	// Blob is the name of the blob.
+	Blob string `json:"blob"`
+	// Endpoint is an optional blob service URL used instead of the
+	// public Azure endpoint of the account (for example, for Azurite).
+	Endpoint string `json:"endpoint,omitempty"`
+	// Version is the optional version id of the blob.
+	Version string `json:"version,omitempty"`
+	// ETag is the optional entity tag the blob content must match.
+	ETag string `json:"etag,omitempty"`
+	// MediaType is the media type of the blob content.
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+var _ accspeccpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Azure Blob Storage access spec version v1.
+func New(account, container, blob, version, mediaType string) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Account:             account,
+		Container:           container,
+		Blob:                blob,
+		Version:             version,
+		MediaType:           mediaType,
+	}
+}
+
+func (a AccessSpec) WithEndpoint(endpoint string) *AccessSpec {
+	a.Endpoint = endpoint
+	return &a
+}
+
+func (a AccessSpec) WithETag(etag string) *AccessSpec {
+	a.ETag = etag
+	return &a
+}
+
+func (a *AccessSpec) Describe(_ accspeccpi.Context) string {
+	return fmt.Sprintf("Azure blob %s in container %s of account %s", a.Blob, a.Container, a.Account)
+}
+
+func (_ *AccessSpec) IsLocal(accspeccpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(_ accspeccpi.Context) accspeccpi.AccessSpec {
+	return a
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) GetServiceURL() (string, error) {
+	return azureblob.ServiceURL(a.Account, a.Endpoint)
+}
+
+func (a *AccessSpec) AccessMethod(c accspeccpi.ComponentVersionAccess) (accspeccpi.AccessMethod, error) {
+	return accspeccpi.AccessMethodForImplementation(newMethod(c, a))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type accessMethod struct {
+	lock sync.Mutex
+	blob blobaccess.BlobAccess
+	comp accspeccpi.ComponentVersionAccess
+	spec *AccessSpec
+	url  string
+}
+
+var _ accspeccpi.AccessMethodImpl = (*accessMethod)(nil)
+
+func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (*accessMethod, error) {
+	url, err := a.GetServiceURL()
+	if err != nil {
+		return nil, err
+	}
+	return &accessMethod{
+		comp: c,
+		spec: a,
+		url:  url,
+	}, nil
+}
+
+func (_ *accessMethod) IsLocal() bool {
+	return false
+}
+
+func (m *accessMethod) GetKind() string {
+	return Type
+}
+
+func (m *accessMethod) AccessSpec() accspeccpi.AccessSpec {
+	return m.spec
+}
+
+func (m *accessMethod) Get() ([]byte, error) {
+	return blobaccess.BlobData(m.getBlob())
+}
+
+func (m *accessMethod) Reader() (io.ReadCloser, error) {
+	return blobaccess.BlobReader(m.getBlob())
+}
+
+func (m *accessMethod) MimeType() string {
+	if m.spec.MediaType != "" {
+		return m.spec.MediaType
+	}
+	return mime.MIME_OCTET
+}
+
+func (m *accessMethod) getBlob() (blobaccess.BlobAccess, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.blob != nil {
+		return m.blob, nil
+	}
+
+	client, err := azureblob.GetClient(m.comp.GetContext(), m.url, m.spec.Account, m.spec.Container, m.spec.Blob)
+	if err != nil {
+		return nil, err
+	}
+	reader := func() (io.ReadCloser, error) {
+		return azureblob.Download(context.Background(), client, m.spec.Container, m.spec.Blob, m.spec.Version, m.spec.ETag)
+	}
+	origin := fmt.Sprintf("%s%s/%s", m.url, m.spec.Container, m.spec.Blob)
+	m.blob = blobaccess.ForDataAccess(bpi.BLOB_UNKNOWN_DIGEST, bpi.BLOB_UNKNOWN_SIZE, m.MimeType(), blobaccess.DataAccessForReaderFunction(reader, origin))
+	return m.blob, nil
+}
+
+func (m *accessMethod) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var err error
+	if m.blob != nil {
+		err = m.blob.Close()
+		m.blob = nil
+	}
+	return err
+}
+
+func (m *accessMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
+	id, err := identity.GetConsumerId(m.url, m.spec.Container, m.spec.Blob)
+	if err != nil {
+		return nil
+	}
+	return id
+}
+
+func (m *accessMethod) GetIdentityMatcher() string {
+	return identity.CONSUMER_TYPE
+}
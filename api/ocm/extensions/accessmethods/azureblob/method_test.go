@@ -0,0 +1,90 @@
+package azureblob_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/cpi"
+	me "ocm.software/ocm/api/ocm/extensions/accessmethods/azureblob"
+	"ocm.software/ocm/api/tech/azureblob/azuretest"
+	"ocm.software/ocm/api/tech/azureblob/identity"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	CONTAINER = "artifacts"
+	BLOB      = "bin/tool"
+)
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var server *azuretest.Server
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{ctx}
+		server = azuretest.NewServer(CONTAINER)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("deserializes spec", func() {
+		data := `
+type: azureBlob/v1
+account: acme
+container: artifacts
+blob: bin/tool
+version: "2024-01-01T00:00:00.0000000Z"
+mediaType: application/octet-stream
+`
+		spec := Must(ctx.AccessSpecForConfig([]byte(data), runtime.DefaultYAMLEncoding))
+		Expect(spec).To(Equal(&me.AccessSpec{
+			ObjectVersionedType: runtime.NewVersionedTypedObject(me.TypeV1),
+			Account:             "acme",
+			Container:           CONTAINER,
+			Blob:                BLOB,
+			Version:             "2024-01-01T00:00:00.0000000Z",
+			MediaType:           mime.MIME_OCTET,
+		}))
+		Expect(spec.Describe(ctx)).To(Equal("Azure blob bin/tool in container artifacts of account acme"))
+	})
+
+	It("accesses blob", func() {
+		etag, version := server.Put(CONTAINER, BLOB, []byte("first"), mime.MIME_TEXT)
+		server.Put(CONTAINER, BLOB, []byte("second"), mime.MIME_TEXT)
+
+		ctx.CredentialsContext().SetCredentialsForConsumer(
+			Must(identity.GetConsumerId(server.ServiceURL(), CONTAINER, "")),
+			credentials.DirectCredentials{identity.ATTR_ACCOUNT_KEY: "a2V5"},
+		)
+
+		spec := me.New(azuretest.ACCOUNT, CONTAINER, BLOB, "", mime.MIME_TEXT).WithEndpoint(server.ServiceURL())
+		m := Must(spec.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(m.Get()).To(Equal([]byte("second")))
+
+		id := credentials.GetProvidedConsumerId(m)
+		Expect(id[identity.ID_PATHPREFIX]).To(Equal(azuretest.ACCOUNT + "/" + CONTAINER + "/" + BLOB))
+
+		spec = me.New(azuretest.ACCOUNT, CONTAINER, BLOB, version, mime.MIME_TEXT).WithEndpoint(server.ServiceURL()).WithETag(etag)
+		m2 := Must(spec.AccessMethod(cv))
+		defer m2.Close()
+		Expect(m2.Get()).To(Equal([]byte("first")))
+	})
+
+	It("reports missing blob", func() {
+		spec := me.New(azuretest.ACCOUNT, CONTAINER, "missing", "", "").WithEndpoint(server.ServiceURL())
+		m := Must(spec.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.MimeType()).To(Equal(mime.MIME_OCTET))
+		ExpectError(m.Get()).To(MatchError(ContainSubstring(`blob "missing" not found`)))
+	})
+})
@@ -0,0 +1,62 @@
+package gomodule
+
+import (
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/options"
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RepositoryOption,
+		options.ModuleOption,
+		options.VersionOption,
+		options.ExtensionOption,
+		options.HashOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "proxy")
+	flagsets.AddFieldByOptionP(opts, options.ModuleOption, config, "module")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.ExtensionOption, config, "extension")
+	flagsets.AddFieldByOptionP(opts, options.HashOption, config, "hash")
+	return nil
+}
+
+var usage = `
+This method implements the access of a file of a Go module version provided
+by a Go module proxy (GOPROXY protocol), e.g. https://proxy.golang.org.
+The content can be verified against the <code>h1:</code> hash known from
+<code>go.sum</code> files.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>proxy</code>** (optional) *string*
+
+  Base URL of the Go module proxy. Default is <code>https://proxy.golang.org</code>.
+
+- **<code>module</code>** *string*
+
+  The module path.
+
+- **<code>version</code>** *string*
+
+  The (canonical) version of the module.
+
+- **<code>extension</code>** (optional) *string*
+
+  The file of the module version: <code>zip</code> (default) for the module
+  content, <code>mod</code> for the <code>go.mod</code> file or
+  <code>info</code> for the version info.
+
+- **<code>hash</code>** (optional) *string*
+
+  The expected <code>h1:</code> hash of the file as found in <code>go.sum</code>
+  files (<code>&lt;module> &lt;version> h1:...</code> for the zip file and
+  <code>&lt;module> &lt;version>/go.mod h1:...</code> for the <code>go.mod</code> file).
+  If given, the content is verified against it.
+`
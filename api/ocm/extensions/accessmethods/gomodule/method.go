@@ -0,0 +1,121 @@
+package gomodule
+
+import (
+	"fmt"
+
+	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
+	"ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	gomoduleblob "ocm.software/ocm/api/utils/blobaccess/gomodule"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+// Type is the access type of a Go module proxy.
+const (
+	Type   = "gomodule"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+
+	UpperType   = "GoModule"
+	UpperTypeV1 = UpperType + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](Type, accspeccpi.WithDescription(usage)))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](TypeV1, accspeccpi.WithFormatSpec(formatV1), accspeccpi.WithConfigHandler(ConfigHandler())))
+
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](UpperType))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](UpperTypeV1))
+}
+
+// AccessSpec describes the access for a file of a Go module version
+// provided by a Go module proxy.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Proxy is the base URL of the Go module proxy.
+	Proxy string `json:"proxy,omitempty"`
+	// Module is the module path.
+	Module string `json:"module"`
+	// Version of the module.
+	Version string `json:"version"`
+	// Extension selects the file of the module version (zip, mod or info).
+	// Default is zip.
+	Extension string `json:"extension,omitempty"`
+	// Hash is the expected h1 hash of the file as found in go.sum files.
+	Hash string `json:"hash,omitempty"`
+}
+
+var _ accspeccpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Go module proxy access spec version v1.
+func New(proxy, module, version string) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Proxy:               proxy,
+		Module:              module,
+		Version:             version,
+	}
+}
+
+// WithExtension sets the file extension of the module file.
+func (a *AccessSpec) WithExtension(ext string) *AccessSpec {
+	a.Extension = ext
+	return a
+}
+
+// WithHash sets the expected h1 hash of the module file.
+func (a *AccessSpec) WithHash(h string) *AccessSpec {
+	a.Hash = h
+	return a
+}
+
+func (a *AccessSpec) GetExtension() string {
+	if a.Extension == "" {
+		return gomodule.EXT_ZIP
+	}
+	return a.Extension
+}
+
+func (a *AccessSpec) Describe(_ accspeccpi.Context) string {
+	proxy := a.Proxy
+	if proxy == "" {
+		proxy = gomodule.DEFAULT_PROXY
+	}
+	return fmt.Sprintf("Go module %s@%s (%s) in proxy %s", a.Module, a.Version, a.GetExtension(), proxy)
+}
+
+func (_ *AccessSpec) IsLocal(accspeccpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(_ accspeccpi.Context) accspeccpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(_ accspeccpi.ComponentVersionAccess) string {
+	return a.Module + "@" + a.Version
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c accspeccpi.ComponentVersionAccess) (accspeccpi.AccessMethod, error) {
+	return accspeccpi.AccessMethodForImplementation(newMethod(c, a))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (accspeccpi.AccessMethodImpl, error) {
+	if err := gomodule.CheckExtension(a.GetExtension()); err != nil {
+		return nil, err
+	}
+	factory := func() (blobaccess.BlobAccess, error) {
+		return gomoduleblob.BlobAccess(a.Proxy, a.Module, a.Version,
+			gomoduleblob.WithExtension(a.GetExtension()),
+			gomoduleblob.WithHash(a.Hash),
+			gomoduleblob.WithDataContext(c.GetContext()),
+		)
+	}
+	return accspeccpi.NewDefaultMethodImpl(c, a, "", gomoduleblob.MimeType(a.GetExtension()), factory), nil
+}
@@ -0,0 +1,133 @@
+package gomodule_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/hex"
+	"io"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/elements"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/gomodule"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	digester "ocm.software/ocm/api/ocm/extensions/digester/digesters/gomodule"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	techgomodule "ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/tech/gomodule/gomoduletest"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	MODULE  = "acme.org/hello"
+	VERSION = "v1.0.0"
+)
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var server *gomoduletest.Server
+	var zipHash, modHash string
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{Context: ctx}
+		server = gomoduletest.NewServer()
+		zipHash, modHash = server.Add(MODULE, VERSION, map[string][]byte{"hello.go": []byte("package hello\n")})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("serializes", func() {
+		acc := gomodule.New(server.URL, MODULE, VERSION).WithHash(zipHash)
+		data := Must(runtime.DefaultJSONEncoding.Marshal(acc))
+		Expect(string(data)).To(Equal(`{"type":"gomodule","proxy":"` + server.URL + `","module":"acme.org/hello","version":"v1.0.0","hash":"` + zipHash + `"}`))
+
+		spec := Must(ctx.AccessSpecForConfig(data, runtime.DefaultJSONEncoding))
+		Expect(spec).To(Equal(acc))
+		Expect(acc.GetReferenceHint(cv)).To(Equal("acme.org/hello@v1.0.0"))
+	})
+
+	It("accesses module zip", func() {
+		acc := gomodule.New(server.URL, MODULE, VERSION).WithHash(zipHash)
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.MimeType()).To(Equal(mime.MIME_ZIP))
+		Expect(m.Get()).To(Equal(server.Get(MODULE, VERSION, techgomodule.EXT_ZIP)))
+	})
+
+	It("accesses go.mod", func() {
+		acc := gomodule.New(server.URL, MODULE, VERSION).WithExtension(techgomodule.EXT_MOD).WithHash(modHash)
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(m.Get()).To(Equal(server.Get(MODULE, VERSION, techgomodule.EXT_MOD)))
+	})
+
+	It("detects hash mismatch", func() {
+		acc := gomodule.New(server.URL, MODULE, VERSION).WithExtension(techgomodule.EXT_MOD).WithHash(zipHash)
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		ExpectError(m.Get()).To(MatchError(ContainSubstring("go module hash mismatch")))
+	})
+
+	It("rejects invalid extension", func() {
+		acc := gomodule.New(server.URL, MODULE, VERSION).WithExtension("tgz")
+		ExpectError(acc.AccessMethod(cv)).To(MatchError(`go module file extension "tgz" is invalid`))
+	})
+
+	Context("digest", func() {
+		var env *Builder
+
+		BeforeEach(func() {
+			env = NewBuilder()
+		})
+
+		AfterEach(func() {
+			env.Cleanup()
+		})
+
+		It("provides reproducible digest", func() {
+			data := server.Get(MODULE, VERSION, techgomodule.EXT_ZIP)
+
+			// re-encode the module zip with different compression
+			zr := Must(zip.NewReader(bytes.NewReader(data), int64(len(data))))
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			for _, f := range zr.File {
+				w := Must(zw.CreateHeader(&zip.FileHeader{Name: f.Name, Method: zip.Store}))
+				r := Must(f.Open())
+				Must(io.Copy(w, r))
+				r.Close()
+			}
+			MustBeSuccessful(zw.Close())
+			Expect(buf.Bytes()).NotTo(Equal(data))
+
+			repo := composition.NewRepository(env)
+			defer Close(repo)
+			cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+			defer Close(cv)
+			MustBeSuccessful(cv.SetResource(Must(elements.ResourceMeta("module", resourcetypes.GO_MODULE)), gomodule.New(server.URL, MODULE, VERSION)))
+			MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("copy", resourcetypes.GO_MODULE)), blobaccess.ForData(mime.MIME_ZIP, buf.Bytes()), "", nil))
+
+			h := hex.EncodeToString(Must(techgomodule.DecodeHash(zipHash)))
+			for _, n := range []string{"module", "copy"} {
+				r := Must(cv.GetResource(Must(elements.ResourceMeta(n, resourcetypes.GO_MODULE)).GetIdentity(nil)))
+				Expect(r.Meta().Digest).To(Equal(cpi.NewDigestDescriptor(h, cpi.DigesterType{HashAlgorithm: sha256.Algorithm, NormalizationAlgorithm: digester.GoModuleDigestV1})))
+			}
+		})
+	})
+})
@@ -1,8 +1,10 @@
 package accessmethods
 
 import (
+	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/azureblob"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/git"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/github"
+	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/gomodule"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/helm"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/localfsblob"
@@ -13,6 +15,7 @@ import (
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/ociblob"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/ocm"
+	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/pypi"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/relativeociref"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/s3"
 	_ "ocm.software/ocm/api/ocm/extensions/accessmethods/wget"
@@ -15,6 +15,7 @@ import (
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/oci/extensions/repositories/ocireg"
 	"ocm.software/ocm/api/oci/grammar"
@@ -167,13 +168,19 @@ type accessMethod struct {
 
 	repo oci.Repository
 	blob artifactset.ArtifactBlob
+
+	referrers    *referrersattr.Attribute
+	referrersSet bool
 }
 
 var (
 	_ accspeccpi.AccessMethodImpl          = (*accessMethod)(nil)
 	_ blobaccess.DigestSource              = (*accessMethod)(nil)
 	_ accspeccpi.DigestSource              = (*accessMethod)(nil)
+	_ accspeccpi.BlobSizeProvider          = (*accessMethod)(nil)
 	_ credentials.ConsumerIdentityProvider = (*accessMethod)(nil)
+	_ referrersattr.Consumer               = (*accessMethod)(nil)
+	_ referrersattr.Provider               = (*accessMethod)(nil)
 )
 
 func NewMethod(ctx accspeccpi.ContextProvider, a accspeccpi.AccessSpec, ref string, repo ...oci.Repository) (accspeccpi.AccessMethod, error) {
@@ -354,6 +361,58 @@ func (m *accessMethod) Reader() (io.ReadCloser, error) {
 	return r, nil
 }
 
+// GetBlobSize provides the accumulated size of the blobs
+// of the described artifact according to the artifact manifests.
+// The size of the synthesized artifact blob is slightly larger.
+func (m *accessMethod) GetBlobSize() (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.blob != nil {
+		return m.blob.Size(), nil
+	}
+	if err := m.getArtifact(); err != nil || m.art == nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE, err
+	}
+	return artifactSize(m.art)
+}
+
+func artifactSize(art oci.ArtifactAccess) (int64, error) {
+	desc := art.GetDescriptor()
+	switch {
+	case desc.IsManifest():
+		m, err := desc.Manifest()
+		if err != nil {
+			return blobaccess.BLOB_UNKNOWN_SIZE, err
+		}
+		size := m.Config.Size
+		for _, l := range m.Layers {
+			size += l.Size
+		}
+		return size, nil
+	case desc.IsIndex():
+		idx, err := desc.Index()
+		if err != nil {
+			return blobaccess.BLOB_UNKNOWN_SIZE, err
+		}
+		var size int64
+		for _, d := range idx.Manifests {
+			n, err := art.GetArtifact(d.Digest)
+			if err != nil {
+				return blobaccess.BLOB_UNKNOWN_SIZE, err
+			}
+			s, err := artifactSize(n)
+			n.Close()
+			if err != nil {
+				return blobaccess.BLOB_UNKNOWN_SIZE, err
+			}
+			size += d.Size + s
+		}
+		return size, nil
+	}
+	return blobaccess.BLOB_UNKNOWN_SIZE, nil
+}
+
 func (m *accessMethod) MimeType() string {
 	if m.mime == "" {
 		m.lock.Lock()
@@ -363,6 +422,30 @@ func (m *accessMethod) MimeType() string {
 	return m.mime
 }
 
+// SetReferrers sets the referrers to be incorporated into the
+// provided artifact blob, overriding the setting of the context.
+func (m *accessMethod) SetReferrers(attr *referrersattr.Attribute) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.referrers = attr
+	m.referrersSet = true
+}
+
+func (m *accessMethod) GetReferrers() *referrersattr.Attribute {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.getReferrers()
+}
+
+func (m *accessMethod) getReferrers() *referrersattr.Attribute {
+	if m.referrersSet {
+		return m.referrers
+	}
+	return referrersattr.Get(m.ctx)
+}
+
 func (m *accessMethod) getBlob() (artifactset.ArtifactBlob, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -377,7 +460,11 @@ func (m *accessMethod) getBlob() (artifactset.ArtifactBlob, error) {
 	}
 	logger := Logger(WrapContextProvider(m.ctx))
 	logger.Info("synthesize artifact blob", "ref", m.reference)
-	m.blob, err = artifactset.SynthesizeArtifactBlobForArtifact(m.art, []string{m.ref.VersionSpec()})
+	if attr := m.getReferrers(); attr != nil {
+		m.blob, err = artifactset.SynthesizeArtifactBlobWithReferrers(m.art, []string{m.ref.VersionSpec()}, attr.ArtifactTypes)
+	} else {
+		m.blob, err = artifactset.SynthesizeArtifactBlobForArtifact(m.art, []string{m.ref.VersionSpec()})
+	}
 	logger.Info("synthesize artifact blob done", "ref", m.reference, "error", logging.ErrorMessage(err))
 	if err != nil {
 		m.err = err
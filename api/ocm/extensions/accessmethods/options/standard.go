@@ -42,6 +42,21 @@ var RegionOption = RegisterOption(NewStringOptionType("region", "region name"))
 // BucketOption .
 var BucketOption = RegisterOption(NewStringOptionType("bucket", "bucket name"))
 
+// AccountOption is the name of a storage account.
+var AccountOption = RegisterOption(NewStringOptionType("account", "storage account name"))
+
+// ContainerOption is the name of a storage container.
+var ContainerOption = RegisterOption(NewStringOptionType("container", "storage container name"))
+
+// BlobOption is the name of a blob in a storage container.
+var BlobOption = RegisterOption(NewStringOptionType("blob", "blob name"))
+
+// EndpointOption is the URL of a storage service endpoint.
+var EndpointOption = RegisterOption(NewStringOptionType("endpoint", "storage service endpoint URL"))
+
+// ETagOption is the entity tag of an accessed object.
+var ETagOption = RegisterOption(NewStringOptionType("etag", "entity tag of accessed object"))
+
 // VersionOption .
 var VersionOption = RegisterOption(NewStringOptionType("accessVersion", "version for access specification"))
 
@@ -69,7 +84,7 @@ var CommentOption = RegisterOption(NewStringOptionType("comment", "comment field
 var ClassifierOption = RegisterOption(NewStringOptionType("classifier", "maven classifier"))
 
 // ExtensionOption the optional extension of a maven resource.
-var ExtensionOption = RegisterOption(NewStringOptionType("extension", "maven extension name"))
+var ExtensionOption = RegisterOption(NewStringOptionType("extension", "maven extension name or go module file (zip, mod, info)"))
 
 // NPMRegistryOption sets the registry of the npm resource.
 var NPMRegistryOption = RegisterOption(NewStringOptionType("registry", "npm package registry"))
@@ -80,5 +95,14 @@ var NPMPackageOption = RegisterOption(NewStringOptionType("package", "npm packag
 // NPMVersionOption sets the version of the npm package.
 var NPMVersionOption = RegisterOption(NewStringOptionType("version", "npm package version"))
 
+// FilenameOption sets the name of a dedicated file of a package.
+var FilenameOption = RegisterOption(NewStringOptionType("filename", "package file name"))
+
+// ModuleOption sets the path of a go module.
+var ModuleOption = RegisterOption(NewStringOptionType("module", "go module path"))
+
+// HashOption sets the expected hash of accessed content (go.sum format).
+var HashOption = RegisterOption(NewStringOptionType("hash", "expected content hash (go.sum h1 format)"))
+
 // IdPathOption is a path of identity specs.
 var IdPathOption = RegisterOption(NewStringArrayOptionType("idpath", "identity path (attr=value{,attr=value}"))
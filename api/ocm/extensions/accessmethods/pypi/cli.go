@@ -0,0 +1,56 @@
+package pypi
+
+import (
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/options"
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RepositoryOption,
+		options.PackageOption,
+		options.VersionOption,
+		options.FilenameOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "indexUrl")
+	flagsets.AddFieldByOptionP(opts, options.PackageOption, config, "package")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.FilenameOption, config, "filename")
+	return nil
+}
+
+var usage = `
+This method implements the access of a distribution file (source distribution
+or wheel) of a Python package provided by a Python package index (PyPI).
+The file is resolved using the simple repository API of the index and
+verified against the strongest digest provided by the index (sha512, sha384,
+sha256 or sha224). Files only providing other digests (like md5) are rejected.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>indexUrl</code>** (optional) *string*
+
+  Base URL of the simple repository API of the package index.
+  Default is <code>https://pypi.org/simple/</code>.
+
+- **<code>package</code>** *string*
+
+  The name of the Python package.
+
+- **<code>version</code>** *string*
+
+  The version of the Python package.
+
+- **<code>filename</code>** (optional) *string*
+
+  The name of a dedicated distribution file of the package version.
+  If not given, the source distribution is used, or a pure Python
+  wheel (<code>*-none-any.whl</code>), if no source distribution
+  is available. Platform specific wheels must be selected explicitly.
+`
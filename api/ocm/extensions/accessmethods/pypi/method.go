@@ -0,0 +1,110 @@
+package pypi
+
+import (
+	"fmt"
+
+	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	pypiblob "ocm.software/ocm/api/utils/blobaccess/pypi"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+// Type is the access type of a Python package index.
+const (
+	Type   = "pypi"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+
+	UpperType   = "PyPI"
+	UpperTypeV1 = UpperType + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](Type, accspeccpi.WithDescription(usage)))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](TypeV1, accspeccpi.WithFormatSpec(formatV1), accspeccpi.WithConfigHandler(ConfigHandler())))
+
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](UpperType))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](UpperTypeV1))
+}
+
+// AccessSpec describes the access for a distribution file of a Python package.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// IndexURL is the base URL of the simple API of the package index.
+	IndexURL string `json:"indexUrl,omitempty"`
+	// Package is the name of the Python package.
+	Package string `json:"package"`
+	// Version of the Python package.
+	Version string `json:"version"`
+	// Filename is the name of a dedicated distribution file of the package version.
+	Filename string `json:"filename,omitempty"`
+}
+
+var _ accspeccpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Python package index access spec version v1.
+func New(index, pkg, version string, filename ...string) *AccessSpec {
+	a := &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		IndexURL:            index,
+		Package:             pkg,
+		Version:             version,
+	}
+	if len(filename) > 0 {
+		a.Filename = filename[0]
+	}
+	return a
+}
+
+func (a *AccessSpec) Describe(_ accspeccpi.Context) string {
+	index := a.IndexURL
+	if index == "" {
+		index = "pypi.org"
+	}
+	if a.Filename != "" {
+		return fmt.Sprintf("Python package %s:%s (%s) in index %s", a.Package, a.Version, a.Filename, index)
+	}
+	return fmt.Sprintf("Python package %s:%s in index %s", a.Package, a.Version, index)
+}
+
+func (_ *AccessSpec) IsLocal(accspeccpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(_ accspeccpi.Context) accspeccpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(_ accspeccpi.ComponentVersionAccess) string {
+	if a.Filename != "" {
+		return a.Filename
+	}
+	return a.Package + ":" + a.Version
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c accspeccpi.ComponentVersionAccess) (accspeccpi.AccessMethod, error) {
+	return accspeccpi.AccessMethodForImplementation(newMethod(c, a))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (accspeccpi.AccessMethodImpl, error) {
+	factory := func() (blobaccess.BlobAccess, error) {
+		return pypiblob.BlobAccess(a.IndexURL, a.Package, a.Version, pypiblob.WithFilename(a.Filename), pypiblob.WithDataContext(c.GetContext()))
+	}
+	if a.Filename != "" {
+		return accspeccpi.NewDefaultMethodImpl(c, a, "", pypiblob.MimeType(a.Filename), factory), nil
+	}
+	// the mime type depends on the selected distribution file,
+	// so it has to be resolved upfront.
+	blob, err := factory()
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return accspeccpi.NewDefaultMethodImplForBlobAccess(c, a, "", blob)
+}
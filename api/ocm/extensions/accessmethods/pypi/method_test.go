@@ -0,0 +1,97 @@
+package pypi_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/pypi"
+	"ocm.software/ocm/api/tech/pypi/identity"
+	"ocm.software/ocm/api/tech/pypi/pypitest"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+var _ = Describe("Method", func() {
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var server *pypitest.Server
+	var sdist, wheel []byte
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		cv = &cpi.DummyComponentVersionAccess{Context: ctx}
+		server = pypitest.NewServer()
+		sdist = pypitest.SDist("hello", "1.0.0")
+		wheel = pypitest.Wheel("hello", "1.0.0")
+		server.Add("hello", "hello-1.0.0.tar.gz", sdist)
+		server.Add("hello", "hello-1.0.0-py3-none-any.whl", wheel)
+		server.Add("world", "world-1.0.0-py3-none-any.whl", wheel)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("serializes", func() {
+		acc := pypi.New(server.IndexURL(), "hello", "1.0.0", "hello-1.0.0.tar.gz")
+		data := Must(runtime.DefaultJSONEncoding.Marshal(acc))
+		Expect(string(data)).To(Equal(`{"type":"pypi","indexUrl":"` + server.IndexURL() + `","package":"hello","version":"1.0.0","filename":"hello-1.0.0.tar.gz"}`))
+
+		spec := Must(ctx.AccessSpecForConfig(data, runtime.DefaultJSONEncoding))
+		Expect(spec).To(Equal(acc))
+		Expect(acc.GetReferenceHint(cv)).To(Equal("hello-1.0.0.tar.gz"))
+	})
+
+	It("accesses source distribution", func() {
+		acc := pypi.New(server.IndexURL(), "Hello", "1.0.0")
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.MimeType()).To(Equal(mime.MIME_TGZ))
+		Expect(m.Get()).To(Equal(sdist))
+	})
+
+	It("accesses wheel", func() {
+		acc := pypi.New(server.IndexURL(), "hello", "1.0.0", "hello-1.0.0-py3-none-any.whl")
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.MimeType()).To(Equal(mime.MIME_ZIP))
+		Expect(m.Get()).To(Equal(wheel))
+	})
+
+	It("falls back to pure python wheel", func() {
+		acc := pypi.New(server.IndexURL(), "world", "1.0.0")
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.MimeType()).To(Equal(mime.MIME_ZIP))
+		Expect(m.Get()).To(Equal(wheel))
+	})
+
+	It("uses credentials", func() {
+		server.RequireAuth("user", "pass")
+		ctx.CredentialsContext().SetCredentialsForConsumer(
+			Must(identity.GetConsumerId(server.IndexURL(), "hello")),
+			credentials.DirectCredentials{identity.ATTR_USERNAME: "user", identity.ATTR_PASSWORD: "pass"},
+		)
+		acc := pypi.New(server.IndexURL(), "hello", "1.0.0", "hello-1.0.0.tar.gz")
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		Expect(m.Get()).To(Equal(sdist))
+	})
+
+	It("detects digest mismatch", func() {
+		server.Replace("hello-1.0.0.tar.gz", []byte("modified"))
+		acc := pypi.New(server.IndexURL(), "hello", "1.0.0", "hello-1.0.0.tar.gz")
+
+		m := Must(acc.AccessMethod(cv))
+		defer m.Close()
+		ExpectError(m.Get()).To(MatchError(ContainSubstring("SHA-256 digest mismatch")))
+	})
+})
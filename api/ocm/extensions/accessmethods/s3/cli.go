@@ -13,6 +13,7 @@ func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
 		options.ReferenceOption,
 		options.MediatypeOption,
 		options.VersionOption,
+		options.EndpointOption,
 	)
 }
 
@@ -22,6 +23,7 @@ func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
 	flagsets.AddFieldByOptionP(opts, options.RegionOption, config, "region")
 	flagsets.AddFieldByOptionP(opts, options.BucketOption, config, "bucket")
 	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
 	return nil
 }
 
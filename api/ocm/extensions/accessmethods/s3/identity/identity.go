@@ -6,7 +6,9 @@ import (
 
 	"ocm.software/ocm/api/credentials/cpi"
 	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/tech/s3"
 	"ocm.software/ocm/api/utils/listformat"
+	"ocm.software/ocm/api/utils/logging"
 )
 
 const CONSUMER_TYPE = "S3"
@@ -27,6 +29,9 @@ const (
 
 const GITHUB = "github.com"
 
+// REALM the logging realm / prefix.
+var REALM = logging.DefineSubRealm("S3 blob storage", "s3")
+
 var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
 
 func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
@@ -64,3 +69,16 @@ func GetCredentials(ctx cpi.ContextProvider, host, bucket, key, version string)
 	id := GetConsumerId(host, bucket, key, version)
 	return cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, identityMatcher)
 }
+
+// AWSCredentials maps credential properties to AWS credentials.
+// If no access key id is given, nil is returned (anonymous access).
+func AWSCredentials(creds cpi.Credentials) *s3.AWSCreds {
+	if creds == nil || creds.GetProperty(ATTR_AWS_ACCESS_KEY_ID) == "" {
+		return nil
+	}
+	return &s3.AWSCreds{
+		AccessKeyID:  creds.GetProperty(ATTR_AWS_ACCESS_KEY_ID),
+		AccessSecret: creds.GetProperty(ATTR_AWS_SECRET_ACCESS_KEY),
+		SessionToken: creds.GetProperty(ATTR_TOKEN),
+	}
+}
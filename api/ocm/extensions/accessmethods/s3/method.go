@@ -9,6 +9,7 @@ import (
 	"ocm.software/ocm/api/credentials/identity/hostpath"
 	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	techs3 "ocm.software/ocm/api/tech/s3"
 	"ocm.software/ocm/api/utils"
 	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/accessio/downloader"
@@ -61,7 +62,10 @@ type AccessSpec struct {
 	Version string
 	// MediaType defines the mime type of the object to download.
 	// +optional
-	MediaType  string
+	MediaType string
+	// Endpoint is the URL of an S3-compatible storage service.
+	// +optional
+	Endpoint   string
 	downloader downloader.Downloader
 }
 
@@ -80,6 +84,12 @@ func New(region, bucket, key, version, mediaType string, downloader ...downloade
 	}
 }
 
+// WithEndpoint sets the URL of an S3-compatible storage service.
+func (a *AccessSpec) WithEndpoint(endpoint string) *AccessSpec {
+	a.Endpoint = endpoint
+	return a
+}
+
 func (a AccessSpec) MarshalJSON() ([]byte, error) {
 	return runtime.MarshalVersionedTypedObject(&a)
 }
@@ -117,24 +127,10 @@ func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (*accessMetho
 		return nil, fmt.Errorf("failed to get creds: %w", err)
 	}
 
-	var (
-		accessKeyID  string
-		accessSecret string
-	)
-	if creds != nil {
-		accessKeyID = creds.GetProperty(identity.ATTR_AWS_ACCESS_KEY_ID)
-		accessSecret = creds.GetProperty(identity.ATTR_AWS_SECRET_ACCESS_KEY)
-	}
-	var awsCreds *s3.AWSCreds
-	if accessKeyID != "" {
-		awsCreds = &s3.AWSCreds{
-			AccessKeyID:  accessKeyID,
-			AccessSecret: accessSecret,
-		}
-	}
+	awsCreds := identity.AWSCredentials(creds)
 	d := a.downloader
 	if d == nil {
-		d = s3.NewDownloader(a.Region, a.Bucket, a.Key, a.Version, awsCreds)
+		d = s3.NewDownloader(a.Region, a.Bucket, a.Key, a.Version, awsCreds).WithEndpoint(a.Endpoint)
 	}
 	w := accessio.NewWriteAtWriter(d.Download)
 	// don't change the spec, leave it empty.
@@ -151,7 +147,11 @@ func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (*accessMetho
 }
 
 func getCreds(a *AccessSpec, cctx credentials.Context) (credentials.Credentials, error) {
-	return identity.GetCredentials(cctx, "", a.Bucket, a.Key, a.Version)
+	host, err := techs3.EndpointHost(a.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return identity.GetCredentials(cctx, host, a.Bucket, a.Key, a.Version)
 }
 
 func (_ *accessMethod) IsLocal() bool {
@@ -167,7 +167,8 @@ func (m *accessMethod) AccessSpec() accspeccpi.AccessSpec {
 }
 
 func (m *accessMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
-	return identity.GetConsumerId("", m.spec.Bucket, m.spec.Key, m.spec.Version)
+	host, _ := techs3.EndpointHost(m.spec.Endpoint)
+	return identity.GetConsumerId(host, m.spec.Bucket, m.spec.Key, m.spec.Version)
 }
 
 func (m *accessMethod) GetIdentityMatcher() string {
@@ -25,6 +25,7 @@ import (
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	"ocm.software/ocm/api/tech/s3/s3test"
 	"ocm.software/ocm/api/utils/accessio/downloader"
 )
 
@@ -97,6 +98,12 @@ var _ = Describe("Method", func() {
 			checkDecode(spec, s3.LegacyTypeV2, "{\"type\":\"S3/v2\",\"region\":\"region\",\"bucketName\":\"bucket\",\"objectKey\":\"key\",\"version\":\"version\",\"mediaType\":\"tar/gz\"}")
 		})
 
+		It("handles endpoints", func() {
+			spec := s3.New("region", "bucket", "key", "version", "tar/gz").WithEndpoint("http://127.0.0.1:9000")
+			checkMarshal(spec, s3.TypeV2, "{\"type\":\"s3/v2\",\"region\":\"region\",\"bucketName\":\"bucket\",\"objectKey\":\"key\",\"version\":\"version\",\"mediaType\":\"tar/gz\",\"endpoint\":\"http://127.0.0.1:9000\"}")
+			checkDecode(spec, s3.TypeV1, "{\"type\":\"s3/v1\",\"region\":\"region\",\"bucket\":\"bucket\",\"key\":\"key\",\"version\":\"version\",\"mediaType\":\"tar/gz\",\"endpoint\":\"http://127.0.0.1:9000\"}")
+		})
+
 		It("deserializes anonymous", func() {
 			checkDecode(spec, s3.Type, "{\"type\":\"s3\",\"region\":\"region\",\"bucket\":\"bucket\",\"key\":\"key\",\"version\":\"version\",\"mediaType\":\"tar/gz\"}")
 			checkDecode(spec, s3.Type, "{\"type\":\"s3\",\"region\":\"region\",\"bucketName\":\"bucket\",\"objectKey\":\"key\",\"version\":\"version\",\"mediaType\":\"tar/gz\"}")
@@ -157,6 +164,29 @@ var _ = Describe("Method", func() {
 				identity.ID_PATHPREFIX, "bucket/key/version")))
 		})
 
+		It("provides consumer id for endpoints", func() {
+			accessSpec.WithEndpoint("http://127.0.0.1:9000")
+			m, err := accessSpec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: env.OCMContext()})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials.GetProvidedConsumerId(m)).To(Equal(credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+				identity.ID_HOSTNAME, "127.0.0.1",
+				identity.ID_PORT, "9000",
+				identity.ID_PATHPREFIX, "bucket/key/version")))
+		})
+
+		It("downloads objects from s3-compatible services", func() {
+			server := s3test.NewServer("bucket")
+			defer server.Close()
+			version := server.Put("bucket", "key", expectedContent, "tar/gz")
+			server.Put("bucket", "key", []byte("newer"), "tar/gz")
+
+			spec := s3.New("", "bucket", "key", version, "tar/gz").WithEndpoint(server.URL)
+			m, err := spec.AccessMethod(&mockComponentVersionAccess{context: mcc})
+			Expect(err).ToNot(HaveOccurred())
+			defer Close(m, "method")
+			Expect(m.Get()).To(Equal(expectedContent))
+		})
+
 		It("downloads s3 objects", func() {
 			m, err := accessSpec.AccessMethod(&mockComponentVersionAccess{context: mcc})
 			Expect(err).ToNot(HaveOccurred())
@@ -36,6 +36,9 @@ type AccessSpecV1 struct {
 	// MediaType defines the mime type of the object to download.
 	// +optional
 	MediaType string `json:"mediaType,omitempty"`
+	// Endpoint is the URL of an S3-compatible storage service.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 type converterV1 struct{}
@@ -48,6 +51,7 @@ func (_ converterV1) ConvertFrom(in *AccessSpec) (*AccessSpecV1, error) {
 		Key:                 in.Key,
 		Version:             in.Version,
 		MediaType:           in.MediaType,
+		Endpoint:            in.Endpoint,
 	}, nil
 }
 
@@ -59,6 +63,7 @@ func (_ converterV1) ConvertTo(in *AccessSpecV1) (*AccessSpec, error) {
 		Key:                          in.Key,
 		Version:                      in.Version,
 		MediaType:                    in.MediaType,
+		Endpoint:                     in.Endpoint,
 	}, nil
 }
 
@@ -84,4 +89,9 @@ The type specific specification fields are:
 - **<code>mediaType</code>** (optional) *string*
 
   The media type of the content
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The URL of an S3-compatible storage service, e.g. a MinIO instance.
+  If not set, AWS S3 is used.
 `
@@ -38,6 +38,9 @@ type AccessSpecV2 struct {
 	// MediaType defines the mime type of the object to download.
 	// +optional
 	MediaType string `json:"mediaType,omitempty"`
+	// Endpoint is the URL of an S3-compatible storage service.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 type converterV2 struct{}
@@ -50,6 +53,7 @@ func (_ converterV2) ConvertFrom(in *AccessSpec) (*AccessSpecV2, error) {
 		Key:                 in.Key,
 		Version:             in.Version,
 		MediaType:           in.MediaType,
+		Endpoint:            in.Endpoint,
 	}, nil
 }
 
@@ -61,6 +65,7 @@ func (_ converterV2) ConvertTo(in *AccessSpecV2) (*AccessSpec, error) {
 		Key:                          in.Key,
 		Version:                      in.Version,
 		MediaType:                    in.MediaType,
+		Endpoint:                     in.Endpoint,
 	}, nil
 }
 
@@ -86,4 +91,9 @@ The type specific specification fields are:
 - **<code>mediaType</code>** (optional) *string*
 
   The media type of the content
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The URL of an S3-compatible storage service, e.g. a MinIO instance.
+  If not set, AWS S3 is used.
 `
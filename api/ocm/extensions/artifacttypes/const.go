@@ -16,6 +16,10 @@ const (
 	HELM_CHART = "helmChart"
 	// NPM_PACKAGE describes a Node.js (npm) package.
 	NPM_PACKAGE = "npmPackage"
+	// GO_MODULE describes the zip file of a Go module version as provided by a Go module proxy.
+	GO_MODULE = "goModule"
+	// PYTHON_PACKAGE describes a Python distribution file (source distribution or wheel).
+	PYTHON_PACKAGE = "pythonPackage"
 	// MAVEN_PACKAGE describes the complete content addressed by a GAV.
 	// The term Maven Package is introduced in the context of ocm since the term Maven Artifact (as used by Maven
 	// itself) is quite ambiguous since it may refer either to the complete content addressed by a GAV or to a single
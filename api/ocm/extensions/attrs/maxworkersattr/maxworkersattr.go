@@ -58,7 +58,10 @@ func (a AttributeType) Description() string {
 	return `
 *integer* or *"auto"*
 Specifies the maximum number of concurrent workers to use for resource and source,
-as well as reference transfer operations.
+as well as reference transfer operations. The limit applies to the complete
+transferred component version closure. Independent references and artifacts
+are processed concurrently, a component version is finalized after all its
+references have been transferred.
 
 Supported values:
   - A positive integer: use exactly that number of workers.
@@ -73,7 +76,8 @@ Precedence:
 
 WARNING: This is an experimental feature and may cause unexpected behavior
 depending on workload concurrency. Values above 1 may result in non-deterministic
-transfer ordering.
+transfer ordering, the progress output is always reported in the order of
+the sequential processing.
 `
 }
 
@@ -0,0 +1,94 @@
+//go:build integration
+
+package azureblob_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/elements"
+	access "ocm.software/ocm/api/ocm/extensions/accessmethods/azureblob"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	me "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/azureblob"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/tech/azureblob"
+	"ocm.software/ocm/api/tech/azureblob/identity"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	AZURITE_IMAGE   = "mcr.microsoft.com/azure-storage/azurite:3.34.0"
+	AZURITE_ACCOUNT = "devstoreaccount1"
+	// AZURITE_KEY is the well-known account key of the Azurite emulator.
+	AZURITE_KEY = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+var _ = Describe("azurite", Ordered, func() {
+	var container testcontainers.Container
+	var endpoint string
+	var env *Builder
+
+	BeforeAll(func() {
+		ctx := context.Background()
+		container = Must(testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:        AZURITE_IMAGE,
+				Cmd:          []string{"azurite-blob", "--blobHost", "0.0.0.0", "--skipApiVersionCheck"},
+				ExposedPorts: []string{"10000/tcp"},
+				WaitingFor:   wait.ForListeningPort("10000/tcp"),
+			},
+			Started: true,
+		}))
+		host := Must(container.Host(ctx))
+		port := Must(container.MappedPort(ctx, "10000/tcp"))
+		endpoint = fmt.Sprintf("http://%s:%s/%s", host, port.Port(), AZURITE_ACCOUNT)
+
+		url := Must(azureblob.ServiceURL(AZURITE_ACCOUNT, endpoint))
+		client := Must(azureblob.NewClient(url, AZURITE_ACCOUNT, credentials.DirectCredentials{identity.ATTR_ACCOUNT_KEY: AZURITE_KEY}))
+		Must(client.CreateContainer(ctx, CONTAINER, nil))
+	})
+
+	AfterAll(func() {
+		if container != nil {
+			MustBeSuccessful(container.Terminate(context.Background()))
+		}
+	})
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		id := Must(identity.GetConsumerId(Must(azureblob.ServiceURL(AZURITE_ACCOUNT, endpoint)), CONTAINER, ""))
+		env.CredentialsContext().SetCredentialsForConsumer(id, credentials.DirectCredentials{identity.ATTR_ACCOUNT_KEY: AZURITE_KEY})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("uploads and accesses blobs", func() {
+		env.OCMContext().BlobHandlers().Register(me.NewArtifactHandler(&me.Config{Account: AZURITE_ACCOUNT, Container: CONTAINER, Endpoint: endpoint}))
+
+		data := []byte("some binary content")
+		ocmrepo := composition.NewRepository(env)
+		defer Close(ocmrepo)
+		cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+		defer Close(cv)
+		meta := Must(elements.ResourceMeta("test", resourcetypes.EXECUTABLE))
+		MustBeSuccessful(cv.SetResourceBlob(meta, blobaccess.ForData(mime.MIME_OCTET, data), "", nil))
+
+		r := Must(cv.GetResource(meta.GetIdentity(nil)))
+		Expect(Must(r.Access())).To(BeAssignableToTypeOf(&access.AccessSpec{}))
+		m := Must(r.AccessMethod())
+		defer Close(m)
+		Expect(m.Get()).To(Equal(data))
+	})
+})
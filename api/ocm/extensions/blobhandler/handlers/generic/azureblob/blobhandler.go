@@ -0,0 +1,80 @@
+package azureblob
+
+import (
+	"context"
+	"path"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/cpi"
+	access "ocm.software/ocm/api/ocm/extensions/accessmethods/azureblob"
+	"ocm.software/ocm/api/tech/azureblob"
+	"ocm.software/ocm/api/tech/azureblob/identity"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+const BLOB_HANDLER_NAME = "ocm/" + access.Type
+
+// artifactHandler stores blobs in the configured Azure storage container.
+// The blob name is composed of the configured prefix and the blob digest.
+// If a blob with this name and the same size is already present in the
+// container, its properties are used for the access specification instead of
+// uploading it again.
+// If no configuration is given, it does nothing.
+type artifactHandler struct {
+	spec *Config
+}
+
+func NewArtifactHandler(cfg *Config) cpi.BlobHandler {
+	return &artifactHandler{cfg}
+}
+
+var log = logging.DynamicLogger(identity.REALM)
+
+func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, _ string, _ string, _ cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil {
+		return nil, nil
+	}
+	if b.spec.Container == "" {
+		return nil, errors.ErrRequired("container")
+	}
+	url, err := azureblob.ServiceURL(b.spec.Account, b.spec.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	dig, err := blobaccess.Digest(blob)
+	if err != nil {
+		return nil, err
+	}
+	name := path.Join(b.spec.Prefix, dig.Algorithm().String(), dig.Encoded())
+	log := log.WithValues("container", b.spec.Container, "blob", name)
+
+	client, err := azureblob.GetClient(ctx.GetContext(), url, b.spec.Account, b.spec.Container, name)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := azureblob.GetProperties(context.Background(), client, b.spec.Container, name)
+	if err != nil {
+		return nil, err
+	}
+	if props != nil && props.Size == blob.Size() {
+		log.Debug("blob already exists, skipping upload")
+	} else {
+		reader, err := blob.Reader()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		log.Debug("uploading")
+		props, err = azureblob.Upload(context.Background(), client, b.spec.Container, name, blob.MimeType(), reader)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("successfully uploaded")
+	}
+	return access.New(b.spec.Account, b.spec.Container, name, props.VersionID, blob.MimeType()).WithEndpoint(b.spec.Endpoint).WithETag(props.ETag), nil
+}
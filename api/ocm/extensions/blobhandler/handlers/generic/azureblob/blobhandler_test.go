@@ -0,0 +1,68 @@
+package azureblob_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/ocm/elements"
+	access "ocm.software/ocm/api/ocm/extensions/accessmethods/azureblob"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	me "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/azureblob"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/tech/azureblob/azuretest"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const CONTAINER = "artifacts"
+
+var _ = Describe("blobhandler generic azure blob tests", func() {
+	var env *Builder
+	var server *azuretest.Server
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		server = azuretest.NewServer(CONTAINER)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("uploads blob to container", func() {
+		cfg := &me.Config{Account: azuretest.ACCOUNT, Container: CONTAINER, Endpoint: server.ServiceURL(), Prefix: "ocm"}
+		env.OCMContext().BlobHandlers().Register(me.NewArtifactHandler(cfg))
+
+		data := []byte("some binary content")
+		name := "ocm/sha256/" + digest.FromBytes(data).Encoded()
+
+		ocmrepo := composition.NewRepository(env)
+		defer Close(ocmrepo)
+		cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+		defer Close(cv)
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("test", resourcetypes.EXECUTABLE)), blobaccess.ForData(mime.MIME_OCTET, data), "", nil))
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("copy", resourcetypes.EXECUTABLE)), blobaccess.ForData(mime.MIME_OCTET, data), "", nil))
+		Expect(server.Uploads()).To(Equal(1))
+		Expect(server.Get(CONTAINER, name)).To(Equal(data))
+
+		r := Must(cv.GetResource(Must(elements.ResourceMeta("test", resourcetypes.EXECUTABLE)).GetIdentity(nil)))
+		spec := Must(r.Access())
+		Expect(spec).To(BeAssignableToTypeOf(&access.AccessSpec{}))
+		a := spec.(*access.AccessSpec)
+		Expect(a.Account).To(Equal(azuretest.ACCOUNT))
+		Expect(a.Container).To(Equal(CONTAINER))
+		Expect(a.Blob).To(Equal(name))
+		Expect(a.Endpoint).To(Equal(server.ServiceURL()))
+		Expect(a.Version).NotTo(BeEmpty())
+		Expect(a.ETag).NotTo(BeEmpty())
+
+		m := Must(r.AccessMethod())
+		defer Close(m)
+		Expect(m.Get()).To(Equal(data))
+	})
+})
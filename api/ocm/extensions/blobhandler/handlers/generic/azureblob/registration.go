@@ -0,0 +1,73 @@
+package azureblob
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/utils/registrations"
+)
+
+type Config struct {
+	// Account is the name of the storage account.
+	Account string `json:"account"`
+	// Container is the name of the container blobs are uploaded to.
+	Container string `json:"container"`
+	// Endpoint is an optional blob service URL used instead of the
+	// public Azure endpoint of the account (for example, for Azurite).
+	Endpoint string `json:"endpoint,omitempty"`
+	// Prefix is an optional prefix for the names of uploaded blobs.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid azureBlob handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("azure blob target specification required")
+	}
+	cfg, err := registrations.DecodeConfig[Config](config)
+	if err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+	if cfg.Container == "" {
+		return true, errors.ErrRequired("container")
+	}
+
+	ctx.BlobHandlers().Register(NewArtifactHandler(cfg),
+		cpi.NewBlobHandlerOptions(olist...),
+		cpi.WithName(BLOB_HANDLER_NAME),
+	)
+
+	return true, nil
+}
+
+func (r *RegistrationHandler) GetHandlers(_ cpi.Context) registrations.HandlerInfos {
+	return registrations.NewLeafHandlerInfo("uploading blobs to Azure Blob Storage", `
+The <code>`+BLOB_HANDLER_NAME+`</code> uploader is able to upload blobs to a
+container of an Azure Blob Storage account. Blobs are stored under the name
+<code>[&lt;prefix>/]&lt;digest algorithm>/&lt;digest></code>, already existing
+blobs are not uploaded again. The uploaded blobs are described by an access
+specification of type <code>azureBlob</code>.
+
+It is not restricted to artifact or mime types by default, therefore it should
+be registered for dedicated artifact types and/or mime types.
+
+It accepts a config with the following fields:
+- 'account': the name of the storage account
+- 'container': the name of the container
+- 'endpoint': (optional) the blob service URL used instead of the public Azure endpoint
+- 'prefix': (optional) the name prefix for uploaded blobs
+`,
+	)
+}
@@ -0,0 +1,23 @@
+package azureblob_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/azureblob"
+	"ocm.software/ocm/api/utils/registrations"
+)
+
+var _ = Describe("Config deserialization Test Environment", func() {
+	It("deserializes struct", func() {
+		cfg := Must(registrations.DecodeConfig[azureblob.Config](`{"account":"acme","container":"artifacts","prefix":"ocm"}`))
+		Expect(cfg).To(Equal(&azureblob.Config{Account: "acme", Container: "artifacts", Prefix: "ocm"}))
+	})
+
+	It("requires container", func() {
+		ctx := ocm.New()
+		ExpectError(ctx.BlobHandlers().RegisterByName(azureblob.BLOB_HANDLER_NAME, ctx, `{"account":"acme"}`)).To(MatchError(ContainSubstring(`"container" required`)))
+	})
+})
@@ -91,6 +91,7 @@ func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, co
 		cpi.ForArtifactType(resourcetypes.MAVEN_PACKAGE),
 		cpi.ForMimeType(mime.MIME_TGZ),
 		cpi.NewBlobHandlerOptions(olist...),
+		cpi.WithName(BlobHandlerName),
 	)
 
 	return true, nil
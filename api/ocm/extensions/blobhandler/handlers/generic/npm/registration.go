@@ -57,6 +57,7 @@ func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, co
 		cpi.ForArtifactType(resourcetypes.NPM_PACKAGE),
 		cpi.ForMimeType(mime.MIME_TGZ),
 		cpi.NewBlobHandlerOptions(olist...),
+		cpi.WithName(BLOB_HANDLER_NAME),
 	)
 
 	return true, nil
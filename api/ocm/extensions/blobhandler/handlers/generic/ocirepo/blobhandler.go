@@ -23,7 +23,7 @@ import (
 
 func init() {
 	for _, mime := range artdesc.ArchiveBlobTypes() {
-		cpi.RegisterBlobHandler(NewArtifactHandler(), cpi.ForMimeType(mime), cpi.WithPrio(10))
+		cpi.RegisterBlobHandler(NewArtifactHandler(), cpi.ForMimeType(mime), cpi.WithPrio(10), cpi.WithName(BLOB_HANDLER_NAME))
 	}
 }
 
@@ -14,8 +14,10 @@ import (
 
 type Config = ociuploadattr.Attribute
 
+const BLOB_HANDLER_NAME = "ocm/ociArtifacts"
+
 func init() {
-	cpi.RegisterBlobHandlerRegistrationHandler("ocm/ociArtifacts", &RegistrationHandler{})
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
 }
 
 type RegistrationHandler struct{}
@@ -55,7 +57,7 @@ func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, co
 	h := NewArtifactHandler(attr)
 	for _, m := range mimes {
 		opts.MimeType = m
-		ctx.BlobHandlers().Register(h, opts)
+		ctx.BlobHandlers().Register(h, opts, cpi.WithName(BLOB_HANDLER_NAME))
 	}
 
 	return true, nil
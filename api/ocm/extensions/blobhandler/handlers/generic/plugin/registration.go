@@ -88,11 +88,17 @@ func RegisterBlobHandler(ctx ocm.Context, pname, name string, artType, mediaType
 		return d[0].Name, nil, err
 	}
 	for k := range keys {
-		ctx.BlobHandlers().Register(h, cpi.ForArtifactType(k.GetArtifactType()), cpi.ForMimeType(k.GetMediaType()))
+		ctx.BlobHandlers().Register(h, cpi.ForArtifactType(k.GetArtifactType()), cpi.ForMimeType(k.GetMediaType()), cpi.WithName(BlobHandlerName(pname, d[0].Name)))
 	}
 	return d[0].Name, keys, nil
 }
 
+// BlobHandlerName provides the registration name of a blob handler
+// provided by an uploader of a plugin.
+func BlobHandlerName(pname, name string) string {
+	return "plugin/" + pname + "/" + name
+}
+
 func (r *RegistrationHandler) GetHandlers(ctx cpi.Context) registrations.HandlerInfos {
 	infos := registrations.NewNodeHandlerInfo("downloaders provided by plugins",
 		"sub namespace of the form <code>&lt;plugin name>/&lt;handler></code>")
@@ -0,0 +1,138 @@
+package pypi
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/datacontext/attrs/tmpcache"
+	"ocm.software/ocm/api/ocm/cpi"
+	access "ocm.software/ocm/api/ocm/extensions/accessmethods/pypi"
+	"ocm.software/ocm/api/tech/pypi"
+	"ocm.software/ocm/api/tech/pypi/identity"
+	"ocm.software/ocm/api/utils/logging"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const BLOB_HANDLER_NAME = "ocm/pythonPackage"
+
+type artifactHandler struct {
+	spec *Config
+}
+
+func NewArtifactHandler(cfg *Config) cpi.BlobHandler {
+	return &artifactHandler{cfg}
+}
+
+// StoreBlob uploads a distribution file to the configured package index.
+// The reference hint is used as filename, if it is a valid distribution
+// filename. Otherwise, the filename is derived from the package metadata.
+func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, _ string, hint string, _ cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil {
+		return nil, nil
+	}
+
+	switch blob.MimeType() {
+	case mime.MIME_TGZ, mime.MIME_TGZ_ALT, mime.MIME_ZIP, mime.MIME_GZIP:
+	default:
+		return nil, nil
+	}
+
+	uploadUrl := b.spec.GetUploadURL()
+	indexUrl, err := b.spec.GetIndexURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// the archive is spooled to a temporary file, because the
+	// metadata must be read from it before it is uploaded.
+	cache := tmpcache.Get(ctx.GetContext())
+	file, err := cache.CreateTempFile("pypi*")
+	if err != nil {
+		return nil, err
+	}
+	defer cache.Filesystem.Remove(file.Name())
+	defer file.Close()
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	size, err := io.Copy(file, reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	filename := ""
+	if _, err := pypi.ParseFilename(hint); err == nil {
+		filename = hint
+	}
+	dist, err := pypi.InspectArchive(file, size, filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid python package")
+	}
+	log := logging.Context().Logger(pypi.REALM).WithValues("package", dist.Metadata.Name, "version", dist.Metadata.Version, "file", dist.Filename)
+	log.Debug("identified")
+
+	result := access.New(indexUrl, dist.Metadata.Name, dist.Metadata.Version, dist.Filename)
+
+	exists, err := fileExists(ctx.GetContext(), indexUrl, dist, io.NewSectionReader(file, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		log.Debug("distribution file already exists, skipping upload")
+		return result, nil
+	}
+
+	creds, err := identity.GetCredentials(ctx.GetContext(), uploadUrl, dist.Metadata.Name)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("uploading")
+	err = pypi.Upload(context.Background(), nil, uploadUrl, creds, dist, file, size)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("successfully uploaded")
+	return result, nil
+}
+
+// fileExists checks whether the distribution file already exists in the
+// package index. If it does, it checks whether it has the same content.
+func fileExists(ctx cpi.Context, indexUrl string, dist *pypi.Distribution, content io.Reader) (bool, error) {
+	creds, err := identity.GetCredentials(ctx, indexUrl, dist.Metadata.Name)
+	if err != nil {
+		return false, err
+	}
+	project, err := pypi.GetProject(context.Background(), nil, indexUrl, dist.Metadata.Name, creds)
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	f, err := project.FindFile(dist.Metadata.Version, dist.Filename)
+	if err != nil {
+		return false, nil
+	}
+	algo, expected, err := f.Digest()
+	if err != nil {
+		return false, errors.Wrapf(err, "distribution file %s already exists", dist.Filename)
+	}
+	if expected == "" {
+		return false, fmt.Errorf("distribution file %s already exists without digest", dist.Filename)
+	}
+	h := algo.New()
+	if _, err := io.Copy(h, content); err != nil {
+		return false, err
+	}
+	if expected != hex.EncodeToString(h.Sum(nil)) {
+		return false, fmt.Errorf("distribution file %s already exists but has different %s digest", dist.Filename, algo)
+	}
+	return true, nil
+}
@@ -0,0 +1,105 @@
+package pypi_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/elements"
+	access "ocm.software/ocm/api/ocm/extensions/accessmethods/pypi"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	me "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/pypi"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/tech/pypi/identity"
+	"ocm.software/ocm/api/tech/pypi/pypitest"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+var _ = Describe("blobhandler generic pypi tests", func() {
+	var env *Builder
+	var server *pypitest.Server
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		server = pypitest.NewServer()
+		server.RequireAuth(identity.TOKEN_USERNAME, "pypi-token")
+		env.CredentialsContext().SetCredentialsForConsumer(Must(identity.GetConsumerId(server.URL, "")), credentials.DirectCredentials{
+			identity.ATTR_TOKEN: "pypi-token",
+		})
+		cfg := &me.Config{Url: server.UploadURL(), IndexUrl: server.IndexURL()}
+		Expect(Must(env.OCMContext().BlobHandlers().RegisterByName(me.BLOB_HANDLER_NAME, env.OCMContext(), cfg))).To(BeTrue())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("uploads source distribution and wheel", func() {
+		sdist := pypitest.SDist("hello", "1.0.0")
+		wheel := pypitest.Wheel("hello", "1.0.0")
+
+		ocmrepo := composition.NewRepository(env)
+		defer Close(ocmrepo)
+		cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+		defer Close(cv)
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("sdist", resourcetypes.PYTHON_PACKAGE)), blobaccess.ForData(mime.MIME_TGZ, sdist), "", nil))
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("wheel", resourcetypes.PYTHON_PACKAGE)), blobaccess.ForData(mime.MIME_ZIP, wheel), "hello-1.0.0-py3-none-any.whl", nil))
+		Expect(server.Get("hello-1.0.0.tar.gz")).To(Equal(sdist))
+		Expect(server.Get("hello-1.0.0-py3-none-any.whl")).To(Equal(wheel))
+		Expect(server.Uploads()["hello-1.0.0.tar.gz"].Get("filetype")).To(Equal("sdist"))
+
+		r := Must(cv.GetResource(Must(elements.ResourceMeta("wheel", resourcetypes.PYTHON_PACKAGE)).GetIdentity(nil)))
+		spec := Must(r.Access())
+		Expect(spec).To(Equal(access.New(server.IndexURL(), "hello", "1.0.0", "hello-1.0.0-py3-none-any.whl")))
+
+		m := Must(r.AccessMethod())
+		defer Close(m)
+		Expect(m.Get()).To(Equal(wheel))
+	})
+
+	It("skips existing identical file", func() {
+		sdist := pypitest.SDist("hello", "1.0.0")
+		server.Add("hello", "hello-1.0.0.tar.gz", sdist)
+
+		ocmrepo := composition.NewRepository(env)
+		defer Close(ocmrepo)
+		cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+		defer Close(cv)
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("sdist", resourcetypes.PYTHON_PACKAGE)), blobaccess.ForData(mime.MIME_TGZ, sdist), "", nil))
+		Expect(server.Uploads()).To(BeEmpty())
+
+		r := Must(cv.GetResource(Must(elements.ResourceMeta("sdist", resourcetypes.PYTHON_PACKAGE)).GetIdentity(nil)))
+		spec := Must(r.Access())
+		Expect(spec.GetType()).To(Equal(access.Type))
+		Expect(spec.(*access.AccessSpec).IndexURL).To(Equal(server.IndexURL()))
+	})
+
+	It("rejects existing different file", func() {
+		server.Add("hello", "hello-1.0.0.tar.gz", []byte("other content"))
+
+		ocmrepo := composition.NewRepository(env)
+		defer Close(ocmrepo)
+		cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+		defer Close(cv)
+		err := cv.SetResourceBlob(Must(elements.ResourceMeta("sdist", resourcetypes.PYTHON_PACKAGE)), blobaccess.ForData(mime.MIME_TGZ, pypitest.SDist("hello", "1.0.0")), "", nil)
+		Expect(err).To(MatchError(ContainSubstring("distribution file hello-1.0.0.tar.gz already exists but has different SHA-256 digest")))
+	})
+
+	It("rejects upload URL without index", func() {
+		cfg := &me.Config{Url: server.UploadURL()}
+		ExpectError(env.OCMContext().BlobHandlers().RegisterByName(me.BLOB_HANDLER_NAME, env.OCMContext(), cfg)).To(MatchError(ContainSubstring("indexUrl")))
+	})
+
+	It("ignores other artifact types", func() {
+		ocmrepo := composition.NewRepository(env)
+		defer Close(ocmrepo)
+		cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+		defer Close(cv)
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("blob", resourcetypes.BLOB)), blobaccess.ForData(mime.MIME_TGZ, pypitest.SDist("hello", "1.0.0")), "", nil))
+		Expect(server.Uploads()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,112 @@
+package pypi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/cpi"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/tech/pypi"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/api/utils/registrations"
+)
+
+type Config struct {
+	// Url is the URL of the legacy upload API of the package index.
+	Url string `json:"url"`
+	// IndexUrl is the URL of the simple repository API of the package index.
+	// It is used to check for existing files and for the resulting access
+	// specification. It defaults to the public package index, if the public
+	// upload API is used, and is required otherwise.
+	IndexUrl string `json:"indexUrl,omitempty"`
+}
+
+// GetUploadURL returns the URL of the legacy upload API.
+func (c *Config) GetUploadURL() string {
+	if c.Url == "" {
+		return pypi.DEFAULT_UPLOAD_URL
+	}
+	return c.Url
+}
+
+// GetIndexURL returns the URL of the simple repository API
+// matching the upload URL.
+func (c *Config) GetIndexURL() (string, error) {
+	if c.IndexUrl != "" {
+		return c.IndexUrl, nil
+	}
+	if c.GetUploadURL() != pypi.DEFAULT_UPLOAD_URL {
+		return "", errors.Wrapf(errors.ErrRequired("indexUrl"), "upload URL %q", c.Url)
+	}
+	return pypi.DEFAULT_INDEX, nil
+}
+
+type rawConfig Config
+
+func (c *Config) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &c.Url)
+	if err == nil {
+		return nil
+	}
+	var raw rawConfig
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*c = Config(raw)
+
+	return nil
+}
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid pythonPackage handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("python package index specification required")
+	}
+	cfg, err := registrations.DecodeConfig[Config](config)
+	if err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+	if _, err := cfg.GetIndexURL(); err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+
+	for _, m := range []string{mime.MIME_TGZ, mime.MIME_ZIP} {
+		ctx.BlobHandlers().Register(NewArtifactHandler(cfg),
+			cpi.ForArtifactType(resourcetypes.PYTHON_PACKAGE),
+			cpi.ForMimeType(m),
+			cpi.NewBlobHandlerOptions(olist...),
+			cpi.WithName(BLOB_HANDLER_NAME),
+		)
+	}
+	return true, nil
+}
+
+func (r *RegistrationHandler) GetHandlers(_ cpi.Context) registrations.HandlerInfos {
+	return registrations.NewLeafHandlerInfo("uploading python packages", `
+The <code>`+BLOB_HANDLER_NAME+`</code> uploader is able to upload distribution
+files of Python packages (source distributions and wheels) to a Python package
+index using the legacy upload API (as used by twine).
+If registered the default mime types are: `+mime.MIME_TGZ+` and `+mime.MIME_ZIP+`
+
+It accepts a plain string for the upload URL or a config with the following fields:
+- <code>url</code>: the URL of the legacy upload API (default `+pypi.DEFAULT_UPLOAD_URL+`).
+- <code>indexUrl</code>: the URL of the simple repository API used to check for
+  existing files and for the resulting access specification. It defaults to
+  `+pypi.DEFAULT_INDEX+` for the default upload URL and is required for
+  other upload URLs.
+`,
+	)
+}
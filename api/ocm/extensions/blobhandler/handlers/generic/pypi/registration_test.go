@@ -0,0 +1,40 @@
+package pypi_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/pypi"
+	pypitech "ocm.software/ocm/api/tech/pypi"
+	"ocm.software/ocm/api/utils/registrations"
+)
+
+var _ = Describe("Config deserialization Test Environment", func() {
+	It("deserializes string", func() {
+		cfg := Must(registrations.DecodeConfig[pypi.Config]("test"))
+		Expect(cfg).To(Equal(&pypi.Config{Url: "test"}))
+	})
+
+	It("deserializes struct", func() {
+		cfg := Must(registrations.DecodeConfig[pypi.Config](`{"url":"test","indexUrl":"index"}`))
+		Expect(cfg).To(Equal(&pypi.Config{Url: "test", IndexUrl: "index"}))
+	})
+
+	It("defaults the index for the public upload API", func() {
+		cfg := &pypi.Config{}
+		Expect(cfg.GetUploadURL()).To(Equal(pypitech.DEFAULT_UPLOAD_URL))
+		Expect(cfg.GetIndexURL()).To(Equal(pypitech.DEFAULT_INDEX))
+
+		cfg = &pypi.Config{Url: pypitech.DEFAULT_UPLOAD_URL}
+		Expect(cfg.GetIndexURL()).To(Equal(pypitech.DEFAULT_INDEX))
+	})
+
+	It("requires the index for other upload APIs", func() {
+		cfg := &pypi.Config{Url: "https://pypi.acme.org/legacy/"}
+		ExpectError(cfg.GetIndexURL()).To(MatchError(ContainSubstring("indexUrl")))
+
+		cfg.IndexUrl = "https://pypi.acme.org/simple/"
+		Expect(cfg.GetIndexURL()).To(Equal("https://pypi.acme.org/simple/"))
+	})
+})
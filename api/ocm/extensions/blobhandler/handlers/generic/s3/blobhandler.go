@@ -0,0 +1,88 @@
+package s3
+
+import (
+	"context"
+	"path"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/cpi"
+	access "ocm.software/ocm/api/ocm/extensions/accessmethods/s3"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	"ocm.software/ocm/api/tech/s3"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+const BLOB_HANDLER_NAME = "ocm/" + access.Type
+
+// artifactHandler stores blobs as objects in the configured S3 bucket.
+// The object key is composed of the configured prefix, the digest algorithm
+// and the encoded blob digest. An object already stored under this key with
+// the same size is referenced together with its version id, instead of
+// uploading the blob again.
+// If no configuration is given, it does nothing.
+type artifactHandler struct {
+	spec *Config
+}
+
+func NewArtifactHandler(cfg *Config) cpi.BlobHandler {
+	return &artifactHandler{cfg}
+}
+
+var log = logging.DynamicLogger(identity.REALM)
+
+func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, _ string, _ string, _ cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil {
+		return nil, nil
+	}
+	if b.spec.Bucket == "" {
+		return nil, errors.ErrRequired("bucket")
+	}
+	host, err := s3.EndpointHost(b.spec.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	dig, err := blobaccess.Digest(blob)
+	if err != nil {
+		return nil, err
+	}
+	key := path.Join(b.spec.Prefix, dig.Algorithm().String(), dig.Encoded())
+	log := log.WithValues("bucket", b.spec.Bucket, "key", key)
+
+	creds, err := identity.GetCredentials(ctx.GetContext(), host, b.spec.Bucket, key, "")
+	if err != nil {
+		return nil, err
+	}
+	client, err := s3.NewClient(context.Background(), b.spec.Region, b.spec.Endpoint, b.spec.Bucket, identity.AWSCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.GetObjectInfo(context.Background(), key)
+	if err != nil {
+		return nil, err
+	}
+	var version string
+	if info != nil && info.Size == blob.Size() {
+		log.Debug("object already exists, skipping upload")
+		version = info.VersionID
+	} else {
+		reader, err := blob.Reader()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		log.Debug("uploading")
+		version, err = client.Upload(context.Background(), key, blob.MimeType(), reader)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("successfully uploaded")
+	}
+	spec := access.New(client.Region(), b.spec.Bucket, key, version, blob.MimeType()).WithEndpoint(b.spec.Endpoint)
+	spec.SetType(access.TypeV2)
+	return spec, nil
+}
@@ -0,0 +1,78 @@
+package s3_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/elements"
+	access "ocm.software/ocm/api/ocm/extensions/accessmethods/s3"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	me "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/s3"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/tech/s3"
+	"ocm.software/ocm/api/tech/s3/s3test"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const BUCKET = "artifacts"
+
+var _ = Describe("blobhandler generic s3 tests", func() {
+	var env *Builder
+	var server *s3test.Server
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		server = s3test.NewServer(BUCKET)
+		host := Must(s3.EndpointHost(server.URL))
+		env.CredentialsContext().SetCredentialsForConsumer(identity.GetConsumerId(host, BUCKET, "", ""), credentials.DirectCredentials{
+			identity.ATTR_AWS_ACCESS_KEY_ID:     "minioadmin",
+			identity.ATTR_AWS_SECRET_ACCESS_KEY: "minioadmin",
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("uploads blob to bucket", func() {
+		cfg := &me.Config{Bucket: BUCKET, Endpoint: server.URL, Prefix: "ocm"}
+		env.OCMContext().BlobHandlers().Register(me.NewArtifactHandler(cfg))
+
+		data := []byte("some binary content")
+		key := "ocm/sha256/" + digest.FromBytes(data).Encoded()
+
+		ocmrepo := composition.NewRepository(env)
+		defer Close(ocmrepo)
+		cv := composition.NewComponentVersion(env, "acme.org/test", "1.0.0")
+		defer Close(cv)
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("test", resourcetypes.EXECUTABLE)), blobaccess.ForData(mime.MIME_OCTET, data), "", nil))
+		MustBeSuccessful(cv.SetResourceBlob(Must(elements.ResourceMeta("copy", resourcetypes.EXECUTABLE)), blobaccess.ForData(mime.MIME_OCTET, data), "", nil))
+		Expect(server.Uploads()).To(Equal(1))
+		Expect(server.Keys(BUCKET)).To(ConsistOf(key))
+		Expect(server.Get(BUCKET, key)).To(Equal(data))
+		Expect(server.MediaType(BUCKET, key)).To(Equal(mime.MIME_OCTET))
+
+		r := Must(cv.GetResource(Must(elements.ResourceMeta("test", resourcetypes.EXECUTABLE)).GetIdentity(nil)))
+		spec := Must(r.Access())
+		Expect(spec.GetType()).To(Equal(access.TypeV2))
+		Expect(spec).To(BeAssignableToTypeOf(&access.AccessSpec{}))
+		a := spec.(*access.AccessSpec)
+		Expect(a.Region).To(Equal(s3test.REGION))
+		Expect(a.Bucket).To(Equal(BUCKET))
+		Expect(a.Key).To(Equal(key))
+		Expect(a.Endpoint).To(Equal(server.URL))
+		Expect(a.Version).NotTo(BeEmpty())
+
+		m := Must(r.AccessMethod())
+		defer Close(m)
+		Expect(m.Get()).To(Equal(data))
+	})
+})
@@ -0,0 +1,74 @@
+package s3
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/utils/registrations"
+)
+
+type Config struct {
+	// Region is the region of the bucket. If not set, it is determined
+	// from the bucket.
+	Region string `json:"region,omitempty"`
+	// Bucket is the name of the bucket objects are uploaded to.
+	Bucket string `json:"bucket"`
+	// Endpoint is an optional URL of an S3-compatible storage service
+	// used instead of AWS S3 (for example, for MinIO).
+	Endpoint string `json:"endpoint,omitempty"`
+	// Prefix is an optional prefix for the keys of uploaded objects.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid s3 handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("s3 target specification required")
+	}
+	cfg, err := registrations.DecodeConfig[Config](config)
+	if err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+	if cfg.Bucket == "" {
+		return true, errors.ErrRequired("bucket")
+	}
+
+	ctx.BlobHandlers().Register(NewArtifactHandler(cfg),
+		cpi.NewBlobHandlerOptions(olist...),
+		cpi.WithName(BLOB_HANDLER_NAME),
+	)
+
+	return true, nil
+}
+
+func (r *RegistrationHandler) GetHandlers(_ cpi.Context) registrations.HandlerInfos {
+	return registrations.NewLeafHandlerInfo("uploading blobs to S3 buckets", `
+The <code>`+BLOB_HANDLER_NAME+`</code> uploader is able to upload blobs to an
+S3 bucket or a bucket of an S3-compatible storage service. Objects are stored
+under the key <code>[&lt;prefix>/]&lt;digest algorithm>/&lt;digest></code>,
+already existing objects are not uploaded again. The uploaded blobs are
+described by an access specification of type <code>s3/v2</code>.
+
+It is not restricted to artifact or mime types by default, therefore it should
+be registered for dedicated artifact types and/or mime types.
+
+It accepts a config with the following fields:
+- 'bucket': the name of the bucket
+- 'region': (optional) the region of the bucket
+- 'endpoint': (optional) the URL of an S3-compatible storage service used instead of AWS S3
+- 'prefix': (optional) the key prefix for uploaded objects
+`,
+	)
+}
@@ -0,0 +1,23 @@
+package s3_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/s3"
+	"ocm.software/ocm/api/utils/registrations"
+)
+
+var _ = Describe("Config deserialization Test Environment", func() {
+	It("deserializes struct", func() {
+		cfg := Must(registrations.DecodeConfig[s3.Config](`{"region":"eu-west-1","bucket":"artifacts","prefix":"ocm"}`))
+		Expect(cfg).To(Equal(&s3.Config{Region: "eu-west-1", Bucket: "artifacts", Prefix: "ocm"}))
+	})
+
+	It("requires bucket", func() {
+		ctx := ocm.New()
+		ExpectError(ctx.BlobHandlers().RegisterByName(s3.BLOB_HANDLER_NAME, ctx, `{"region":"eu-west-1"}`)).To(MatchError(ContainSubstring(`"bucket" required`)))
+	})
+})
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/azureblob"
 	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/maven"
 	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/npm"
 	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/ocirepo"
+	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/pypi"
+	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/s3"
 	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/oci/ocirepo"
 	_ "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/ocm/comparch"
 )
@@ -14,6 +14,8 @@ import (
 	cfgcpi "ocm.software/ocm/api/config/cpi"
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/oci/artdesc"
+	ocicpi "ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/oci/extensions/repositories/ocireg"
 	"ocm.software/ocm/api/oci/grammar"
@@ -31,6 +33,7 @@ import (
 	"ocm.software/ocm/api/ocm/extensions/attrs/preferrelativeattr"
 	storagecontext "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/oci"
 	"ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/oci/ocirepo/config"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
 	"ocm.software/ocm/api/utils/accessobj"
 	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
 )
@@ -224,6 +227,7 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 	}
 
 	var art oci.ArtifactAccess
+	var src accspeccpi.AccessMethodView
 	var err error
 	var finalizer Finalizer
 	defer finalizer.Finalize()
@@ -239,12 +243,18 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 	// config.
 	preferrelativeattr.ApplyTo(ctx.GetContext(), &opts.PreferRelativeAccess)
 
+	referrers := referrersattr.Get(ctx.GetContext())
 	if m, ok := blob.(blobaccess.AnnotatedBlobAccess[accspeccpi.AccessMethodView]); ok {
+		// the referrers may be configured for the transfer operation
+		// providing the blob.
+		referrers = referrersattr.For(ctx.GetContext(), m.Source().Unwrap())
+
 		// prepare for optimized point to point implementation
 		log.Debug("oci artifact handler with ocm access source",
 			sliceutils.CopyAppend[any](values, "sourcetype", m.Source().AccessSpec().GetType())...,
 		)
 		if ocimeth, ok := m.Source().Unwrap().(ociartifact.AccessMethodImpl); !keep && ok {
+			src = m.Source()
 			art, _, err = ocimeth.GetArtifact()
 			if err != nil {
 				return nil, errors.Wrapf(err, "cannot access source artifact")
@@ -337,7 +347,18 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 		}
 	}
 
-	err = transfer.TransferArtifact(art, namespace, oci.AsTags(tag)...)
+	var sink ocicpi.ArtifactSink = namespace
+	if src != nil {
+		// the blobs are not read from the access method, therefore
+		// the transferred blobs are reported to keep track of the
+		// transfer progress.
+		sink = &progressSink{sink, src}
+	}
+	if referrers != nil {
+		err = transfer.TransferArtifactWithReferrers(art, sink, referrers.ArtifactTypes, oci.AsTags(tag)...)
+	} else {
+		err = transfer.TransferArtifact(art, sink, oci.AsTags(tag)...)
+	}
 	if err != nil {
 		return nil, wrap(err, errhint, "transfer artifact")
 	}
@@ -361,6 +382,28 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 	return ociartifact.New(ref), nil
 }
 
+// progressSink reports the blobs added to a sink as progress of
+// the access method the artifact is taken from.
+type progressSink struct {
+	ocicpi.ArtifactSink
+	method accspeccpi.AccessMethodView
+}
+
+func (s *progressSink) AddReferrer(art oci.Artifact, tags ...string) (oci.BlobAccess, error) {
+	if r, ok := s.ArtifactSink.(ocicpi.ReferrersSink); ok {
+		return r.AddReferrer(art, tags...)
+	}
+	return s.ArtifactSink.AddArtifact(art, tags...)
+}
+
+func (s *progressSink) AddBlob(blob oci.BlobAccess) error {
+	err := s.ArtifactSink.AddBlob(blob)
+	if err == nil {
+		progress.ReportBlob(s.method, blob.Size())
+	}
+	return err
+}
+
 func wrap(err error, msg string, args ...interface{}) error {
 	for _, a := range args {
 		msg = fmt.Sprintf("%s: %s", msg, a)
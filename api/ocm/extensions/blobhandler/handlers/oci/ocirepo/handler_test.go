@@ -29,6 +29,7 @@ import (
 	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
 	"ocm.software/ocm/api/ocm/extensions/repositories/genericocireg"
 	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
 	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/accessobj"
@@ -442,6 +443,29 @@ var _ = Describe("oci artifact transfer", func() {
 			data = Must(blob.Get())
 			Expect(string(data)).To(Equal(OCILAYER))
 		})
+
+		It("reports the progress of a direct artifact transfer", func() {
+			env.OCMContext().BlobHandlers().Register(ocirepo.NewArtifactHandler(FakeOCIRegBaseFunction),
+				cpi.ForRepo(oci.CONTEXT_TYPE, ocictf.Type), cpi.ForMimeType(artdesc.ToContentMediaType(artdesc.MediaTypeImageManifest)))
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+			defer Close(cv, "source cv")
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			var finished *progress.ArtifactFinished
+			collect := progress.HandlerFunc(func(e progress.Event) {
+				if ev, ok := e.(*progress.ArtifactFinished); ok && ev.Identity.Get(metav1.SystemIdentityName) == "artifact" {
+					finished = ev
+				}
+			})
+			MustBeSuccessful(transfer.Transfer(cv, tgt, standard.ResourcesByValue(), transfer.WithProgress(collect)))
+			Expect(finished).NotTo(BeNil())
+			Expect(finished.Error).To(BeNil())
+			Expect(finished.Copied).To(Equal(int64(len("{}") + len(OCILAYER))))
+		})
 	})
 
 	Context("with tag + digest", func() {
@@ -0,0 +1,73 @@
+package gomodule
+
+import (
+	"encoding/hex"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+// GoModuleDigestV1 normalizes a module zip file to the list of file names
+// and their content, as used for the h1 dirhash of go.sum files. The digest
+// is the sha256 digest encoded in the h1 hash. It does not depend on the
+// zip encoding, so it is reproducible even if the zip file is recreated.
+const GoModuleDigestV1 = "goModuleDigest/v1"
+
+func init() {
+	cpi.MustRegisterDigester(New(), artifacttypes.GO_MODULE)
+}
+
+func New() cpi.BlobDigester {
+	return &Digester{
+		cpi.DigesterType{
+			HashAlgorithm:          sha256.Algorithm,
+			NormalizationAlgorithm: GoModuleDigestV1,
+		},
+	}
+}
+
+type Digester struct {
+	typ cpi.DigesterType
+}
+
+var _ cpi.BlobDigester = (*Digester)(nil)
+
+func (d *Digester) GetType() cpi.DigesterType {
+	return d.typ
+}
+
+func (d *Digester) DetermineDigest(reftyp string, method cpi.AccessMethod, preferred signing.Hasher) (*cpi.DigestDescriptor, error) {
+	if preferred.Algorithm() != d.typ.HashAlgorithm {
+		return nil, nil
+	}
+	switch method.MimeType() {
+	case mime.MIME_ZIP:
+	default:
+		return nil, nil
+	}
+	r, err := method.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	h, err := gomodule.HashZip(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "go module")
+	}
+	dig, err := gomodule.DecodeHash(h)
+	if err != nil {
+		return nil, err
+	}
+	return cpi.NewDigestDescriptor(hex.EncodeToString(dig), d.typ), nil
+}
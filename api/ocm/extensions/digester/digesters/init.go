@@ -3,4 +3,5 @@ package digesters
 import (
 	_ "ocm.software/ocm/api/ocm/extensions/digester/digesters/artifact"
 	_ "ocm.software/ocm/api/ocm/extensions/digester/digesters/blob"
+	_ "ocm.software/ocm/api/ocm/extensions/digester/digesters/gomodule"
 )
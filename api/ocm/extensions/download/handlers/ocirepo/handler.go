@@ -11,6 +11,7 @@ import (
 
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/oci/grammar"
 	"ocm.software/ocm/api/oci/tools/transfer"
@@ -188,7 +189,11 @@ func (h *handler) Download(p common.Printer, racc cpi.ResourceAccess, path strin
 	}
 
 	p.Printf("uploading resource %s to %s[%s:%s]...\n", racc.Meta().GetName(), repo.GetSpecification().UniformRepositorySpec(), namespace, tag)
-	err = transfer.TransferArtifact(art, ns, oci.AsTags(tag)...)
+	if attr := referrersattr.Get(ctx); attr != nil {
+		err = transfer.TransferArtifactWithReferrers(art, ns, attr.ArtifactTypes, oci.AsTags(tag)...)
+	} else {
+		err = transfer.TransferArtifact(art, ns, oci.AsTags(tag)...)
+	}
 	if err != nil {
 		return true, "", errors.Wrapf(err, "transfer artifact")
 	}
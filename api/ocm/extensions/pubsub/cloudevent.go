@@ -0,0 +1,80 @@
+package pubsub
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"ocm.software/ocm/api/ocm/cpi"
+)
+
+const (
+	// CLOUDEVENTS_SPEC_VERSION is the CloudEvents specification version
+	// used for the generated events.
+	CLOUDEVENTS_SPEC_VERSION = "1.0"
+	// CLOUDEVENTS_CONTENT_TYPE is the content type of a CloudEvent
+	// in structured content mode.
+	CLOUDEVENTS_CONTENT_TYPE = "application/cloudevents+json"
+)
+
+// CloudEvent is the JSON representation of an event according to
+// the CloudEvents specification (structured content mode).
+// It is used by pub/sub types forwarding events to generic
+// consumers.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// NewCloudEvent creates a CloudEvent with a new unique id
+// and the actual time.
+func NewCloudEvent(source, typ, subject string, data interface{}) (*CloudEvent, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &CloudEvent{
+		SpecVersion:     CLOUDEVENTS_SPEC_VERSION,
+		ID:              hex.EncodeToString(id),
+		Source:          source,
+		Type:            typ,
+		Subject:         subject,
+		Time:            time.Now().UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            raw,
+	}, nil
+}
+
+// EventCloudEvent creates a CloudEvent for an event of the given
+// repository. The CloudEvents type is the event type and the data
+// is the event payload.
+func EventCloudEvent(repo cpi.Repository, evt *Event) (*CloudEvent, error) {
+	return NewCloudEvent(RepositorySource(repo), evt.Type, evt.GetComponentVersion().String(), evt)
+}
+
+// RepositorySource provides the CloudEvents source used for events
+// of a repository. It is the uniform repository spec notation.
+func RepositorySource(repo cpi.Repository) string {
+	return repo.GetSpecification().AsUniformSpec(repo.GetContext()).String()
+}
+
+// HMACSignature calculates the hex-encoded HMAC-SHA256 signature
+// of a payload, prefixed by the algorithm (sha256=<hex>).
+func HMACSignature(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,162 @@
+package pubsub
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"reflect"
+
+	"github.com/mandelsoft/goutils/maputils"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/cpi"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// EVENT_PAYLOAD_VERSION is the version of the Event format.
+const EVENT_PAYLOAD_VERSION = "v1"
+
+// Event types.
+const (
+	EVENT_COMPONENT_VERSION_ADDED       = "software.ocm.componentversion.added"
+	EVENT_COMPONENT_VERSION_OVERWRITTEN = "software.ocm.componentversion.overwritten"
+	EVENT_COMPONENT_VERSION_DELETED     = "software.ocm.componentversion.deleted"
+	EVENT_SIGNATURE_ADDED               = "software.ocm.componentversion.signature.added"
+	EVENT_ROUTINGSLIP_APPENDED          = "software.ocm.componentversion.routingslip.appended"
+	EVENT_TRANSFER_COMPLETED            = "software.ocm.componentversion.transfer.completed"
+)
+
+// routingSlipLabel is the name of the label used to store routing slips
+// (see package ocm.software/ocm/api/ocm/extensions/labels/routingslip,
+// which cannot be used here, because it depends on the repository
+// implementation support).
+const routingSlipLabel = "routing-slips"
+
+// Event describes a lifecycle event of a component version
+// in a repository. It is serializable and versioned by the field
+// PayloadVersion.
+type Event struct {
+	PayloadVersion string `json:"payloadVersion"`
+	Type           string `json:"type"`
+	Component      string `json:"component"`
+	Version        string `json:"version"`
+	// DescriptorDigest is the digest of the normalized component descriptor
+	// after the event, if it could be determined.
+	DescriptorDigest *metav1.DigestSpec `json:"descriptorDigest,omitempty"`
+	// Repository is the specification of the repository the event
+	// is published for.
+	Repository json.RawMessage `json:"repository,omitempty"`
+
+	// Signature is the name of an added signature.
+	Signature string `json:"signature,omitempty"`
+	// RoutingSlip is the name of an appended routing slip.
+	RoutingSlip string `json:"routingSlip,omitempty"`
+	// Entries is the number of entries appended to a routing slip.
+	Entries int `json:"entries,omitempty"`
+	// Source is the specification of the source repository of a
+	// transfer.
+	Source json.RawMessage `json:"source,omitempty"`
+}
+
+// NewEvent creates an event of the given type for a component version of a
+// repository. If a component descriptor is given, its digest is
+// added to the event.
+func NewEvent(typ string, repo cpi.Repository, nv common.NameVersion, cd *compdesc.ComponentDescriptor) *Event {
+	evt := &Event{
+		PayloadVersion: EVENT_PAYLOAD_VERSION,
+		Type:           typ,
+		Component:      nv.GetName(),
+		Version:        nv.GetVersion(),
+		Repository:     repositorySpec(repo),
+	}
+	if cd != nil {
+		if d, err := compdesc.Hash(cd, compdesc.JsonNormalisationV3, sha256.New()); err == nil {
+			evt.DescriptorDigest = &metav1.DigestSpec{
+				HashAlgorithm:          "SHA-256",
+				NormalisationAlgorithm: compdesc.JsonNormalisationV3,
+				Value:                  d,
+			}
+		}
+	}
+	return evt
+}
+
+// NewTransferCompletedEvent creates an event for a completed transfer of a
+// component version from the given source into the repository.
+func NewTransferCompletedEvent(repo cpi.Repository, src cpi.ComponentVersionAccess) *Event {
+	evt := NewEvent(EVENT_TRANSFER_COMPLETED, repo, common.VersionedElementKey(src), src.GetDescriptor())
+	evt.Source = repositorySpec(src.Repository())
+	return evt
+}
+
+// NewDeletedEvent creates an event for a component version deleted from
+// the repository. It does not carry a descriptor digest.
+func NewDeletedEvent(repo cpi.Repository, nv common.NameVersion) *Event {
+	return NewEvent(EVENT_COMPONENT_VERSION_DELETED, repo, nv, nil)
+}
+
+// GetComponentVersion provides the component version of the event.
+func (e *Event) GetComponentVersion() common.NameVersion {
+	return common.NewNameVersion(e.Component, e.Version)
+}
+
+func repositorySpec(repo cpi.Repository) json.RawMessage {
+	if repo == nil {
+		return nil
+	}
+	data, err := json.Marshal(repo.GetSpecification())
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// UpdateEvents provides the events for the update of a component version
+// in a repository. old is the previously stored descriptor, if known, and
+// existing indicates whether the version has already been stored
+// before (for example, if it is overwritten by a new version with the
+// same name).
+// Signature and routing slip events are only generated for updates of
+// known descriptors.
+func UpdateEvents(repo cpi.Repository, old, cur *compdesc.ComponentDescriptor, existing bool) []*Event {
+	nv := common.NewNameVersion(cur.GetName(), cur.GetVersion())
+
+	typ := EVENT_COMPONENT_VERSION_ADDED
+	if old != nil || existing {
+		typ = EVENT_COMPONENT_VERSION_OVERWRITTEN
+	}
+	main := NewEvent(typ, repo, nv, cur)
+	events := []*Event{main}
+	if old == nil {
+		return events
+	}
+
+	for _, s := range cur.Signatures {
+		o := old.Signatures.GetByName(s.Name)
+		if o == nil || !reflect.DeepEqual(o, &s) {
+			evt := *main
+			evt.Type = EVENT_SIGNATURE_ADDED
+			evt.Signature = s.Name
+			events = append(events, &evt)
+		}
+	}
+
+	oldslips := routingSlips(old)
+	curslips := routingSlips(cur)
+	for _, n := range maputils.OrderedKeys(curslips) {
+		if cnt := len(curslips[n]) - len(oldslips[n]); cnt > 0 {
+			evt := *main
+			evt.Type = EVENT_ROUTINGSLIP_APPENDED
+			evt.RoutingSlip = n
+			evt.Entries = cnt
+			events = append(events, &evt)
+		}
+	}
+	return events
+}
+
+func routingSlips(cd *compdesc.ComponentDescriptor) map[string][]json.RawMessage {
+	var slips map[string][]json.RawMessage
+	cd.Labels.GetValue(routingSlipLabel, &slips)
+	return slips
+}
@@ -57,6 +57,15 @@ type PubSubMethod interface {
 	NotifyComponentVersion(version common.NameVersion) error
 }
 
+// EventPubSubMethod is an optional interface of a PubSubMethod
+// able to publish lifecycle events (see Event).
+// Methods not implementing it are only notified about
+// added or overwritten component versions.
+type EventPubSubMethod interface {
+	PubSubMethod
+	NotifyEvent(evt *Event) error
+}
+
 // TypeScheme is the registry for specification types for
 // PubSub types. A PubSub type is finally able to
 // provide an implementation for notifying a dedicated
@@ -9,10 +9,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/mandelsoft/goutils/general"
 	"github.com/mandelsoft/goutils/sliceutils"
 
 	"ocm.software/ocm/api/datacontext"
 	"ocm.software/ocm/api/ocm"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/extensions/pubsub"
 	"ocm.software/ocm/api/ocm/extensions/pubsub/types/compound"
@@ -31,6 +33,7 @@ type Provider struct {
 	lock      sync.Mutex
 	settings  map[string]pubsub.PubSubSpec
 	published sliceutils.Slice[common.NameVersion]
+	events    []*pubsub.Event
 }
 
 var _ pubsub.Provider = (*Provider)(nil)
@@ -90,16 +93,20 @@ const TYPE = "test"
 // Spec provides a pub sub adapter registering events at its provider.
 type Spec struct {
 	runtime.ObjectVersionedType
+	Events   bool `json:"events,omitempty"`
 	provider *Provider
 }
 
 var _ pubsub.PubSubSpec = (*Spec)(nil)
 
-func NewSpec() pubsub.PubSubSpec {
-	return &Spec{runtime.NewVersionedObjectType(TYPE), nil}
+func NewSpec(events ...bool) pubsub.PubSubSpec {
+	return &Spec{runtime.NewVersionedObjectType(TYPE), general.Optional(events...), nil}
 }
 
 func (s *Spec) PubSubMethod(repo ocm.Repository) (pubsub.PubSubMethod, error) {
+	if s.Events {
+		return &EventMethod{Method{s.provider}}, nil
+	}
 	return &Method{s.provider}, nil
 }
 
@@ -122,6 +129,28 @@ func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
 	return nil
 }
 
+// EventMethod additionally registers lifecycle events.
+type EventMethod struct {
+	Method
+}
+
+var _ pubsub.EventPubSubMethod = (*EventMethod)(nil)
+
+func (m *EventMethod) NotifyEvent(evt *pubsub.Event) error {
+	m.provider.lock.Lock()
+	defer m.provider.lock.Unlock()
+
+	if evt.Type == pubsub.EVENT_COMPONENT_VERSION_ADDED {
+		m.provider.published.Add(evt.GetComponentVersion())
+	}
+	m.provider.events = append(m.provider.events, evt)
+	return nil
+}
+
+func eventTypes(events []*pubsub.Event) []string {
+	return sliceutils.Transform(events, func(e *pubsub.Event) string { return e.Type })
+}
+
 var _ = Describe("Pub SubTest Environment", func() {
 	var ctx ocm.Context
 	var prov *Provider
@@ -166,4 +195,101 @@ var _ = Describe("Pub SubTest Environment", func() {
 		MustBeSuccessful(repo.AddComponentVersion(cv))
 		Expect(prov.published).To(ConsistOf(common.VersionedElementKey(cv)))
 	})
+
+	Context("lifecycle events", func() {
+		var repo ocm.Repository
+
+		BeforeEach(func() {
+			repo = composition.NewRepository(ctx, "testrepo")
+			pubsub.SetForRepo(repo, NewSpec(true))
+
+			cv := composition.NewComponentVersion(ctx, COMP, VERS)
+			defer Close(cv)
+			MustBeSuccessful(repo.AddComponentVersion(cv))
+			prov.events = nil
+			prov.published = nil
+		})
+
+		AfterEach(func() {
+			MustBeSuccessful(repo.Close())
+		})
+
+		It("provides versioned event payload", func() {
+			cv := composition.NewComponentVersion(ctx, COMP, "v2")
+			defer Close(cv)
+			MustBeSuccessful(repo.AddComponentVersion(cv))
+
+			Expect(eventTypes(prov.events)).To(Equal([]string{pubsub.EVENT_COMPONENT_VERSION_ADDED}))
+			evt := prov.events[0]
+			Expect(evt.PayloadVersion).To(Equal(pubsub.EVENT_PAYLOAD_VERSION))
+			Expect(evt.GetComponentVersion()).To(Equal(common.NewNameVersion(COMP, "v2")))
+			Expect(evt.DescriptorDigest).NotTo(BeNil())
+			Expect(evt.Repository).To(MatchJSON(Must(json.Marshal(repo.GetSpecification()))))
+		})
+
+		It("notifies overwritten versions", func() {
+			cv := composition.NewComponentVersion(ctx, COMP, VERS)
+			defer Close(cv)
+			cv.GetDescriptor().Provider.Name = "acme.org"
+			MustBeSuccessful(repo.AddComponentVersion(cv, true))
+
+			Expect(eventTypes(prov.events)).To(Equal([]string{pubsub.EVENT_COMPONENT_VERSION_OVERWRITTEN}))
+		})
+
+		It("notifies added signatures and routing slip entries", func() {
+			cv := Must(repo.LookupComponentVersion(COMP, VERS))
+			defer Close(cv)
+
+			cv.GetDescriptor().Signatures = append(cv.GetDescriptor().Signatures, metav1.Signature{
+				Name:   "acme",
+				Digest: metav1.DigestSpec{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v1", Value: "0815"},
+				Signature: metav1.SignatureSpec{
+					Algorithm: "RSASSA-PKCS1-V1_5",
+					Value:     "4711",
+					MediaType: "application/vnd.ocm.signature.rsa",
+				},
+			})
+			MustBeSuccessful(cv.GetDescriptor().Labels.SetValue("routing-slips", map[string][]interface{}{
+				"acme.org": {map[string]interface{}{"payload": map[string]interface{}{"type": "comment", "comment": "test"}}},
+			}))
+			MustBeSuccessful(cv.Update())
+
+			Expect(eventTypes(prov.events)).To(Equal([]string{
+				pubsub.EVENT_COMPONENT_VERSION_OVERWRITTEN,
+				pubsub.EVENT_SIGNATURE_ADDED,
+				pubsub.EVENT_ROUTINGSLIP_APPENDED,
+			}))
+			Expect(prov.events[1].Signature).To(Equal("acme"))
+			Expect(prov.events[2].RoutingSlip).To(Equal("acme.org"))
+			Expect(prov.events[2].Entries).To(Equal(1))
+		})
+
+		It("notifies deleted versions", func() {
+			MustBeSuccessful(pubsub.NotifyEvents(repo, pubsub.NewDeletedEvent(repo, common.NewNameVersion(COMP, VERS))))
+
+			Expect(eventTypes(prov.events)).To(Equal([]string{pubsub.EVENT_COMPONENT_VERSION_DELETED}))
+			Expect(prov.events[0].GetComponentVersion()).To(Equal(common.NewNameVersion(COMP, VERS)))
+			Expect(prov.events[0].DescriptorDigest).To(BeNil())
+		})
+
+		It("notifies methods without event support about versions, only", func() {
+			pubsub.SetForRepo(repo, NewSpec())
+
+			cv := Must(repo.LookupComponentVersion(COMP, VERS))
+			defer Close(cv)
+			cv.GetDescriptor().Signatures = append(cv.GetDescriptor().Signatures, metav1.Signature{
+				Name:   "acme",
+				Digest: metav1.DigestSpec{HashAlgorithm: "SHA-256", NormalisationAlgorithm: "jsonNormalisation/v1", Value: "0815"},
+				Signature: metav1.SignatureSpec{
+					Algorithm: "RSASSA-PKCS1-V1_5",
+					Value:     "4711",
+					MediaType: "application/vnd.ocm.signature.rsa",
+				},
+			})
+			MustBeSuccessful(cv.Update())
+
+			Expect(prov.events).To(BeNil())
+			Expect(prov.published).To(ConsistOf(common.NewNameVersion(COMP, VERS)))
+		})
+	})
 })
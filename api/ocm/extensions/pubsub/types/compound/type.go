@@ -91,7 +91,7 @@ type Method struct {
 	meths []pubsub.PubSubMethod
 }
 
-var _ pubsub.PubSubMethod = (*Method)(nil)
+var _ pubsub.EventPubSubMethod = (*Method)(nil)
 
 func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
 	list := errors.ErrList()
@@ -100,3 +100,11 @@ func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
 	}
 	return list.Result()
 }
+
+func (m *Method) NotifyEvent(evt *pubsub.Event) error {
+	list := errors.ErrList()
+	for _, m := range m.meths {
+		list.Add(pubsub.PublishEvent(m, evt))
+	}
+	return list.Result()
+}
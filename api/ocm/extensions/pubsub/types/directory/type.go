@@ -0,0 +1,113 @@
+package directory
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Type   = "directory"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+// SUFFIX is the file suffix used for spooled events.
+const SUFFIX = ".json"
+
+func init() {
+	pubsub.RegisterType(pubsub.NewPubSubType[*Spec](Type,
+		pubsub.WithDesciption("a filesystem directory spooling events for later processing.")))
+	pubsub.RegisterType(pubsub.NewPubSubType[*Spec](TypeV1,
+		pubsub.WithFormatSpec(`It is described by the following field:
+
+- **<code>path</code>**  *spool directory*
+
+  For every change a file with a CloudEvents JSON payload (structured content
+  mode) is written to the spool directory. The directory is created, if it
+  does not exist. The file names start with the UTC time stamp of the event
+  and use the suffix <code>`+SUFFIX+`</code>, so the events can be processed
+  in the order of their creation. Files are written under a temporary name
+  and renamed after they are complete.
+`)))
+}
+
+// Spec provides a pub sub adapter spooling events into a directory.
+type Spec struct {
+	runtime.ObjectVersionedType
+	Path string `json:"path"`
+}
+
+var _ pubsub.PubSubSpec = (*Spec)(nil)
+
+func New(path string) (*Spec, error) {
+	return &Spec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		Path:                path,
+	}, nil
+}
+
+func (s *Spec) PubSubMethod(repo cpi.Repository) (pubsub.PubSubMethod, error) {
+	if s.Path == "" {
+		return nil, errors.ErrRequired("spool directory path")
+	}
+	return &Method{s, repo, vfsattr.Get(repo.GetContext())}, nil
+}
+
+func (s *Spec) Describe(_ cpi.Context) string {
+	return fmt.Sprintf("spool directory %s", s.Path)
+}
+
+// Method finally publishes events.
+type Method struct {
+	spec *Spec
+	repo cpi.Repository
+	fs   vfs.FileSystem
+}
+
+var _ pubsub.EventPubSubMethod = (*Method)(nil)
+
+func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
+	return m.NotifyEvent(pubsub.NewEvent(pubsub.EVENT_COMPONENT_VERSION_ADDED, m.repo, version, nil))
+}
+
+func (m *Method) NotifyEvent(evt *pubsub.Event) error {
+	cevt, err := pubsub.EventCloudEvent(m.repo, evt)
+	if err != nil {
+		return err
+	}
+	return m.spool(cevt)
+}
+
+func (m *Method) spool(evt *pubsub.CloudEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	err = m.fs.MkdirAll(m.spec.Path, 0o755)
+	if err != nil {
+		return errors.Wrapf(err, "cannot create spool directory %q", m.spec.Path)
+	}
+
+	ts := time.Now().UTC().Format("20060102T150405.000000000Z")
+	name := vfs.Join(m.fs, m.spec.Path, ts+"-"+evt.ID+SUFFIX)
+	tmp := vfs.Join(m.fs, m.spec.Path, "."+ts+"-"+evt.ID+".tmp")
+	err = vfs.WriteFile(m.fs, tmp, data, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "cannot write event file")
+	}
+	err = m.fs.Rename(tmp, name)
+	if err != nil {
+		m.fs.Remove(tmp)
+		return errors.Wrapf(err, "cannot write event file")
+	}
+	return nil
+}
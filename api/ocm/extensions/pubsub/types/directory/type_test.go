@@ -0,0 +1,75 @@
+package directory_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	"ocm.software/ocm/api/ocm/extensions/pubsub/providers/ocireg"
+	"ocm.software/ocm/api/ocm/extensions/pubsub/types/directory"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	ARCH  = "ctf"
+	SPOOL = "/spool"
+	COMP  = "acme.org/component"
+	VERS  = "v1"
+)
+
+var _ = Describe("directory pub/sub", func() {
+	var env *Builder
+	var repo ocm.Repository
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory)
+		attr := pubsub.For(env)
+		attr.ProviderRegistry.Register(ctf.Type, &ocireg.Provider{})
+
+		repo = Must(ctf.Open(env, ctf.ACC_WRITABLE, ARCH, 0o600, env))
+	})
+
+	AfterEach(func() {
+		if repo != nil {
+			MustBeSuccessful(repo.Close())
+		}
+		env.Cleanup()
+	})
+
+	It("spools events", func() {
+		MustBeSuccessful(pubsub.SetForRepo(repo, Must(directory.New(SPOOL))))
+
+		cv := composition.NewComponentVersion(env, COMP, VERS)
+		defer Close(cv)
+		MustBeSuccessful(repo.AddComponentVersion(cv))
+
+		files := Must(vfs.ReadDir(env, SPOOL))
+		Expect(files).To(HaveLen(1))
+		Expect(strings.HasSuffix(files[0].Name(), directory.SUFFIX)).To(BeTrue())
+
+		var evt pubsub.CloudEvent
+		MustBeSuccessful(json.Unmarshal(Must(vfs.ReadFile(env, vfs.Join(env, SPOOL, files[0].Name()))), &evt))
+		Expect(evt.SpecVersion).To(Equal(pubsub.CLOUDEVENTS_SPEC_VERSION))
+		Expect(evt.Type).To(Equal(pubsub.EVENT_COMPONENT_VERSION_ADDED))
+		Expect(evt.Subject).To(Equal(COMP + ":" + VERS))
+		Expect(evt.Source).To(Equal(pubsub.RepositorySource(repo)))
+		var data pubsub.Event
+		MustBeSuccessful(json.Unmarshal(evt.Data, &data))
+		Expect(data.PayloadVersion).To(Equal(pubsub.EVENT_PAYLOAD_VERSION))
+		Expect(data.Type).To(Equal(pubsub.EVENT_COMPONENT_VERSION_ADDED))
+		Expect(data.GetComponentVersion()).To(Equal(common.NewNameVersion(COMP, VERS)))
+		Expect(data.DescriptorDigest).NotTo(BeNil())
+	})
+})
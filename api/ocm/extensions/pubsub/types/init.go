@@ -2,5 +2,7 @@ package types
 
 import (
 	_ "ocm.software/ocm/api/ocm/extensions/pubsub/types/compound"
+	_ "ocm.software/ocm/api/ocm/extensions/pubsub/types/directory"
 	_ "ocm.software/ocm/api/ocm/extensions/pubsub/types/redis"
+	_ "ocm.software/ocm/api/ocm/extensions/pubsub/types/webhook"
 )
@@ -2,8 +2,11 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/general"
 	"github.com/redis/go-redis/v9"
 
 	credcpi "ocm.software/ocm/api/credentials/cpi"
@@ -19,6 +22,12 @@ const (
 	TypeV1 = Type + runtime.VersionSeparator + "v1"
 )
 
+// Message formats.
+const (
+	FORMAT_NAMEVERSION = "nameversion"
+	FORMAT_EVENT       = "event"
+)
+
 func init() {
 	pubsub.RegisterType(pubsub.NewPubSubType[*Spec](Type,
 		pubsub.WithDesciption("a redis pubsub system.")))
@@ -28,11 +37,17 @@ func init() {
 - **<code>serverAddr</code>**  *Address of redis server*
 - **<code>channel</code>**  *pubsub channel*
 - **<code>database</code>**  *database number*
-
-  Publishing using the redis pubsub API. For every change a string message
-  with the format <component>:<version> is published. If multiple repositories
-  should be used, each repository should be configured with a different
-  channel.
+- **<code>format</code>** (optional) *message format*
+
+  Publishing using the redis pubsub API. With the default format
+  <code>`+FORMAT_NAMEVERSION+`</code>, for every added or overwritten
+  component version a string message with the format
+  &lt;component>:&lt;version> is published.
+  With the format <code>`+FORMAT_EVENT+`</code>, all lifecycle events
+  are published as JSON event payload (added, overwritten, deleted, signature added,
+  routing slip appended, transfer completed, ...).
+  If multiple repositories should be used, each repository should be
+  configured with a different channel.
 `)))
 }
 
@@ -42,14 +57,15 @@ type Spec struct {
 	ServerAddr string `json:"serverAddr"`
 	Channel    string `json:"channel"`
 	Database   int    `json:"database"`
+	Format     string `json:"format,omitempty"`
 }
 
 var _ pubsub.PubSubSpec = (*Spec)(nil)
 
-func New(serverurl, channel string, db int) (*Spec, error) {
+func New(serverurl, channel string, db int, format ...string) (*Spec, error) {
 	return &Spec{
 		runtime.NewVersionedObjectType(Type),
-		serverurl, channel, db,
+		serverurl, channel, db, general.Optional(format...),
 	}, nil
 }
 
@@ -58,6 +74,11 @@ func (s *Spec) PubSubMethod(repo cpi.Repository) (pubsub.PubSubMethod, error) {
 	if err != nil {
 		return nil, err
 	}
+	switch s.Format {
+	case "", FORMAT_NAMEVERSION, FORMAT_EVENT:
+	default:
+		return nil, errors.ErrInvalid("redis message format", s.Format)
+	}
 
 	creds, err := identity.GetCredentials(repo.GetContext(), s.ServerAddr, s.Channel, s.Database)
 	if err != nil {
@@ -76,9 +97,31 @@ type Method struct {
 	creds credcpi.Credentials
 }
 
-var _ pubsub.PubSubMethod = (*Method)(nil)
+var _ pubsub.EventPubSubMethod = (*Method)(nil)
 
 func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
+	return m.publish(version.String())
+}
+
+func (m *Method) NotifyEvent(evt *pubsub.Event) error {
+	var msg string
+	switch m.spec.Format {
+	case FORMAT_EVENT:
+		data, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		msg = string(data)
+	default:
+		if evt.Type != pubsub.EVENT_COMPONENT_VERSION_ADDED && evt.Type != pubsub.EVENT_COMPONENT_VERSION_OVERWRITTEN {
+			return nil
+		}
+		msg = evt.GetComponentVersion().String()
+	}
+	return m.publish(msg)
+}
+
+func (m *Method) publish(msg string) error {
 	// TODO: update to credential provider interface
 	opts := &redis.Options{
 		Addr: m.spec.ServerAddr,
@@ -91,5 +134,5 @@ func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
 
 	rdb := redis.NewClient(opts)
 	defer rdb.Close()
-	return rdb.Publish(context.Background(), m.spec.Channel, version.String()).Err()
+	return rdb.Publish(context.Background(), m.spec.Channel, msg).Err()
 }
@@ -0,0 +1,60 @@
+package identity
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+// CONSUMER_TYPE is the webhook pub/sub consumer type.
+const CONSUMER_TYPE = "Webhook"
+
+// used identity properties.
+const (
+	ID_TYPE       = hostpath.ID_TYPE
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+	ID_SCHEME     = hostpath.ID_SCHEME
+)
+
+// used credential properties.
+const (
+	ATTR_SECRET         = "secret"
+	ATTR_USERNAME       = cpi.ATTR_USERNAME
+	ATTR_PASSWORD       = cpi.ATTR_PASSWORD
+	ATTR_IDENTITY_TOKEN = cpi.ATTR_IDENTITY_TOKEN
+)
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_SECRET, "the HMAC secret used to sign the event payload",
+		ATTR_USERNAME, "the basic auth user name",
+		ATTR_PASSWORD, "the basic auth password",
+		ATTR_IDENTITY_TOKEN, "the bearer token used for non-basic auth authorization",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, IdentityMatcher, `Webhook PubSub credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.`,
+		attrs)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+func GetConsumerId(url string) cpi.ConsumerIdentity {
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, url)
+}
+
+func GetCredentials(ctx cpi.ContextProvider, url string) (cpi.Credentials, error) {
+	id := GetConsumerId(url)
+	if id == nil {
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, IdentityMatcher)
+}
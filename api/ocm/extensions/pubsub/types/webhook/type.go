@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	credcpi "ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	"ocm.software/ocm/api/ocm/extensions/pubsub/types/webhook/identity"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Type   = "webhook"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+// SIGNATURE_HEADER is the HTTP header used to pass the HMAC signature
+// of the event payload.
+const SIGNATURE_HEADER = "X-OCM-Signature"
+
+const defaultTimeout = 30 * time.Second
+
+func init() {
+	pubsub.RegisterType(pubsub.NewPubSubType[*Spec](Type,
+		pubsub.WithDesciption("a webhook receiving CloudEvents.")))
+	pubsub.RegisterType(pubsub.NewPubSubType[*Spec](TypeV1,
+		pubsub.WithFormatSpec(`It is described by the following fields:
+
+- **<code>url</code>**  *URL of the webhook*
+- **<code>timeout</code>** (optional) *request timeout (default 30s)*
+
+  For every change a CloudEvents JSON payload (structured content mode) is
+  posted to the webhook URL. If credentials with the property <code>secret</code>
+  are configured for the consumer type <code>`+identity.CONSUMER_TYPE+`</code>,
+  the payload is signed with an HMAC-SHA256 of this secret, which is passed with the
+  header <code>`+SIGNATURE_HEADER+`</code> (format <code>sha256=&lt;hex digest></code>).
+  Basic authentication or a bearer token are used, if the
+  credentials provide the appropriate properties.
+`)))
+}
+
+// Spec provides a pub sub adapter posting events to a webhook.
+type Spec struct {
+	runtime.ObjectVersionedType
+	URL     string `json:"url"`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+var _ pubsub.PubSubSpec = (*Spec)(nil)
+
+func New(url string) (*Spec, error) {
+	return &Spec{
+		ObjectVersionedType: runtime.NewVersionedObjectType(Type),
+		URL:                 url,
+	}, nil
+}
+
+func (s *Spec) PubSubMethod(repo cpi.Repository) (pubsub.PubSubMethod, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid webhook URL %q", s.URL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.ErrInvalid("webhook URL", s.URL)
+	}
+	creds, err := identity.GetCredentials(repo.GetContext(), s.URL)
+	if err != nil {
+		return nil, err
+	}
+	timeout := defaultTimeout
+	if s.Timeout != "" {
+		timeout, err = time.ParseDuration(s.Timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid webhook timeout %q", s.Timeout)
+		}
+	}
+	return &Method{
+		spec:   s,
+		repo:   repo,
+		creds:  creds,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *Spec) Describe(_ cpi.Context) string {
+	return fmt.Sprintf("webhook %s", s.URL)
+}
+
+// Method finally publishes events.
+type Method struct {
+	spec   *Spec
+	repo   cpi.Repository
+	creds  credcpi.Credentials
+	client *http.Client
+}
+
+var _ pubsub.EventPubSubMethod = (*Method)(nil)
+
+func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
+	return m.NotifyEvent(pubsub.NewEvent(pubsub.EVENT_COMPONENT_VERSION_ADDED, m.repo, version, nil))
+}
+
+func (m *Method) NotifyEvent(evt *pubsub.Event) error {
+	cevt, err := pubsub.EventCloudEvent(m.repo, evt)
+	if err != nil {
+		return err
+	}
+	return m.post(cevt)
+}
+
+func (m *Method) post(evt *pubsub.CloudEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, m.spec.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", pubsub.CLOUDEVENTS_CONTENT_TYPE)
+	if m.creds != nil {
+		if secret := m.creds.GetProperty(identity.ATTR_SECRET); secret != "" {
+			req.Header.Set(SIGNATURE_HEADER, pubsub.HMACSignature([]byte(secret), data))
+		}
+		if token := m.creds.GetProperty(identity.ATTR_IDENTITY_TOKEN); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if user := m.creds.GetProperty(identity.ATTR_USERNAME); user != "" {
+			req.SetBasicAuth(user, m.creds.GetProperty(identity.ATTR_PASSWORD))
+		}
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "cannot post event to webhook %s", m.spec.URL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook %s returned status %d: %s", m.spec.URL, resp.StatusCode, string(msg))
+	}
+	return nil
+}
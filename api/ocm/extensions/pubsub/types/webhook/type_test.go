@@ -0,0 +1,120 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	"ocm.software/ocm/api/ocm/extensions/pubsub/providers/ocireg"
+	"ocm.software/ocm/api/ocm/extensions/pubsub/types/webhook"
+	"ocm.software/ocm/api/ocm/extensions/pubsub/types/webhook/identity"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	ARCH   = "ctf"
+	COMP   = "acme.org/component"
+	VERS   = "v1"
+	SECRET = "webhook-secret"
+)
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+var _ = Describe("webhook pub/sub", func() {
+	var env *Builder
+	var repo ocm.Repository
+	var server *httptest.Server
+	var lock sync.Mutex
+	var requests []request
+	var status int
+
+	BeforeEach(func() {
+		requests = nil
+		status = http.StatusAccepted
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			lock.Lock()
+			requests = append(requests, request{r.Header.Clone(), body})
+			lock.Unlock()
+			w.WriteHeader(status)
+		}))
+
+		env = NewBuilder()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory)
+		attr := pubsub.For(env)
+		attr.ProviderRegistry.Register(ctf.Type, &ocireg.Provider{})
+
+		repo = Must(ctf.Open(env, ctf.ACC_WRITABLE, ARCH, 0o600, env))
+	})
+
+	AfterEach(func() {
+		if repo != nil {
+			MustBeSuccessful(repo.Close())
+		}
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("posts signed cloud events", func() {
+		env.CredentialsContext().SetCredentialsForConsumer(
+			identity.GetConsumerId(server.URL),
+			credentials.NewCredentials(common.Properties{identity.ATTR_SECRET: SECRET}),
+		)
+		MustBeSuccessful(pubsub.SetForRepo(repo, Must(webhook.New(server.URL))))
+
+		cv := composition.NewComponentVersion(env, COMP, VERS)
+		defer Close(cv)
+		MustBeSuccessful(repo.AddComponentVersion(cv))
+
+		Expect(requests).To(HaveLen(1))
+		r := requests[0]
+		Expect(r.header.Get("Content-Type")).To(Equal(pubsub.CLOUDEVENTS_CONTENT_TYPE))
+		Expect(r.header.Get(webhook.SIGNATURE_HEADER)).To(Equal(pubsub.HMACSignature([]byte(SECRET), r.body)))
+
+		var evt pubsub.CloudEvent
+		MustBeSuccessful(json.Unmarshal(r.body, &evt))
+		Expect(evt.SpecVersion).To(Equal(pubsub.CLOUDEVENTS_SPEC_VERSION))
+		Expect(evt.Type).To(Equal(pubsub.EVENT_COMPONENT_VERSION_ADDED))
+		Expect(evt.Subject).To(Equal(COMP + ":" + VERS))
+		Expect(evt.ID).NotTo(BeEmpty())
+		var data pubsub.Event
+		MustBeSuccessful(json.Unmarshal(evt.Data, &data))
+		Expect(data.PayloadVersion).To(Equal(pubsub.EVENT_PAYLOAD_VERSION))
+		Expect(data.Type).To(Equal(pubsub.EVENT_COMPONENT_VERSION_ADDED))
+		Expect(data.GetComponentVersion()).To(Equal(common.NewNameVersion(COMP, VERS)))
+		Expect(data.DescriptorDigest).NotTo(BeNil())
+	})
+
+	It("posts unsigned events without credentials", func() {
+		MustBeSuccessful(pubsub.SetForRepo(repo, Must(webhook.New(server.URL))))
+
+		cv := composition.NewComponentVersion(env, COMP, VERS)
+		defer Close(cv)
+		MustBeSuccessful(repo.AddComponentVersion(cv))
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].header.Get(webhook.SIGNATURE_HEADER)).To(BeEmpty())
+	})
+
+	It("reports failing webhooks", func() {
+		status = http.StatusInternalServerError
+		m := Must(Must(webhook.New(server.URL)).PubSubMethod(repo))
+		ExpectError(m.NotifyComponentVersion(common.NewNameVersion(COMP, VERS))).To(MatchError(ContainSubstring("returned status 500")))
+	})
+})
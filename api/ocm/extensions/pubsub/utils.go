@@ -38,12 +38,41 @@ func PubSubForRepo(repo cpi.Repository) (PubSubMethod, error) {
 	return spec.PubSubMethod(repo)
 }
 
+// Notify publishes an added event for a component version
+// without descriptor information.
 func Notify(repo cpi.Repository, nv common.NameVersion) error {
+	return NotifyEvents(repo, NewEvent(EVENT_COMPONENT_VERSION_ADDED, repo, nv, nil))
+}
+
+// NotifyEvents publishes events using the pub/sub method
+// configured for the given repository, if there is any.
+func NotifyEvents(repo cpi.Repository, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
 	m, err := PubSubForRepo(repo)
 	if m == nil || err != nil {
 		return err
 	}
-	return m.NotifyComponentVersion(nv)
+	list := errors.ErrList()
+	for _, e := range events {
+		list.Add(PublishEvent(m, e))
+	}
+	return list.Result()
+}
+
+// PublishEvent publishes an event using the given pub/sub method.
+// Methods not supporting lifecycle events (see EventPubSubMethod)
+// are notified about added and overwritten component versions, only.
+func PublishEvent(m PubSubMethod, evt *Event) error {
+	if e, ok := m.(EventPubSubMethod); ok {
+		return e.NotifyEvent(evt)
+	}
+	switch evt.Type {
+	case EVENT_COMPONENT_VERSION_ADDED, EVENT_COMPONENT_VERSION_OVERWRITTEN:
+		return m.NotifyComponentVersion(evt.GetComponentVersion())
+	}
+	return nil
 }
 
 func PubSubUsage(scheme TypeScheme, providers ProviderRegistry, cli bool) string {
@@ -31,7 +31,10 @@ type localBlobAccessMethod struct {
 	mimeType  string
 }
 
-var _ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+var (
+	_ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+	_ accspeccpi.BlobSizeProvider = (*localBlobAccessMethod)(nil)
+)
 
 func newLocalBlobAccessMethod(a *localblob.AccessSpec, ns oci.NamespaceAccess, art oci.ArtifactAccess, ref refmgmt.ExtendedAllocatable) (accspeccpi.AccessMethod, error) {
 	return accspeccpi.AccessMethodForImplementation(newLocalBlobAccessMethodImpl(a, ns, art, ref))
@@ -154,6 +157,27 @@ func (m *localBlobAccessMethod) Get() ([]byte, error) {
 	return blobaccess.BlobData(m.getBlob())
 }
 
+// GetBlobSize provides the size of the blob according to the layer
+// descriptors of the component version artifact.
+// The size of blobs stored as nested OCI artifact is unknown.
+func (m *localBlobAccessMethod) GetBlobSize() (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.artifact == nil || m.artifact.ManifestAccess() == nil || artdesc.IsOCIMediaType(m.spec.MediaType) {
+		return blobaccess.BLOB_UNKNOWN_SIZE, nil
+	}
+	var size int64
+	for _, ref := range strings.Split(m.spec.LocalReference, ",") {
+		d := m.artifact.ManifestAccess().GetBlobDescriptor(digest.Digest(ref))
+		if d == nil {
+			return blobaccess.BLOB_UNKNOWN_SIZE, nil
+		}
+		size += d.Size
+	}
+	return size, nil
+}
+
 func (m *localBlobAccessMethod) MimeType() string {
 	if m.mimeType != "" {
 		return m.mimeType
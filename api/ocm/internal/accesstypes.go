@@ -66,6 +66,14 @@ type DigestSpecProvider interface {
 	GetDigestSpec() (*metav1.DigestSpec, error)
 }
 
+// BlobSizeProvider is an optional interface for an access method
+// implementation to provide the size of the described blob without
+// accessing the blob content. If the size cannot be determined
+// blobaccess.BLOB_UNKNOWN_SIZE is returned.
+type BlobSizeProvider interface {
+	GetBlobSize() (int64, error)
+}
+
 // AccessMethodImpl is the implementation interface
 // for access methods provided by access types. It describes
 // the access to a dedicated resource
@@ -94,6 +94,9 @@ func (m MultiBlobHandler) Swap(i, j int) {
 type BlobHandlerOptions struct {
 	BlobHandlerKey `json:",inline"`
 	Priority       int `json:"priority,omitempty"`
+	// Name is the name the handler is registered with
+	// (for example the name of the registration handler).
+	Name string `json:"-"`
 }
 
 func NewBlobHandlerOptions(olist ...BlobHandlerOption) *BlobHandlerOptions {
@@ -108,6 +111,9 @@ func (o *BlobHandlerOptions) ApplyBlobHandlerOptionTo(opts *BlobHandlerOptions)
 	if o.Priority > 0 {
 		opts.Priority = o.Priority
 	}
+	if o.Name != "" {
+		opts.Name = o.Name
+	}
 	o.BlobHandlerKey.ApplyBlobHandlerOptionTo(opts)
 }
 
@@ -127,6 +133,19 @@ func (o prio) ApplyBlobHandlerOptionTo(opts *BlobHandlerOptions) {
 	opts.Priority = o.prio
 }
 
+type name struct {
+	name string
+}
+
+// WithName sets the name a handler is registered with.
+func WithName(n string) BlobHandlerOption {
+	return name{n}
+}
+
+func (o name) ApplyBlobHandlerOptionTo(opts *BlobHandlerOptions) {
+	opts.Name = o.name
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 // BlobHandlerKey is the registration key for BlobHandlers.
@@ -267,6 +286,24 @@ type PrioBlobHandler struct {
 	Prio int
 }
 
+// NamedBlobHandler is a blob handler registered with a name.
+type NamedBlobHandler struct {
+	BlobHandler
+	Name string
+}
+
+// GetBlobHandlerName returns the name a blob handler is registered
+// with. An empty string is returned for handlers registered without name.
+func GetBlobHandlerName(h BlobHandler) string {
+	switch t := h.(type) {
+	case *PrioBlobHandler:
+		return GetBlobHandlerName(t.BlobHandler)
+	case *NamedBlobHandler:
+		return t.Name
+	}
+	return ""
+}
+
 type handlerCache struct {
 	cache map[BlobHandlerKey]BlobHandler
 }
@@ -342,6 +379,9 @@ func (r *blobHandlerRegistry) Register(handler BlobHandler, olist ...BlobHandler
 
 	def := BlobHandlerKey{}
 
+	if opts.Name != "" {
+		handler = &NamedBlobHandler{handler, opts.Name}
+	}
 	if opts.Priority != 0 {
 		handler = &PrioBlobHandler{handler, opts.Priority}
 	}
@@ -0,0 +1,295 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/optionutils"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	KIND_COMPONENT = "component"
+	KIND_RESOURCE  = "resource"
+	KIND_SOURCE    = "source"
+	KIND_REFERENCE = "reference"
+	KIND_SIGNATURE = "signature"
+)
+
+type ChangeType string
+
+const (
+	ADDED   ChangeType = "added"
+	REMOVED ChangeType = "removed"
+	CHANGED ChangeType = "changed"
+)
+
+// Change describes a single difference between two component descriptors.
+// Old and New describe the values found in the first and the second
+// component descriptor.
+type Change struct {
+	Kind    string      `json:"kind"`
+	Element string      `json:"element,omitempty"`
+	Field   string      `json:"field,omitempty"`
+	Type    ChangeType  `json:"change"`
+	Old     interface{} `json:"old,omitempty"`
+	New     interface{} `json:"new,omitempty"`
+}
+
+// Result describes the differences between two component versions.
+// If the comparison is done recursively, References contains the results
+// for the component versions referenced by both component versions.
+type Result struct {
+	ComponentVersion common.NameVersion `json:"componentVersion"`
+	Other            common.NameVersion `json:"other"`
+	Equivalent       bool               `json:"equivalent"`
+	HashEqual        bool               `json:"hashEqual"`
+	Changes          []Change           `json:"changes,omitempty"`
+	References       []*Result          `json:"references,omitempty"`
+}
+
+// IsEmpty checks whether there are no differences, neither
+// for the compared component versions nor for nested ones.
+func (r *Result) IsEmpty() bool {
+	if r == nil {
+		return true
+	}
+	if len(r.Changes) > 0 {
+		return false
+	}
+	for _, n := range r.References {
+		if !n.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+type Cache = map[[2]common.NameVersion]*Result
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Diff provides a diff object for comparing component versions.
+// By default, only the given component versions are compared.
+// Optionally, the comparison can be done recursively for
+// the referenced component versions.
+func Diff(opts ...Option) *Options {
+	return optionutils.EvalOptions(opts...)
+}
+
+// For compares two component versions.
+func (o *Options) For(a, b ocm.ComponentVersionAccess) (*Result, error) {
+	return o.handle(Cache{}, a, b, common.History{common.VersionedElementKey(a)})
+}
+
+func (o *Options) handle(cache Cache, a, b ocm.ComponentVersionAccess, h common.History) (*Result, error) {
+	key := [2]common.NameVersion{common.VersionedElementKey(a), common.VersionedElementKey(b)}
+	if r, ok := cache[key]; ok {
+		return r, nil
+	}
+
+	result := Descriptors(a.GetDescriptor(), b.GetDescriptor())
+	cache[key] = result
+	if !optionutils.AsBool(o.Recursive) {
+		return result, nil
+	}
+
+	refs := b.GetDescriptor().References
+	for _, ra := range a.GetDescriptor().References {
+		rb := compdesc.GetByIdentity(refs, ra.GetIdentity(a.GetDescriptor().References))
+		if rb == nil {
+			continue
+		}
+		ida := common.NewNameVersion(ra.ComponentName, ra.Version)
+		idb := common.NewNameVersion(rb.(*compdesc.Reference).ComponentName, rb.(*compdesc.Reference).Version)
+
+		nh := h.Copy()
+		if err := nh.Add(ocm.KIND_COMPONENTVERSION, ida); err != nil {
+			return result, err
+		}
+		n, err := o.nested(cache, a.Repository(), b.Repository(), ida, idb, nh)
+		if err != nil {
+			return result, errors.Wrapf(err, "reference %s", ra.Name)
+		}
+		result.References = append(result.References, n)
+	}
+	return result, nil
+}
+
+func (o *Options) nested(cache Cache, ra, rb ocm.Repository, ida, idb common.NameVersion, h common.History) (*Result, error) {
+	a, err := ra.LookupComponentVersion(ida.GetName(), ida.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	defer a.Close()
+	b, err := rb.LookupComponentVersion(idb.GetName(), idb.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+	return o.handle(cache, a, b, h)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Descriptors compares two component descriptors.
+func Descriptors(a, b *compdesc.ComponentDescriptor) *Result {
+	state := a.Equivalent(b)
+	result := &Result{
+		ComponentVersion: common.VersionedElementKey(a),
+		Other:            common.VersionedElementKey(b),
+		Equivalent:       state.IsEquivalent(),
+		HashEqual:        state.IsHashEqual(),
+	}
+
+	result.field(KIND_COMPONENT, "", "name", a.Name, b.Name)
+	result.field(KIND_COMPONENT, "", "version", a.Version, b.Version)
+	result.field(KIND_COMPONENT, "", "provider", a.Provider, b.Provider)
+	result.labels(KIND_COMPONENT, "", a.Labels, b.Labels)
+
+	result.elements(KIND_RESOURCE, a.Resources, b.Resources)
+	result.elements(KIND_SOURCE, a.Sources, b.Sources)
+	result.elements(KIND_REFERENCE, a.References, b.References)
+	result.signatures(a.Signatures, b.Signatures)
+	return result
+}
+
+func (r *Result) add(kind, elem, field string, t ChangeType, o, n interface{}) {
+	r.Changes = append(r.Changes, Change{
+		Kind:    kind,
+		Element: elem,
+		Field:   field,
+		Type:    t,
+		Old:     o,
+		New:     n,
+	})
+}
+
+func (r *Result) field(kind, elem, field string, o, n interface{}) {
+	o, n = generic(o), generic(n)
+	switch {
+	case reflect.DeepEqual(o, n):
+	case o == nil:
+		r.add(kind, elem, field, ADDED, nil, n)
+	case n == nil:
+		r.add(kind, elem, field, REMOVED, o, nil)
+	default:
+		r.add(kind, elem, field, CHANGED, o, n)
+	}
+}
+
+func (r *Result) labels(kind, elem string, a, b metav1.Labels) {
+	for _, la := range a {
+		lb := b.GetDef(la.Name)
+		if lb == nil {
+			r.add(kind, elem, "label "+la.Name, REMOVED, generic(la), nil)
+			continue
+		}
+		r.field(kind, elem, "label "+la.Name, la, lb)
+	}
+	for _, lb := range b {
+		if a.GetDef(lb.Name) == nil {
+			r.add(kind, elem, "label "+lb.Name, ADDED, nil, generic(lb))
+		}
+	}
+}
+
+func (r *Result) elements(kind string, a, b compdesc.ElementListAccessor) {
+	for i := 0; i < a.Len(); i++ {
+		ea := a.Get(i)
+		id := ea.GetMeta().GetIdentity(a)
+		eb := compdesc.GetByIdentity(b, id)
+		if eb == nil {
+			r.add(kind, id.String(), "", REMOVED, nil, nil)
+			continue
+		}
+		fa, fb := fields(ea), fields(eb)
+		for _, f := range utils.StringMapKeys(fa) {
+			r.field(kind, id.String(), f, fa[f], fb[f])
+		}
+		r.labels(kind, id.String(), ea.GetMeta().GetLabels(), eb.GetMeta().GetLabels())
+	}
+	for i := 0; i < b.Len(); i++ {
+		eb := b.Get(i)
+		id := eb.GetMeta().GetIdentity(b)
+		if compdesc.GetByIdentity(a, id) == nil {
+			r.add(kind, id.String(), "", ADDED, nil, nil)
+		}
+	}
+}
+
+func (r *Result) signatures(a, b metav1.Signatures) {
+	for _, sa := range a {
+		i := b.GetIndex(sa.Name)
+		if i < 0 {
+			r.add(KIND_SIGNATURE, sa.Name, "", REMOVED, nil, nil)
+			continue
+		}
+		r.field(KIND_SIGNATURE, sa.Name, "digest", sa.Digest, b[i].Digest)
+		r.field(KIND_SIGNATURE, sa.Name, "signature", sa.Signature, b[i].Signature)
+	}
+	for _, sb := range b {
+		if a.GetIndex(sb.Name) < 0 {
+			r.add(KIND_SIGNATURE, sb.Name, "", ADDED, nil, nil)
+		}
+	}
+}
+
+// fields provides the kind specific comparable attributes of an element.
+func fields(e compdesc.ElementMetaAccessor) map[string]interface{} {
+	m := map[string]interface{}{
+		"version": e.GetMeta().GetVersion(),
+	}
+	switch t := e.(type) {
+	case *compdesc.Resource:
+		m["type"] = t.Type
+		m["relation"] = t.Relation
+		m["srcRefs"] = t.SourceRefs
+		m["access"] = t.Access
+		m["digest"] = t.Digest
+	case *compdesc.Source:
+		m["type"] = t.Type
+		m["access"] = t.Access
+	case *compdesc.Reference:
+		m["componentName"] = t.ComponentName
+		m["digest"] = t.Digest
+	}
+	return m
+}
+
+// generic converts a value into its generic JSON representation
+// used for comparison and serialization. Empty values are mapped to nil.
+func generic(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var r interface{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return v
+	}
+	switch t := r.(type) {
+	case string:
+		if t == "" {
+			return nil
+		}
+	case []interface{}:
+		if len(t) == 0 {
+			return nil
+		}
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return nil
+		}
+	}
+	return r
+}
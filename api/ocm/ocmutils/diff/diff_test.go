@@ -0,0 +1,164 @@
+package diff_test
+
+import (
+	"encoding/json"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"ocm.software/ocm/api/ocm"
+	v1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/ocmutils/diff"
+	"ocm.software/ocm/api/utils/accessio"
+)
+
+const (
+	ARCH1   = "/tmp/ca1"
+	ARCH2   = "/tmp/ca2"
+	VERSION = "v1"
+	COMP    = "test.de/x"
+	COMP2   = "test.de/y"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *Builder
+	var repo1, repo2 ocm.Repository
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.ModificationOptions(ocm.SkipDigest())
+
+		env.OCMCommonTransport(ARCH1, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Label("purpose", "test")
+				env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("ghcr.io/acme/image:v1"))
+				})
+				env.Resource("removed", VERSION, resourcetypes.PLAIN_TEXT, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("ghcr.io/acme/removed:v1"))
+				})
+				env.Reference("ref", COMP2, VERSION)
+			})
+			env.ComponentVersion(COMP2, VERSION, func() {
+				env.Resource("data", VERSION, resourcetypes.PLAIN_TEXT, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("ghcr.io/acme/data:v1"))
+				})
+			})
+		})
+		env.OCMCommonTransport(ARCH2, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Label("purpose", "prod")
+				env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("registry.acme.org/acme/image:v1"))
+				})
+				env.Resource("added", VERSION, resourcetypes.PLAIN_TEXT, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("ghcr.io/acme/added:v1"))
+				})
+				env.Reference("ref", COMP2, "v2")
+			})
+			env.ComponentVersion(COMP2, "v2", func() {
+				env.Resource("data", "v2", resourcetypes.PLAIN_TEXT, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("ghcr.io/acme/data:v1"))
+				})
+			})
+		})
+
+		repo1 = Must(env.OCMContext().RepositoryForSpec(Must(ctf.NewRepositorySpec(ctf.ACC_READONLY, ARCH1, env))))
+		repo2 = Must(env.OCMContext().RepositoryForSpec(Must(ctf.NewRepositorySpec(ctf.ACC_READONLY, ARCH2, env))))
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(repo1.Close())
+		MustBeSuccessful(repo2.Close())
+		env.Cleanup()
+	})
+
+	It("detects no differences", func() {
+		cv := Must(repo1.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv, "cv")
+
+		result := Must(diff.Diff(diff.Recursive()).For(cv, cv))
+		Expect(result.IsEmpty()).To(BeTrue())
+	})
+
+	It("compares component versions", func() {
+		cv1 := Must(repo1.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv1, "cv1")
+		cv2 := Must(repo2.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv2, "cv2")
+
+		result := Must(diff.Diff().For(cv1, cv2))
+		Expect(result.IsEmpty()).To(BeFalse())
+		Expect(json.Marshal(result)).To(YAMLEqual(`
+componentVersion: test.de/x:v1
+other: test.de/x:v1
+equivalent: false
+hashEqual: false
+changes:
+- kind: component
+  field: label purpose
+  change: changed
+  old:
+    name: purpose
+    value: test
+  new:
+    name: purpose
+    value: prod
+- kind: resource
+  element: '"name"="image"'
+  field: access
+  change: changed
+  old:
+    imageReference: ghcr.io/acme/image:v1
+    type: ociArtifact
+  new:
+    imageReference: registry.acme.org/acme/image:v1
+    type: ociArtifact
+- kind: resource
+  element: '"name"="removed"'
+  change: removed
+- kind: resource
+  element: '"name"="added"'
+  change: added
+- kind: reference
+  element: '"name"="ref"'
+  field: version
+  change: changed
+  old: v1
+  new: v2
+`))
+	})
+
+	It("compares recursively", func() {
+		cv1 := Must(repo1.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv1, "cv1")
+		cv2 := Must(repo2.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv2, "cv2")
+
+		result := Must(diff.Diff(diff.Recursive()).For(cv1, cv2))
+		Expect(result.References).To(HaveLen(1))
+		Expect(json.Marshal(result.References[0])).To(YAMLEqual(`
+componentVersion: test.de/y:v1
+other: test.de/y:v2
+equivalent: false
+hashEqual: false
+changes:
+- kind: component
+  field: version
+  change: changed
+  old: v1
+  new: v2
+- kind: resource
+  element: '"name"="data"'
+  field: version
+  change: changed
+  old: v1
+  new: v2
+`))
+	})
+})
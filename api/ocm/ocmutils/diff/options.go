@@ -0,0 +1,34 @@
+package diff
+
+import (
+	"github.com/mandelsoft/goutils/generics"
+	"github.com/mandelsoft/goutils/optionutils"
+
+	"ocm.software/ocm/api/utils"
+)
+
+type Option = optionutils.Option[*Options]
+
+type Options struct {
+	Recursive *bool
+}
+
+var _ Option = (*Options)(nil)
+
+func (o *Options) ApplyTo(opts *Options) {
+	optionutils.ApplyOption(o.Recursive, &opts.Recursive)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type recursive bool
+
+// Recursive enables the comparison of the component versions
+// referenced by both compared component versions.
+func Recursive(b ...bool) Option {
+	return recursive(utils.OptionalDefaultedBool(true, b...))
+}
+
+func (l recursive) ApplyTo(t *Options) {
+	t.Recursive = generics.Pointer(bool(l))
+}
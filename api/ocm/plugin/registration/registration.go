@@ -96,7 +96,7 @@ func RegisterExtensions(ctxp ocm.ContextProvider) error {
 								"context", c.ContextType+":"+c.RepositoryType,
 								"plugin", p.Name(),
 								"handler", u.Name)
-							ctx.BlobHandlers().Register(hdlr, cpi.ForRepo(c.ContextType, c.RepositoryType), cpi.ForMimeType(c.MediaType))
+							ctx.BlobHandlers().Register(hdlr, cpi.ForRepo(c.ContextType, c.RepositoryType), cpi.ForMimeType(c.MediaType), cpi.WithName(pluginupload.BlobHandlerName(p.Name(), u.Name)))
 						}
 					}
 				}
@@ -0,0 +1,78 @@
+package signing
+
+import (
+	cfgcpi "ocm.software/ocm/api/config/cpi"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	ConfigType   = "verification.policy" + cfgcpi.OCM_CONFIG_TYPE_SUFFIX
+	ConfigTypeV1 = ConfigType + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cfgcpi.RegisterConfigType(cfgcpi.NewConfigType[*Config](ConfigType, usage))
+	cfgcpi.RegisterConfigType(cfgcpi.NewConfigType[*Config](ConfigTypeV1, usage))
+}
+
+// Config describes a verification policy used by Apply,
+// if no explicit policy is given by the signing options.
+type Config struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Policy                      `json:",inline"`
+}
+
+// NewConfig creates a new verification policy config.
+func NewConfig(rules ...PolicyRule) *Config {
+	return &Config{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(ConfigType),
+		Policy:              Policy{Rules: rules},
+	}
+}
+
+func (c *Config) GetType() string {
+	return ConfigType
+}
+
+func (c *Config) ApplyTo(ctx cfgcpi.Context, target interface{}) error {
+	if opts, ok := target.(VerificationPolicyOption); ok {
+		if err := c.Policy.Validate(); err != nil {
+			return err
+		}
+		opts.SetVerificationPolicy(&c.Policy)
+	}
+	return nil
+}
+
+const usage = `
+The config type <code>` + ConfigType + `</code> can be used to define
+a verification policy evaluated for all component versions verified:
+
+<pre>
+    type: ` + ConfigType + `
+    rules:
+    - name: acme
+      components:
+      - acme.org/*
+      signatures:
+      - A
+      - B
+      - C
+      required: 2
+      timestamp: true
+      issuer: "O=acme"
+      sameOrganization: true
+</pre>
+
+A rule applies to all component versions matching one of the
+component name patterns (<code>*</code> matches any sequence of characters).
+It requires at least <code>required</code> valid signatures out of the
+given signature names (default: all given names, or one if no names are given).
+Additionally, the accepted signatures can be required to carry a valid TSA
+timestamp and to have an issuer distinguished name matching a regular expression.
+The issuer is the subject of the certificate used to verify a signature,
+signatures verified without certificate never match an issuer.
+With <code>sameOrganization</code> the directly referenced component versions
+must be signed by the same organization, which is taken from these certificates,
+also.
+`
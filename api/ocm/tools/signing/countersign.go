@@ -0,0 +1,78 @@
+package signing
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+)
+
+// countersignatureDigest calculates the digest of the signature
+// to be countersigned according to the given options.
+// The countersigned signature must match the actual component descriptor.
+func countersignatureDigest(digests *compdesc.CompDescDigests, opts *Options) (*metav1.DigestSpec, error) {
+	target := digests.Descriptor().Signatures.GetByName(opts.Countersign)
+	if target == nil {
+		return nil, errors.ErrNotFound(compdesc.KIND_SIGNATURE, opts.Countersign)
+	}
+	err := checkCountersigned(digests, target, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "countersigned signature %q", target.Name)
+	}
+	digest, err := compdesc.SignatureDigest(target, opts.Hasher.Create())
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.DigestSpec{
+		HashAlgorithm:          opts.Hasher.Algorithm(),
+		NormalisationAlgorithm: metav1.CountersignatureNormalisation,
+		Value:                  digest,
+	}, nil
+}
+
+// checkCountersigned checks the digests of the chain of countersigned
+// signatures starting with the given signature down to the signature
+// covering the component descriptor. The signature values of
+// the countersigned signatures are not verified.
+func checkCountersigned(digests *compdesc.CompDescDigests, sig *metav1.Signature, opts *Options) error {
+	chain, err := digests.Descriptor().Signatures.GetCountersigned(sig.Name)
+	if err != nil {
+		return err
+	}
+	for i, s := range chain {
+		hasher := opts.Registry.GetHasher(s.Digest.HashAlgorithm)
+		if hasher == nil {
+			return errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, s.Digest.HashAlgorithm)
+		}
+		var digest string
+		if s.IsCountersignature() {
+			if s.Digest.NormalisationAlgorithm != metav1.CountersignatureNormalisation {
+				return errors.ErrNotSupported(compdesc.KIND_NORM_ALGORITHM, s.Digest.NormalisationAlgorithm, "countersignature "+s.Name)
+			}
+			digest, err = compdesc.SignatureDigest(chain[i+1], hasher.Create())
+		} else {
+			_, digest, err = digests.Get(s.Digest.NormalisationAlgorithm, hasher)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "signature %q", s.Name)
+		}
+		if s.Digest.Value != digest {
+			return errors.Newf("digest (%s) of signature %q does not match found digest (%s)", s.Digest.Value, s.Name, digest)
+		}
+	}
+	return nil
+}
+
+// descriptorDigest returns the digest of the component descriptor
+// covered by a signature, either directly or via the chain of
+// countersigned signatures.
+func descriptorDigest(sigs metav1.Signatures, sig *metav1.Signature) (*metav1.DigestSpec, error) {
+	if !sig.IsCountersignature() {
+		return &sig.Digest, nil
+	}
+	chain, err := sigs.GetCountersigned(sig.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &chain[len(chain)-1].Digest, nil
+}
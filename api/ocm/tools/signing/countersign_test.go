@@ -0,0 +1,183 @@
+package signing_test
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/tools/signing"
+
+	"golang.org/x/crypto/ocsp"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const COUNTERSIGNATURE = "approval"
+
+var _ = Describe("countersigning", func() {
+	digest := "9cf14695c864411cad03071a8766e6769bb00373bdd8c65887e4644cc285dc78"
+
+	priv, pub := Must2(rsa.CreateKeyPair())
+	cpriv, cpub := Must2(rsa.CreateKeyPair())
+
+	ctx := ocm.DefaultContext()
+
+	var cv ocm.ComponentVersionAccess
+	var res ocm.ComponentVersionResolver
+
+	BeforeEach(func() {
+		cv = composition.NewComponentVersion(ctx, COMPONENTA, VERSION)
+		res = resolvers.NewDedicatedResolver(cv)
+		SignComponent(res, SIGNATURE, COMPONENTA, digest, PrivateKey(SIGNATURE, priv))
+	})
+
+	It("countersigns signature", func() {
+		SignComponent(res, COUNTERSIGNATURE, COMPONENTA, digest, PrivateKey(COUNTERSIGNATURE, cpriv), Countersign(SIGNATURE))
+
+		sigs := cv.GetDescriptor().Signatures
+		Expect(len(sigs)).To(Equal(2))
+		sig := sigs.GetByName(COUNTERSIGNATURE)
+		Expect(sig.Countersigns).To(Equal(SIGNATURE))
+		Expect(sig.Digest.HashAlgorithm).To(Equal(sha256.Algorithm))
+		Expect(sig.Digest.NormalisationAlgorithm).To(Equal(metav1.CountersignatureNormalisation))
+		Expect(sig.Digest.Value).To(Equal(Must(compdesc.SignatureDigest(sigs.GetByName(SIGNATURE), sha256.Handler{}.Create()))))
+
+		VerifyComponent(res, COUNTERSIGNATURE, COMPONENTA, digest, PublicKey(COUNTERSIGNATURE, cpub))
+		VerifyComponent(res, SIGNATURE, COMPONENTA, digest, PublicKey(SIGNATURE, pub))
+		FailVerifyComponent(res, COUNTERSIGNATURE, COMPONENTA, digest,
+			`github.com/mandelsoft/test:v1: signature "approval": signature verification failed, crypto/rsa: verification error`,
+			PublicKey(COUNTERSIGNATURE, pub))
+	})
+
+	It("countersigns countersignature", func() {
+		SignComponent(res, COUNTERSIGNATURE, COMPONENTA, digest, PrivateKey(COUNTERSIGNATURE, cpriv), Countersign(SIGNATURE))
+		SignComponent(res, "final", COMPONENTA, digest, PrivateKey("final", priv), Countersign(COUNTERSIGNATURE))
+
+		chain := Must(cv.GetDescriptor().Signatures.GetCountersigned("final"))
+		Expect(len(chain)).To(Equal(3))
+		Expect(chain[2].Name).To(Equal(SIGNATURE))
+
+		VerifyComponent(res, "final", COMPONENTA, digest, PublicKey("final", pub))
+	})
+
+	It("detects modified countersigned signature", func() {
+		SignComponent(res, COUNTERSIGNATURE, COMPONENTA, digest, PrivateKey(COUNTERSIGNATURE, cpriv), Countersign(SIGNATURE))
+
+		cd := cv.GetDescriptor()
+		cd.Signatures[cd.GetSignatureIndex(SIGNATURE)].Signature.Issuer = "CN=acme.org"
+
+		opts := NewOptions(
+			VerifySignature(COUNTERSIGNATURE),
+			PublicKey(COUNTERSIGNATURE, cpub),
+			Resolver(res),
+			VerifyDigests(),
+		)
+		_, err := Apply(nil, nil, cv, opts)
+		Expect(err).To(MatchError(ContainSubstring(`countersignature "approval": digest (`)))
+		Expect(err).To(MatchError(ContainSubstring(`) of signature "approval" does not match found digest (`)))
+	})
+
+	It("detects dangling countersignature", func() {
+		SignComponent(res, COUNTERSIGNATURE, COMPONENTA, digest, PrivateKey(COUNTERSIGNATURE, cpriv), Countersign(SIGNATURE))
+
+		cd := cv.GetDescriptor()
+		cd.Signatures[cd.GetSignatureIndex(SIGNATURE)].Name = "other"
+
+		FailVerifyComponent(res, COUNTERSIGNATURE, COMPONENTA, digest,
+			`github.com/mandelsoft/test:v1: failed to determine signature info: signature "test" not found`,
+			PublicKey(COUNTERSIGNATURE, cpub))
+	})
+
+	It("fails for unknown signature", func() {
+		opts := NewOptions(
+			Sign(signing.DefaultHandlerRegistry().GetSigner(SIGN_ALGO), COUNTERSIGNATURE),
+			PrivateKey(COUNTERSIGNATURE, cpriv),
+			Countersign("unknown"),
+			Resolver(res),
+		)
+		ExpectError(Apply(nil, nil, cv, opts)).To(MatchError(`github.com/mandelsoft/test:v1: signature "unknown" not found`))
+	})
+
+	It("rejects self countersignature", func() {
+		opts := NewOptions(
+			Sign(signing.DefaultHandlerRegistry().GetSigner(SIGN_ALGO), SIGNATURE),
+			PrivateKey(SIGNATURE, priv),
+			Countersign(SIGNATURE),
+		)
+		ExpectError(opts.Complete(ctx)).To(MatchError(`signature "test" cannot countersign itself`))
+	})
+})
+
+var _ = Describe("revocation", func() {
+	digest := "9cf14695c864411cad03071a8766e6769bb00373bdd8c65887e4644cc285dc78"
+
+	capriv, _ := Must2(rsa.CreateKeyPair())
+	ca, _ := Must2(signutils.CreateCertificate(&signutils.Specification{
+		Subject:      *signutils.CommonName("ca-authority"),
+		Validity:     10 * time.Hour,
+		CAPrivateKey: capriv,
+		IsCA:         true,
+		Usages:       []interface{}{x509.ExtKeyUsageCodeSigning, x509.KeyUsageDigitalSignature, x509.KeyUsageCRLSign},
+	}))
+	cert, pemBytes, priv := Must3(rsa.CreateSigningCertificate(signutils.CommonName(PROVIDER), ca, ca, capriv, time.Hour))
+
+	ctx := ocm.DefaultContext()
+
+	var cv ocm.ComponentVersionAccess
+	var res ocm.ComponentVersionResolver
+
+	BeforeEach(func() {
+		cv = composition.NewComponentVersion(ctx, COMPONENTA, VERSION)
+		res = resolvers.NewDedicatedResolver(cv)
+		SignComponent(res, PROVIDER, COMPONENTA, digest, PrivateKey(PROVIDER, priv), PublicKey(PROVIDER, pemBytes), RootCertificates(ca))
+	})
+
+	It("accepts unrevoked certificate", func() {
+		info := signutils.NewRevocationInfo()
+		MustBeSuccessful(info.AddOCSPResponse(Must(ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: cert.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Hour),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}, capriv.(*rsa.PrivateKey)))))
+
+		pr, buf := common.NewBufferedPrinter()
+		opts := NewOptions(
+			VerifySignature(PROVIDER),
+			RootCertificates(ca),
+			Revocation(info),
+			Resolver(res),
+		)
+		Must(Apply(pr, nil, cv, opts))
+		Expect(buf.String()).To(ContainSubstring(`signature "mandelsoft": certificate "CN=mandelsoft" not revoked (OCSP)`))
+	})
+
+	It("rejects revoked certificate", func() {
+		info := signutils.NewRevocationInfo()
+		MustBeSuccessful(info.AddCRL(Must(x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:     big.NewInt(1),
+			ThisUpdate: time.Now().Add(-time.Hour),
+			NextUpdate: time.Now().Add(time.Hour),
+			RevokedCertificateEntries: []x509.RevocationListEntry{
+				{SerialNumber: cert.SerialNumber, RevocationTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		}, ca, capriv.(*rsa.PrivateKey)))))
+
+		FailVerifyComponent(res, PROVIDER, COMPONENTA, digest,
+			`github.com/mandelsoft/test:v1: public key from signature: public key certificate: certificate "CN=mandelsoft" revoked at 2024-01-01T00:00:00Z (CRL)`,
+			RootCertificates(ca), Revocation(info))
+	})
+})
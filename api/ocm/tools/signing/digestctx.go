@@ -44,6 +44,9 @@ type DigestContext struct {
 	Signed     bool
 	Source     common.NameVersion
 	Refs       map[common.NameVersion]*metav1.DigestSpec
+
+	// verified caches the signatures verified for the policy evaluation.
+	verified []verifiedSignature
 }
 
 func NewDigestContext(cd *compdesc.ComponentDescriptor, parent *DigestContext) *DigestContext {
@@ -207,7 +210,11 @@ func (dc *DigestContext) determineSignatureInfo(state WalkingState, cv ocm.Compo
 		var found bool
 		for _, sig := range dc.Descriptor.Signatures {
 			if sig.Name == opts.SignatureName() {
-				dc.DigestType = DigesterType(&sig.Digest)
+				digest, err := descriptorDigest(dc.Descriptor.Signatures, &sig)
+				if err != nil {
+					return nil, err
+				}
+				dc.DigestType = DigesterType(digest)
 				found = true
 				break
 			}
@@ -222,7 +229,12 @@ func (dc *DigestContext) determineSignatureInfo(state WalkingState, cv ocm.Compo
 	// have a public key for determines the
 	// digester type we can commonly check.
 	for _, sig := range dc.Descriptor.Signatures {
-		st := DigesterType(&sig.Digest)
+		digest, err := descriptorDigest(dc.Descriptor.Signatures, &sig)
+		if err != nil {
+			opts.Printer.Printf("Warning: %s for signature %q in %s (signature ignored)\n", err, sig.Name, state.History)
+			continue
+		}
+		st := DigesterType(digest)
 		//nolint: gocritic //yes
 		if opts.Keyless {
 			if dc.DigestType.IsInitial() {
@@ -253,7 +265,7 @@ func (dc *DigestContext) determineSignatureInfo(state WalkingState, cv ocm.Compo
 						return nil, errors.Wrapf(err, "cannot decode signature PEM for %q", sig.Name)
 					}
 					signatures = append(signatures, sig.Name)
-					dc.DigestType = DigesterType(&sig.Digest)
+					dc.DigestType = st
 				} else {
 					if opts.SignatureName() != "" {
 						return nil, errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY, sig.Name)
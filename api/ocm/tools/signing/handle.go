@@ -3,6 +3,7 @@ package signing
 import (
 	"context"
 	"crypto"
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"reflect"
@@ -15,6 +16,7 @@ import (
 	"github.com/mandelsoft/goutils/maputils"
 	"github.com/mandelsoft/logging"
 
+	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/compdesc"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
@@ -120,6 +122,18 @@ func ApplyWithContext(ctx context.Context, state *WalkingState, cv ocm.Component
 	if err != nil {
 		return nil, err
 	}
+	if opts.DoVerify() && !opts.DoSign() && opts.Policy == nil {
+		// use verification policy provided by the config context
+		eff := opts.Dup()
+		_, err = cv.GetContext().ConfigContext().ApplyTo(-1, eff)
+		if err != nil {
+			return nil, errors.Wrapf(err, "verification policy")
+		}
+		opts = eff
+	}
+	if err := opts.Policy.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid verification policy")
+	}
 	if state == nil {
 		state = DefaultWalkingState(cv.GetContext())
 	}
@@ -294,7 +308,7 @@ func _apply(cctx context.Context, state WalkingState, nv common.NameVersion, cv
 	}
 
 	if opts.DoVerify() {
-		dig, err := doVerify(digests, state, signatureNames, opts)
+		dig, err := doVerify(cv.GetContext().CredentialsContext(), digests, state, signatureNames, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -304,6 +318,12 @@ func _apply(cctx context.Context, state WalkingState, nv common.NameVersion, cv
 
 		addVerified(state, cd, opts, signatureNames...)
 	}
+	if opts.Policy != nil && !ctx.RootContextInfo.Sign {
+		err := evaluatePolicy(cv.GetContext().CredentialsContext(), state, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
 	err := ctx.Propagate(spec)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed propagating digest context")
@@ -321,8 +341,16 @@ func _apply(cctx context.Context, state WalkingState, nv common.NameVersion, cv
 			PublicKey:  opts.PublicKey(opts.SignatureName()),
 			RootCerts:  opts.RootCerts,
 			Issuer:     opts.GetIssuer(),
+			Context:    cv.GetContext().CredentialsContext(),
 		}
-		sig, err := opts.Signer.Sign(cv.GetContext().CredentialsContext(), ctx.Digest.Value, sctx)
+		digest := ctx.Digest
+		if opts.Countersign != "" {
+			digest, err = countersignatureDigest(digests, opts)
+			if err != nil {
+				return nil, err
+			}
+		}
+		sig, err := opts.Signer.Sign(cv.GetContext().CredentialsContext(), digest.Value, sctx)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed signing component descriptor")
 		}
@@ -339,17 +367,18 @@ func _apply(cctx context.Context, state WalkingState, nv common.NameVersion, cv
 		}
 		signature := metav1.Signature{
 			Name:   opts.SignatureName(),
-			Digest: *ctx.Digest,
+			Digest: *digest,
 			Signature: metav1.SignatureSpec{
 				Algorithm: sig.Algorithm,
 				Value:     sig.Value,
 				MediaType: sig.MediaType,
 				Issuer:    sig.Issuer,
 			},
+			Countersigns: opts.Countersign,
 		}
 
 		if url := opts.EffectiveTSAUrl(); url != "" {
-			h, d, err := DigestInfo(opts, ctx.Digest)
+			h, d, err := DigestInfo(opts, digest)
 			if err != nil {
 				return nil, err
 			}
@@ -442,14 +471,9 @@ func DigestInfo(opts *Options, d *metav1.DigestSpec) (crypto.Hash, []byte, error
 	return hasher.Crypto(), data, nil
 }
 
-func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNames []string, opts *Options) (*metav1.DigestSpec, error) {
+func doVerify(cctx credentials.Context, digests *compdesc.CompDescDigests, state WalkingState, signatureNames []string, opts *Options) (*metav1.DigestSpec, error) {
 	var spec *metav1.DigestSpec
 
-	sctx := &signing.DefaultSigningContext{
-		Hash:      opts.Hasher.Crypto(),
-		RootCerts: opts.RootCerts,
-	}
-
 	found := []string{}
 	for _, n := range signatureNames {
 		f := digests.Descriptor().GetSignatureIndex(n)
@@ -458,33 +482,85 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 		}
 		sig := &digests.Descriptor().Signatures[f]
 
-		sctx.Issuer = opts.IssuerFor(n)
-		if !opts.Keyless {
-			sctx.PublicKey = opts.PublicKey(n)
-			if sctx.PublicKey == nil {
-				var err error
-
-				opts.Printer.Printf("no public key found for signature %q -> extract key from signature\n", n)
-				sctx.PublicKey, err = GetPublicKeyFromSignature(sig, sctx, opts)
-				if err != nil {
-					return nil, errors.Wrapf(err, "public key from signature")
-				}
+		err := verifySignature(cctx, digests, sig, opts)
+		if err != nil {
+			if errors.IsErrUnknownKind(err, compdesc.KIND_VERIFY_ALGORITHM) && !opts.SignatureConfigured(n) {
+				opts.Printer.Printf("Warning: no verifier (%s) found for signature %q in %s\n", sig.Signature.Algorithm, n, state.History)
+				continue
 			}
+			return nil, err
 		}
-		verifier := opts.Registry.GetVerifier(sig.Signature.Algorithm)
-		if verifier == nil {
-			if opts.SignatureConfigured(n) {
-				return nil, errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, n)
+		found = append(found, n)
+		if opts.SignatureName() == sig.Name {
+			dig, err := descriptorDigest(digests.Descriptor().Signatures, sig)
+			if err != nil {
+				return nil, err
 			}
-			opts.Printer.Printf("Warning: no verifier (%s) found for signature %q in %s\n", sig.Signature.Algorithm, n, state.History)
-			continue
+			d := *dig
+			d.HashAlgorithm = signing.NormalizeHashAlgorithm(d.HashAlgorithm)
+			spec = &d
 		}
+	}
+	if len(found) == 0 {
+		if !opts.DoSign() {
+			return nil, errors.Newf("no verifiable signature found")
+		}
+	}
 
-		hasher := opts.Registry.GetHasher(sig.Digest.HashAlgorithm)
-		if hasher == nil {
-			return nil, errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, sig.Digest.HashAlgorithm)
+	return spec, nil
+}
+
+// verifySignature verifies a single signature of a component descriptor
+// against the digest calculated for the given digest set.
+func verifySignature(cctx credentials.Context, digests *compdesc.CompDescDigests, sig *compdesc.Signature, opts *Options) error {
+	_, err := verifySignatureCertificate(cctx, digests, sig, opts)
+	return err
+}
+
+// verifySignatureCertificate verifies a single signature like verifySignature
+// and additionally provides the certificate used to verify the signature,
+// if the public key is given by a certificate.
+func verifySignatureCertificate(cctx credentials.Context, digests *compdesc.CompDescDigests, sig *compdesc.Signature, opts *Options) (*x509.Certificate, error) {
+	n := sig.Name
+	sctx := &signing.DefaultSigningContext{
+		Hash:      opts.Hasher.Crypto(),
+		RootCerts: opts.RootCerts,
+		Issuer:    opts.IssuerFor(n),
+		Context:   cctx,
+	}
+
+	var cert *x509.Certificate
+	if !opts.Keyless {
+		sctx.PublicKey = opts.PublicKey(n)
+		if sctx.PublicKey == nil {
+			var err error
+
+			opts.Printer.Printf("no public key found for signature %q -> extract key from signature\n", n)
+			cert, err = getCertificateFromSignature(sig, sctx, opts)
+			if err != nil {
+				return nil, errors.Wrapf(err, "public key from signature")
+			}
+			sctx.PublicKey = cert.PublicKey
+		} else {
+			cert = publicKeyCertificate(sctx.PublicKey)
 		}
+	}
+	verifier := opts.Registry.GetVerifier(sig.Signature.Algorithm)
+	if verifier == nil {
+		return nil, errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, n)
+	}
 
+	hasher := opts.Registry.GetHasher(sig.Digest.HashAlgorithm)
+	if hasher == nil {
+		return nil, errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, sig.Digest.HashAlgorithm)
+	}
+
+	if sig.IsCountersignature() {
+		err := checkCountersigned(digests, sig, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "countersignature %q", n)
+		}
+	} else {
 		_, digest, err := digests.Get(sig.Digest.NormalisationAlgorithm, hasher)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed hashing component descriptor")
@@ -492,29 +568,41 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 		if sig.Digest.Value != digest {
 			return nil, errors.Newf("signature digest (%s) does not match found digest (%s)", sig.Digest.Value, digest)
 		}
+	}
 
-		sctx.Hash = hasher.Crypto()
-		err = verifier.Verify(sig.Digest.Value, sig.ConvertToSigning(), sctx)
-		if err != nil {
-			return nil, errors.Wrapf(err, "signature %q", n)
-		}
-		found = append(found, n)
-		if opts.SignatureName() == sig.Name {
-			d := sig.Digest
-			d.HashAlgorithm = signing.NormalizeHashAlgorithm(d.HashAlgorithm)
-			spec = &d
-		}
+	sctx.Hash = hasher.Crypto()
+	err := verifier.Verify(sig.Digest.Value, sig.ConvertToSigning(), sctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature %q", n)
 	}
-	if len(found) == 0 {
-		if !opts.DoSign() {
-			return nil, errors.Newf("no verifiable signature found")
+	return cert, nil
+}
+
+// publicKeyCertificate provides the certificate, if the
+// given public key is described by a certificate.
+func publicKeyCertificate(key signutils.GenericPublicKey) *x509.Certificate {
+	switch k := key.(type) {
+	case *x509.Certificate:
+		return k
+	case []byte:
+		if cert, err := signutils.ParseCertificate(k); err == nil {
+			return cert
 		}
 	}
-
-	return spec, nil
+	return nil
 }
 
 func GetPublicKeyFromSignature(sig *compdesc.Signature, sctx signing.SigningContext, opts *Options) (signutils.GenericPublicKey, error) {
+	cert, err := getCertificateFromSignature(sig, sctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cert.PublicKey, nil
+}
+
+// getCertificateFromSignature provides the verified certificate
+// provided together with a PEM signature.
+func getCertificateFromSignature(sig *compdesc.Signature, sctx signing.SigningContext, opts *Options) (*x509.Certificate, error) {
 	if sig.Signature.MediaType != signutils.MediaTypePEM {
 		return nil, errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY)
 	}
@@ -533,29 +621,50 @@ func GetPublicKeyFromSignature(sig *compdesc.Signature, sctx signing.SigningCont
 
 	var timestamp *time.Time
 	if sig.Timestamp != nil {
-		ts, err := tsa.FromPem([]byte(sig.Timestamp.Value))
-		if err != nil {
-			return nil, errors.Wrapf(err, "signature timestamp")
-		}
-		h, d, err := DigestInfo(opts, &sig.Digest)
+		timestamp, err = verifyTimestamp(sig, sctx.GetRootCerts(), opts)
 		if err != nil {
-			return nil, errors.Wrapf(err, "signature digest")
-		}
-		mi, err := tsa.NewMessageImprint(h, d)
-		if err != nil {
-			return nil, errors.Wrapf(err, "signature digest")
+			return nil, err
 		}
-		timestamp, err = tsa.Verify(mi, ts, false, sctx.GetRootCerts())
+		err = signutils.CheckValidityAt(*timestamp, certs...)
 		if err != nil {
-			return nil, errors.Wrapf(err, "signature timestamp verification")
+			return nil, errors.Wrapf(err, "public key certificate")
 		}
 	}
 
-	err = signutils.VerifyCertificate(cert, pool, sctx.GetRootCerts(), sctx.GetIssuer(), timestamp)
+	chain, err := signutils.VerifyCertificateChain(cert, pool, sctx.GetRootCerts(), sctx.GetIssuer(), timestamp)
 	if err != nil {
 		return nil, errors.Wrapf(err, "public key certificate")
 	}
-	return cert.PublicKey, nil
+	if timestamp != nil {
+		opts.Printer.Printf("  signature %q: certificate %q valid at signing time %s\n", sig.Name, cert.Subject.String(), timestamp.UTC().Format(time.RFC3339))
+	}
+	err = checkRevocation(sig, chain, timestamp, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "public key certificate")
+	}
+	return cert, nil
+}
+
+// verifyTimestamp verifies the TSA timestamp of a signature
+// and returns the timestamped time.
+func verifyTimestamp(sig *compdesc.Signature, roots signutils.GenericCertificatePool, opts *Options) (*time.Time, error) {
+	ts, err := tsa.FromPem([]byte(sig.Timestamp.Value))
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature timestamp")
+	}
+	h, d, err := DigestInfo(opts, &sig.Digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature digest")
+	}
+	mi, err := tsa.NewMessageImprint(h, d)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature digest")
+	}
+	timestamp, err := tsa.Verify(mi, ts, false, roots)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature timestamp verification")
+	}
+	return timestamp, nil
 }
 
 func calculateReferenceDigests(cctx context.Context, state WalkingState, opts *Options, legacy bool) (rerr error) {
@@ -631,6 +740,7 @@ func calculateResourceDigests(cctx context.Context, state WalkingState, cv ocm.C
 
 	octx := cv.GetContext()
 	blobdigesters := octx.BlobDigesters()
+	vcd := verifiedDescriptor(state.Context, opts)
 	for i, res := range cv.GetResources() {
 		if err := common.IsContextCanceled(cctx); err != nil {
 			opts.Printer.Printf("cancelled by caller\n")
@@ -665,6 +775,18 @@ func calculateResourceDigests(cctx context.Context, state WalkingState, cv ocm.C
 			continue
 		}
 
+		if preset == nil || reflect.DeepEqual(&preset.Digest, raw.Digest) {
+			if vdigest := verifiedResourceDigest(cd, vcd, i); vdigest != nil {
+				rid := res.Meta().GetIdentity(cv.GetDescriptor().Resources)
+				state.Logger.Debug("reusing verified resource digest", "index", i, "id", rid, "hashalgo", vdigest.HashAlgorithm, "normalgo", vdigest.NormalisationAlgorithm, "digest", vdigest.Value)
+				opts.Printer.Printf("  resource %d:  %s: digest %s (already verified)\n", i, rid, vdigest)
+				if err := loop.Finalize(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
 		meth, err := acc.AccessMethod(cv)
 		if err != nil {
 			return errors.Wrapf(err, resMsg(raw, acc.Describe(octx), "failed creating access for resource"))
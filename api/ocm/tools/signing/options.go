@@ -448,6 +448,84 @@ func (o *verifyedstore) ApplySigningOption(opts *Options) {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type incremental struct {
+	flag bool
+}
+
+// Incremental provides an option enabling the incremental verification mode.
+// If enabled, the digests of resources already verified for a component version
+// recorded in the VerifiedStore are reused without accessing the resource
+// content, again, if neither the access specification nor the digest of the
+// resource has changed.
+// It is only used for verification, if a VerifiedStore is configured.
+func Incremental(flags ...bool) Option {
+	return &incremental{utils.GetOptionFlag(flags...)}
+}
+
+func (o *incremental) ApplySigningOption(opts *Options) {
+	opts.Incremental = o.flag
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// VerificationPolicyOption is the interface used by the
+// verification policy config type to configure policy rules.
+type VerificationPolicyOption interface {
+	SetVerificationPolicy(p *Policy)
+}
+
+type verificationPolicy struct {
+	policy *Policy
+}
+
+// VerificationPolicy provides an option adding the rules of a
+// verification policy. The rules are evaluated for all component
+// versions verified.
+func VerificationPolicy(p *Policy) Option {
+	return &verificationPolicy{p}
+}
+
+func (o *verificationPolicy) ApplySigningOption(opts *Options) {
+	opts.SetVerificationPolicy(o.policy)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type countersign struct {
+	name string
+}
+
+// Countersign provides an option requesting to sign the signature with the
+// given name (countersignature) instead of the component descriptor
+// for a signing operation. The signature to create is still selected
+// by the signature name.
+func Countersign(name string) Option {
+	return &countersign{strings.TrimSpace(name)}
+}
+
+func (o *countersign) ApplySigningOption(opts *Options) {
+	opts.Countersign = o.name
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type revocation struct {
+	info *signutils.RevocationInfo
+}
+
+// Revocation provides an option to use offline revocation information
+// (CRLs and OCSP responses) to check the certificates of signatures
+// for a verification operation.
+func Revocation(info *signutils.RevocationInfo) Option {
+	return &revocation{info}
+}
+
+func (o *revocation) ApplySigningOption(opts *Options) {
+	opts.Revocation = o.info
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type Options struct {
 	Printer           common.Printer
 	Update            bool
@@ -470,10 +548,14 @@ type Options struct {
 	Keyless           bool
 	TSAUrl            string
 	UseTSA            bool
+	Countersign       string
+	Revocation        *signutils.RevocationInfo
 
 	effectiveRegistry signing.Registry
 
 	VerifiedStore VerifiedStore
+	Incremental   bool
+	Policy        *Policy
 }
 
 var _ Option = (*Options)(nil)
@@ -541,6 +623,18 @@ func (o *Options) ApplySigningOption(opts *Options) {
 	if o.UseTSA {
 		opts.UseTSA = o.UseTSA
 	}
+	if o.Countersign != "" {
+		opts.Countersign = o.Countersign
+	}
+	if o.Revocation != nil {
+		opts.Revocation = o.Revocation
+	}
+	if o.Incremental {
+		opts.Incremental = o.Incremental
+	}
+	if o.Policy != nil {
+		opts.Policy = o.Policy
+	}
 }
 
 // Complete takes either nil, an ocm.ContextProvider or a signing.Registry.
@@ -612,6 +706,11 @@ func (o *Options) Complete(ctx interface{}) error {
 		if priv == nil && !o.Keyless {
 			return errors.ErrNotFound(compdesc.KIND_PRIVATE_KEY, o.SignatureNames[0])
 		}
+		if o.Countersign == o.SignatureName() {
+			return errors.Newf("signature %q cannot countersign itself", o.Countersign)
+		}
+	} else if o.Countersign != "" {
+		return errors.Newf("countersigning requires a signer")
 	}
 	if o.DigestMode == "" {
 		o.DigestMode = DIGESTMODE_LOCAL
@@ -677,6 +776,12 @@ func (o *Options) DoSign() bool {
 	return o.Signer != nil && len(o.SignatureNames) > 0
 }
 
+// DoIncremental checks whether resource digests already
+// verified by the VerifiedStore should be reused.
+func (o *Options) DoIncremental() bool {
+	return o.Incremental && o.VerifiedStore != nil && !o.DoSign()
+}
+
 func (o *Options) StoreLocally() bool {
 	return o.DigestMode == DIGESTMODE_LOCAL
 }
@@ -739,6 +844,20 @@ func (o *Options) EffectiveTSAUrl() string {
 	return ""
 }
 
+// SetVerificationPolicy adds the rules of the given policy
+// to the verification policy of the options.
+func (o *Options) SetVerificationPolicy(p *Policy) {
+	if p == nil {
+		return
+	}
+	n := &Policy{}
+	if o.Policy != nil {
+		n.AddRules(o.Policy.Rules...)
+	}
+	n.AddRules(p.Rules...)
+	o.Policy = n
+}
+
 func (o *Options) Dup() *Options {
 	opts := *o
 	return &opts
@@ -751,6 +870,7 @@ func (o *Options) Nested() *Options {
 		opts.Update = opts.DoUpdate() && opts.DigestMode == DIGESTMODE_LOCAL
 		opts.Signer = nil
 	}
+	opts.Countersign = "" // countersignatures are only created for the root component version
 	opts.Printer = opts.Printer.AddGap("  ")
 	return opts
 }
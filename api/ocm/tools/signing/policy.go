@@ -0,0 +1,276 @@
+package signing
+
+import (
+	"crypto/x509"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/maputils"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// Policy describes a declarative verification policy.
+// It consists of a set of rules evaluated for every
+// component version verified by Apply.
+type Policy struct {
+	Rules []PolicyRule `json:"rules,omitempty"`
+}
+
+// PolicyRule describes a single rule of a verification policy.
+// A rule is evaluated for all component versions matching
+// one of the component patterns.
+type PolicyRule struct {
+	// Name is used to report the result of the rule.
+	Name string `json:"name"`
+	// Components is a list of component name patterns the rule
+	// applies to. A * matches any sequence of characters.
+	// If empty, the rule applies to all component versions.
+	Components []string `json:"components,omitempty"`
+	// Signatures is the list of accepted signature names.
+	// If empty, all signatures are accepted.
+	Signatures []string `json:"signatures,omitempty"`
+	// Required is the number of valid accepted signatures required.
+	// It defaults to the number of configured signatures or to 1,
+	// if no signature names are given.
+	Required int `json:"required,omitempty"`
+	// Timestamp requires a signature to carry a valid TSA timestamp.
+	Timestamp bool `json:"timestamp,omitempty"`
+	// Issuer is a regular expression the subject distinguished name
+	// of the certificate used to verify a signature must match.
+	Issuer string `json:"issuer,omitempty"`
+	// SameOrganization requires the directly referenced component versions
+	// to be signed by the same organization as the matching component version.
+	// The organizations are taken from the certificates used to verify
+	// the signatures.
+	SameOrganization bool `json:"sameOrganization,omitempty"`
+}
+
+func (r *PolicyRule) GetName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return strings.Join(r.Components, ",")
+}
+
+func (r *PolicyRule) Validate() error {
+	for _, c := range r.Components {
+		if _, err := compilePattern(c); err != nil {
+			return errors.Wrapf(err, "component pattern %q", c)
+		}
+	}
+	if r.Issuer != "" {
+		if _, err := regexp.Compile(r.Issuer); err != nil {
+			return errors.Wrapf(err, "issuer pattern %q", r.Issuer)
+		}
+	}
+	if r.Required < 0 {
+		return errors.Newf("required signature count must not be negative")
+	}
+	if len(r.Signatures) > 0 && r.Required > len(r.Signatures) {
+		return errors.Newf("required signature count %d exceeds number of signatures %d", r.Required, len(r.Signatures))
+	}
+	return nil
+}
+
+func (r *PolicyRule) Matches(name string) bool {
+	if len(r.Components) == 0 {
+		return true
+	}
+	for _, c := range r.Components {
+		if exp, err := compilePattern(c); err == nil && exp.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *PolicyRule) required() int {
+	if r.Required > 0 {
+		return r.Required
+	}
+	if len(r.Signatures) > 0 {
+		return len(r.Signatures)
+	}
+	return 1
+}
+
+func compilePattern(p string) (*regexp.Regexp, error) {
+	parts := strings.Split(p, "*")
+	for i, e := range parts {
+		parts[i] = regexp.QuoteMeta(e)
+	}
+	return regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+}
+
+func (p *Policy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Rules {
+		if err := p.Rules[i].Validate(); err != nil {
+			return errors.Wrapf(err, "rule %d (%s)", i, p.Rules[i].GetName())
+		}
+	}
+	return nil
+}
+
+// AddRules adds the given rules to the policy.
+func (p *Policy) AddRules(rules ...PolicyRule) {
+	p.Rules = append(p.Rules, rules...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// evaluatePolicy evaluates all policy rules matching the component version
+// of the actual digest context and reports the result per rule.
+func evaluatePolicy(cctx credentials.Context, state WalkingState, opts *Options) error {
+	ctx := state.Context
+	cd := ctx.Descriptor
+	list := errors.ErrListf("verification policy violated for %s", common.VersionedElementKey(cd))
+	for i := range opts.Policy.Rules {
+		r := &opts.Policy.Rules[i]
+		if !r.Matches(cd.Name) {
+			continue
+		}
+		err := evaluateRule(cctx, state, r, opts)
+		if err != nil {
+			opts.Printer.Printf("  policy rule %q: violated: %s\n", r.GetName(), err)
+			list.Add(errors.Wrapf(err, "rule %q", r.GetName()))
+		} else {
+			opts.Printer.Printf("  policy rule %q: satisfied\n", r.GetName())
+		}
+	}
+	return list.Result()
+}
+
+func evaluateRule(cctx credentials.Context, state WalkingState, r *PolicyRule, opts *Options) error {
+	cd := state.Context.Descriptor
+
+	sigs, err := validSignatures(cctx, state.Context, r, opts)
+	if err != nil {
+		return err
+	}
+	if len(sigs) < r.required() {
+		return errors.Newf("%d of %d required signatures valid", len(sigs), r.required())
+	}
+
+	if r.SameOrganization {
+		orgs := map[string]bool{}
+		for _, s := range sigs {
+			if s.cert == nil {
+				continue
+			}
+			for _, o := range s.cert.Subject.Organization {
+				orgs[o] = true
+			}
+		}
+		if len(orgs) == 0 {
+			return errors.Newf("no organization found for valid signatures")
+		}
+		for _, ref := range cd.References {
+			rnv := ocm.ComponentRefKey(&ref)
+			rctx := state.GetContext(rnv, state.Context.CtxKey)
+			if rctx == nil {
+				return errors.Newf("no digest context found for reference %s", rnv)
+			}
+			rsigs, err := validSignatures(cctx, rctx, nil, opts)
+			if err != nil {
+				return errors.Wrapf(err, "reference %s", rnv)
+			}
+			found := false
+			for _, s := range rsigs {
+				if s.cert == nil {
+					continue
+				}
+				for _, o := range s.cert.Subject.Organization {
+					if orgs[o] {
+						found = true
+					}
+				}
+			}
+			if !found {
+				return errors.Newf("reference %s not signed by organization %s", rnv, strings.Join(maputils.OrderedKeys(orgs), ","))
+			}
+		}
+	}
+	return nil
+}
+
+// verifiedSignature is the result of the verification of
+// a signature of a component descriptor.
+type verifiedSignature struct {
+	sig *compdesc.Signature
+	// cert is the certificate used to verify the signature, if
+	// the public key is given by a certificate.
+	cert *x509.Certificate
+	// timestamp indicates a valid TSA timestamp.
+	timestamp bool
+}
+
+// verifiedSignatures provides all successfully verified signatures of
+// the component descriptor of a digest context. The signatures are verified
+// only once, the result is cached in the digest context.
+func verifiedSignatures(cctx credentials.Context, ctx *DigestContext, opts *Options) []verifiedSignature {
+	if ctx.verified != nil {
+		return ctx.verified
+	}
+	cd := ctx.Descriptor
+	result := []verifiedSignature{}
+	digests := compdesc.NewCompDescDigests(cd)
+	for i := range cd.Signatures {
+		sig := &cd.Signatures[i]
+		cert, err := verifySignatureCertificate(cctx, digests, sig, opts)
+		if err != nil {
+			continue
+		}
+		v := verifiedSignature{sig: sig, cert: cert}
+		if sig.Timestamp != nil {
+			_, err := verifyTimestamp(sig, opts.RootCerts, opts)
+			v.timestamp = err == nil
+		}
+		result = append(result, v)
+	}
+	ctx.verified = result
+	return result
+}
+
+// validSignatures determines the verified signatures of the component
+// descriptor of a digest context accepted by a rule.
+// If no rule is given, all signatures are considered.
+// The issuer is always taken from the subject of the certificate
+// used to verify a signature. Signatures not verified by a certificate
+// never match an issuer.
+func validSignatures(cctx credentials.Context, ctx *DigestContext, r *PolicyRule, opts *Options) ([]verifiedSignature, error) {
+	var issuer *regexp.Regexp
+	if r != nil && r.Issuer != "" {
+		exp, err := regexp.Compile(r.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		issuer = exp
+	}
+
+	var result []verifiedSignature
+	for _, v := range verifiedSignatures(cctx, ctx, opts) {
+		if r != nil && len(r.Signatures) > 0 && !slices.Contains(r.Signatures, v.sig.Name) {
+			continue
+		}
+		if r != nil && r.Timestamp && !v.timestamp {
+			continue
+		}
+		if issuer != nil {
+			if v.cert == nil || !issuer.MatchString(signutils.DNAsString(v.cert.Subject)) {
+				continue
+			}
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package signing
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+)
+
+// checkRevocation checks the certificates of a verified certificate chain
+// against the revocation information given by the options.
+// A certificate revoked after the signing time provided by a
+// verified timestamp is accepted, because the signature has been
+// created while the certificate was still valid. This does not hold,
+// if the revocation reason does not exclude a compromised key, because
+// the key could have been used by someone else before the revocation.
+func checkRevocation(sig *compdesc.Signature, chain []*x509.Certificate, timestamp *time.Time, opts *Options) error {
+	if opts.Revocation.IsEmpty() {
+		return nil
+	}
+	for i := 0; i < len(chain)-1; i++ {
+		cert := chain[i]
+		status, err := opts.Revocation.Check(cert, chain[i+1])
+		if err != nil {
+			return err
+		}
+		subject := cert.Subject.String()
+		switch {
+		case status == nil:
+			opts.Printer.Printf("  signature %q: no revocation information found for certificate %q\n", sig.Name, subject)
+		case !status.Revoked:
+			opts.Printer.Printf("  signature %q: certificate %q not revoked (%s)\n", sig.Name, subject, status.Source)
+		case timestamp != nil && status.RevokedAt.After(*timestamp) && !status.KeyMayBeCompromised():
+			opts.Printer.Printf("  signature %q: certificate %q revoked at %s after signing time %s (%s)\n", sig.Name, subject,
+				status.RevokedAt.UTC().Format(time.RFC3339), timestamp.UTC().Format(time.RFC3339), status.Source)
+		default:
+			return errors.Newf("certificate %q revoked at %s (%s)", subject, status.RevokedAt.UTC().Format(time.RFC3339), status.Source)
+		}
+	}
+	return nil
+}
@@ -299,6 +299,93 @@ applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/test:v
 			Entry(DIGESTMODE_LOCAL, DIGESTMODE_LOCAL),
 		)
 
+		It("evaluates verification policy from config", func() {
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			session.AddCloser(src)
+			resolver := resolvers.NewCompoundResolver(src)
+
+			cv := Must(resolver.LookupComponentVersion(COMPONENTA, VERSION))
+			session.AddCloser(cv)
+
+			opts := NewOptions(
+				Sign(signingattr.Get(env.OCMContext()).GetSigner(SIGN_ALGO), SIGNATURE),
+				Resolver(resolver),
+				Update(), VerifyDigests(),
+			)
+			Must(Apply(nil, nil, cv, opts))
+
+			MustBeSuccessful(env.ConfigContext().ApplyConfig(NewConfig(PolicyRule{
+				Name:       "two",
+				Components: []string{"github.com/mandelsoft/*"},
+				Signatures: []string{SIGNATURE, "other"},
+			}), "policy"))
+
+			opts = NewOptions(
+				VerifySignature(SIGNATURE),
+				Resolver(resolver),
+				VerifyDigests(),
+			)
+			pr, buf := common.NewBufferedPrinter()
+			_, err := Apply(pr, nil, cv, opts)
+			Expect(err).To(MatchError(ContainSubstring(`rule "two": 1 of 2 required signatures valid`)))
+			Expect(buf.String()).To(ContainSubstring(`policy rule "two": violated: 1 of 2 required signatures valid`))
+
+			opts = NewOptions(
+				VerifySignature(SIGNATURE),
+				Resolver(resolver),
+				VerifyDigests(),
+				VerificationPolicy(&Policy{Rules: []PolicyRule{{
+					Name:       "one",
+					Components: []string{"github.com/mandelsoft/*"},
+					Signatures: []string{SIGNATURE, "other"},
+					Required:   1,
+				}}}),
+			)
+			pr, buf = common.NewBufferedPrinter()
+			Must(Apply(pr, nil, cv, opts))
+			Expect(buf.String()).To(ContainSubstring(`policy rule "one": satisfied`))
+		})
+
+		It("ignores issuers not provided by a certificate for policies", func() {
+			session := datacontext.NewSession()
+			defer session.Close()
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			session.AddCloser(src)
+			resolver := resolvers.NewCompoundResolver(src)
+
+			cv := Must(resolver.LookupComponentVersion(COMPONENTA, VERSION))
+			session.AddCloser(cv)
+
+			opts := NewOptions(
+				Sign(signingattr.Get(env.OCMContext()).GetSigner(SIGN_ALGO), SIGNATURE),
+				Resolver(resolver),
+				Update(), VerifyDigests(),
+			)
+			Must(Apply(nil, nil, cv, opts))
+
+			// the issuer of a signature is just a claim, if there
+			// is no certificate.
+			cv.GetDescriptor().Signatures[0].Signature.Issuer = "O=acme"
+
+			opts = NewOptions(
+				VerifySignature(SIGNATURE),
+				Resolver(resolver),
+				VerifyDigests(),
+				VerificationPolicy(&Policy{Rules: []PolicyRule{{
+					Name:   "issuer",
+					Issuer: "O=acme",
+				}}}),
+			)
+			pr, buf := common.NewBufferedPrinter()
+			_, err := Apply(pr, nil, cv, opts)
+			Expect(err).To(MatchError(ContainSubstring(`rule "issuer": 0 of 1 required signatures valid`)))
+			Expect(buf.String()).To(ContainSubstring(`policy rule "issuer": violated: 0 of 1 required signatures valid`))
+		})
+
 		DescribeTable("sign flat version with generic verification", func(mode string) {
 			session := datacontext.NewSession()
 			defer session.Close()
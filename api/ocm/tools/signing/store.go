@@ -3,6 +3,8 @@ package signing
 import (
 	"io"
 	"os"
+	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/mandelsoft/filepath/pkg/filepath"
@@ -216,6 +218,74 @@ func (v *verifiedStore) GetResourceDigestByIndex(n common.VersionedElement, idx
 	return cd.Resources[idx].Digest
 }
 
+// verifiedDescriptor provides the descriptor recorded in the VerifiedStore
+// for the component version of the actual digest context, if the incremental
+// mode is enabled. It is only used, if its normalized digest matches a digest
+// expected for the component version. For a root component version these
+// are the digests of the signatures to be verified, which are recorded
+// as verified and identical in both descriptors. For nested component versions
+// it is the digest of the reference in the referencing component version.
+func verifiedDescriptor(ctx *DigestContext, opts *Options) *compdesc.ComponentDescriptor {
+	if !opts.DoIncremental() {
+		return nil
+	}
+	cd := ctx.Descriptor
+	entry := opts.VerifiedStore.GetEntry(cd)
+	if entry == nil || entry.Descriptor == nil {
+		return nil
+	}
+	vcd := entry.Descriptor.Descriptor()
+
+	var expected []*metav1.DigestSpec
+	if ctx.Parent == nil {
+		for _, n := range opts.SignatureNames {
+			sig := cd.Signatures.GetByName(n)
+			if sig != nil && slices.Contains(entry.Signatures, n) && reflect.DeepEqual(sig, vcd.Signatures.GetByName(n)) {
+				expected = append(expected, &sig.Digest)
+			}
+		}
+	} else {
+		for _, ref := range ctx.Parent.Descriptor.References {
+			if ref.Digest != nil && ref.ComponentName == cd.GetName() && ref.Version == cd.GetVersion() {
+				expected = append(expected, ref.Digest)
+			}
+		}
+	}
+	for _, d := range expected {
+		hasher := opts.Registry.GetHasher(d.HashAlgorithm)
+		if hasher == nil {
+			continue
+		}
+		digest, err := compdesc.Hash(vcd, d.NormalisationAlgorithm, hasher.Create())
+		if err == nil && digest == d.Value {
+			return vcd
+		}
+	}
+	return nil
+}
+
+// verifiedResourceDigest provides the digest of the resource with the given index
+// if the verified descriptor (see verifiedDescriptor) contains an identical
+// resource (same access specification and digest). Otherwise, nil is returned
+// and the digest has to be calculated.
+func verifiedResourceDigest(cd, vcd *compdesc.ComponentDescriptor, idx int) *metav1.DigestSpec {
+	if vcd == nil {
+		return nil
+	}
+	raw := &cd.Resources[idx]
+	if raw.Digest == nil || raw.Access == nil {
+		return nil
+	}
+	vres, err := vcd.GetResourceByIdentity(raw.GetIdentity(cd.Resources))
+	if err != nil || vres.Digest == nil || vres.Access == nil {
+		return nil
+	}
+	if !reflect.DeepEqual(vres.Digest, raw.Digest) || !reflect.DeepEqual(vres.Access, raw.Access) {
+		return nil
+	}
+	return raw.Digest
+}
+
 type StorageEntry struct {
 	Signatures []string                             `json:"signatures,omitempty"`
 	Descriptor *compdesc.GenericComponentDescriptor `json:"descriptor"`
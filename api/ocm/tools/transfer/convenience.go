@@ -27,7 +27,7 @@ func Transfer(cv ocm.ComponentVersionAccess, tgt ocm.Repository, optlist ...Tran
 	if err != nil {
 		return err
 	}
-	return TransferVersionWithContext(common.WithPrinter(context.Background(), local.printer), nil, cv, tgt, h)
+	return TransferVersionWithContext(local.context(context.Background()), nil, cv, tgt, h)
 }
 
 // TransferWithContext uses the transfer handler based on the given options to control
@@ -42,8 +42,5 @@ func TransferWithContext(ctx context.Context, cv ocm.ComponentVersionAccess, tgt
 	if err != nil {
 		return err
 	}
-	if local.printer != nil {
-		ctx = common.WithPrinter(ctx, local.printer)
-	}
-	return TransferVersionWithContext(ctx, nil, cv, tgt, h)
+	return TransferVersionWithContext(local.context(ctx), nil, cv, tgt, h)
 }
@@ -0,0 +1,284 @@
+package journal
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	KIND_JOURNAL = "transfer journal"
+
+	ARTIFACT_RESOURCE = "resource"
+	ARTIFACT_SOURCE   = "source"
+)
+
+// Journal is a checkpoint journal for a transfer process.
+// It records component versions, whose transfer has been completed,
+// and the artifacts already uploaded to external (non-local) storage locations
+// for component versions still in progress. A transfer process provided with a journal skips
+// work already recorded and continues with the remaining work.
+//
+// If the journal is bound to a file, every modification
+// is persisted immediately, so that an interrupted transfer can be
+// resumed later.
+//
+// A journal is bound to the target repository of the first transfer
+// using it. It is rejected for transfers to other target repositories,
+// because the recorded state is only valid for this target.
+type Journal struct {
+	lock     sync.Mutex
+	fs       vfs.FileSystem
+	path     string
+	target   string
+	versions map[common.NameVersion]*versionEntry
+}
+
+// Data is the serialized form of a journal.
+type Data struct {
+	Target            string         `json:"target,omitempty"`
+	ComponentVersions []VersionEntry `json:"componentVersions,omitempty"`
+}
+
+type VersionEntry struct {
+	Component string          `json:"component"`
+	Version   string          `json:"version"`
+	Completed bool            `json:"completed,omitempty"`
+	Artifacts []ArtifactEntry `json:"artifacts,omitempty"`
+}
+
+// ArtifactEntry describes an artifact already transferred
+// for a component version. The original access specification
+// and digest are used to verify that the recorded
+// target access still matches the source artifact.
+type ArtifactEntry struct {
+	Kind     string                           `json:"kind"`
+	Identity metav1.Identity                  `json:"identity"`
+	Digest   *metav1.DigestSpec               `json:"digest,omitempty"`
+	Source   *runtime.UnstructuredTypedObject `json:"source,omitempty"`
+	Target   *runtime.UnstructuredTypedObject `json:"target"`
+}
+
+type versionEntry struct {
+	completed bool
+	artifacts []ArtifactEntry
+}
+
+// New provides a new in-memory journal.
+func New() *Journal {
+	return &Journal{versions: map[common.NameVersion]*versionEntry{}}
+}
+
+// Create creates a new empty journal persisted in the given file.
+// An existing file is overwritten.
+func Create(fs vfs.FileSystem, path string) (*Journal, error) {
+	j := New()
+	j.fs = fs
+	j.path = path
+	if err := j.save(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Open reads an existing journal from the given file. All further
+// modifications are persisted in this file.
+func Open(fs vfs.FileSystem, path string) (*Journal, error) {
+	data, err := vfs.ReadFile(fs, path)
+	if err != nil {
+		if vfs.IsNotExist(err) {
+			return nil, errors.ErrNotFound(KIND_JOURNAL, path)
+		}
+		return nil, errors.Wrapf(err, "cannot read %s %q", KIND_JOURNAL, path)
+	}
+	var d Data
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, errors.ErrInvalidWrap(err, KIND_JOURNAL, path)
+	}
+	j := New()
+	j.fs = fs
+	j.path = path
+	j.target = d.Target
+	for _, v := range d.ComponentVersions {
+		j.versions[common.NewNameVersion(v.Component, v.Version)] = &versionEntry{
+			completed: v.Completed,
+			artifacts: v.Artifacts,
+		}
+	}
+	return j, nil
+}
+
+// GetTarget returns the target repository the journal is bound to.
+// An empty string is returned for an unbound journal.
+func (j *Journal) GetTarget() string {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return j.target
+}
+
+// CheckTarget checks whether the journal can be used for a transfer
+// to the given target repository.
+func (j *Journal) CheckTarget(target string) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return j.checkTarget(target)
+}
+
+func (j *Journal) checkTarget(target string) error {
+	if j.target != "" && j.target != target {
+		return errors.Newf("%s belongs to target repository %q, but found %q", KIND_JOURNAL, j.target, target)
+	}
+	return nil
+}
+
+// BindTarget binds an unbound journal to the given target repository.
+// It fails, if the journal is already bound to another target repository.
+func (j *Journal) BindTarget(target string) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if err := j.checkTarget(target); err != nil {
+		return err
+	}
+	if j.target == target {
+		return nil
+	}
+	j.target = target
+	return j.save()
+}
+
+// IsCompleted checks whether the transfer of the given component version
+// (including its transitive references) is already recorded as completed.
+func (j *Journal) IsCompleted(nv common.NameVersion) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := j.versions[nv]
+	return e != nil && e.completed
+}
+
+// Completed records the given component version as completely transferred.
+// Artifact entries are not required anymore and discarded.
+func (j *Journal) Completed(nv common.NameVersion) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.versions[nv] = &versionEntry{completed: true}
+	return j.save()
+}
+
+// GetArtifact returns the target access recorded for an artifact of a component
+// version, if the artifact has already been transferred with the same
+// source access and digest. Otherwise, nil is returned.
+func (j *Journal) GetArtifact(nv common.NameVersion, kind string, id metav1.Identity, digest *metav1.DigestSpec, src compdesc.AccessSpec) compdesc.AccessSpec {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := j.versions[nv]
+	if e == nil {
+		return nil
+	}
+	usrc, err := runtime.ToUnstructuredTypedObject(src)
+	if err != nil {
+		return nil
+	}
+	for _, a := range e.artifacts {
+		if a.Kind != kind || !a.Identity.Equals(id) {
+			continue
+		}
+		if !a.Digest.Equal(digest) || !runtime.UnstructuredTypesEqual(a.Source, usrc) || a.Target == nil {
+			return nil
+		}
+		return compdesc.GenericAccessSpec(a.Target)
+	}
+	return nil
+}
+
+// AddArtifact records the target access of an artifact transferred
+// for a component version.
+func (j *Journal) AddArtifact(nv common.NameVersion, kind string, id metav1.Identity, digest *metav1.DigestSpec, src, tgt compdesc.AccessSpec) error {
+	usrc, err := runtime.ToUnstructuredTypedObject(src)
+	if err != nil {
+		return errors.Wrapf(err, "cannot serialize source access")
+	}
+	utgt, err := runtime.ToUnstructuredTypedObject(tgt)
+	if err != nil {
+		return errors.Wrapf(err, "cannot serialize target access")
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := j.versions[nv]
+	if e == nil {
+		e = &versionEntry{}
+		j.versions[nv] = e
+	}
+	entry := ArtifactEntry{
+		Kind:     kind,
+		Identity: id.Copy(),
+		Digest:   digest.Copy(),
+		Source:   usrc,
+		Target:   utgt,
+	}
+	for i, a := range e.artifacts {
+		if a.Kind == kind && a.Identity.Equals(id) {
+			e.artifacts[i] = entry
+			return j.save()
+		}
+	}
+	e.artifacts = append(e.artifacts, entry)
+	return j.save()
+}
+
+// GetData returns the serializable content of the journal.
+func (j *Journal) GetData() *Data {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return j.data()
+}
+
+func (j *Journal) data() *Data {
+	d := &Data{Target: j.target}
+	for nv, e := range j.versions {
+		d.ComponentVersions = append(d.ComponentVersions, VersionEntry{
+			Component: nv.GetName(),
+			Version:   nv.GetVersion(),
+			Completed: e.completed,
+			Artifacts: e.artifacts,
+		})
+	}
+	sort.Slice(d.ComponentVersions, func(a, b int) bool {
+		return common.CompareNameVersion(
+			common.NewNameVersion(d.ComponentVersions[a].Component, d.ComponentVersions[a].Version),
+			common.NewNameVersion(d.ComponentVersions[b].Component, d.ComponentVersions[b].Version),
+		) < 0
+	})
+	return d
+}
+
+func (j *Journal) save() error {
+	if j.fs == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(j.data(), "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal %s", KIND_JOURNAL)
+	}
+	tmp := j.path + ".tmp"
+	if err := vfs.WriteFile(j.fs, tmp, data, 0o640); err != nil {
+		return errors.Wrapf(err, "cannot write %s %q", KIND_JOURNAL, j.path)
+	}
+	if err := j.fs.Rename(tmp, j.path); err != nil {
+		return errors.Wrapf(err, "cannot write %s %q", KIND_JOURNAL, j.path)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package transfer
 
 import (
+	"context"
+
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	common "ocm.software/ocm/api/utils/misc"
 )
@@ -36,7 +39,8 @@ type (
 // To distinguish them from transferhandler options, they do NOT implement
 // the transferhandler.TransferOptionsCreator interface.
 type localOptions struct {
-	printer common.Printer
+	printer  common.Printer
+	progress progress.Handler
 }
 
 func (opts *localOptions) Eval(optlist ...transferhandler.TransferOption) error {
@@ -59,11 +63,33 @@ func WithPrinter(p common.Printer) transferhandler.TransferOption {
 	}
 }
 
+// WithProgress provides a handler for the progress events
+// of a transfer (see package progress).
+func WithProgress(h progress.Handler) transferhandler.TransferOption {
+	return &localOptions{
+		progress: h,
+	}
+}
+
 func (l *localOptions) ApplyTransferOption(options TransferOptions) error {
 	if t, ok := options.(*localOptions); ok {
 		if l.printer != nil {
 			t.printer = l.printer
 		}
+		if l.progress != nil {
+			t.progress = l.progress
+		}
 	}
 	return nil
 }
+
+// context provides a context enriched by the local options.
+func (l *localOptions) context(ctx context.Context) context.Context {
+	if l.printer != nil {
+		ctx = common.WithPrinter(ctx, l.printer)
+	}
+	if l.progress != nil {
+		ctx = progress.WithHandler(ctx, l.progress)
+	}
+	return ctx
+}
@@ -0,0 +1,387 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/finalizer"
+
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	ocmcpi "ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
+	"ocm.software/ocm/api/ocm/cpi/repocpi"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/none"
+	"ocm.software/ocm/api/ocm/extensions/repositories/genericocireg"
+	cpi "ocm.software/ocm/api/ocm/internal"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/errkind"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// Actions used by a transfer plan.
+const (
+	// ACTION_CREATE describes a component version not yet present in the target.
+	ACTION_CREATE = "create"
+	// ACTION_OVERWRITE describes an element replaced in the target.
+	ACTION_OVERWRITE = "overwrite"
+	// ACTION_UPDATE describes an existing component version, which is updated.
+	ACTION_UPDATE = "update"
+	// ACTION_SKIP describes an element left untouched.
+	ACTION_SKIP = "skip"
+	// ACTION_ABORT describes a component version, whose transfer would fail,
+	// because it differs from the version already present in the target.
+	ACTION_ABORT = "abort"
+	// ACTION_COPY describes an artifact copied by value.
+	ACTION_COPY = "copy"
+	// ACTION_REFERENCE describes an artifact kept by reference.
+	ACTION_REFERENCE = "reference"
+)
+
+// UPLOADER_BUILTIN describes an upload handler registered without name.
+const UPLOADER_BUILTIN = "builtin"
+
+// Plan describes the actions a transfer would execute for a
+// set of component versions.
+type Plan struct {
+	Versions []*VersionPlan `json:"componentVersions,omitempty"`
+}
+
+// Size provides the accumulated estimated size of all artifacts
+// copied by value. Artifacts with unknown size are ignored.
+func (p *Plan) Size() int64 {
+	var size int64
+	for _, v := range p.Versions {
+		size += v.Size()
+	}
+	return size
+}
+
+// VersionPlan describes the actions a transfer would execute for
+// a single component version.
+type VersionPlan struct {
+	Component string          `json:"component"`
+	Version   string          `json:"version"`
+	Action    string          `json:"action"`
+	Message   string          `json:"message,omitempty"`
+	Resources []*ArtifactPlan `json:"resources,omitempty"`
+	Sources   []*ArtifactPlan `json:"sources,omitempty"`
+}
+
+// Size provides the accumulated estimated size of the artifacts
+// copied by value. Artifacts with unknown size are ignored.
+func (p *VersionPlan) Size() int64 {
+	var size int64
+	for _, a := range append(append([]*ArtifactPlan{}, p.Resources...), p.Sources...) {
+		if a.Size > 0 && (a.Action == ACTION_COPY || a.Action == ACTION_OVERWRITE) {
+			size += a.Size
+		}
+	}
+	return size
+}
+
+// ArtifactPlan describes the action a transfer would execute for a
+// resource or source of a component version.
+// If an artifact is copied by value, the blob upload handler used for
+// the target repository is reported, if there is any. Otherwise,
+// the blob is stored as local blob. The Size is the estimated blob
+// size, or blobaccess.BLOB_UNKNOWN_SIZE, if it cannot be determined
+// without accessing the blob content.
+type ArtifactPlan struct {
+	Kind       string          `json:"kind"`
+	Identity   metav1.Identity `json:"identity"`
+	Type       string          `json:"type"`
+	AccessType string          `json:"accessType"`
+	Action     string          `json:"action"`
+	Message    string          `json:"message,omitempty"`
+	Uploader   string          `json:"uploader,omitempty"`
+	Size       int64           `json:"size"`
+}
+
+// PlanVersion determines the actions a transfer of the given component
+// version to the target repository would execute, without modifying
+// the target repository.
+// The decisions are taken by the given transfer handler, the same
+// way as for TransferVersion. Component versions already found
+// in the closure are not planned again, this can be used to plan
+// multiple component versions with a shared closure.
+func PlanVersion(ctx context.Context, closure TransportClosure, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) (*Plan, error) {
+	if closure == nil {
+		closure = TransportClosure{}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if j := journalFor(handler); j != nil {
+		err := j.CheckTarget(journalTarget(tgt))
+		if err != nil {
+			return nil, err
+		}
+	}
+	plan := &Plan{}
+	state := WalkingState{Closure: closure}
+	err := planVersion(ctx, state, plan, src, tgt, handler)
+	return plan, err
+}
+
+func planVersion(ctx context.Context, state WalkingState, plan *Plan, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) (rerr error) {
+	if err := common.IsContextCanceled(ctx); err != nil {
+		return err
+	}
+	nv := common.VersionedElementKey(src)
+	if state.History.Contains(nv) {
+		return errors.ErrRecusion(ocm.KIND_COMPONENTVERSION, nv, state.History)
+	}
+	state.History = state.History.Append(nv)
+	if !state.Closure.Add(nv) {
+		return nil
+	}
+	if handler == nil {
+		var err error
+		handler, err = standard.New(standard.Overwrite())
+		if err != nil {
+			return err
+		}
+	}
+
+	vp := &VersionPlan{
+		Component: nv.GetName(),
+		Version:   nv.GetVersion(),
+	}
+	plan.Versions = append(plan.Versions, vp)
+
+	if j := journalFor(handler); j != nil && j.IsCompleted(nv) {
+		vp.Action = ACTION_SKIP
+		vp.Message = "already transferred according to journal"
+		return nil
+	}
+
+	var finalize finalizer.Finalizer
+	defer finalize.FinalizeWithErrorPropagation(&rerr)
+
+	d := src.GetDescriptor()
+
+	comp, err := tgt.LookupComponent(src.GetName())
+	if err != nil {
+		return errors.Wrapf(err, "%s: lookup target component", state.History)
+	}
+	finalize.Close(comp, "closing target component")
+
+	t, err := comp.LookupVersion(src.GetVersion())
+	finalize.Close(t, "existing target version")
+	if err != nil {
+		if !errors.IsErrNotFound(err) {
+			return errors.Wrapf(err, "%s: lookup target version", state.History)
+		}
+		// planning must not modify the target, therefore no new
+		// version is created. Artifacts are planned against an
+		// empty descriptor.
+		t = nil
+	}
+
+	vt, err := decideVersionTransport(src, d, t, handler)
+	if err != nil {
+		return err
+	}
+	vp.Action = vt.Action
+	vp.Message = vt.Message
+	if !vt.References {
+		return nil
+	}
+
+	if vt.Transport && vt.Copy {
+		if err := planArtifacts(ctx, state.History, vp, src, tgt, t, handler); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range d.References {
+		cv, shdlr, err := handler.TransferVersion(src.Repository(), src, &ref, tgt)
+		if err != nil {
+			return errors.Wrapf(err, "%s: nested component %s[%s:%s]",
+				state.History, ref.GetName(), ref.ComponentName, ref.GetVersion())
+		}
+		if cv != nil {
+			err = planVersion(ctx, state, plan, cv, tgt, shdlr)
+			cv.Close()
+			if err != nil {
+				return errors.Wrapf(err, "%s: planning reference %s[%s:%s]",
+					state.History, ref.GetName(), ref.ComponentName, ref.GetVersion())
+			}
+		}
+	}
+	return nil
+}
+
+func planArtifacts(ctx context.Context, hist common.History, vp *VersionPlan, src ocm.ComponentVersionAccess, tgt ocm.Repository, t ocm.ComponentVersionAccess, handler TransferHandler) error {
+	nv := common.VersionedElementKey(src)
+	j := journalFor(handler)
+	sd := src.GetDescriptor()
+	cur := &compdesc.ComponentDescriptor{}
+	if t != nil {
+		cur = t.GetDescriptor()
+	}
+	impltyp := implementationRepositoryType(tgt, t)
+
+	for i, r := range src.GetResources() {
+		if err := common.IsContextCanceled(ctx); err != nil {
+			return err
+		}
+		a, err := r.Access()
+		if err != nil {
+			return errors.Wrapf(err, "%s: planning resource %d", hist, i)
+		}
+		ap := &ArtifactPlan{
+			Kind:       journal.ARTIFACT_RESOURCE,
+			Identity:   r.Meta().GetIdentity(sd.Resources),
+			Type:       r.Meta().GetType(),
+			AccessType: a.GetType(),
+			Action:     ACTION_REFERENCE,
+			Size:       blobaccess.BLOB_UNKNOWN_SIZE,
+		}
+		vp.Resources = append(vp.Resources, ap)
+
+		ok := a.IsLocal(src.GetContext())
+		if !ok && !none.IsNone(a.GetKind()) {
+			ok, err = handler.TransferResource(src, a, r)
+			if err != nil {
+				return errors.Wrapf(err, "%s: planning resource %d", hist, i)
+			}
+		}
+		if !ok {
+			continue
+		}
+		if j != nil && j.GetArtifact(nv, journal.ARTIFACT_RESOURCE, ap.Identity, r.Meta().Digest, a) != nil {
+			ap.Action = ACTION_SKIP
+			ap.Message = "already transferred according to journal"
+			continue
+		}
+		old, err := cur.GetResourceByIdentity(ap.Identity)
+		if err != nil && !errors.IsErrNotFound(err) {
+			return err
+		}
+		changed := err != nil || old.Digest == nil || !old.Digest.Equal(r.Meta().Digest)
+		if !changed && !needsTransport(src.GetContext(), r, &old) {
+			ap.Action = ACTION_SKIP
+			ap.Message = "already present"
+			continue
+		}
+		ap.Action = ACTION_COPY
+		if err == nil && changed {
+			ap.Action = ACTION_OVERWRITE
+		}
+		if err := planBlob(ap, src, a, impltyp); err != nil {
+			return errors.Wrapf(err, "%s: planning resource %d", hist, i)
+		}
+	}
+
+	for i, s := range src.GetSources() {
+		if err := common.IsContextCanceled(ctx); err != nil {
+			return err
+		}
+		a, err := s.Access()
+		if err != nil {
+			return errors.Wrapf(err, "%s: planning source %d", hist, i)
+		}
+		ap := &ArtifactPlan{
+			Kind:       journal.ARTIFACT_SOURCE,
+			Identity:   s.Meta().GetIdentity(sd.Sources),
+			Type:       s.Meta().GetType(),
+			AccessType: a.GetType(),
+			Action:     ACTION_REFERENCE,
+			Size:       blobaccess.BLOB_UNKNOWN_SIZE,
+		}
+		vp.Sources = append(vp.Sources, ap)
+
+		ok := a.IsLocal(src.GetContext())
+		if !ok && !none.IsNone(a.GetKind()) {
+			ok, err = handler.TransferSource(src, a, s)
+			if err != nil {
+				return errors.Wrapf(err, "%s: planning source %d", hist, i)
+			}
+		}
+		if !ok {
+			continue
+		}
+		if j != nil && j.GetArtifact(nv, journal.ARTIFACT_SOURCE, ap.Identity, nil, a) != nil {
+			ap.Action = ACTION_SKIP
+			ap.Message = "already transferred according to journal"
+			continue
+		}
+		// sources do not have digests so far, so they have to copied, always.
+		ap.Action = ACTION_COPY
+		if err := planBlob(ap, src, a, impltyp); err != nil {
+			if !errors.IsErrUnknownKind(err, errkind.KIND_ACCESSMETHOD) {
+				return errors.Wrapf(err, "%s: planning source %d", hist, i)
+			}
+			ap.Action = ACTION_REFERENCE
+			ap.Message = fmt.Sprintf("%s (enforce transport by reference)", err)
+		}
+	}
+	return nil
+}
+
+// planBlob determines the upload handler and the estimated size
+// for an artifact blob copied by value.
+func planBlob(ap *ArtifactPlan, src ocm.ComponentVersionAccess, a ocm.AccessSpec, impltyp cpi.ImplementationRepositoryType) error {
+	m, err := a.AccessMethod(src)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if p, ok := accspeccpi.GetAccessMethodImplementation(m).(accspeccpi.BlobSizeProvider); ok {
+		if size, err := p.GetBlobSize(); err == nil {
+			ap.Size = size
+		}
+	}
+
+	if h := src.GetContext().BlobHandlers().LookupHandler(impltyp, ap.Type, m.MimeType()); h != nil {
+		ap.Uploader = strings.Join(blobHandlerNames(h), ",")
+	}
+	return nil
+}
+
+// implementationRepositoryType determines the implementation repository
+// type used to select the blob handlers for uploads into the target.
+// For new versions it is derived from the target repository, because
+// planning must not create versions in the target.
+func implementationRepositoryType(tgt ocm.Repository, t ocm.ComponentVersionAccess) cpi.ImplementationRepositoryType {
+	if t != nil {
+		if b, err := repocpi.GetComponentVersionAccessBridge(t); err == nil {
+			return b.GetStorageContext().GetImplementationRepositoryType()
+		}
+	}
+	if r := genericocireg.GetOCIRepository(tgt); r != nil {
+		return cpi.ImplementationRepositoryType{ContextType: oci.CONTEXT_TYPE, RepositoryType: r.GetSpecification().GetKind()}
+	}
+	return cpi.ImplementationRepositoryType{ContextType: cpi.CONTEXT_TYPE, RepositoryType: tgt.GetSpecification().GetKind()}
+}
+
+// blobHandlerNames describes the blob handlers used for an upload
+// by their registration names. Handlers registered without name
+// are reported as builtin handlers.
+func blobHandlerNames(h cpi.BlobHandler) []string {
+	var names []string
+	if m, ok := h.(cpi.MultiBlobHandler); ok {
+		for _, e := range m {
+			for _, n := range blobHandlerNames(e) {
+				if !slices.Contains(names, n) {
+					names = append(names, n)
+				}
+			}
+		}
+		return names
+	}
+	if n := cpi.GetBlobHandlerName(h); n != "" {
+		return []string{n}
+	}
+	return []string{UPLOADER_BUILTIN}
+}
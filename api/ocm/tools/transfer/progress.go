@@ -0,0 +1,231 @@
+package transfer
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"ocm.software/ocm/api/credentials"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// progressInterval is the minimal number of bytes between two
+// progress events for an artifact blob.
+const progressInterval = 64 * 1024
+
+// artifactProgress keeps track of the progress of copying an artifact blob.
+type artifactProgress struct {
+	lock     sync.Mutex
+	ctx      context.Context
+	event    progress.ArtifactEvent
+	total    int64
+	interval int64
+	copied   int64
+}
+
+// startArtifactProgress reports the start of an artifact transfer and
+// provides a tracker, if there is a progress handler configured for the
+// given context. Otherwise, nil is returned.
+func startArtifactProgress(ctx context.Context, event progress.ArtifactEvent, m accspeccpi.AccessMethod) *artifactProgress {
+	if progress.GetHandler(ctx) == nil {
+		return nil
+	}
+	total := int64(blobaccess.BLOB_UNKNOWN_SIZE)
+	if p, ok := accspeccpi.GetAccessMethodImplementation(m).(accspeccpi.BlobSizeProvider); ok {
+		if s, err := p.GetBlobSize(); err == nil {
+			total = s
+		}
+	}
+	interval := int64(progressInterval)
+	if total/100 > interval {
+		interval = total / 100
+	}
+	p := &artifactProgress{ctx: ctx, event: event, total: total, interval: interval}
+	progress.Report(ctx, &progress.ArtifactStarted{ArtifactEvent: event, Total: total})
+	return p
+}
+
+// update records the number of bytes read by an attempt and
+// reports it, if the interval has been exceeded or the blob is complete.
+// The total size provided by an access method may only be an estimation,
+// (for example, the size of the layers of an OCI artifact, which is read
+// as artifact set archive), it is adjusted, if more bytes are read.
+func (p *artifactProgress) update(last, copied int64, eof bool) {
+	p.lock.Lock()
+	if copied > p.copied {
+		p.copied = copied
+	}
+	if p.total >= 0 && copied > p.total {
+		p.total = copied
+	}
+	total := p.total
+	p.lock.Unlock()
+	if eof || copied/p.interval != last/p.interval {
+		progress.Report(p.ctx, &progress.ArtifactProgress{ArtifactEvent: p.event, Copied: copied, Total: total})
+	}
+}
+
+// add records a blob copied without reading it from the access method.
+// The sizes of all reported blobs are accumulated.
+func (p *artifactProgress) add(size int64) {
+	if size <= 0 {
+		return
+	}
+	p.lock.Lock()
+	last := p.copied
+	p.copied += size
+	if p.total >= 0 && p.copied > p.total {
+		p.total = p.copied
+	}
+	copied, total := p.copied, p.total
+	p.lock.Unlock()
+	if copied == total || copied/p.interval != last/p.interval {
+		progress.Report(p.ctx, &progress.ArtifactProgress{ArtifactEvent: p.event, Copied: copied, Total: total})
+	}
+}
+
+func (p *artifactProgress) retry(attempt int, err error) {
+	progress.Report(p.ctx, &progress.Retry{ArtifactEvent: p.event, Attempt: attempt, Error: err})
+}
+
+func (p *artifactProgress) finish(err error) {
+	p.lock.Lock()
+	copied, total := p.copied, p.total
+	p.lock.Unlock()
+	progress.Report(p.ctx, &progress.ArtifactFinished{ArtifactEvent: p.event, Copied: copied, Total: total, Error: err})
+}
+
+// method wraps an access method to track the bytes read from it.
+// If the method cannot be wrapped, a new view of the original method
+// is returned.
+// The wrapper must be closed by the caller.
+func (p *artifactProgress) method(m accspeccpi.AccessMethod) (accspeccpi.AccessMethod, error) {
+	if _, ok := m.(accspeccpi.AccessMethodView); !ok {
+		return m.Dup()
+	}
+	d, err := m.Dup()
+	if err != nil {
+		return nil, err
+	}
+	return &progressMethod{d.(accspeccpi.AccessMethodView), p}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// progressMethod is an access method view reporting the bytes read
+// from the method to an artifactProgress.
+// It keeps the access to the original method implementation,
+// so that upload handlers are able to work on the
+// implementation object.
+type progressMethod struct {
+	accspeccpi.AccessMethodView
+	progress *artifactProgress
+}
+
+var (
+	_ accspeccpi.AccessMethodView          = (*progressMethod)(nil)
+	_ credentials.ConsumerIdentityProvider = (*progressMethod)(nil)
+	_ progress.RetryReporter               = (*progressMethod)(nil)
+	_ progress.BlobReporter                = (*progressMethod)(nil)
+)
+
+func (m *progressMethod) Dup() (accspeccpi.AccessMethod, error) {
+	d, err := m.AccessMethodView.Dup()
+	if err != nil {
+		return nil, err
+	}
+	return &progressMethod{d.(accspeccpi.AccessMethodView), m.progress}, nil
+}
+
+func (m *progressMethod) AsBlobAccess() blobaccess.BlobAccess {
+	return blobaccess.ForDataAccess("", -1, m.MimeType(), m)
+}
+
+func (m *progressMethod) Get() ([]byte, error) {
+	data, err := m.AccessMethodView.Get()
+	if err == nil {
+		m.progress.update(0, int64(len(data)), true)
+	}
+	return data, err
+}
+
+func (m *progressMethod) Reader() (io.ReadCloser, error) {
+	r, err := m.AccessMethodView.Reader()
+	if err != nil {
+		return nil, err
+	}
+	return &progressReader{ReadCloser: r, progress: m.progress}, nil
+}
+
+func (m *progressMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
+	if p, ok := m.AccessMethodView.(credentials.ConsumerIdentityProvider); ok {
+		return p.GetConsumerId(uctx...)
+	}
+	return nil
+}
+
+func (m *progressMethod) GetIdentityMatcher() string {
+	if p, ok := m.AccessMethodView.(credentials.ConsumerIdentityProvider); ok {
+		return p.GetIdentityMatcher()
+	}
+	return ""
+}
+
+func (m *progressMethod) ReportRetry(attempt int, err error) {
+	m.progress.retry(attempt, err)
+}
+
+func (m *progressMethod) ReportBlob(size int64) {
+	m.progress.add(size)
+}
+
+// progressReader counts the bytes read by a single reader.
+type progressReader struct {
+	io.ReadCloser
+	progress *artifactProgress
+	copied   int64
+}
+
+func (r *progressReader) Read(buf []byte) (int, error) {
+	n, err := r.ReadCloser.Read(buf)
+	if n > 0 || err == io.EOF {
+		last := r.copied
+		r.copied += int64(n)
+		r.progress.update(last, r.copied, err == io.EOF)
+	}
+	return n, err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func artifactEvent(nv common.NameVersion, kind string, index int, id metav1.Identity, typ string) progress.ArtifactEvent {
+	return progress.ArtifactEvent{
+		ComponentVersionEvent: progress.ComponentVersionEvent{ComponentVersion: nv},
+		Kind:                  kind,
+		Index:                 index,
+		Identity:              id,
+		Type:                  typ,
+	}
+}
+
+// handleWithProgress calls a transfer handler function for the blob of
+// an artifact. If a progress handler is configured for the context,
+// the access method is wrapped to report the progress of reading the blob.
+func handleWithProgress(ctx context.Context, event progress.ArtifactEvent, m accspeccpi.AccessMethod, f func(m accspeccpi.AccessMethod) error) (err error) {
+	p := startArtifactProgress(ctx, event, m)
+	if p == nil {
+		return f(m)
+	}
+	defer func() { p.finish(err) }()
+
+	pm, err := p.method(m)
+	if err != nil {
+		return err
+	}
+	defer pm.Close()
+	return f(pm)
+}
@@ -0,0 +1,186 @@
+// Package progress provides typed events describing the progress
+// of a component version transfer.
+//
+// A Handler can be attached to a transfer context with WithHandler
+// (or the option transfer.WithProgress). It is called for every
+// component version started and finished, and for every resource
+// or source blob copied by value. Because artifacts and references may be
+// transferred concurrently (see maxworkersattr), a handler must be
+// able to handle events from multiple goroutines.
+package progress
+
+import (
+	"context"
+	"reflect"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	ARTIFACT_RESOURCE = "resource"
+	ARTIFACT_SOURCE   = "source"
+)
+
+// Event is the common interface of all progress events.
+type Event interface {
+	// GetComponentVersion describes the component version the event
+	// belongs to.
+	GetComponentVersion() common.NameVersion
+}
+
+// ComponentVersionEvent is the common part of all events.
+type ComponentVersionEvent struct {
+	ComponentVersion common.NameVersion
+}
+
+func (e *ComponentVersionEvent) GetComponentVersion() common.NameVersion {
+	return e.ComponentVersion
+}
+
+// ComponentStarted is reported when the transfer of a component version
+// is started.
+type ComponentStarted struct {
+	ComponentVersionEvent
+}
+
+// ComponentFinished is reported when the transfer of a component version
+// is finished, the Error describes a failed transfer.
+type ComponentFinished struct {
+	ComponentVersionEvent
+	Error error
+}
+
+// ArtifactEvent is the common part of all events for a resource or
+// source of a component version.
+type ArtifactEvent struct {
+	ComponentVersionEvent
+	// Kind is either ARTIFACT_RESOURCE or ARTIFACT_SOURCE.
+	Kind     string
+	Index    int
+	Identity metav1.Identity
+	Type     string
+}
+
+// ArtifactStarted is reported when the blob of an artifact starts to be
+// copied by value. Total is the expected blob size, or
+// blobaccess.BLOB_UNKNOWN_SIZE, if it is not known in advance.
+// The expected size may be an estimation, the Total of subsequent
+// events is adjusted if more bytes are read.
+type ArtifactStarted struct {
+	ArtifactEvent
+	Total int64
+}
+
+// ArtifactProgress is reported while the blob of an artifact is copied.
+// Copied is the number of bytes read from the source in the actual
+// attempt, a new attempt (for example a retry) starts again with zero.
+type ArtifactProgress struct {
+	ArtifactEvent
+	Copied int64
+	Total  int64
+}
+
+// ArtifactFinished is reported when copying the blob of an artifact
+// is finished, the Error describes a failed transfer.
+// Copied may be zero, if the blob has not been read from the
+// source access method, for example, because an upload handler
+// transfers the artifact directly between repositories.
+type ArtifactFinished struct {
+	ArtifactEvent
+	Copied int64
+	Total  int64
+	Error  error
+}
+
+// Retry is reported when copying the blob of an artifact is retried.
+// Attempt is the number of the retry (starting with one) and Error
+// the error of the previous attempt.
+type Retry struct {
+	ArtifactEvent
+	Attempt int
+	Error   error
+}
+
+// Handler is the interface for consumers of progress events.
+type Handler interface {
+	HandleEvent(e Event)
+}
+
+// HandlerFunc is a function usable as Handler.
+type HandlerFunc func(e Event)
+
+func (f HandlerFunc) HandleEvent(e Event) {
+	f(e)
+}
+
+// Handlers is a Handler forwarding events to a sequence of handlers.
+type Handlers []Handler
+
+func (h Handlers) HandleEvent(e Event) {
+	for _, hdlr := range h {
+		if hdlr != nil {
+			hdlr.HandleEvent(e)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var handlerKey = reflect.TypeOf(HandlerFunc(nil))
+
+// WithHandler provides a context with a progress handler.
+func WithHandler(ctx context.Context, h Handler) context.Context {
+	return context.WithValue(ctx, handlerKey, h)
+}
+
+// GetHandler provides the progress handler of a context,
+// or nil, if none is configured.
+func GetHandler(ctx context.Context) Handler {
+	if h, ok := ctx.Value(handlerKey).(Handler); ok {
+		return h
+	}
+	return nil
+}
+
+// Report reports an event to the progress handler of a context,
+// if there is any.
+func Report(ctx context.Context, e Event) {
+	if h := GetHandler(ctx); h != nil {
+		h.HandleEvent(e)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RetryReporter is an optional interface of the access methods passed
+// to a transfer handler. It can be used by the handler to report retries
+// for copying the artifact blob.
+type RetryReporter interface {
+	ReportRetry(attempt int, err error)
+}
+
+// ReportRetry reports a retry for the artifact described by the given
+// access method, if the method supports progress reporting.
+func ReportRetry(m interface{}, attempt int, err error) {
+	if r, ok := m.(RetryReporter); ok {
+		r.ReportRetry(attempt, err)
+	}
+}
+
+// BlobReporter is an optional interface of the access methods passed
+// to a transfer handler. Handlers transferring the content of an access
+// method without reading it from the method (for example, by copying the
+// blobs of an OCI artifact directly from the implementation of the method)
+// use it to report the copied blobs.
+type BlobReporter interface {
+	ReportBlob(size int64)
+}
+
+// ReportBlob reports a blob copied for the artifact described by the given
+// access method, if the method supports progress reporting.
+func ReportBlob(m interface{}, size int64) {
+	if r, ok := m.(BlobReporter); ok {
+		r.ReportBlob(size)
+	}
+}
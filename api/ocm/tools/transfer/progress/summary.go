@@ -0,0 +1,64 @@
+package progress
+
+import (
+	"sync"
+)
+
+// Summary is a Handler accumulating the progress events
+// of a transfer.
+type Summary struct {
+	lock sync.Mutex
+	data SummaryData
+}
+
+// SummaryData is the accumulated information of a Summary.
+type SummaryData struct {
+	// Versions is the number of successfully transferred component versions.
+	Versions int `json:"versions"`
+	// FailedVersions is the number of component versions failed to transfer.
+	FailedVersions int `json:"failedVersions,omitempty"`
+	// Artifacts is the number of artifacts successfully copied by value.
+	Artifacts int `json:"artifacts"`
+	// FailedArtifacts is the number of artifacts failed to copy.
+	FailedArtifacts int `json:"failedArtifacts,omitempty"`
+	// Bytes is the number of bytes read for the copied artifacts.
+	Bytes int64 `json:"bytes"`
+	// Retries is the number of retried artifact transfers.
+	Retries int `json:"retries,omitempty"`
+}
+
+var _ Handler = (*Summary)(nil)
+
+func NewSummary() *Summary {
+	return &Summary{}
+}
+
+func (s *Summary) HandleEvent(e Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch ev := e.(type) {
+	case *ComponentFinished:
+		if ev.Error != nil {
+			s.data.FailedVersions++
+		} else {
+			s.data.Versions++
+		}
+	case *ArtifactFinished:
+		if ev.Error != nil {
+			s.data.FailedArtifacts++
+		} else {
+			s.data.Artifacts++
+		}
+		s.data.Bytes += ev.Copied
+	case *Retry:
+		s.data.Retries++
+	}
+}
+
+// Get returns the actual state of the summary.
+func (s *Summary) Get() SummaryData {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.data
+}
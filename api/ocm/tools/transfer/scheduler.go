@@ -0,0 +1,268 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/attrs/maxworkersattr"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// scheduler coordinates the transfer of a component version closure.
+// If more than one worker is configured (see maxworkersattr), independent
+// references and the artifacts of a component version are processed
+// concurrently. Only the artifact transfer and the resolution of references
+// occupy a worker, so that component versions waiting for their references
+// never block the processing of the closure.
+// Every component version is finalized only after all its references
+// have been transferred. Component versions referenced multiple times
+// are transferred only once, other referencing versions wait for the
+// completion. To detect reference cycles spanning concurrent walks,
+// which would otherwise wait for each other forever, the scheduler keeps
+// track of the references between the versions in progress.
+type scheduler struct {
+	lock    sync.Mutex
+	workers chan struct{}
+	jobs    map[common.NameVersion]*job
+	refs    map[common.NameVersion][]common.NameVersion
+}
+
+type job struct {
+	done chan struct{}
+	err  error
+}
+
+func newJob() *job {
+	return &job{done: make(chan struct{})}
+}
+
+func (j *job) isDone() bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (j *job) finish(err error) {
+	j.err = err
+	close(j.done)
+}
+
+// Wait waits for the completion of the job and returns its result.
+func (j *job) Wait() error {
+	<-j.done
+	return j.err
+}
+
+var schedulerKey = reflect.TypeOf(scheduler{})
+
+func newScheduler(ctx datacontext.Context) (*scheduler, error) {
+	n, err := maxworkersattr.Get(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get max workers attribute")
+	}
+	if n < 1 {
+		n = maxworkersattr.SingleWorker
+	}
+	return &scheduler{
+		workers: make(chan struct{}, n),
+		jobs:    map[common.NameVersion]*job{},
+		refs:    map[common.NameVersion][]common.NameVersion{},
+	}, nil
+}
+
+// withScheduler provides a context with a scheduler. An already
+// configured scheduler is kept.
+func withScheduler(ctx context.Context, octx ocm.Context) (context.Context, error) {
+	if ctx.Value(schedulerKey) != nil {
+		return ctx, nil
+	}
+	s, err := newScheduler(octx)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, schedulerKey, s), nil
+}
+
+func getScheduler(ctx context.Context, octx ocm.Context) (*scheduler, error) {
+	if s := ctx.Value(schedulerKey); s != nil {
+		return s.(*scheduler), nil
+	}
+	return newScheduler(octx)
+}
+
+// IsSequential reports whether everything is processed sequentially.
+func (s *scheduler) IsSequential() bool {
+	return cap(s.workers) <= 1
+}
+
+// Add adds a component version to the walking state.
+// If it is added it returns a job, which must be finished after the
+// version has been processed. If the version is already in progress or has
+// been processed, the job of this version is returned, which can be used to wait
+// for its completion. If waiting for this job would close a reference cycle
+// with the version in progress, a recursion error is returned instead.
+func (s *scheduler) Add(state *WalkingState, nv common.NameVersion) (bool, *job, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if state.History.Contains(nv) {
+		return false, nil, errors.ErrRecusion(ocm.KIND_COMPONENTVERSION, nv, state.History)
+	}
+	hist := state.History
+	// use a copy to avoid sharing the history array among concurrent
+	// walks.
+	state.History = state.History.Append(nv)
+	if !state.Closure.Add(nv) {
+		j := s.jobs[nv]
+		if j != nil && !j.isDone() && len(hist) > 0 {
+			parent := hist[len(hist)-1]
+			if s.reaches(nv, parent, map[common.NameVersion]bool{}) {
+				return false, nil, errors.ErrRecusion(ocm.KIND_COMPONENTVERSION, nv, hist)
+			}
+			s.refs[parent] = append(s.refs[parent], nv)
+		}
+		return false, j, nil
+	}
+	if len(hist) > 0 {
+		parent := hist[len(hist)-1]
+		s.refs[parent] = append(s.refs[parent], nv)
+	}
+	j := newJob()
+	s.jobs[nv] = j
+	return true, j, nil
+}
+
+// reaches checks whether the target version is reachable from the
+// given version following the references of versions still in progress.
+func (s *scheduler) reaches(nv, target common.NameVersion, visited map[common.NameVersion]bool) bool {
+	if nv == target {
+		return true
+	}
+	if visited[nv] {
+		return false
+	}
+	visited[nv] = true
+	if j := s.jobs[nv]; j == nil || j.isDone() {
+		return false
+	}
+	for _, r := range s.refs[nv] {
+		if s.reaches(r, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// Go executes the given function in the background, if concurrent
+// processing is enabled. Otherwise, it is executed synchronously.
+func (s *scheduler) Go(f func() error) *job {
+	j := newJob()
+	if s.IsSequential() {
+		j.finish(f())
+		return j
+	}
+	go func() {
+		var err error
+		defer func() { j.finish(err) }()
+		err = f()
+	}()
+	return j
+}
+
+// Work executes the given function occupying a worker.
+func (s *scheduler) Work(ctx context.Context, f func() error) error {
+	select {
+	case s.workers <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.workers }()
+	return f()
+}
+
+// runTasks executes a function for all given tasks. In sequential mode
+// the tasks are processed in order and the first error aborts the processing.
+// Otherwise, all tasks are started concurrently, if requested occupying a
+// worker. The output of the tasks is forwarded to the printer of the context
+// in task order, regardless of their completion order, and all errors
+// are reported.
+func runTasks[T any](ctx context.Context, s *scheduler, useWorker bool, tasks []T, f func(ctx context.Context, task T) error) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	if s.IsSequential() {
+		for _, t := range tasks {
+			if err := f(ctx, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	seq := newOutputSequencer(common.GetPrinter(ctx))
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i, t := range tasks {
+		p, buf := common.NewBufferedPrinter()
+		wg.Go(func() {
+			defer seq.Done(i, buf)
+			tctx := common.WithPrinter(ctx, p)
+			if useWorker {
+				errs[i] = s.Work(tctx, func() error { return f(tctx, t) })
+			} else {
+				errs[i] = f(tctx, t)
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// outputSequencer collects the output of concurrently executed tasks
+// and forwards it to a printer in task order as soon as all preceding
+// tasks are finished.
+type outputSequencer struct {
+	lock    sync.Mutex
+	printer common.Printer
+	next    int
+	done    map[int]*bytes.Buffer
+}
+
+func newOutputSequencer(p common.Printer) *outputSequencer {
+	return &outputSequencer{
+		printer: p,
+		done:    map[int]*bytes.Buffer{},
+	}
+}
+
+// Done marks the task with the given index as finished providing
+// its output.
+func (s *outputSequencer) Done(i int, buf *bytes.Buffer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.done[i] = buf
+	for {
+		b, ok := s.done[s.next]
+		if !ok {
+			return
+		}
+		delete(s.done, s.next)
+		s.next++
+		for _, l := range strings.SplitAfter(b.String(), "\n") {
+			if l != "" {
+				s.printer.Printf("%s", l)
+			}
+		}
+	}
+}
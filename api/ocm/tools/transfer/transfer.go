@@ -1,6 +1,7 @@
 package transfer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -12,10 +13,13 @@ import (
 	"ocm.software/ocm/api/ocm/compdesc"
 	ocmcpi "ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/none"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
 	cpi "ocm.software/ocm/api/ocm/internal"
 	"ocm.software/ocm/api/ocm/tools/transfer/internal"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
-	"ocm.software/ocm/api/utils/concurrency"
 	"ocm.software/ocm/api/utils/errkind"
 	common "ocm.software/ocm/api/utils/misc"
 	runtimeutil "ocm.software/ocm/api/utils/runtime"
@@ -34,7 +38,27 @@ func TransferVersionWithContext(ctx context.Context, closure TransportClosure, s
 		closure = TransportClosure{}
 	}
 	state := WalkingState{Closure: closure}
-	return transferVersion(ctx, Logger(src), state, src, tgt, handler)
+	ctx, err := withScheduler(ctx, src.GetContext())
+	if err != nil {
+		return err
+	}
+	if j := journalFor(handler); j != nil {
+		err = j.BindTarget(journalTarget(tgt))
+		if err != nil {
+			return err
+		}
+	}
+	log := Logger(src)
+	err = transferVersion(ctx, log, state, src, tgt, handler)
+	if err != nil {
+		return err
+	}
+	// the target has already been written, therefore a failing
+	// notification does not fail the transfer.
+	if err := pubsub.NotifyEvents(tgt, pubsub.NewTransferCompletedEvent(tgt, src)); err != nil {
+		log.Warn("cannot publish transfer event", "error", err.Error())
+	}
+	return nil
 }
 
 func transferVersion(ctx context.Context, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) (rerr error) {
@@ -45,9 +69,25 @@ func transferVersion(ctx context.Context, log logging.Logger, state WalkingState
 	}
 	nv := common.VersionedElementKey(src)
 	log = log.WithValues("history", state.History.String(), "version", nv)
-	if ok, err := state.Add(ocm.KIND_COMPONENTVERSION, nv); !ok {
+	sched, err := getScheduler(ctx, src.GetContext())
+	if err != nil {
 		return err
 	}
+	added, job, err := sched.Add(&state, nv)
+	if !added {
+		if err != nil || job == nil {
+			return err
+		}
+		// wait for the completion of the version, which is transferred
+		// concurrently for another referencing version.
+		select {
+		case <-job.done:
+			return job.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	defer func() { job.finish(rerr) }()
 	log.Info("transferring version")
 	printer.Printf("transferring version %q...\n", nv)
 	if handler == nil {
@@ -58,6 +98,25 @@ func transferVersion(ctx context.Context, log logging.Logger, state WalkingState
 		}
 	}
 
+	// versions skipped according to the journal are not reported
+	// to the progress handler, because nothing is transferred.
+	j := journalFor(handler)
+	if j != nil && j.IsCompleted(nv) {
+		printer.Printf("  version %q already transferred according to journal -> skip\n", nv)
+		return nil
+	}
+	progress.Report(ctx, &progress.ComponentStarted{ComponentVersionEvent: progress.ComponentVersionEvent{ComponentVersion: nv}})
+	defer func() {
+		progress.Report(ctx, &progress.ComponentFinished{ComponentVersionEvent: progress.ComponentVersionEvent{ComponentVersion: nv}, Error: rerr})
+	}()
+	if j != nil {
+		defer func() {
+			if rerr == nil {
+				rerr = j.Completed(nv)
+			}
+		}()
+	}
+
 	var finalize finalizer.Finalizer
 	defer finalize.FinalizeWithErrorPropagation(&rerr)
 
@@ -69,111 +128,68 @@ func transferVersion(ctx context.Context, log logging.Logger, state WalkingState
 	}
 	finalize.Close(comp, "closing target component")
 
-	var ok bool
 	t, err := comp.LookupVersion(src.GetVersion())
 	finalize.Close(t, "existing target version")
+	if err != nil {
+		if !errors.IsErrNotFound(err) {
+			return errors.Wrapf(err, "%s: creating target version", state.History)
+		}
+		t = nil
+	}
 
 	// references have always to be handled, because of potentially different
 	// transport modes, which could affect the desired access methods in
 	// the target environment.
-
-	// doTransport controls, whether the transport of the local component
-	// version has to be re-considered.
-	doTransport := true
-
-	// doMerge controls. whether a potential current version in the target
-	// environment has to be merged into the transported one.
-	doMerge := false
-
-	// doCopy controls, whether the artifact content has to be considered.
-	doCopy := true
-
+	vt, err := decideVersionTransport(src, d, t, handler)
 	if err != nil {
-		if errors.IsErrNotFound(err) {
-			t, err = comp.NewVersion(src.GetVersion())
-			finalize.Close(t, "new target version")
-		}
-	} else {
-		ok, err = handler.EnforceTransport(src, t)
+		return err
+	}
+	if vt.report != "" {
+		printer.Printf(vt.report, nv)
+	}
+	switch {
+	case vt.Action == ACTION_ABORT:
+		return errors.ErrAlreadyExists(ocm.KIND_COMPONENTVERSION, nv.String())
+	case !vt.References:
+		return nil
+	}
+
+	if t == nil {
+		t, err = comp.NewVersion(src.GetVersion())
+		finalize.Close(t, "new target version")
 		if err != nil {
-			return err
-		}
-		if ok {
-			//  execute transport as if the component version were not present
-			//  on the target side.
-		} else {
-			// determine transport mode for component version present
-			// on the target side.
-			if eq := d.Equivalent(t.GetDescriptor()); eq.IsHashEqual() {
-				if eq.IsEquivalent() {
-					if !needsResourceTransport(src, d, t.GetDescriptor(), handler) {
-						printer.Printf("  version %q already present -> skip transport\n", nv)
-						doTransport = false
-					} else {
-						printer.Printf("  version %q already present -> but requires resource transport\n", nv)
-					}
-				} else {
-					ok, err = handler.UpdateVersion(src, t)
-					if err != nil {
-						return err
-					}
-					if !ok {
-						printer.Printf("  version %q requires update of volatile data, but skipped\n", nv)
-						return nil
-					}
-					ok, err = handler.OverwriteVersion(src, t)
-					if ok {
-						printer.Printf("  warning: version %q already present, but transport enforced by overwrite option)\n", nv)
-						doMerge = false
-						doCopy = true
-					} else {
-						printer.Printf("  updating volatile properties of %q\n", nv)
-						doMerge = true
-						doCopy = false
-					}
-				}
-			} else {
-				msg := "  version %q already present, but"
-				if eq.IsLocalHashEqual() {
-					if eq.IsArtifactDetectable() {
-						msg += " differs because some artifact digests are changed"
-					} else {
-						msg += " might differ, because not all artifact digests are known"
-					}
-				} else {
-					if eq.IsArtifactDetectable() {
-						if eq.IsArtifactEqual() {
-							msg += " differs because signature relevant properties have been changed"
-						} else {
-							msg += " differs because some artifacts and signature relevant properties have been changed"
-						}
-					} else {
-						msg += "differs because signature relevant properties have been changed (and not all artifact digests are known)"
-					}
-				}
-				ok, err = handler.OverwriteVersion(src, t)
-				if ok {
-					doMerge = false
-					printer.Printf("warning: "+msg+" (transport enforced by overwrite option)\n", nv)
-				} else {
-					printer.Printf(msg+" -> transport aborted (use option overwrite option to enforce transport)\n", nv)
-					return errors.ErrAlreadyExists(ocm.KIND_COMPONENTVERSION, nv.String())
-				}
-			}
+			return errors.Wrapf(err, "%s: creating target version", state.History)
 		}
 	}
-	if err != nil {
-		return errors.Wrapf(err, "%s: creating target version", state.History)
-	}
 
+	// references are transferred concurrently to the artifacts of the
+	// component version, if concurrent processing is enabled.
+	// The output of the references and the artifacts is sequenced
+	// according to the sequential processing.
 	list := errors.ErrListf("component references for %s", nv)
-	if err := transferReferences(ctx, log, state, src, tgt, handler, d); err != nil {
-		return err
+	out := newOutputSequencer(printer)
+	rctx, rbuf := ctx, (*bytes.Buffer)(nil)
+	if !sched.IsSequential() {
+		var p common.Printer
+		p, rbuf = common.NewBufferedPrinter()
+		rctx = common.WithPrinter(ctx, p)
+	}
+	refs := sched.Go(func() error {
+		if rbuf != nil {
+			defer out.Done(0, rbuf)
+		}
+		return transferReferences(rctx, log, state, src, tgt, handler, d)
+	})
+	defer refs.Wait()
+	if sched.IsSequential() {
+		if err := refs.Wait(); err != nil {
+			return err
+		}
 	}
 
-	if doTransport {
+	if vt.Transport {
 		var n *compdesc.ComponentDescriptor
-		if doMerge {
+		if vt.Merge {
 			log.WithValues("source", src.GetDescriptor(), "target", t.GetDescriptor()).Info("  applying 2-way merge")
 			n, err = internal.PrepareDescriptor(log, src.GetContext(), src.GetDescriptor(), t.GetDescriptor())
 			if err != nil {
@@ -198,35 +214,195 @@ func transferVersion(ctx context.Context, log logging.Logger, state WalkingState
 			}
 		}
 
-		if !doMerge || doCopy {
-			err = copyVersion(ctx, printer, log, state.History, src, t, n, handler)
-			if err != nil {
-				return err
-			}
+		cprinter, cbuf := printer, (*bytes.Buffer)(nil)
+		if !sched.IsSequential() {
+			cprinter, cbuf = common.NewBufferedPrinter()
+		}
+		if !vt.Merge || vt.Copy {
+			err = copyVersion(ctx, cprinter, log, state.History, src, t, n, handler)
 		} else {
 			*t.GetDescriptor() = *n
 		}
+		if cbuf != nil {
+			out.Done(1, cbuf)
+		}
+		if err := refs.Wait(); err != nil {
+			return err
+		}
+		if err != nil {
+			return err
+		}
 
 		printer.Printf("...adding component version...\n")
 		log.Info("  adding component version")
 		list.Add(comp.AddVersion(t))
+	} else if err := refs.Wait(); err != nil {
+		return err
 	}
 	return list.Result()
 }
 
-func transferReferences(ctx context.Context, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler, d *compdesc.ComponentDescriptor) error {
-	if len(d.References) > 0 {
-		if err := concurrency.RunInWorkerPool(ctx, src.GetContext(), d.References, func(ctx context.Context, ref compdesc.Reference) error {
-			return transferReference(ctx, log, state, src, tgt, handler, ref)
-		}); err != nil {
-			return err
+// versionTransport describes how a transfer handles a component version
+// with respect to the version already present in the target repository.
+// It is used by the transfer as well as by the transfer plan, to keep
+// both in sync.
+type versionTransport struct {
+	// Action is the plan action for the component version.
+	Action string
+	// Message describes the reason for the action in a transfer plan.
+	Message string
+	// report is the format of the transfer output for the version.
+	report string
+
+	// Transport controls, whether the transport of the local component
+	// version has to be re-considered.
+	Transport bool
+	// Merge controls, whether a potential current version in the target
+	// environment has to be merged into the transported one.
+	Merge bool
+	// Copy controls, whether the artifact content has to be considered.
+	Copy bool
+	// References controls, whether the references have to be handled.
+	References bool
+}
+
+// decideVersionTransport determines the transport mode for a component
+// version. t is the version found in the target, or nil, if it is not
+// present, yet.
+func decideVersionTransport(src ocmcpi.ComponentVersionAccess, d *compdesc.ComponentDescriptor, t ocmcpi.ComponentVersionAccess, handler TransferHandler) (*versionTransport, error) {
+	vt := &versionTransport{Transport: true, Copy: true, References: true}
+	if t == nil {
+		vt.Action = ACTION_CREATE
+		return vt, nil
+	}
+
+	ok, err := handler.EnforceTransport(src, t)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		//  execute transport as if the component version were not present
+		//  on the target side.
+		vt.Action = ACTION_OVERWRITE
+		vt.Message = "transport enforced"
+		return vt, nil
+	}
+
+	// determine transport mode for component version present
+	// on the target side.
+	eq := d.Equivalent(t.GetDescriptor())
+	if eq.IsHashEqual() {
+		if eq.IsEquivalent() {
+			if !needsResourceTransport(src, d, t.GetDescriptor(), handler) {
+				vt.Action = ACTION_SKIP
+				vt.Message = "already present"
+				vt.report = "  version %q already present -> skip transport\n"
+				vt.Transport = false
+			} else {
+				vt.Action = ACTION_UPDATE
+				vt.Message = "already present, but requires resource transport"
+				vt.report = "  version %q already present -> but requires resource transport\n"
+			}
+			return vt, nil
+		}
+		ok, err = handler.UpdateVersion(src, t)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			vt.Action = ACTION_SKIP
+			vt.Message = "requires update of volatile data, but skipped"
+			vt.report = "  version %q requires update of volatile data, but skipped\n"
+			vt.Transport = false
+			vt.References = false
+			return vt, nil
+		}
+		ok, err = handler.OverwriteVersion(src, t)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			vt.Action = ACTION_OVERWRITE
+			vt.Message = "already present, but transport enforced by overwrite option"
+			vt.report = "  warning: version %q already present, but transport enforced by overwrite option)\n"
+		} else {
+			vt.Action = ACTION_UPDATE
+			vt.Message = "updating volatile properties"
+			vt.report = "  updating volatile properties of %q\n"
+			vt.Merge = true
+			vt.Copy = false
+		}
+		return vt, nil
+	}
+
+	msg := "already present, but"
+	if eq.IsLocalHashEqual() {
+		if eq.IsArtifactDetectable() {
+			msg += " differs because some artifact digests are changed"
+		} else {
+			msg += " might differ, because not all artifact digests are known"
+		}
+	} else {
+		if eq.IsArtifactDetectable() {
+			if eq.IsArtifactEqual() {
+				msg += " differs because signature relevant properties have been changed"
+			} else {
+				msg += " differs because some artifacts and signature relevant properties have been changed"
+			}
+		} else {
+			msg += " differs because signature relevant properties have been changed (and not all artifact digests are known)"
 		}
 	}
+	ok, err = handler.OverwriteVersion(src, t)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		vt.Action = ACTION_ABORT
+		vt.Message = msg
+		vt.report = "  version %q " + msg + " -> transport aborted (use option overwrite option to enforce transport)\n"
+		vt.Transport = false
+		vt.References = false
+		return vt, nil
+	}
+	vt.Action = ACTION_OVERWRITE
+	vt.Message = msg + " (transport enforced by overwrite option)"
+	vt.report = "warning:   version %q " + msg + " (transport enforced by overwrite option)\n"
+	return vt, nil
+}
+
+// journalTarget provides the identity of a target repository
+// used to bind a checkpoint journal.
+func journalTarget(tgt ocmcpi.Repository) string {
+	return tgt.GetSpecification().AsUniformSpec(tgt.GetContext()).String()
+}
+
+// journalFor provides the checkpoint journal configured for a
+// transfer handler, or nil, if no journal is used.
+func journalFor(handler TransferHandler) *journal.Journal {
+	if p, ok := handler.(transferhandler.JournalProvider); ok {
+		return p.GetJournal()
+	}
 	return nil
 }
 
-func transferReference(ctx context.Context, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler, ref compdesc.Reference) error {
-	cv, shdlr, err := handler.TransferVersion(src.Repository(), src, &ref, tgt)
+func transferReferences(ctx context.Context, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler, d *compdesc.ComponentDescriptor) error {
+	sched, err := getScheduler(ctx, src.GetContext())
+	if err != nil {
+		return err
+	}
+	return runTasks(ctx, sched, false, d.References, func(ctx context.Context, ref compdesc.Reference) error {
+		return transferReference(ctx, log, sched, state, src, tgt, handler, ref)
+	})
+}
+
+func transferReference(ctx context.Context, log logging.Logger, sched *scheduler, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler, ref compdesc.Reference) error {
+	var cv ocm.ComponentVersionAccess
+	var shdlr TransferHandler
+	err := sched.Work(ctx, func() (err error) {
+		cv, shdlr, err = handler.TransferVersion(src.Repository(), src, &ref, tgt)
+		return err
+	})
 	if err != nil {
 		return errors.Wrapf(err, "%s: nested component %s[%s:%s]",
 			state.History, ref.GetName(), ref.ComponentName, ref.GetVersion())
@@ -247,6 +423,10 @@ func CopyVersion(printer common.Printer, log logging.Logger, hist common.History
 }
 
 func CopyVersionWithContext(cctx context.Context, printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, handler TransferHandler) (rerr error) {
+	cctx, err := withScheduler(cctx, src.GetContext())
+	if err != nil {
+		return err
+	}
 	return copyVersion(cctx, printer, log, hist, src, t, src.GetDescriptor().Copy(), handler)
 }
 
@@ -322,7 +502,7 @@ func copyVersionWithWorkers(
 		tasks = append(tasks, transferTask{
 			id: fmt.Sprintf("resource-%d", i),
 			exec: func(ctx context.Context) error {
-				return copyResource(src, finalize, hist, handler, curDesc, srcDesc, printer, log, target, r, i)
+				return copyResource(ctx, src, finalize, hist, handler, curDesc, srcDesc, common.GetPrinter(ctx), log, target, r, i)
 			},
 		})
 	}
@@ -330,13 +510,16 @@ func copyVersionWithWorkers(
 		tasks = append(tasks, transferTask{
 			id: fmt.Sprintf("source-%d", i),
 			exec: func(ctx context.Context) error {
-				return copySource(ctx, src, hist, s, handler, printer, log, i, target)
+				return copySource(ctx, src, hist, s, handler, common.GetPrinter(ctx), log, i, target)
 			},
 		})
 	}
 
-	// Run all tasks using the generic worker pool
-	return concurrency.RunInWorkerPool(ctx, src.GetContext(), tasks, func(ctx context.Context, t transferTask) error {
+	sched, err := getScheduler(ctx, src.GetContext())
+	if err != nil {
+		return err
+	}
+	return runTasks(common.WithPrinter(ctx, printer), sched, true, tasks, func(ctx context.Context, t transferTask) error {
 		log.Debug("starting transfer task", "task", t.id)
 		if err := t.exec(ctx); err != nil {
 			return fmt.Errorf("%s failed: %w", t.id, err)
@@ -370,8 +553,7 @@ func copySource(cctx context.Context, src ocm.ComponentVersionAccess, hist commo
 		if ok {
 			// sources do not have digests so far, so they have to copied, always.
 			hint := ocmcpi.ArtifactNameHint(a, src)
-			notifyArtifactInfo(printer, log, "source", i, srcAccess.Meta(), hint)
-			err = errors.Join(err, handler.HandleTransferSource(srcAccess, m, hint, t))
+			err = errors.Join(err, transferSource(cctx, src, handler, printer, log, i, srcAccess, a, m, hint, t))
 		}
 		err = errors.Join(err, m.Close())
 	}
@@ -384,7 +566,7 @@ func copySource(cctx context.Context, src ocm.ComponentVersionAccess, hist commo
 	return nil
 }
 
-func copyResource(src ocm.ComponentVersionAccess, finalize *finalizer.Finalizer, hist common.History, handler TransferHandler, currentDesc, sourceDesc *compdesc.ComponentDescriptor, printer common.Printer, log logging.Logger, t ocm.ComponentVersionAccess, r cpi.ResourceAccess, i int) error {
+func copyResource(ctx context.Context, src ocm.ComponentVersionAccess, finalize *finalizer.Finalizer, hist common.History, handler TransferHandler, currentDesc, sourceDesc *compdesc.ComponentDescriptor, printer common.Printer, log logging.Logger, t ocm.ComponentVersionAccess, r cpi.ResourceAccess, i int) error {
 	nested := finalize.Nested()
 	a, err := r.Access()
 	if err != nil {
@@ -424,8 +606,7 @@ func copyResource(src ocm.ComponentVersionAccess, finalize *finalizer.Finalizer,
 				msgs = append(msgs, "overwrite")
 			}
 		}
-		notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, msgs...)
-		return handler.HandleTransferResource(r, m, hint, t)
+		return transferResource(ctx, src, handler, printer, log, i, r, a, m, hint, t, sourceDesc, msgs...)
 	}
 
 	if err := t.SetResource(r.Meta(), old.Access, ocm.ModifyElement(), ocm.SkipVerify(), ocm.DisableExtraIdentityDefaulting()); err != nil {
@@ -434,3 +615,78 @@ func copyResource(src ocm.ComponentVersionAccess, finalize *finalizer.Finalizer,
 	notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already present")
 	return nil
 }
+
+// transferResource transfers the blob of a resource, if it has not already
+// been transferred according to the checkpoint journal of the handler.
+// Only blobs uploaded to an external (non-local) target access are recorded,
+// local blobs are bound to the storage of the target component version and
+// must be added again if the version has not been completed.
+func transferResource(ctx context.Context, src ocm.ComponentVersionAccess, handler TransferHandler, printer common.Printer, log logging.Logger, i int, r cpi.ResourceAccess, a ocm.AccessSpec, m ocmcpi.AccessMethod, hint string, t ocm.ComponentVersionAccess, sourceDesc *compdesc.ComponentDescriptor, msgs ...interface{}) error {
+	j := journalFor(handler)
+	nv := common.VersionedElementKey(src)
+	id := r.Meta().GetIdentity(sourceDesc.Resources)
+	if j != nil {
+		if acc := j.GetArtifact(nv, journal.ARTIFACT_RESOURCE, id, r.Meta().Digest, a); acc != nil {
+			if err := t.SetResource(r.Meta(), acc, ocm.ModifyElement(), ocm.SkipVerify(), ocm.DisableExtraIdentityDefaulting()); err != nil {
+				return fmt.Errorf("failed to set resource based on journal %d: %w", i, err)
+			}
+			notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already transferred according to journal")
+			return nil
+		}
+	}
+	notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, msgs...)
+	err := handleWithProgress(ctx, artifactEvent(nv, progress.ARTIFACT_RESOURCE, i, id, r.Meta().GetType()), m, func(m ocmcpi.AccessMethod) error {
+		return handler.HandleTransferResource(r, m, hint, t)
+	})
+	if err != nil {
+		return err
+	}
+	if j != nil {
+		ta, err := t.GetResource(id)
+		if err != nil {
+			return err
+		}
+		acc, err := ta.Access()
+		if err != nil || acc.IsLocal(t.GetContext()) {
+			return err
+		}
+		return j.AddArtifact(nv, journal.ARTIFACT_RESOURCE, id, r.Meta().Digest, a, acc)
+	}
+	return nil
+}
+
+// transferSource transfers the blob of a source, if it has not already
+// been transferred according to the checkpoint journal of the handler.
+func transferSource(ctx context.Context, src ocm.ComponentVersionAccess, handler TransferHandler, printer common.Printer, log logging.Logger, i int, s cpi.SourceAccess, a ocm.AccessSpec, m ocmcpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
+	j := journalFor(handler)
+	nv := common.VersionedElementKey(src)
+	id := s.Meta().GetIdentity(src.GetDescriptor().Sources)
+	if j != nil {
+		if acc := j.GetArtifact(nv, journal.ARTIFACT_SOURCE, id, nil, a); acc != nil {
+			if err := t.SetSource(s.Meta(), acc, ocm.DisableExtraIdentityDefaulting()); err != nil {
+				return fmt.Errorf("failed to set source based on journal %d: %w", i, err)
+			}
+			notifyArtifactInfo(printer, log, "source", i, s.Meta(), hint, "already transferred according to journal")
+			return nil
+		}
+	}
+	notifyArtifactInfo(printer, log, "source", i, s.Meta(), hint)
+	err := handleWithProgress(ctx, artifactEvent(nv, progress.ARTIFACT_SOURCE, i, id, s.Meta().GetType()), m, func(m ocmcpi.AccessMethod) error {
+		return handler.HandleTransferSource(s, m, hint, t)
+	})
+	if err != nil {
+		return err
+	}
+	if j != nil {
+		ts, err := t.GetSource(id)
+		if err != nil {
+			return err
+		}
+		acc, err := ts.Access()
+		if err != nil || acc.IsLocal(t.GetContext()) {
+			return err
+		}
+		return j.AddArtifact(nv, journal.ARTIFACT_SOURCE, id, nil, a, acc)
+	}
+	return nil
+}
@@ -5,12 +5,15 @@ import (
 
 	"github.com/mandelsoft/goutils/errors"
 
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/compdesc"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
 	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/utils/accessio"
 )
@@ -81,12 +84,17 @@ func (h *Handler) TransferSource(src ocm.ComponentVersionAccess, a ocm.AccessSpe
 }
 
 func (h *Handler) HandleTransferResource(r ocm.ResourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
+	if attr := h.opts.GetReferrers(); attr != nil {
+		if c, ok := accspeccpi.GetAccessMethodImplementation(m).(referrersattr.Consumer); ok {
+			c.SetReferrers(attr)
+		}
+	}
 	blob, err := accspeccpi.BlobAccessForAccessMethod(m)
 	if err != nil {
 		return err
 	}
 	defer blob.Close()
-	return accessio.Retry(h.opts.GetRetries(), time.Second, func() error {
+	return h.retry(m, func() error {
 		return t.SetResourceBlob(r.Meta(), blob, hint, h.GlobalAccess(t.GetContext(), m), ocm.SkipVerify(), ocm.DisableExtraIdentityDefaulting())
 	})
 }
@@ -97,11 +105,31 @@ func (h *Handler) HandleTransferSource(r ocm.SourceAccess, m cpi.AccessMethod, h
 		return err
 	}
 	defer blob.Close()
-	return accessio.Retry(h.opts.GetRetries(), time.Second, func() error {
+	return h.retry(m, func() error {
 		return t.SetSourceBlob(r.Meta(), blob, hint, h.GlobalAccess(t.GetContext(), m), ocm.DisableExtraIdentityDefaulting())
 	})
 }
 
+// retry executes a transfer step with the configured number of retries.
+// Retries are reported to the progress handler of the transfer, if the
+// access method supports it.
+func (h *Handler) retry(m cpi.AccessMethod, f func() error) error {
+	var last error
+	attempt := 0
+	return accessio.Retry(h.opts.GetRetries(), time.Second, func() error {
+		if attempt > 0 {
+			progress.ReportRetry(m, attempt, last)
+		}
+		attempt++
+		last = f()
+		return last
+	})
+}
+
+func (h *Handler) GetJournal() *journal.Journal {
+	return h.opts.GetJournal()
+}
+
 func (h *Handler) GlobalAccess(ctx ocm.Context, m ocm.AccessMethod) ocm.AccessSpec {
 	if h.opts.IsKeepGlobalAccess() {
 		return m.AccessSpec().GlobalAccessSpec(ctx)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	. "github.com/mandelsoft/goutils/testutils"
 	. "github.com/onsi/ginkgo/v2"
@@ -18,6 +20,7 @@ import (
 	"ocm.software/ocm/api/oci/artdesc"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
@@ -68,6 +71,32 @@ func (o *optionsChecker) ApplyTransferOption(options transferhandler.TransferOpt
 	return nil
 }
 
+// cycleHandler delays the resolution of the nested references until
+// both walks of a reference cycle are in progress.
+type cycleHandler struct {
+	*standard.Handler
+	lock  sync.Mutex
+	count int
+	both  chan struct{}
+}
+
+func (h *cycleHandler) TransferVersion(repo ocm.Repository, src ocm.ComponentVersionAccess, meta *compdesc.Reference, tgt ocm.Repository) (ocm.ComponentVersionAccess, transferhandler.TransferHandler, error) {
+	if src != nil && src.GetName() != "acme.org/a" {
+		h.lock.Lock()
+		h.count++
+		if h.count == 2 {
+			close(h.both)
+		}
+		h.lock.Unlock()
+		select {
+		case <-h.both:
+		case <-time.After(time.Second):
+		}
+	}
+	cv, _, err := h.Handler.TransferVersion(repo, src, meta, tgt)
+	return cv, h, err
+}
+
 var _ = Describe("Transfer handler", func() {
 	var env *Builder
 	var ldesc *artdesc.Descriptor
@@ -595,6 +624,83 @@ warning:   version "github.com/mandelsoft/test:v1" already present, but differs
 			_ = buf
 		})
 
+		It("transfers reference closure concurrently with deterministic output", func() {
+			parentSrc := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH2, 0, env))
+			defer Close(parentSrc, "parent source")
+			cv := Must(parentSrc.LookupComponentVersion(COMPONENT2, VERSION))
+			defer Close(cv, "source cv")
+			childSrc := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+			defer Close(childSrc, "child source")
+
+			transferClosure := func(out string) string {
+				tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, out, 0o700, accessio.FormatDirectory, env))
+				defer Close(tgt, "target")
+				p, buf := common.NewBufferedPrinter()
+				MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.ResourcesByValue(), standard.Resolver(childSrc), transfer.WithPrinter(p)))
+				Expect(Must(tgt.ExistsComponentVersion(COMPONENT, VERSION))).To(BeTrue())
+				Expect(Must(tgt.ExistsComponentVersion(COMPONENT2, VERSION))).To(BeTrue())
+				return buf.String()
+			}
+
+			seq := transferClosure(OUT + "_seq")
+			Expect(maxworkersattr.Set(env.OCMContext(), 4)).To(Succeed())
+			for i := 0; i < 5; i++ {
+				Expect(transferClosure(fmt.Sprintf("%s_conc%d", OUT, i))).To(Equal(seq))
+			}
+			Expect(seq).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test2:v1"...
+  transferring version "github.com/mandelsoft/test:v1"...
+  ...resource 0 testdata[PlainText]...
+  ...resource 1 artifact[ociImage](ocm/value:v2.0)...
+  ...adding component version...
+...adding component version...
+`))
+		})
+
+		It("detects reference cycles spanning concurrent walks", func(ctx SpecContext) {
+			env.OCMCommonTransport("/tmp/cycle", accessio.FormatDirectory, func() {
+				env.Component("acme.org/a", func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Reference("refb", "acme.org/b", VERSION)
+						env.Reference("refc", "acme.org/c", VERSION)
+					})
+				})
+				env.Component("acme.org/b", func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Reference("refc", "acme.org/c", VERSION)
+					})
+				})
+				env.Component("acme.org/c", func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Reference("refb", "acme.org/b", VERSION)
+					})
+				})
+			})
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, "/tmp/cycle", 0, env))
+			defer Close(src, "source")
+			cv := Must(src.LookupComponentVersion("acme.org/a", VERSION))
+			defer Close(cv, "source cv")
+
+			transferClosure := func(out string) error {
+				tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, out, 0o700, accessio.FormatDirectory, env))
+				defer Close(tgt, "target")
+				opts := &standard.Options{}
+				MustBeSuccessful(opts.Apply(standard.Recursive(), standard.Resolver(src)))
+				h := &cycleHandler{Handler: standard.NewDefaultHandler(opts), both: make(chan struct{})}
+				return transfer.TransferVersionWithContext(ctx, nil, cv, tgt, h)
+			}
+
+			Expect(transferClosure(OUT + "_seq")).To(MatchError(ContainSubstring("component version recursion")))
+			Expect(maxworkersattr.Set(env.OCMContext(), 4)).To(Succeed())
+			for i := 0; i < 5; i++ {
+				Expect(transferClosure(fmt.Sprintf("%s_conc%d", OUT, i))).To(MatchError(ContainSubstring("component version recursion")))
+			}
+		}, SpecTimeout(time.Minute))
+
 		It("honors cancellation during concurrent transfer", func() {
 			Expect(maxworkersattr.Set(env.OCMContext(), 4)).To(Succeed())
 
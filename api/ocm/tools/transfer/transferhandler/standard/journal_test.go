@@ -0,0 +1,177 @@
+package standard_test
+
+import (
+	"fmt"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const JOURNAL = "/tmp/journal.json"
+
+// failingHandler simulates an interrupted transfer by failing
+// the transfer of a dedicated resource.
+type failingHandler struct {
+	*standard.Handler
+	fail string
+}
+
+func (h *failingHandler) HandleTransferResource(r ocm.ResourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
+	if r.Meta().GetName() == h.fail {
+		return fmt.Errorf("interrupted")
+	}
+	return h.Handler.HandleTransferResource(r, m, hint, t)
+}
+
+var _ = Describe("Transfer journal", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					TestDataResource(env)
+					env.Resource("other", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "other data")
+					})
+				})
+			})
+			env.Component(COMPONENT2, func() {
+				env.Version(VERSION, func() {
+					env.Reference("ref", COMPONENT, VERSION)
+					env.Provider(PROVIDER)
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("skips completed component versions", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		j := Must(journal.Create(env, JOURNAL))
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.Journal(j)))
+
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(j.IsCompleted(common.NewNameVersion(COMPONENT, VERSION))).To(BeTrue())
+		Expect(j.IsCompleted(common.NewNameVersion(COMPONENT2, VERSION))).To(BeTrue())
+
+		p, buf := common.NewBufferedPrinter()
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.Journal(j), transfer.WithPrinter(p)))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test2:v1"...
+  version "github.com/mandelsoft/test2:v1" already transferred according to journal -> skip
+`))
+	})
+
+	It("rejects journal of other target", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		j := Must(journal.Create(env, JOURNAL))
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.Journal(j)))
+
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(j.GetTarget()).To(ContainSubstring(OUT))
+
+		other := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, "/tmp/other", 0o700, accessio.FormatDirectory, env))
+		defer Close(other, "other target")
+		ExpectError(transfer.Transfer(cv, other, standard.Recursive(), standard.Journal(j))).To(MatchError(ContainSubstring(`transfer journal belongs to target repository "` + j.GetTarget() + `"`)))
+	})
+
+	It("resumes an interrupted transfer", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		j := Must(journal.Create(env, JOURNAL))
+		opts := &standard.Options{}
+		MustBeSuccessful(opts.Apply(standard.Journal(j)))
+		h := &failingHandler{Handler: standard.NewDefaultHandler(opts), fail: "other"}
+		Expect(transfer.TransferVersion(nil, nil, cv, tgt, h)).NotTo(Succeed())
+
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(j.IsCompleted(common.NewNameVersion(COMPONENT, VERSION))).To(BeFalse())
+		// local blobs are not recorded, they are bound to the target version
+		Expect(len(j.GetData().ComponentVersions)).To(Equal(0))
+
+		p, buf := common.NewBufferedPrinter()
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Journal(j), transfer.WithPrinter(p)))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0 testdata[PlainText]...
+...resource 1 other[PlainText]...
+...adding component version...
+`))
+		Expect(j.IsCompleted(common.NewNameVersion(COMPONENT, VERSION))).To(BeTrue())
+
+		tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(tcv, "target cv")
+		r := Must(tcv.GetResource(metav1.NewIdentity("testdata")))
+		m := Must(r.AccessMethod())
+		defer Close(m, "method")
+		Expect(string(Must(m.Get()))).To(Equal(S_TESTDATA))
+	})
+
+	It("reuses recorded uploads", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		uploaded := ociartifact.New("ghcr.io/acme/testdata:v1")
+		r := Must(cv.GetResource(metav1.NewIdentity("testdata")))
+		j := journal.New()
+		MustBeSuccessful(j.AddArtifact(common.VersionedElementKey(cv), journal.ARTIFACT_RESOURCE, metav1.NewIdentity("testdata"), r.Meta().Digest, Must(r.Access()), uploaded))
+
+		p, buf := common.NewBufferedPrinter()
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Journal(j), transfer.WithPrinter(p)))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0 testdata[PlainText] (already transferred according to journal)
+...resource 1 other[PlainText]...
+...adding component version...
+`))
+
+		tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(tcv, "target cv")
+		acc := Must(Must(tcv.GetResource(metav1.NewIdentity("testdata"))).Access())
+		Expect(acc.GetType()).To(Equal(ociartifact.Type))
+	})
+})
@@ -9,7 +9,9 @@ import (
 	"github.com/mandelsoft/goutils/set"
 	"github.com/mandelsoft/goutils/sliceutils"
 
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/utils/runtime"
 )
@@ -32,6 +34,8 @@ type Options struct {
 	omitAccessTypes   set.Set[string]
 	omitArtifactTypes set.Set[string]
 	resolver          ocm.ComponentVersionResolver
+	journal           *journal.Journal
+	referrers         *referrersattr.Attribute
 }
 
 var (
@@ -49,6 +53,8 @@ var (
 	_ KeepGlobalAccessOption      = (*Options)(nil)
 	_ OmitAccessTypesOption       = (*Options)(nil)
 	_ OmitArtifactTypesOption     = (*Options)(nil)
+	_ JournalOption               = (*Options)(nil)
+	_ ReferrersOption             = (*Options)(nil)
 )
 
 type TransferOptionsCreator = transferhandler.SpecializedOptionsCreator[*Options, Options]
@@ -127,6 +133,16 @@ func (o *Options) ApplyTransferOption(target transferhandler.TransferOptions) er
 			opts.SetResolver(o.resolver)
 		}
 	}
+	if o.journal != nil {
+		if opts, ok := target.(JournalOption); ok {
+			opts.SetJournal(o.journal)
+		}
+	}
+	if o.referrers != nil {
+		if opts, ok := target.(ReferrersOption); ok {
+			opts.SetReferrers(o.referrers)
+		}
+	}
 	return nil
 }
 
@@ -217,6 +233,22 @@ func (o *Options) GetResolver() ocm.ComponentVersionResolver {
 	return o.resolver
 }
 
+func (o *Options) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
+func (o *Options) GetJournal() *journal.Journal {
+	return o.journal
+}
+
+func (o *Options) SetReferrers(attr *referrersattr.Attribute) {
+	o.referrers = attr
+}
+
+func (o *Options) GetReferrers() *referrersattr.Attribute {
+	return o.referrers
+}
+
 func (o *Options) SetStopOnExistingVersion(stopOnExistingVersion bool) {
 	o.stopOnExisting = &stopOnExistingVersion
 }
@@ -661,3 +693,65 @@ func AddOmittedArtifactTypes(list ...string) transferhandler.TransferOption {
 		list: slices.Clone(list),
 	}
 }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type JournalOption interface {
+	SetJournal(*journal.Journal)
+	GetJournal() *journal.Journal
+}
+
+type journalOption struct {
+	TransferOptionsCreator
+	journal *journal.Journal
+}
+
+func (o *journalOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(JournalOption); ok {
+		eff.SetJournal(o.journal)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "journal")
+	}
+}
+
+// Journal specifies a checkpoint journal used to record the progress of
+// a transfer. Component versions and artifacts already recorded as
+// transferred are skipped, which allows to resume an interrupted transfer.
+func Journal(j *journal.Journal) transferhandler.TransferOption {
+	return &journalOption{
+		journal: j,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type ReferrersOption interface {
+	SetReferrers(*referrersattr.Attribute)
+	GetReferrers() *referrersattr.Attribute
+}
+
+type referrersOption struct {
+	TransferOptionsCreator
+	attr *referrersattr.Attribute
+}
+
+func (o *referrersOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(ReferrersOption); ok {
+		eff.SetReferrers(o.attr)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "referrers")
+	}
+}
+
+// Referrers enables the transport of the artifacts referring to
+// OCI artifacts transported by value (OCI 1.1 referrers, for example
+// signatures or SBOM attestations). If artifact types are given, only
+// referrers with those types are transported. It overrides the
+// referrers attribute of the context for this transfer.
+func Referrers(artifactTypes ...string) transferhandler.TransferOption {
+	return &referrersOption{
+		attr: &referrersattr.Attribute{ArtifactTypes: slices.Clone(artifactTypes)},
+	}
+}
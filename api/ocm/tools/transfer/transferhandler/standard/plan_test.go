@@ -0,0 +1,91 @@
+package standard_test
+
+import (
+	"context"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+var _ = Describe("Transfer plan", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					TestDataResource(env)
+				})
+			})
+			env.Component(COMPONENT2, func() {
+				env.Version(VERSION, func() {
+					env.Reference("ref", COMPONENT, VERSION)
+					env.Provider(PROVIDER)
+					env.Resource("other", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "other data")
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("plans transfer without modifying the target", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		h := Must(standard.New(standard.Recursive()))
+		plan := Must(transfer.PlanVersion(context.Background(), nil, cv, tgt, h))
+		Expect(plan.Versions).To(HaveLen(2))
+
+		Expect(plan.Versions[0].Component).To(Equal(COMPONENT2))
+		Expect(plan.Versions[0].Action).To(Equal(transfer.ACTION_CREATE))
+		Expect(plan.Versions[0].Resources).To(HaveLen(1))
+		Expect(plan.Versions[0].Resources[0].Action).To(Equal(transfer.ACTION_COPY))
+		Expect(plan.Versions[0].Resources[0].Size).To(Equal(int64(len("other data"))))
+
+		Expect(plan.Versions[1].Component).To(Equal(COMPONENT))
+		Expect(plan.Versions[1].Action).To(Equal(transfer.ACTION_CREATE))
+		Expect(plan.Size()).To(Equal(int64(len("other data") + len(S_TESTDATA))))
+
+		Expect(Must(tgt.ComponentLister().GetComponents("", true))).To(BeEmpty())
+	})
+
+	It("plans skipping versions already present", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		MustBeSuccessful(transfer.Transfer(cv, tgt))
+
+		plan := Must(transfer.PlanVersion(context.Background(), nil, cv, tgt, nil))
+		Expect(plan.Versions).To(HaveLen(1))
+		Expect(plan.Versions[0].Action).To(Equal(transfer.ACTION_SKIP))
+		Expect(plan.Versions[0].Message).To(Equal("already present"))
+		Expect(plan.Size()).To(Equal(int64(0)))
+	})
+})
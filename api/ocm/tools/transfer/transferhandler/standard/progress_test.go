@@ -0,0 +1,125 @@
+package standard_test
+
+import (
+	"sync"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("Transfer progress", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					TestDataResource(env)
+				})
+			})
+			env.Component(COMPONENT2, func() {
+				env.Version(VERSION, func() {
+					env.Reference("ref", COMPONENT, VERSION)
+					env.Provider(PROVIDER)
+					env.Resource("other", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "other data")
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("reports progress events", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		var lock sync.Mutex
+		var events []progress.Event
+		summary := progress.NewSummary()
+		collect := progress.HandlerFunc(func(e progress.Event) {
+			lock.Lock()
+			defer lock.Unlock()
+			events = append(events, e)
+		})
+
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), transfer.WithProgress(progress.Handlers{collect, summary})))
+
+		finished := map[common.NameVersion]error{}
+		artifacts := map[common.NameVersion]*progress.ArtifactFinished{}
+		started := 0
+		for _, e := range events {
+			switch ev := e.(type) {
+			case *progress.ComponentStarted:
+				started++
+			case *progress.ComponentFinished:
+				finished[ev.GetComponentVersion()] = ev.Error
+			case *progress.ArtifactFinished:
+				artifacts[ev.GetComponentVersion()] = ev
+			}
+		}
+		Expect(started).To(Equal(2))
+		Expect(finished).To(Equal(map[common.NameVersion]error{
+			common.NewNameVersion(COMPONENT, VERSION):  nil,
+			common.NewNameVersion(COMPONENT2, VERSION): nil,
+		}))
+
+		a := artifacts[common.NewNameVersion(COMPONENT2, VERSION)]
+		Expect(a).NotTo(BeNil())
+		Expect(a.Kind).To(Equal(progress.ARTIFACT_RESOURCE))
+		Expect(a.Identity).To(Equal(metav1.Identity{metav1.SystemIdentityName: "other"}))
+		Expect(a.Total).To(Equal(int64(len("other data"))))
+		Expect(a.Copied).To(Equal(int64(len("other data"))))
+		Expect(a.Error).To(BeNil())
+
+		a = artifacts[common.NewNameVersion(COMPONENT, VERSION)]
+		Expect(a).NotTo(BeNil())
+		Expect(a.Copied).To(Equal(int64(len(S_TESTDATA))))
+
+		Expect(summary.Get()).To(Equal(progress.SummaryData{
+			Versions:  2,
+			Artifacts: 2,
+			Bytes:     int64(len("other data") + len(S_TESTDATA)),
+		}))
+	})
+	It("does not report versions skipped according to journal", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		j := Must(journal.Create(env, JOURNAL))
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.Journal(j)))
+
+		summary := progress.NewSummary()
+		j = Must(journal.Open(env, JOURNAL))
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.Journal(j), transfer.WithProgress(summary)))
+		Expect(summary.Get()).To(Equal(progress.SummaryData{}))
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
 )
 
 const KIND_TRANSFEROPTION = "transfer option"
@@ -105,6 +106,14 @@ type TransferHandler interface {
 	HandleTransferSource(r ocm.SourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error
 }
 
+// JournalProvider is an optional interface for a TransferHandler.
+// If implemented, the transfer process uses the provided checkpoint
+// journal to skip work already done by a previous (interrupted)
+// transfer and to record its own progress.
+type JournalProvider interface {
+	GetJournal() *journal.Journal
+}
+
 func ApplyOptions(set TransferOptions, opts ...TransferOption) error {
 	list := errors.ErrListf("transfer options")
 	for _, o := range opts {
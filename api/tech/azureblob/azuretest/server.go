@@ -0,0 +1,208 @@
+package azuretest
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ACCOUNT is the storage account provided by the fake blob service.
+const ACCOUNT = "devstoreaccount1"
+
+type blob struct {
+	data      []byte
+	mediaType string
+	etag      string
+	version   string
+}
+
+// Server is a minimal in-memory stand-in for an Azure Blob Storage
+// service using path-style URLs (like Azurite). It supports block
+// blob upload, download and property requests. Every upload creates
+// a new blob version.
+type Server struct {
+	*httptest.Server
+	lock       sync.Mutex
+	containers map[string]map[string][]*blob
+	blocks     map[string][]byte
+	uploads    int
+}
+
+func NewServer(containers ...string) *Server {
+	s := &Server{
+		containers: map[string]map[string][]*blob{},
+		blocks:     map[string][]byte{},
+	}
+	for _, c := range containers {
+		s.containers[c] = map[string][]*blob{}
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// ServiceURL provides the blob service URL for the fake account.
+func (s *Server) ServiceURL() string {
+	return s.URL + "/" + ACCOUNT
+}
+
+// Uploads provides the number of committed uploads.
+func (s *Server) Uploads() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.uploads
+}
+
+// Put stores a blob and provides its etag and version id.
+func (s *Server) Put(container, name string, data []byte, mediaType string) (string, string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	b := s.put(container, name, data, mediaType)
+	return b.etag, b.version
+}
+
+// Get provides the actual content of a blob.
+func (s *Server) Get(container, name string) []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	b := s.lookup(container, name, "")
+	if b == nil {
+		return nil
+	}
+	return b.data
+}
+
+func (s *Server) put(container, name string, data []byte, mediaType string) *blob {
+	s.uploads++
+	sum := md5.Sum(data)
+	b := &blob{
+		data:      data,
+		mediaType: mediaType,
+		etag:      fmt.Sprintf(`"0x%X"`, sum[:8]),
+		version:   time.Now().UTC().Format("2006-01-02T15:04:05.") + fmt.Sprintf("%07dZ", s.uploads),
+	}
+	s.containers[container][name] = append(s.containers[container][name], b)
+	return b
+}
+
+func (s *Server) lookup(container, name, version string) *blob {
+	versions := s.containers[container][name]
+	if len(versions) == 0 {
+		return nil
+	}
+	if version == "" {
+		return versions[len(versions)-1]
+	}
+	for _, b := range versions {
+		if b.version == version {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *Server) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	w.Header().Set("x-ms-request-id", "00000000-0000-0000-0000-000000000000")
+	w.Header().Set("x-ms-version", r.Header.Get("x-ms-version"))
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+	if len(parts) != 3 || parts[0] != ACCOUNT {
+		s.error(w, http.StatusBadRequest, "InvalidUri")
+		return
+	}
+	container, name := parts[1], parts[2]
+	if s.containers[container] == nil {
+		s.error(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+
+	q := r.URL.Query()
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+		switch q.Get("comp") {
+		case "block":
+			s.blocks[container+"/"+name+"/"+q.Get("blockid")] = data
+			w.WriteHeader(http.StatusCreated)
+		case "blocklist":
+			var list struct {
+				Blocks []string `xml:",any"`
+			}
+			if xml.Unmarshal(data, &list) != nil {
+				s.error(w, http.StatusBadRequest, "InvalidXmlDocument")
+				return
+			}
+			var content []byte
+			for _, id := range list.Blocks {
+				key := container + "/" + name + "/" + id
+				block, ok := s.blocks[key]
+				if !ok {
+					s.error(w, http.StatusBadRequest, "InvalidBlockList")
+					return
+				}
+				content = append(content, block...)
+				delete(s.blocks, key)
+			}
+			s.created(w, s.put(container, name, content, r.Header.Get("x-ms-blob-content-type")))
+		case "":
+			s.created(w, s.put(container, name, data, r.Header.Get("x-ms-blob-content-type")))
+		default:
+			s.error(w, http.StatusBadRequest, "UnsupportedQueryParameter")
+		}
+	case http.MethodGet, http.MethodHead:
+		b := s.lookup(container, name, q.Get("versionid"))
+		if b == nil {
+			s.error(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		if m := r.Header.Get("If-Match"); m != "" && m != b.etag {
+			s.error(w, http.StatusPreconditionFailed, "ConditionNotMet")
+			return
+		}
+		h := w.Header()
+		h.Set("Content-Length", strconv.Itoa(len(b.data)))
+		h.Set("Content-Type", b.mediaType)
+		h.Set("ETag", b.etag)
+		h.Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		h.Set("x-ms-blob-type", "BlockBlob")
+		h.Set("x-ms-version-id", b.version)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(b.data)
+		}
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "UnsupportedHttpVerb")
+	}
+}
+
+func (s *Server) created(w http.ResponseWriter, b *blob) {
+	sum := md5.Sum(b.data)
+	h := w.Header()
+	h.Set("ETag", b.etag)
+	h.Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	h.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	h.Set("x-ms-version-id", b.version)
+	h.Set("x-ms-request-server-encrypted", "true")
+	w.WriteHeader(http.StatusCreated)
+}
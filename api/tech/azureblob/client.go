@@ -0,0 +1,179 @@
+package azureblob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/tech/azureblob/identity"
+)
+
+// DEFAULT_ENDPOINT_SUFFIX is the endpoint suffix of the public Azure cloud.
+const DEFAULT_ENDPOINT_SUFFIX = "blob.core.windows.net"
+
+// ServiceURL provides the blob service URL of a storage account.
+// If an endpoint is given (for example, for Azurite or sovereign clouds),
+// it is used instead of the public Azure endpoint of the account.
+func ServiceURL(account, endpoint string) (string, error) {
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid endpoint %q", endpoint)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return "", errors.ErrInvalid("endpoint", endpoint)
+		}
+		return strings.TrimSuffix(u.String(), "/") + "/", nil
+	}
+	if account == "" {
+		return "", errors.ErrRequired("storage account")
+	}
+	return fmt.Sprintf("https://%s.%s/", account, DEFAULT_ENDPOINT_SUFFIX), nil
+}
+
+// NewClient creates a client for the given blob service URL using
+// the given credentials. Supported are the account key, a SAS token and
+// a service principal. Without credentials anonymous access is used.
+func NewClient(serviceURL, account string, creds cpi.Credentials) (*azblob.Client, error) {
+	if creds == nil {
+		return azblob.NewClientWithNoCredential(serviceURL, nil)
+	}
+	switch {
+	case creds.ExistsProperty(identity.ATTR_ACCOUNT_KEY):
+		if account == "" {
+			return nil, errors.ErrRequired("storage account", "", "shared key authentication")
+		}
+		cred, err := azblob.NewSharedKeyCredential(account, creds.GetProperty(identity.ATTR_ACCOUNT_KEY))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid account key")
+		}
+		return azblob.NewClientWithSharedKeyCredential(serviceURL, cred, nil)
+	case creds.ExistsProperty(identity.ATTR_SAS_TOKEN):
+		return azblob.NewClientWithNoCredential(serviceURL+"?"+strings.TrimPrefix(creds.GetProperty(identity.ATTR_SAS_TOKEN), "?"), nil)
+	case creds.ExistsProperty(identity.ATTR_CLIENT_ID):
+		for _, a := range []string{identity.ATTR_TENANT_ID, identity.ATTR_CLIENT_SECRET} {
+			if !creds.ExistsProperty(a) {
+				return nil, errors.ErrRequired("credential property", a, "service principal")
+			}
+		}
+		cred, err := azidentity.NewClientSecretCredential(
+			creds.GetProperty(identity.ATTR_TENANT_ID),
+			creds.GetProperty(identity.ATTR_CLIENT_ID),
+			creds.GetProperty(identity.ATTR_CLIENT_SECRET),
+			nil,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid service principal")
+		}
+		return azblob.NewClient(serviceURL, cred, nil)
+	default:
+		return azblob.NewClientWithNoCredential(serviceURL, nil)
+	}
+}
+
+// GetClient creates a client for the given blob service URL using
+// the credentials configured for the given container and blob.
+func GetClient(ctx cpi.ContextProvider, serviceURL, account, container, blob string) (*azblob.Client, error) {
+	creds, err := identity.GetCredentials(ctx, serviceURL, container, blob)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(serviceURL, account, creds)
+}
+
+// Download provides a reader for the content of a blob. If a version id is
+// given, this version of the blob is read. If an etag is given, the
+// request fails if the blob has been modified.
+func Download(ctx context.Context, client *azblob.Client, container, name, version, etag string) (io.ReadCloser, error) {
+	b := client.ServiceClient().NewContainerClient(container).NewBlobClient(name)
+	if version != "" {
+		var err error
+		b, err = b.WithVersionID(version)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var opts *blob.DownloadStreamOptions
+	if etag != "" {
+		e := azcore.ETag(etag)
+		opts = &blob.DownloadStreamOptions{
+			AccessConditions: &blob.AccessConditions{
+				ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfMatch: &e},
+			},
+		}
+	}
+	resp, err := b.DownloadStream(ctx, opts)
+	if err != nil {
+		return nil, wrapError(err, container, name)
+	}
+	return resp.Body, nil
+}
+
+// Properties describes an existing blob.
+type Properties struct {
+	Size      int64
+	ETag      string
+	VersionID string
+}
+
+// GetProperties provides the properties of a blob. If the blob does not
+// exist, nil is returned.
+func GetProperties(ctx context.Context, client *azblob.Client, container, name string) (*Properties, error) {
+	resp, err := client.ServiceClient().NewContainerClient(container).NewBlobClient(name).GetProperties(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, nil
+		}
+		return nil, wrapError(err, container, name)
+	}
+	p := &Properties{}
+	if resp.ContentLength != nil {
+		p.Size = *resp.ContentLength
+	}
+	if resp.ETag != nil {
+		p.ETag = string(*resp.ETag)
+	}
+	if resp.VersionID != nil {
+		p.VersionID = *resp.VersionID
+	}
+	return p, nil
+}
+
+// Upload uploads the content of a reader as block blob.
+func Upload(ctx context.Context, client *azblob.Client, container, name, mediaType string, r io.Reader) (*Properties, error) {
+	var opts *azblob.UploadStreamOptions
+	if mediaType != "" {
+		opts = &azblob.UploadStreamOptions{
+			HTTPHeaders: &blob.HTTPHeaders{BlobContentType: &mediaType},
+		}
+	}
+	resp, err := client.UploadStream(ctx, container, name, r, opts)
+	if err != nil {
+		return nil, wrapError(err, container, name)
+	}
+	p := &Properties{}
+	if resp.ETag != nil {
+		p.ETag = string(*resp.ETag)
+	}
+	if resp.VersionID != nil {
+		p.VersionID = *resp.VersionID
+	}
+	return p, nil
+}
+
+func wrapError(err error, container, name string) error {
+	if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+		return errors.ErrNotFoundWrap(err, "blob", name, container)
+	}
+	return errors.Wrapf(err, "blob %s in container %s", name, container)
+}
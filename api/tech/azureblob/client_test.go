@@ -0,0 +1,89 @@
+package azureblob_test
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	me "ocm.software/ocm/api/tech/azureblob"
+	"ocm.software/ocm/api/tech/azureblob/azuretest"
+	"ocm.software/ocm/api/tech/azureblob/identity"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const CONTAINER = "artifacts"
+
+var _ = Describe("azure blob client", func() {
+	It("provides service urls", func() {
+		Expect(me.ServiceURL("acme", "")).To(Equal("https://acme.blob.core.windows.net/"))
+		Expect(me.ServiceURL("acme", "http://127.0.0.1:10000/devstoreaccount1")).To(Equal("http://127.0.0.1:10000/devstoreaccount1/"))
+		ExpectError(me.ServiceURL("", "")).To(MatchError(`"storage account" required`))
+		ExpectError(me.ServiceURL("acme", "127.0.0.1")).To(MatchError(`endpoint "127.0.0.1" is invalid`))
+	})
+
+	It("provides consumer ids", func() {
+		id := Must(identity.GetConsumerId("https://acme.blob.core.windows.net/", CONTAINER, "bin/tool"))
+		Expect(id).To(Equal(credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME, "acme.blob.core.windows.net",
+			identity.ID_PORT, "443",
+			identity.ID_SCHEME, "https",
+			identity.ID_PATHPREFIX, CONTAINER+"/bin/tool",
+		)))
+	})
+
+	It("validates service principal credentials", func() {
+		creds := credentials.DirectCredentials{
+			identity.ATTR_CLIENT_ID: "client",
+		}
+		ExpectError(me.NewClient("https://acme.blob.core.windows.net/", "acme", creds)).To(MatchError(`credential property "tenantId" required for service principal`))
+	})
+
+	Context("blob service", func() {
+		var server *azuretest.Server
+
+		BeforeEach(func() {
+			server = azuretest.NewServer(CONTAINER)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("uploads and downloads blobs", func() {
+			creds := credentials.DirectCredentials{
+				identity.ATTR_ACCOUNT_KEY: "a2V5",
+			}
+			client := Must(me.NewClient(server.ServiceURL()+"/", azuretest.ACCOUNT, creds))
+
+			Expect(me.GetProperties(context.Background(), client, CONTAINER, "blob")).To(BeNil())
+
+			props := Must(me.Upload(context.Background(), client, CONTAINER, "blob", mime.MIME_TEXT, strings.NewReader("first")))
+			Expect(props.ETag).NotTo(BeEmpty())
+			Expect(props.VersionID).NotTo(BeEmpty())
+			Must(me.Upload(context.Background(), client, CONTAINER, "blob", mime.MIME_TEXT, strings.NewReader("second")))
+
+			p := Must(me.GetProperties(context.Background(), client, CONTAINER, "blob"))
+			Expect(p.Size).To(Equal(int64(6)))
+
+			r := Must(me.Download(context.Background(), client, CONTAINER, "blob", "", ""))
+			Expect(io.ReadAll(r)).To(Equal([]byte("second")))
+			r.Close()
+
+			r = Must(me.Download(context.Background(), client, CONTAINER, "blob", props.VersionID, props.ETag))
+			Expect(io.ReadAll(r)).To(Equal([]byte("first")))
+			r.Close()
+
+			ExpectError(me.Download(context.Background(), client, CONTAINER, "blob", "", props.ETag)).To(MatchError(ContainSubstring("ConditionNotMet")))
+
+			_, err := me.Download(context.Background(), client, CONTAINER, "missing", "", "")
+			Expect(errors.IsErrNotFound(err)).To(BeTrue())
+		})
+	})
+})
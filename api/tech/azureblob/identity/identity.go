@@ -0,0 +1,82 @@
+package identity
+
+import (
+	"net/url"
+	"path"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils/listformat"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+const (
+	// CONSUMER_TYPE is the Azure Blob Storage type.
+	CONSUMER_TYPE = "AzureBlobStorage"
+
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_SCHEME     = hostpath.ID_SCHEME
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+
+	// ATTR_ACCOUNT_KEY is the shared key of the storage account.
+	ATTR_ACCOUNT_KEY = "accountKey"
+	// ATTR_SAS_TOKEN is a shared access signature token.
+	ATTR_SAS_TOKEN = "sasToken"
+	// ATTR_TENANT_ID is the Entra ID tenant of a service principal.
+	ATTR_TENANT_ID = "tenantId"
+	// ATTR_CLIENT_ID is the client id of a service principal.
+	ATTR_CLIENT_ID = "clientId"
+	// ATTR_CLIENT_SECRET is the client secret of a service principal.
+	ATTR_CLIENT_SECRET = "clientSecret"
+)
+
+// REALM the logging realm / prefix.
+var REALM = logging.DefineSubRealm("Azure Blob Storage", "azureblob")
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_ACCOUNT_KEY, "the shared key of the storage account",
+		ATTR_SAS_TOKEN, "a shared access signature token (alternatively)",
+		ATTR_TENANT_ID, "the tenant id of a service principal (alternatively, together with client id and secret)",
+		ATTR_CLIENT_ID, "the client id of a service principal",
+		ATTR_CLIENT_SECRET, "the client secret of a service principal",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, identityMatcher, `Azure Blob Storage credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type. The path prefix is composed of
+the path of the service URL, the container and the blob name.`,
+		attrs)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a blob (or a container,
+// if no blob name is given) of the storage service with the given service URL.
+func GetConsumerId(serviceURL, container, blob string) (cpi.ConsumerIdentity, error) {
+	u, err := url.Parse(serviceURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, container, blob)
+	u.RawQuery = ""
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, u.String()), nil
+}
+
+func GetCredentials(ctx cpi.ContextProvider, serviceURL, container, blob string) (cpi.Credentials, error) {
+	id, err := GetConsumerId(serviceURL, container, blob)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		logging.DynamicLogger(REALM).Debug("No consumer identity found.", "url", serviceURL, "container", container)
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, identityMatcher)
+}
@@ -0,0 +1,85 @@
+package gomodule_test
+
+import (
+	"context"
+	"io"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	me "ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/tech/gomodule/gomoduletest"
+	"ocm.software/ocm/api/tech/gomodule/identity"
+)
+
+const (
+	MODULE  = "github.com/Acme/Hello"
+	VERSION = "v1.2.3"
+)
+
+var _ = Describe("gomodule", func() {
+	It("composes escaped urls", func() {
+		Expect(me.URL("", MODULE, VERSION, me.EXT_ZIP)).To(Equal("https://proxy.golang.org/github.com/!acme/!hello/@v/v1.2.3.zip"))
+		Expect(me.URL("https://goproxy.acme.org/", "acme.org/x", "v1.0.0-RC1", me.EXT_MOD)).To(Equal("https://goproxy.acme.org/acme.org/x/@v/v1.0.0-!r!c1.mod"))
+		ExpectError(me.URL("", MODULE, VERSION, "tgz")).To(MatchError(`go module file extension "tgz" is invalid`))
+	})
+
+	It("hashes like go.sum", func() {
+		// go.sum: github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+		mod := []byte("module github.com/pkg/errors\n")
+		Expect(me.HashMod(mod)).To(Equal("h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0="))
+
+		zip := Must(me.CreateZip("acme.org/x", "v1.0.0", map[string][]byte{"go.mod": []byte("module acme.org/x\n"), "x.go": []byte("package x\n")}))
+		h := Must(me.HashZip(zip))
+		Expect(h).To(Equal("h1:4RpbE4UZspO4z/NZvHTurXhzFy4mLIRjhd24S9omHP0="))
+		Expect(me.DecodeHash(h)).To(HaveLen(32))
+
+		MustBeSuccessful(me.Verify(me.EXT_ZIP, zip, h))
+		Expect(me.Verify(me.EXT_ZIP, zip, "h1:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=")).To(MatchError(ContainSubstring("go module hash mismatch")))
+		Expect(me.Verify(me.EXT_INFO, zip, h)).To(MatchError("no hash defined for go module info file"))
+	})
+
+	Context("proxy", func() {
+		var server *gomoduletest.Server
+		var zipHash, modHash string
+
+		BeforeEach(func() {
+			server = gomoduletest.NewServer()
+			zipHash, modHash = server.Add(MODULE, VERSION, map[string][]byte{"hello.go": []byte("package hello\n")})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("fetches module files", func() {
+			r := Must(me.Fetch(context.Background(), nil, server.URL, MODULE, VERSION, me.EXT_ZIP, nil))
+			data := Must(io.ReadAll(r))
+			r.Close()
+			Expect(me.HashZip(data)).To(Equal(zipHash))
+
+			r = Must(me.Fetch(context.Background(), nil, server.URL, MODULE, VERSION, me.EXT_MOD, nil))
+			data = Must(io.ReadAll(r))
+			r.Close()
+			Expect(string(data)).To(Equal("module " + MODULE + "\n\ngo 1.22\n"))
+			Expect(me.HashMod(data)).To(Equal(modHash))
+
+			info := Must(me.GetInfo(context.Background(), nil, server.URL, MODULE, VERSION, nil))
+			Expect(info.Version).To(Equal(VERSION))
+			Expect(info.Time).To(Equal(gomoduletest.TIME))
+
+			ExpectError(me.Fetch(context.Background(), nil, server.URL, MODULE, "v9.9.9", me.EXT_ZIP, nil)).To(MatchError(ContainSubstring(`go module "` + MODULE + `@v9.9.9" not found`)))
+		})
+
+		It("uses credentials", func() {
+			server.RequireAuth("user", "pass")
+			ExpectError(me.Fetch(context.Background(), nil, server.URL, MODULE, VERSION, me.EXT_MOD, nil)).To(MatchError(ContainSubstring("401 Unauthorized")))
+
+			creds := credentials.DirectCredentials{identity.ATTR_USERNAME: "user", identity.ATTR_PASSWORD: "pass"}
+			r := Must(me.Fetch(context.Background(), nil, server.URL, MODULE, VERSION, me.EXT_MOD, creds))
+			r.Close()
+		})
+	})
+})
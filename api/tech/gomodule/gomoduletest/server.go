@@ -0,0 +1,172 @@
+package gomoduletest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/module"
+
+	"ocm.software/ocm/api/tech/gomodule"
+)
+
+// TIME is the version time reported for all module versions.
+var TIME = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type version struct {
+	files map[string][]byte
+}
+
+// Server is a minimal in-memory Go module proxy following the
+// GOPROXY protocol (<module>/@v/list and <module>/@v/<version>.{info,mod,zip}).
+type Server struct {
+	*httptest.Server
+	lock     sync.Mutex
+	modules  map[string]map[string]*version
+	username string
+	password string
+}
+
+func NewServer() *Server {
+	s := &Server{
+		modules: map[string]map[string]*version{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// RequireAuth requires basic authentication for all requests.
+func (s *Server) RequireAuth(username, password string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.username = username
+	s.password = password
+}
+
+// Add adds a module version with the given files (path relative to the module
+// root -> content). If no go.mod file is given, a minimal one is generated.
+// It provides the h1 hashes of the module zip and the go.mod file.
+func (s *Server) Add(mod, vers string, files map[string][]byte) (string, string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if files["go.mod"] == nil {
+		c := map[string][]byte{}
+		for k, v := range files {
+			c[k] = v
+		}
+		c["go.mod"] = []byte("module " + mod + "\n\ngo 1.22\n")
+		files = c
+	}
+	zip, err := gomodule.CreateZip(mod, vers, files)
+	if err != nil {
+		panic(err)
+	}
+	info, _ := json.Marshal(&gomodule.Info{Version: vers, Time: TIME})
+
+	v := &version{files: map[string][]byte{
+		gomodule.EXT_ZIP:  zip,
+		gomodule.EXT_MOD:  files["go.mod"],
+		gomodule.EXT_INFO: info,
+	}}
+	if s.modules[mod] == nil {
+		s.modules[mod] = map[string]*version{}
+	}
+	s.modules[mod][vers] = v
+
+	zh, err := gomodule.HashZip(zip)
+	if err != nil {
+		panic(err)
+	}
+	mh, err := gomodule.HashMod(files["go.mod"])
+	if err != nil {
+		panic(err)
+	}
+	return zh, mh
+}
+
+// Get provides a file (zip, mod or info) of a module version.
+func (s *Server) Get(mod, vers, ext string) []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if v := s.modules[mod][vers]; v != nil {
+		return v.files[ext]
+	}
+	return nil
+}
+
+// Replace replaces a file of a module version to simulate
+// a corrupted proxy.
+func (s *Server) Replace(mod, vers, ext string, data []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if v := s.modules[mod][vers]; v != nil {
+		v.files[ext] = data
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.username != "" {
+		u, p, ok := r.BasicAuth()
+		if !ok || u != s.username || p != s.password {
+			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path, file, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/@v/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	mod, err := module.UnescapePath(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	versions := s.modules[mod]
+	if file == "list" {
+		var list []string
+		for v := range versions {
+			list = append(list, v)
+		}
+		sort.Strings(list)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(strings.Join(list, "\n")))
+		return
+	}
+	i := strings.LastIndex(file, ".")
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	vers, err := module.UnescapeVersion(file[:i])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	v := versions[vers]
+	if v == nil || v.files[file[i+1:]] == nil {
+		http.Error(w, "not found: "+mod+"@"+vers, http.StatusNotFound)
+		return
+	}
+	switch file[i+1:] {
+	case gomodule.EXT_ZIP:
+		w.Header().Set("Content-Type", "application/zip")
+	case gomodule.EXT_INFO:
+		w.Header().Set("Content-Type", "application/json")
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+	}
+	w.Write(v.files[file[i+1:]])
+}
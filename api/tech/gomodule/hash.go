@@ -0,0 +1,117 @@
+package gomodule
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// HASH_PREFIX is the prefix of the hash format used in go.sum files.
+const HASH_PREFIX = "h1:"
+
+// HashZip provides the h1 dirhash of a module zip file as used in go.sum
+// files (<module> <version> h1:...). Only the file names and their
+// contents are included in the hash, the zip encoding is ignored.
+func HashZip(data []byte) (string, error) {
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid module zip")
+	}
+	var files []string
+	zfiles := map[string]*zip.File{}
+	for _, f := range z.File {
+		files = append(files, f.Name)
+		zfiles[f.Name] = f
+	}
+	return dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		f := zfiles[name]
+		if f == nil {
+			return nil, fmt.Errorf("file %q not found in zip", name)
+		}
+		return f.Open()
+	})
+}
+
+// HashMod provides the h1 hash of a go.mod file as used in go.sum
+// files (<module> <version>/go.mod h1:...).
+func HashMod(data []byte) (string, error) {
+	return dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	})
+}
+
+// Hash provides the h1 hash for a file of a module version.
+// The info file has no hash.
+func Hash(ext string, data []byte) (string, error) {
+	switch ext {
+	case EXT_ZIP:
+		return HashZip(data)
+	case EXT_MOD:
+		return HashMod(data)
+	default:
+		return "", errors.Newf("no hash defined for go module %s file", ext)
+	}
+}
+
+// Verify verifies the content of a file of a module version against an
+// expected h1 hash.
+func Verify(ext string, data []byte, expected string) error {
+	if !strings.HasPrefix(expected, HASH_PREFIX) {
+		return errors.ErrInvalid("go module hash", expected)
+	}
+	h, err := Hash(ext, data)
+	if err != nil {
+		return err
+	}
+	if h != expected {
+		return errors.Newf("go module hash mismatch: expected %s, found %s", expected, h)
+	}
+	return nil
+}
+
+// DecodeHash provides the raw sha256 digest of an h1 hash.
+func DecodeHash(h string) ([]byte, error) {
+	if !strings.HasPrefix(h, HASH_PREFIX) {
+		return nil, errors.ErrInvalid("go module hash", h)
+	}
+	data, err := base64.StdEncoding.DecodeString(h[len(HASH_PREFIX):])
+	if err != nil || len(data) != sha256.Size {
+		return nil, errors.ErrInvalid("go module hash", h)
+	}
+	return data, nil
+}
+
+// CreateZip creates a module zip file for the given files (path -> content).
+// The files are stored with the prefix <module>@<version>/ as required for
+// module zip files.
+func CreateZip(mod, version string, files map[string][]byte) ([]byte, error) {
+	var names []string
+	for n := range files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, n := range names {
+		f, err := w.Create(mod + "@" + version + "/" + n)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write(files[n]); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,64 @@
+package identity
+
+import (
+	"net/url"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils/listformat"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+const (
+	// CONSUMER_TYPE is the Go module proxy type.
+	CONSUMER_TYPE = "GoModuleProxy"
+
+	// ATTR_USERNAME is the username attribute.
+	ATTR_USERNAME = cpi.ATTR_USERNAME
+	// ATTR_PASSWORD is the password attribute.
+	ATTR_PASSWORD = cpi.ATTR_PASSWORD
+)
+
+// Logging Realm.
+var REALM = logging.DefineSubRealm("Go module proxy", "gomodule")
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_USERNAME, "the basic auth user name",
+		ATTR_PASSWORD, "the basic auth password",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, identityMatcher, `Go module proxy (GOPROXY protocol)
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.`,
+		attrs)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a module
+// provided by the proxy with the given URL.
+func GetConsumerId(rawURL, module string) (cpi.ConsumerIdentity, error) {
+	_url, err := url.JoinPath(rawURL, module)
+	if err != nil {
+		return nil, err
+	}
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, _url), nil
+}
+
+func GetCredentials(ctx cpi.ContextProvider, proxyUrl string, module string) (cpi.Credentials, error) {
+	id, err := GetConsumerId(proxyUrl, module)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		logging.DynamicLogger(REALM).Debug("No consumer identity found.", "url", proxyUrl, "module", module)
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, identityMatcher)
+}
@@ -0,0 +1,124 @@
+package gomodule
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+	"golang.org/x/mod/module"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/tech/gomodule/identity"
+)
+
+var REALM = identity.REALM
+
+// DEFAULT_PROXY is the public Go module proxy.
+const DEFAULT_PROXY = "https://proxy.golang.org"
+
+// Files provided by a Go module proxy for a module version.
+const (
+	EXT_ZIP  = "zip"
+	EXT_MOD  = "mod"
+	EXT_INFO = "info"
+)
+
+// Info is the version info provided by a Go module proxy.
+type Info struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time,omitempty"`
+}
+
+// CheckExtension checks for a valid file extension of a module version.
+func CheckExtension(ext string) error {
+	switch ext {
+	case EXT_ZIP, EXT_MOD, EXT_INFO:
+		return nil
+	default:
+		return errors.ErrInvalid("go module file extension", ext)
+	}
+}
+
+// URL provides the URL of a file of a module version according to
+// the GOPROXY protocol (<proxy>/<module>/@v/<version>.<ext>).
+// Module path and version are escaped as required by the protocol.
+func URL(proxy, mod, version, ext string) (string, error) {
+	if proxy == "" {
+		proxy = DEFAULT_PROXY
+	}
+	if err := CheckExtension(ext); err != nil {
+		return "", err
+	}
+	p, err := module.EscapePath(mod)
+	if err != nil {
+		return "", err
+	}
+	v, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(proxy, "/") + "/" + p + "/@v/" + v + "." + ext, nil
+}
+
+// Fetch provides the content of a file of a module version.
+// If the module version does not exist, a not found error is returned.
+func Fetch(ctx context.Context, client *http.Client, proxy, mod, version, ext string, creds cpi.Credentials) (io.ReadCloser, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u, err := URL(proxy, mod, version, ext)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	SetAuth(req, creds)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound, http.StatusGone:
+		resp.Body.Close()
+		return nil, errors.ErrNotFound("go module", mod+"@"+version, proxy)
+	default:
+		defer resp.Body.Close()
+		buf, _ := io.ReadAll(io.LimitReader(resp.Body, 2000))
+		return nil, errors.Newf("go module request %s provides %s: %s", u, resp.Status, string(buf))
+	}
+}
+
+// GetInfo provides the version info of a module version.
+func GetInfo(ctx context.Context, client *http.Client, proxy, mod, version string, creds cpi.Credentials) (*Info, error) {
+	r, err := Fetch(ctx, client, proxy, mod, version, EXT_INFO, creds)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var info Info
+	err = json.NewDecoder(r).Decode(&info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid version info for %s@%s", mod, version)
+	}
+	return &info, nil
+}
+
+// SetAuth sets the basic auth header for the given credentials.
+func SetAuth(req *http.Request, creds cpi.Credentials) {
+	if creds == nil {
+		return
+	}
+	username, password := creds.GetProperty(identity.ATTR_USERNAME), creds.GetProperty(identity.ATTR_PASSWORD)
+	if username != "" && password != "" {
+		req.SetBasicAuth(username, password)
+	}
+}
@@ -23,6 +23,7 @@ const (
 	ATTR_USERNAME              = cpi.ATTR_USERNAME
 	ATTR_PASSWORD              = cpi.ATTR_PASSWORD
 	ATTR_IDENTITY_TOKEN        = cpi.ATTR_IDENTITY_TOKEN
+	ATTR_ACCESS_TOKEN          = "accessToken"
 	ATTR_CERTIFICATE_AUTHORITY = cpi.ATTR_CERTIFICATE_AUTHORITY
 )
 
@@ -31,6 +32,7 @@ func init() {
 		ATTR_USERNAME, "the basic auth username",
 		ATTR_PASSWORD, "the basic auth password",
 		ATTR_IDENTITY_TOKEN, "the bearer token used for non-basic auth authorization",
+		ATTR_ACCESS_TOKEN, "the registry access token directly used as bearer token (for example provided by the OIDC credential repository)",
 		ATTR_CERTIFICATE_AUTHORITY, "the certificate authority certificate used to verify certificates",
 	})
 
@@ -57,4 +57,9 @@ type Pusher interface {
 
 type Lister interface {
 	List(context.Context) ([]string, error)
+
+	// Referrers lists the descriptors of the manifests referring to the given
+	// artifact, optionally filtered by an artifact type. If the registry
+	// does not support the referrers API, the referrers tag schema is used.
+	Referrers(ctx context.Context, desc ocispec.Descriptor, artifactType string) ([]ocispec.Descriptor, error)
 }
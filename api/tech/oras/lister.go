@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
@@ -29,3 +30,20 @@ func (c *OrasLister) List(ctx context.Context) ([]string, error) {
 
 	return result, nil
 }
+
+func (c *OrasLister) Referrers(ctx context.Context, desc ociv1.Descriptor, artifactType string) ([]ociv1.Descriptor, error) {
+	src, err := createRepository(c.ref, c.client, c.plainHTTP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ref %q: %w", c.ref, err)
+	}
+
+	var result []ociv1.Descriptor
+	if err := src.Referrers(ctx, desc, artifactType, func(referrers []ociv1.Descriptor) error {
+		result = append(result, referrers...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list referrers for %s: %w", desc.Digest, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,241 @@
+package pypi
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/mail"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+const (
+	FILETYPE_SDIST = "sdist"
+	FILETYPE_WHEEL = "bdist_wheel"
+
+	EXT_WHEEL     = ".whl"
+	EXT_SDIST     = ".tar.gz"
+	EXT_SDIST_ZIP = ".zip"
+
+	// PYVERSION_SOURCE is the python version used for source distributions.
+	PYVERSION_SOURCE = "source"
+)
+
+// DistributionInfo describes the information encoded in the filename
+// of a distribution file.
+type DistributionInfo struct {
+	Name      string
+	Version   string
+	FileType  string
+	PyVersion string
+}
+
+// ParseFilename parses the filename of a source distribution
+// (<name>-<version>.tar.gz) or wheel
+// (<name>-<version>[-<build>]-<python>-<abi>-<platform>.whl).
+func ParseFilename(filename string) (*DistributionInfo, error) {
+	switch {
+	case strings.HasSuffix(filename, EXT_WHEEL):
+		parts := strings.Split(strings.TrimSuffix(filename, EXT_WHEEL), "-")
+		if len(parts) != 5 && len(parts) != 6 {
+			return nil, errors.ErrInvalid("wheel filename", filename)
+		}
+		return &DistributionInfo{
+			Name:      parts[0],
+			Version:   parts[1],
+			FileType:  FILETYPE_WHEEL,
+			PyVersion: parts[len(parts)-3],
+		}, nil
+	case strings.HasSuffix(filename, EXT_SDIST), strings.HasSuffix(filename, EXT_SDIST_ZIP):
+		base := strings.TrimSuffix(strings.TrimSuffix(filename, EXT_SDIST), EXT_SDIST_ZIP)
+		i := strings.LastIndex(base, "-")
+		if i <= 0 || i == len(base)-1 {
+			return nil, errors.ErrInvalid("source distribution filename", filename)
+		}
+		return &DistributionInfo{
+			Name:      base[:i],
+			Version:   base[i+1:],
+			FileType:  FILETYPE_SDIST,
+			PyVersion: PYVERSION_SOURCE,
+		}, nil
+	default:
+		return nil, errors.ErrInvalid("distribution filename", filename)
+	}
+}
+
+// Metadata is the core metadata of a distribution (PKG-INFO or METADATA).
+type Metadata struct {
+	MetadataVersion string
+	Name            string
+	Version         string
+	Summary         string
+}
+
+// Distribution describes a distribution file.
+type Distribution struct {
+	DistributionInfo
+	Filename string
+	Metadata Metadata
+}
+
+var wheelNameExp = regexp.MustCompile(`[^\w\d.]+`)
+
+// Inspect reads the metadata of a distribution archive (wheel or source
+// distribution). If no filename is given, it is derived from the metadata.
+func Inspect(data []byte, filename string) (*Distribution, error) {
+	return InspectArchive(bytes.NewReader(data), int64(len(data)), filename)
+}
+
+// InspectArchive reads the metadata of a distribution archive of the given
+// size without reading the complete archive into memory.
+// If no filename is given, it is derived from the metadata.
+func InspectArchive(r io.ReaderAt, size int64, filename string) (*Distribution, error) {
+	var (
+		meta []byte
+		tags []string
+		err  error
+	)
+	magic := make([]byte, 4)
+	n, _ := r.ReadAt(magic, 0)
+	isZip := bytes.Equal(magic[:n], []byte("PK\x03\x04"))
+	if isZip {
+		meta, tags, err = readZip(r, size)
+	} else {
+		meta, err = readTGZ(io.NewSectionReader(r, 0, size))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, errors.ErrNotFound("distribution metadata")
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(meta))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid distribution metadata")
+	}
+	d := &Distribution{
+		Filename: filename,
+		Metadata: Metadata{
+			MetadataVersion: msg.Header.Get("Metadata-Version"),
+			Name:            msg.Header.Get("Name"),
+			Version:         msg.Header.Get("Version"),
+			Summary:         msg.Header.Get("Summary"),
+		},
+	}
+	if d.Metadata.Name == "" || d.Metadata.Version == "" {
+		return nil, errors.Newf("distribution metadata requires name and version")
+	}
+
+	if filename == "" {
+		name := wheelNameExp.ReplaceAllString(d.Metadata.Name, "_")
+		switch {
+		case len(tags) > 0:
+			d.Filename = name + "-" + d.Metadata.Version + "-" + compressTags(tags) + EXT_WHEEL
+		case isZip:
+			d.Filename = strings.ToLower(name) + "-" + d.Metadata.Version + EXT_SDIST_ZIP
+		default:
+			d.Filename = strings.ToLower(name) + "-" + d.Metadata.Version + EXT_SDIST
+		}
+	}
+	info, err := ParseFilename(d.Filename)
+	if err != nil {
+		return nil, err
+	}
+	d.DistributionInfo = *info
+	return d, nil
+}
+
+// compressTags composes the compressed tag set of a wheel filename
+// from the list of tags found in the WHEEL file.
+func compressTags(tags []string) string {
+	var parts [3][]string
+	for _, t := range tags {
+		fields := strings.Split(t, "-")
+		if len(fields) != 3 {
+			continue
+		}
+		for i, f := range fields {
+			found := false
+			for _, e := range parts[i] {
+				if e == f {
+					found = true
+					break
+				}
+			}
+			if !found {
+				parts[i] = append(parts[i], f)
+			}
+		}
+	}
+	return strings.Join(parts[0], ".") + "-" + strings.Join(parts[1], ".") + "-" + strings.Join(parts[2], ".")
+}
+
+func readZip(data io.ReaderAt, size int64) ([]byte, []string, error) {
+	r, err := zip.NewReader(data, size)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid distribution archive")
+	}
+	var meta []byte
+	var tags []string
+	for _, f := range r.File {
+		dir, name := path.Split(f.Name)
+		switch {
+		case strings.HasSuffix(dir, ".dist-info/") && strings.Count(dir, "/") == 1 && (name == "METADATA" || name == "WHEEL"):
+			content, err := readZipFile(f)
+			if err != nil {
+				return nil, nil, err
+			}
+			if name == "METADATA" {
+				meta = content
+				continue
+			}
+			msg, err := mail.ReadMessage(bytes.NewReader(content))
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "invalid WHEEL file")
+			}
+			tags = msg.Header["Tag"]
+		case meta == nil && strings.Count(dir, "/") == 1 && name == "PKG-INFO":
+			meta, err = readZipFile(f)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return meta, tags, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func readTGZ(data io.Reader) ([]byte, error) {
+	zr, err := gzip.NewReader(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid distribution archive")
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+	for {
+		h, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "invalid distribution archive")
+		}
+		dir, name := path.Split(strings.TrimPrefix(h.Name, "./"))
+		if name == "PKG-INFO" && strings.Count(dir, "/") == 1 {
+			return io.ReadAll(tr)
+		}
+	}
+}
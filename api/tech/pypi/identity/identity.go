@@ -0,0 +1,70 @@
+package identity
+
+import (
+	"net/url"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils/listformat"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+const (
+	// CONSUMER_TYPE is the Python package index type.
+	CONSUMER_TYPE = "PyPI"
+
+	// ATTR_USERNAME is the username attribute.
+	ATTR_USERNAME = cpi.ATTR_USERNAME
+	// ATTR_PASSWORD is the password attribute.
+	ATTR_PASSWORD = cpi.ATTR_PASSWORD
+	// ATTR_TOKEN is an API token used instead of username and password.
+	ATTR_TOKEN = cpi.ATTR_TOKEN
+)
+
+// TOKEN_USERNAME is the username used together with API tokens.
+const TOKEN_USERNAME = "__token__"
+
+// Logging Realm.
+var REALM = logging.DefineSubRealm("Python package index", "pypi")
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_USERNAME, "the basic auth user name",
+		ATTR_PASSWORD, "the basic auth password",
+		ATTR_TOKEN, "an API token (alternatively, used with user name <code>" + TOKEN_USERNAME + "</code>)",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, identityMatcher, `Python package index
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.`,
+		attrs)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a package of the
+// index with the given URL.
+func GetConsumerId(rawURL, pkg string) (cpi.ConsumerIdentity, error) {
+	_url, err := url.JoinPath(rawURL, pkg)
+	if err != nil {
+		return nil, err
+	}
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, _url), nil
+}
+
+func GetCredentials(ctx cpi.ContextProvider, repoUrl string, pkg string) (cpi.Credentials, error) {
+	id, err := GetConsumerId(repoUrl, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		logging.DynamicLogger(REALM).Debug("No consumer identity found.", "url", repoUrl, "package", pkg)
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, identityMatcher)
+}
@@ -0,0 +1,149 @@
+package pypi_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	me "ocm.software/ocm/api/tech/pypi"
+	"ocm.software/ocm/api/tech/pypi/identity"
+	"ocm.software/ocm/api/tech/pypi/pypitest"
+)
+
+var _ = Describe("pypi", func() {
+	It("normalizes names", func() {
+		Expect(me.NormalizeName("Friendly-Bard")).To(Equal("friendly-bard"))
+		Expect(me.NormalizeName("friendly.bard")).To(Equal("friendly-bard"))
+		Expect(me.NormalizeName("FRIENDLY-._BARD")).To(Equal("friendly-bard"))
+		Expect(me.ProjectURL("https://acme.org/simple", "Friendly_Bard")).To(Equal("https://acme.org/simple/friendly-bard/"))
+	})
+
+	It("parses filenames", func() {
+		Expect(me.ParseFilename("python-dateutil-2.8.2.tar.gz")).To(Equal(&me.DistributionInfo{
+			Name: "python-dateutil", Version: "2.8.2", FileType: me.FILETYPE_SDIST, PyVersion: me.PYVERSION_SOURCE,
+		}))
+		Expect(me.ParseFilename("requests-2.31.0-py3-none-any.whl")).To(Equal(&me.DistributionInfo{
+			Name: "requests", Version: "2.31.0", FileType: me.FILETYPE_WHEEL, PyVersion: "py3",
+		}))
+		Expect(me.ParseFilename("numpy-1.26.0-1-cp311-cp311-manylinux_2_17_x86_64.whl")).To(Equal(&me.DistributionInfo{
+			Name: "numpy", Version: "1.26.0", FileType: me.FILETYPE_WHEEL, PyVersion: "cp311",
+		}))
+		ExpectError(me.ParseFilename("requests.whl")).To(MatchError(`wheel filename "requests.whl" is invalid`))
+		ExpectError(me.ParseFilename("requests.exe")).To(MatchError(`distribution filename "requests.exe" is invalid`))
+	})
+
+	It("inspects distributions", func() {
+		d := Must(me.Inspect(pypitest.SDist("Hello-World", "1.0.0"), ""))
+		Expect(d.Filename).To(Equal("hello_world-1.0.0.tar.gz"))
+		Expect(d.FileType).To(Equal(me.FILETYPE_SDIST))
+		Expect(d.Metadata).To(Equal(me.Metadata{MetadataVersion: "2.1", Name: "Hello-World", Version: "1.0.0", Summary: "test package Hello-World"}))
+
+		d = Must(me.Inspect(pypitest.Wheel("Hello-World", "1.0.0"), ""))
+		Expect(d.Filename).To(Equal("Hello_World-1.0.0-py2.py3-none-any.whl"))
+		Expect(d.FileType).To(Equal(me.FILETYPE_WHEEL))
+		Expect(d.PyVersion).To(Equal("py2.py3"))
+		Expect(d.Metadata.Name).To(Equal("Hello-World"))
+
+		d = Must(me.Inspect(pypitest.Wheel("Hello-World", "1.0.0"), "hello_world-1.0.0-py3-none-any.whl"))
+		Expect(d.Filename).To(Equal("hello_world-1.0.0-py3-none-any.whl"))
+		Expect(d.PyVersion).To(Equal("py3"))
+
+		ExpectError(me.Inspect([]byte("no archive"), "")).To(HaveOccurred())
+	})
+
+	It("parses html project pages", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><body>
+<a href="../../packages/hello-1.0.tar.gz#sha256=abcd">hello-1.0.tar.gz</a><br/>
+<a href="https://files.acme.org/hello-0.9.tar.gz" data-yanked="">hello-0.9.tar.gz</a>
+<a data-requires-python="&gt;=3.8" href="/packages/hello-1.0-py3-none-any.whl">hello-1.0-py3-none-any.whl</a>
+</body></html>`))
+		}))
+		defer server.Close()
+
+		p := Must(me.GetProject(context.Background(), nil, server.URL+"/simple", "Hello", nil))
+		Expect(p.Name).To(Equal("hello"))
+		Expect(p.Files).To(Equal([]me.File{
+			{Filename: "hello-1.0.tar.gz", URL: server.URL + "/packages/hello-1.0.tar.gz", Hashes: map[string]string{"sha256": "abcd"}},
+			{Filename: "hello-0.9.tar.gz", URL: "https://files.acme.org/hello-0.9.tar.gz", Yanked: true},
+			{Filename: "hello-1.0-py3-none-any.whl", URL: server.URL + "/packages/hello-1.0-py3-none-any.whl", RequiresPython: ">=3.8"},
+		}))
+		Expect(p.Files[1].IsYanked()).To(BeTrue())
+
+		Expect(Must(p.FindFile("1.0", "")).Filename).To(Equal("hello-1.0.tar.gz"))
+		Expect(Must(p.FindFile("1.0", "hello-1.0-py3-none-any.whl")).Filename).To(Equal("hello-1.0-py3-none-any.whl"))
+		ExpectError(p.FindFile("2.0", "")).To(MatchError(`distribution file "2.0" not found in hello`))
+	})
+
+	It("selects the strongest supported digest", func() {
+		f := &me.File{Hashes: map[string]string{"md5": "0a", "sha256": "AB", "sha512": "cd"}}
+		algo, digest := Must2(f.Digest())
+		Expect(algo).To(Equal(crypto.SHA512))
+		Expect(digest).To(Equal("cd"))
+
+		f = &me.File{Hashes: map[string]string{"sha256": "AB"}}
+		algo, digest = Must2(f.Digest())
+		Expect(algo).To(Equal(crypto.SHA256))
+		Expect(digest).To(Equal("ab"))
+
+		f = &me.File{}
+		_, digest = Must2(f.Digest())
+		Expect(digest).To(Equal(""))
+
+		f = &me.File{Hashes: map[string]string{"md5": "0a", "sha1": "1b"}}
+		_, _, err := f.Digest()
+		Expect(err).To(MatchError(ContainSubstring("digest algorithm")))
+	})
+
+	Context("index", func() {
+		var server *pypitest.Server
+
+		BeforeEach(func() {
+			server = pypitest.NewServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("lists project files", func() {
+			digest := server.Add("hello", "hello-1.0.tar.gz", pypitest.SDist("hello", "1.0"))
+			server.Add("hello", "hello-1.0-py3-none-any.whl", pypitest.Wheel("hello", "1.0"))
+
+			p := Must(me.GetProject(context.Background(), nil, server.IndexURL(), "Hello", nil))
+			Expect(p.Name).To(Equal("hello"))
+			Expect(len(p.Files)).To(Equal(2))
+			f := Must(p.FindFile("1.0", ""))
+			Expect(f.URL).To(Equal(server.URL + "/packages/hello-1.0.tar.gz"))
+			Expect(f.Hashes).To(Equal(map[string]string{"sha256": digest}))
+
+			ExpectError(me.GetProject(context.Background(), nil, server.IndexURL(), "unknown", nil)).To(MatchError(ContainSubstring(`python package "unknown" not found`)))
+		})
+
+		It("uploads distributions", func() {
+			server.RequireAuth(identity.TOKEN_USERNAME, "pypi-token")
+			data := pypitest.Wheel("hello", "1.0")
+			d := Must(me.Inspect(data, ""))
+
+			ExpectError(me.Upload(context.Background(), nil, server.UploadURL(), nil, d, bytes.NewReader(data), int64(len(data)))).To(MatchError(ContainSubstring("http (403)")))
+
+			creds := credentials.DirectCredentials{identity.ATTR_TOKEN: "pypi-token"}
+			MustBeSuccessful(me.Upload(context.Background(), nil, server.UploadURL(), creds, d, bytes.NewReader(data), int64(len(data))))
+			Expect(server.Get("hello-1.0-py2.py3-none-any.whl")).To(Equal(data))
+			fields := server.Uploads()["hello-1.0-py2.py3-none-any.whl"]
+			Expect(fields.Get("filetype")).To(Equal(me.FILETYPE_WHEEL))
+			Expect(fields.Get("pyversion")).To(Equal("py2.py3"))
+			Expect(fields.Get("metadata_version")).To(Equal("2.1"))
+
+			ExpectError(me.Upload(context.Background(), nil, server.UploadURL(), creds, d, bytes.NewReader(data), int64(len(data)))).To(MatchError(ContainSubstring("File already exists")))
+		})
+	})
+})
@@ -0,0 +1,51 @@
+package pypitest
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+)
+
+func metadata(name, version string) string {
+	return fmt.Sprintf("Metadata-Version: 2.1\nName: %s\nVersion: %s\nSummary: test package %s\n\n", name, version, name)
+}
+
+// SDist provides a minimal source distribution for a project version.
+func SDist(name, version string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	root := strings.ToLower(strings.ReplaceAll(name, "-", "_")) + "-" + version + "/"
+	for _, f := range [][2]string{
+		{root + "PKG-INFO", metadata(name, version)},
+		{root + "setup.py", "from setuptools import setup\nsetup()\n"},
+		{root + "mod.py", "VALUE = 1\n"},
+	} {
+		tw.WriteHeader(&tar.Header{Name: f[0], Mode: 0o644, Size: int64(len(f[1])), Typeflag: tar.TypeReg})
+		tw.Write([]byte(f[1]))
+	}
+	tw.Close()
+	zw.Close()
+	return buf.Bytes()
+}
+
+// Wheel provides a minimal pure Python wheel for a project version.
+func Wheel(name, version string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	info := strings.ReplaceAll(name, "-", "_") + "-" + version + ".dist-info/"
+	for _, f := range [][2]string{
+		{"mod.py", "VALUE = 1\n"},
+		{info + "METADATA", metadata(name, version)},
+		{info + "WHEEL", "Wheel-Version: 1.0\nGenerator: test\nRoot-Is-Purelib: true\nTag: py2-none-any\nTag: py3-none-any\n\n"},
+		{info + "RECORD", ""},
+	} {
+		w, _ := zw.Create(f[0])
+		w.Write([]byte(f[1]))
+	}
+	zw.Close()
+	return buf.Bytes()
+}
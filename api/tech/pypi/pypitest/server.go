@@ -0,0 +1,223 @@
+package pypitest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"ocm.software/ocm/api/tech/pypi"
+)
+
+type file struct {
+	filename string
+	data     []byte
+	sha256   string
+}
+
+// Server is a minimal in-memory stand-in for a Python package index
+// (like pypiserver). It provides the simple repository API in JSON and
+// HTML format under /simple/, the distribution files under /packages/
+// and the legacy upload API under /legacy/.
+type Server struct {
+	*httptest.Server
+	lock     sync.Mutex
+	projects map[string][]*file
+	uploads  map[string]url.Values
+	username string
+	password string
+}
+
+func NewServer() *Server {
+	s := &Server{
+		projects: map[string][]*file{},
+		uploads:  map[string]url.Values{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// RequireAuth requires basic authentication for all requests.
+func (s *Server) RequireAuth(username, password string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.username = username
+	s.password = password
+}
+
+// IndexURL provides the URL of the simple repository API.
+func (s *Server) IndexURL() string {
+	return s.URL + "/simple/"
+}
+
+// UploadURL provides the URL of the legacy upload API.
+func (s *Server) UploadURL() string {
+	return s.URL + "/legacy/"
+}
+
+// Add adds a distribution file to a project and provides its sha256 digest.
+func (s *Server) Add(project, filename string, data []byte) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.add(project, filename, data).sha256
+}
+
+// Get provides the content of a distribution file.
+func (s *Server) Get(filename string) []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if f := s.lookup(filename); f != nil {
+		return f.data
+	}
+	return nil
+}
+
+// Replace replaces the content of a distribution file without
+// updating its digest to simulate a corrupted file.
+func (s *Server) Replace(filename string, data []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if f := s.lookup(filename); f != nil {
+		f.data = data
+	}
+}
+
+// Uploads provides the form fields of all uploads by filename.
+func (s *Server) Uploads() map[string]url.Values {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	r := map[string]url.Values{}
+	for k, v := range s.uploads {
+		r[k] = v
+	}
+	return r
+}
+
+func (s *Server) add(project, filename string, data []byte) *file {
+	sum := sha256.Sum256(data)
+	f := &file{filename: filename, data: data, sha256: hex.EncodeToString(sum[:])}
+	name := pypi.NormalizeName(project)
+	s.projects[name] = append(s.projects[name], f)
+	return f
+}
+
+func (s *Server) lookup(filename string) *file {
+	for _, files := range s.projects {
+		for _, f := range files {
+			if f.filename == filename {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.username != "" {
+		u, p, ok := r.BasicAuth()
+		if !ok || u != s.username || p != s.password {
+			http.Error(w, "invalid credentials", http.StatusForbidden)
+			return
+		}
+	}
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/simple/"):
+		s.project(w, r, strings.Trim(strings.TrimPrefix(r.URL.Path, "/simple/"), "/"))
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/packages/"):
+		f := s.lookup(strings.TrimPrefix(r.URL.Path, "/packages/"))
+		if f == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(f.data)
+	case r.Method == http.MethodPost && r.URL.Path == "/legacy/":
+		s.upload(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) project(w http.ResponseWriter, r *http.Request, name string) {
+	if name != pypi.NormalizeName(name) {
+		http.Redirect(w, r, "/simple/"+pypi.NormalizeName(name)+"/", http.StatusMovedPermanently)
+		return
+	}
+	files := s.projects[name]
+	if len(files) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].filename < files[j].filename })
+
+	if strings.Contains(r.Header.Get("Accept"), pypi.MEDIA_TYPE_SIMPLE_JSON) {
+		project := pypi.Project{Name: name}
+		for _, f := range files {
+			project.Files = append(project.Files, pypi.File{
+				Filename: f.filename,
+				URL:      "../../packages/" + f.filename,
+				Hashes:   map[string]string{"sha256": f.sha256},
+			})
+		}
+		data, _ := json.Marshal(&project)
+		w.Header().Set("Content-Type", pypi.MEDIA_TYPE_SIMPLE_JSON)
+		w.Write(data)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html><body>\n<h1>Links for %s</h1>\n", html.EscapeString(name))
+	for _, f := range files {
+		fmt.Fprintf(w, "<a href=\"/packages/%s#sha256=%s\">%s</a><br/>\n", f.filename, f.sha256, html.EscapeString(f.filename))
+	}
+	fmt.Fprintf(w, "</body></html>\n")
+}
+
+func (s *Server) upload(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.FormValue(":action") != "file_upload" {
+		http.Error(w, "invalid action", http.StatusBadRequest)
+		return
+	}
+	content, header, err := r.FormFile("content")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sum := sha256.Sum256(data)
+	if r.FormValue("sha256_digest") != hex.EncodeToString(sum[:]) {
+		http.Error(w, "digest mismatch", http.StatusBadRequest)
+		return
+	}
+	info, err := pypi.ParseFilename(header.Filename)
+	if err != nil || pypi.NormalizeName(info.Name) != pypi.NormalizeName(r.FormValue("name")) || info.Version != r.FormValue("version") {
+		http.Error(w, "invalid filename "+header.Filename, http.StatusBadRequest)
+		return
+	}
+	if s.lookup(header.Filename) != nil {
+		http.Error(w, "File already exists", http.StatusBadRequest)
+		return
+	}
+	s.add(r.FormValue("name"), header.Filename, data)
+	s.uploads[header.Filename] = r.MultipartForm.Value
+	w.WriteHeader(http.StatusOK)
+}
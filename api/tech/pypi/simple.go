@@ -0,0 +1,263 @@
+package pypi
+
+import (
+	"context"
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/json"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/tech/pypi/identity"
+	"ocm.software/ocm/api/utils"
+)
+
+var REALM = identity.REALM
+
+// DEFAULT_INDEX is the simple API of the public Python package index.
+const DEFAULT_INDEX = "https://pypi.org/simple/"
+
+const (
+	// MEDIA_TYPE_SIMPLE_JSON is the JSON format of the simple repository API (PEP 691).
+	MEDIA_TYPE_SIMPLE_JSON = "application/vnd.pypi.simple.v1+json"
+	// MEDIA_TYPE_SIMPLE_HTML is the HTML format of the simple repository API (PEP 503).
+	MEDIA_TYPE_SIMPLE_HTML = "application/vnd.pypi.simple.v1+html"
+)
+
+// File describes a distribution file of a project provided by the simple API.
+type File struct {
+	Filename       string            `json:"filename"`
+	URL            string            `json:"url"`
+	Hashes         map[string]string `json:"hashes,omitempty"`
+	RequiresPython string            `json:"requires-python,omitempty"`
+	// Yanked is either a boolean or the reason for yanking a file.
+	Yanked interface{} `json:"yanked,omitempty"`
+}
+
+// Project is the file list of a project provided by the simple API.
+type Project struct {
+	Name  string `json:"name"`
+	Files []File `json:"files"`
+}
+
+var normalizeExp = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName provides the normalized name of a project (PEP 503).
+func NormalizeName(name string) string {
+	return strings.ToLower(normalizeExp.ReplaceAllString(name, "-"))
+}
+
+// ProjectURL provides the simple API URL of a project.
+func ProjectURL(index, pkg string) string {
+	if index == "" {
+		index = DEFAULT_INDEX
+	}
+	return strings.TrimSuffix(index, "/") + "/" + NormalizeName(pkg) + "/"
+}
+
+// SetAuth sets the basic auth header for the given credentials.
+// An API token is used together with the user name __token__.
+func SetAuth(req *http.Request, creds cpi.Credentials) {
+	if creds == nil {
+		return
+	}
+	if token := creds.GetProperty(identity.ATTR_TOKEN); token != "" {
+		req.SetBasicAuth(identity.TOKEN_USERNAME, token)
+		return
+	}
+	username, password := creds.GetProperty(identity.ATTR_USERNAME), creds.GetProperty(identity.ATTR_PASSWORD)
+	if username != "" && password != "" {
+		req.SetBasicAuth(username, password)
+	}
+}
+
+// GetProject queries the file list of a project from the simple API of an index.
+// It supports the JSON (PEP 691) and the HTML (PEP 503) format. File URLs
+// are resolved relative to the project URL. If the project does not exist,
+// a not found error is returned.
+func GetProject(ctx context.Context, client *http.Client, index, pkg string, creds cpi.Credentials) (*Project, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	u := ProjectURL(index, pkg)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", MEDIA_TYPE_SIMPLE_JSON+", "+MEDIA_TYPE_SIMPLE_HTML+";q=0.2, text/html;q=0.1")
+	SetAuth(req, creds)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.ErrNotFound("python package", pkg, index)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("project request %s provides %s: %s", u, resp.Status, limit(data))
+	}
+
+	var project Project
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if strings.HasSuffix(mediaType, "json") {
+		err = json.Unmarshal(data, &project)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid project data from %s", u)
+		}
+	} else {
+		project.Name = NormalizeName(pkg)
+		project.Files = parseHTML(string(data))
+	}
+
+	base, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range project.Files {
+		ref, err := url.Parse(f.URL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid file url %q", f.URL)
+		}
+		ref = base.ResolveReference(ref)
+		if ref.Fragment != "" && len(f.Hashes) == 0 {
+			project.Files[i].Hashes = parseHashFragment(ref.Fragment)
+		}
+		ref.Fragment = ""
+		project.Files[i].URL = ref.String()
+	}
+	return &project, nil
+}
+
+var (
+	anchorExp = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)
+	attrExp   = regexp.MustCompile(`(?is)([a-z-]+)\s*=\s*"([^"]*)"`)
+)
+
+func parseHTML(data string) []File {
+	var files []File
+	for _, m := range anchorExp.FindAllStringSubmatch(data, -1) {
+		f := File{Filename: strings.TrimSpace(html.UnescapeString(m[2]))}
+		for _, a := range attrExp.FindAllStringSubmatch(m[1], -1) {
+			v := html.UnescapeString(a[2])
+			switch strings.ToLower(a[1]) {
+			case "href":
+				f.URL = v
+			case "data-requires-python":
+				f.RequiresPython = v
+			case "data-yanked":
+				if v == "" {
+					f.Yanked = true
+				} else {
+					f.Yanked = v
+				}
+			}
+		}
+		if f.URL != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+func parseHashFragment(fragment string) map[string]string {
+	algo, value, ok := strings.Cut(fragment, "=")
+	if !ok || value == "" {
+		return nil
+	}
+	return map[string]string{algo: value}
+}
+
+// IsYanked checks whether a file has been yanked (PEP 592).
+func (f *File) IsYanked() bool {
+	switch v := f.Yanked.(type) {
+	case bool:
+		return v
+	case string:
+		return true
+	default:
+		return false
+	}
+}
+
+// digestAlgorithms are the hash algorithms supported for distribution files
+// (PEP 503) in order of preference. Weak algorithms like md5 and sha1
+// are not accepted.
+var digestAlgorithms = []struct {
+	name string
+	hash crypto.Hash
+}{
+	{"sha512", crypto.SHA512},
+	{"sha384", crypto.SHA384},
+	{"sha256", crypto.SHA256},
+	{"sha224", crypto.SHA224},
+}
+
+// Digest provides the strongest supported digest of a file.
+// If the index provides no digest, an empty digest is returned.
+// If only unsupported digests are provided, an error is returned.
+func (f *File) Digest() (crypto.Hash, string, error) {
+	for _, a := range digestAlgorithms {
+		if d := f.Hashes[a.name]; d != "" {
+			return a.hash, strings.ToLower(d), nil
+		}
+	}
+	if len(f.Hashes) > 0 {
+		return 0, "", errors.ErrNotSupported("digest algorithm", strings.Join(utils.StringMapKeys(f.Hashes), ", "))
+	}
+	return 0, "", nil
+}
+
+// FindFile looks up a distribution file of a project version. If a filename
+// is given, exactly this file is used. Otherwise, the source distribution is
+// preferred over a pure Python wheel. Platform specific wheels must be
+// selected explicitly by their filename.
+func (p *Project) FindFile(version, filename string) (*File, error) {
+	if filename != "" {
+		for i, f := range p.Files {
+			if f.Filename == filename {
+				return &p.Files[i], nil
+			}
+		}
+		return nil, errors.ErrNotFound("distribution file", filename, p.Name)
+	}
+
+	var wheels []*File
+	for i, f := range p.Files {
+		d, err := ParseFilename(f.Filename)
+		if err != nil || d.Version != version || NormalizeName(d.Name) != NormalizeName(p.Name) {
+			continue
+		}
+		if d.FileType == FILETYPE_SDIST {
+			return &p.Files[i], nil
+		}
+		if strings.HasSuffix(f.Filename, "-none-any"+EXT_WHEEL) {
+			wheels = append(wheels, &p.Files[i])
+		}
+	}
+	if len(wheels) > 0 {
+		return wheels[0], nil
+	}
+	return nil, errors.ErrNotFound("distribution file", version, p.Name)
+}
+
+func limit(data []byte) string {
+	if len(data) > 2000 {
+		data = data[:2000]
+	}
+	return string(data)
+}
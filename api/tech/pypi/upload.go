@@ -0,0 +1,89 @@
+package pypi
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+)
+
+// DEFAULT_UPLOAD_URL is the legacy upload API of the public Python package index.
+const DEFAULT_UPLOAD_URL = "https://upload.pypi.org/legacy/"
+
+// Upload uploads a distribution file using the legacy upload API
+// (as used by twine). The content is streamed and read twice,
+// once to calculate the digests and once for the upload.
+func Upload(ctx context.Context, client *http.Client, uploadURL string, creds cpi.Credentials, dist *Distribution, content io.ReaderAt, size int64) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if uploadURL == "" {
+		uploadURL = DEFAULT_UPLOAD_URL
+	}
+	sha := sha256.New()
+	md := md5.New()
+	if _, err := io.Copy(io.MultiWriter(sha, md), io.NewSectionReader(content, 0, size)); err != nil {
+		return err
+	}
+
+	var header bytes.Buffer
+	w := multipart.NewWriter(&header)
+	fields := [][2]string{
+		{":action", "file_upload"},
+		{"protocol_version", "1"},
+		{"metadata_version", dist.Metadata.MetadataVersion},
+		{"name", dist.Metadata.Name},
+		{"version", dist.Metadata.Version},
+		{"summary", dist.Metadata.Summary},
+		{"filetype", dist.FileType},
+		{"pyversion", dist.PyVersion},
+		{"sha256_digest", hex.EncodeToString(sha.Sum(nil))},
+		{"md5_digest", hex.EncodeToString(md.Sum(nil))},
+	}
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		if err := w.WriteField(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	if _, err := w.CreateFormFile("content", dist.Filename); err != nil {
+		return err
+	}
+	// the file content is streamed between the already written
+	// header and the closing boundary written by Close.
+	n := header.Len()
+	if err := w.Close(); err != nil {
+		return err
+	}
+	prefix, suffix := header.Bytes()[:n], header.Bytes()[n:]
+
+	body := io.MultiReader(bytes.NewReader(prefix), io.NewSectionReader(content, 0, size), bytes.NewReader(suffix))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(prefix)) + size + int64(len(suffix))
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	SetAuth(req, creds)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		all, _ := io.ReadAll(resp.Body)
+		return errors.Newf("http (%d) - failed to upload %s: %s", resp.StatusCode, dist.Filename, limit(all))
+	}
+	return nil
+}
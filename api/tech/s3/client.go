@@ -0,0 +1,207 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscreds "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/mandelsoft/goutils/errors"
+)
+
+// DefaultRegion is the region used to discover the region of a bucket,
+// if no region is specified.
+const DefaultRegion = "us-west-1"
+
+// AWSCreds groups AWS related credential values together.
+type AWSCreds struct {
+	AccessKeyID  string
+	AccessSecret string
+	SessionToken string
+}
+
+// Client is an S3 client for a dedicated bucket.
+type Client struct {
+	client *s3.Client
+	bucket string
+	region string
+}
+
+// NewClient provides a client for the given bucket. If no region is given, the region
+// of the bucket is determined. The endpoint is optional and is used to address
+// S3-compatible storage services, which are accessed using path-style addressing.
+func NewClient(ctx context.Context, region, endpoint, bucket string, creds *AWSCreds) (*Client, error) {
+	if bucket == "" {
+		return nil, errors.ErrRequired("bucket")
+	}
+	var awsCred aws.CredentialsProvider = aws.AnonymousCredentials{}
+	if creds != nil {
+		awsCred = awscreds.StaticCredentialsProvider{
+			Value: aws.Credentials{
+				AccessKeyID:     creds.AccessKeyID,
+				SecretAccessKey: creds.AccessSecret,
+				SessionToken:    creds.SessionToken,
+			},
+		}
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region), config.WithCredentialsProvider(awsCred))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration for AWS: %w", err)
+	}
+
+	options := func(r string) func(o *s3.Options) {
+		return func(o *s3.Options) {
+			// Pass in creds because of https://github.com/aws/aws-sdk-go-v2/issues/1797
+			o.Credentials = awsCred
+			o.Region = r
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+				o.UsePathStyle = true
+			}
+		}
+	}
+
+	if region == "" {
+		// deliberately use a different client so the real one will use the right region.
+		// Region has to be provided to get the region of the specified bucket. We use the
+		// global "default" of us-west-1 here. This will be updated to the right region
+		// once we retrieve it or die trying.
+		// With the new API introduced, transfermanager no longer has GetBucketRegion.
+		// Thus, we just implement GetBucketRegion here instead as it was in the old manager SDK.
+		region, err = getBucketRegion(ctx, s3.NewFromConfig(cfg, options(DefaultRegion)), bucket)
+		if err != nil {
+			return nil, err
+		}
+		if region == "" {
+			region = DefaultRegion
+		}
+		cfg.Region = region
+	}
+	return &Client{
+		client: s3.NewFromConfig(cfg, options(region)),
+		bucket: bucket,
+		region: region,
+	}, nil
+}
+
+func getBucketRegion(ctx context.Context, client *s3.Client, bucket string) (string, error) {
+	resp, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err == nil {
+		return aws.ToString(resp.BucketRegion), nil
+	}
+	// S3 returns 301 when the bucket is in a different region than the hint region.
+	// The SDK does not follow 301 redirects, but the correct region is in X-Amz-Bucket-Region.
+	var respErr *smithyhttp.ResponseError
+	if !errors.As(err, &respErr) || respErr.HTTPStatusCode() != http.StatusMovedPermanently {
+		return "", fmt.Errorf("failed to find bucket region: %w", err)
+	}
+	region := respErr.Response.Header.Get("X-Amz-Bucket-Region")
+	if region == "" {
+		return "", fmt.Errorf("failed to find bucket region: %w", err)
+	}
+	return region, nil
+}
+
+// Region provides the (discovered) region of the bucket.
+func (c *Client) Region() string {
+	return c.region
+}
+
+// Bucket provides the name of the bucket.
+func (c *Client) Bucket() string {
+	return c.bucket
+}
+
+// Download writes the content of the object with the given key to w.
+// If no version is given, the latest version is downloaded.
+func (c *Client) Download(ctx context.Context, key, version string, w io.WriterAt) error {
+	input := &transfermanager.DownloadObjectInput{
+		Bucket:   aws.String(c.bucket),
+		Key:      aws.String(key),
+		WriterAt: w,
+	}
+	if version != "" {
+		input.VersionID = aws.String(version)
+	}
+	if _, err := transfermanager.New(c.client).DownloadObject(ctx, input); err != nil {
+		return fmt.Errorf("failed to download object: %w", err)
+	}
+	return nil
+}
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Size      int64
+	ETag      string
+	VersionID string
+}
+
+// GetObjectInfo provides information about the object with the given key.
+// If the object does not exist, nil is returned.
+func (c *Client) GetObjectInfo(ctx context.Context, key string) (*ObjectInfo, error) {
+	resp, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		var respErr *smithyhttp.ResponseError
+		if errors.As(err, &notFound) || (errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get object info: %w", err)
+	}
+	return &ObjectInfo{
+		Size:      aws.ToInt64(resp.ContentLength),
+		ETag:      aws.ToString(resp.ETag),
+		VersionID: aws.ToString(resp.VersionId),
+	}, nil
+}
+
+// Upload stores the content of r under the given key and provides the version id
+// of the created object, if the bucket is versioned.
+func (c *Client) Upload(ctx context.Context, key, mediaType string, r io.Reader) (string, error) {
+	input := &transfermanager.UploadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+	}
+	if mediaType != "" {
+		input.ContentType = aws.String(mediaType)
+	}
+	resp, err := transfermanager.New(c.client).UploadObject(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload object: %w", err)
+	}
+	return aws.ToString(resp.VersionID), nil
+}
+
+// EndpointHost provides the host[:port] part of an endpoint URL
+// as used for the consumer identity of an S3 object.
+func EndpointHost(endpoint string) (string, error) {
+	if endpoint == "" {
+		return "", nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.ErrInvalidWrap(err, "endpoint", endpoint)
+	}
+	if u.Host == "" {
+		return "", errors.ErrInvalid("endpoint", endpoint)
+	}
+	return u.Host, nil
+}
@@ -0,0 +1,78 @@
+package s3_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager/types"
+
+	me "ocm.software/ocm/api/tech/s3"
+	"ocm.software/ocm/api/tech/s3/s3test"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const BUCKET = "artifacts"
+
+var _ = Describe("s3 client", func() {
+	It("provides endpoint hosts", func() {
+		Expect(me.EndpointHost("")).To(Equal(""))
+		Expect(me.EndpointHost("http://127.0.0.1:9000")).To(Equal("127.0.0.1:9000"))
+		Expect(me.EndpointHost("https://minio.acme.org/")).To(Equal("minio.acme.org"))
+		Expect(me.EndpointHost("minio.acme.org:9000")).To(Equal("minio.acme.org:9000"))
+		ExpectError(me.EndpointHost("http://")).To(MatchError(`endpoint "http://" is invalid`))
+	})
+
+	It("requires a bucket", func() {
+		ExpectError(me.NewClient(context.Background(), "", "", "", nil)).To(MatchError(`"bucket" required`))
+	})
+
+	Context("storage service", func() {
+		var server *s3test.Server
+		var creds *me.AWSCreds
+
+		BeforeEach(func() {
+			server = s3test.NewServer(BUCKET)
+			creds = &me.AWSCreds{AccessKeyID: "minioadmin", AccessSecret: "minioadmin"}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("discovers the bucket region", func() {
+			client := Must(me.NewClient(context.Background(), "", server.URL, BUCKET, creds))
+			Expect(client.Region()).To(Equal(s3test.REGION))
+		})
+
+		It("uploads and downloads objects", func() {
+			ctx := context.Background()
+			client := Must(me.NewClient(ctx, "", server.URL, BUCKET, creds))
+
+			Expect(client.GetObjectInfo(ctx, "bin/tool")).To(BeNil())
+			version := Must(client.Upload(ctx, "bin/tool", mime.MIME_TEXT, strings.NewReader("hello world")))
+			Expect(version).NotTo(BeEmpty())
+			Expect(string(server.Get(BUCKET, "bin/tool"))).To(Equal("hello world"))
+			Expect(server.MediaType(BUCKET, "bin/tool")).To(Equal(mime.MIME_TEXT))
+
+			info := Must(client.GetObjectInfo(ctx, "bin/tool"))
+			Expect(info.Size).To(Equal(int64(11)))
+			Expect(info.VersionID).To(Equal(version))
+
+			server.Put(BUCKET, "bin/tool", []byte("other"), mime.MIME_TEXT)
+
+			buf := types.NewWriteAtBuffer(nil)
+			MustBeSuccessful(client.Download(ctx, "bin/tool", version, buf))
+			Expect(string(buf.Bytes())).To(Equal("hello world"))
+		})
+
+		It("fails for unknown objects", func() {
+			ctx := context.Background()
+			client := Must(me.NewClient(ctx, s3test.REGION, server.URL, BUCKET, creds))
+			Expect(client.Download(ctx, "unknown", "", types.NewWriteAtBuffer(nil))).To(HaveOccurred())
+		})
+	})
+})
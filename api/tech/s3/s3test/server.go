@@ -0,0 +1,314 @@
+package s3test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// REGION is the region reported for all buckets of the fake service.
+const REGION = "eu-west-1"
+
+type object struct {
+	data      []byte
+	mediaType string
+	etag      string
+	version   string
+}
+
+type upload struct {
+	bucket string
+	key    string
+	media  string
+	parts  map[int][]byte
+}
+
+// Server is a minimal in-memory stand-in for an S3-compatible storage
+// service using path-style URLs (like MinIO). It supports bucket
+// region lookup, simple and multipart uploads, object download and
+// head requests. All buckets are versioned.
+type Server struct {
+	*httptest.Server
+	lock     sync.Mutex
+	buckets  map[string]map[string][]*object
+	multi    map[string]*upload
+	uploads  int
+	versions int
+}
+
+func NewServer(buckets ...string) *Server {
+	s := &Server{
+		buckets: map[string]map[string][]*object{},
+		multi:   map[string]*upload{},
+	}
+	for _, b := range buckets {
+		s.buckets[b] = map[string][]*object{}
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Uploads provides the number of completed uploads.
+func (s *Server) Uploads() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.uploads
+}
+
+// Put stores an object and provides its version id.
+func (s *Server) Put(bucket, key string, data []byte, mediaType string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.put(bucket, key, data, mediaType).version
+}
+
+// Get provides the actual content of an object.
+func (s *Server) Get(bucket, key string) []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	o := s.lookup(bucket, key, "")
+	if o == nil {
+		return nil
+	}
+	return o.data
+}
+
+// MediaType provides the content type of an object.
+func (s *Server) MediaType(bucket, key string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	o := s.lookup(bucket, key, "")
+	if o == nil {
+		return ""
+	}
+	return o.mediaType
+}
+
+// Keys provides the keys of all objects in a bucket.
+func (s *Server) Keys(bucket string) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var keys []string
+	for k := range s.buckets[bucket] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *Server) put(bucket, key string, data []byte, mediaType string) *object {
+	s.versions++
+	sum := md5.Sum(data)
+	o := &object{
+		data:      data,
+		mediaType: mediaType,
+		etag:      `"` + hex.EncodeToString(sum[:]) + `"`,
+		version:   fmt.Sprintf("v%d", s.versions),
+	}
+	s.buckets[bucket][key] = append(s.buckets[bucket][key], o)
+	return o
+}
+
+func (s *Server) lookup(bucket, key, version string) *object {
+	list := s.buckets[bucket][key]
+	if len(list) == 0 {
+		return nil
+	}
+	if version == "" {
+		return list[len(list)-1]
+	}
+	for _, o := range list {
+		if o.version == version {
+			return o
+		}
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if _, ok := s.buckets[bucket]; !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	w.Header().Set("X-Amz-Bucket-Region", REGION)
+	if key == "" {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		writeError(w, http.StatusNotImplemented, "NotImplemented")
+		return
+	}
+
+	q := r.URL.Query()
+	switch r.Method {
+	case http.MethodHead, http.MethodGet:
+		s.get(w, r, bucket, key)
+	case http.MethodPut:
+		data, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		if id := q.Get("uploadId"); id != "" {
+			u := s.multi[id]
+			n, err := strconv.Atoi(q.Get("partNumber"))
+			if u == nil || err != nil {
+				writeError(w, http.StatusNotFound, "NoSuchUpload")
+				return
+			}
+			u.parts[n] = data
+			sum := md5.Sum(data)
+			w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		o := s.put(bucket, key, data, r.Header.Get("Content-Type"))
+		s.uploads++
+		w.Header().Set("ETag", o.etag)
+		w.Header().Set("x-amz-version-id", o.version)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		if q.Has("uploads") {
+			id := fmt.Sprintf("upload-%d", len(s.multi)+1)
+			s.multi[id] = &upload{bucket: bucket, key: key, media: r.Header.Get("Content-Type"), parts: map[int][]byte{}}
+			writeXML(w, &initiateResult{Bucket: bucket, Key: key, UploadID: id})
+			return
+		}
+		id := q.Get("uploadId")
+		u := s.multi[id]
+		if u == nil {
+			writeError(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		delete(s.multi, id)
+		var nums []int
+		for n := range u.parts {
+			nums = append(nums, n)
+		}
+		sort.Ints(nums)
+		var data []byte
+		for _, n := range nums {
+			data = append(data, u.parts[n]...)
+		}
+		o := s.put(u.bucket, u.key, data, u.media)
+		s.uploads++
+		w.Header().Set("x-amz-version-id", o.version)
+		writeXML(w, &completeResult{Bucket: bucket, Key: key, ETag: o.etag})
+	case http.MethodDelete:
+		delete(s.multi, q.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	o := s.lookup(bucket, key, r.URL.Query().Get("versionId"))
+	if o == nil {
+		writeError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+	data := o.data
+	status := http.StatusOK
+	if rng := r.Header.Get("Range"); rng != "" {
+		var start, end int
+		if _, err := fmt.Sscanf(rng, "bytes=%d-%d", &start, &end); err != nil || start >= len(data) {
+			writeError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+			return
+		}
+		end = min(end, len(data)-1)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+		data = data[start : end+1]
+		status = http.StatusPartialContent
+	}
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("x-amz-version-id", o.version)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	if o.mediaType != "" {
+		w.Header().Set("Content-Type", o.mediaType)
+	}
+	w.WriteHeader(status)
+	if r.Method == http.MethodGet {
+		w.Write(data)
+	}
+}
+
+// readBody reads a request body, decoding the aws-chunked encoding
+// used by the SDK for streamed payloads with trailing checksums.
+func readBody(r *http.Request) ([]byte, error) {
+	if !strings.Contains(r.Header.Get("Content-Encoding"), "aws-chunked") &&
+		!strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	var data bytes.Buffer
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		n, err := strconv.ParseInt(size, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return data.Bytes(), nil
+		}
+		if _, err := io.CopyN(&data, br, n); err != nil {
+			return nil, err
+		}
+		if _, err := br.ReadString('\n'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type initiateResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type completeResult struct {
+	XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+	Bucket  string   `xml:"Bucket"`
+	Key     string   `xml:"Key"`
+	ETag    string   `xml:"ETag"`
+}
+
+type errorResult struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+func writeXML(w http.ResponseWriter, v interface{}) {
+	data, _ := xml.Marshal(v)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	data, _ := xml.Marshal(&errorResult{Code: code, Message: code})
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,197 @@
+package ecdsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// Algorithm defines the type for the ECDSA signature algorithm
+// based on the NIST P-256 curve.
+// The algorithms are named by the curve, because, like for all other
+// algorithms, the signed digest is the one provided by the configured
+// hash algorithm.
+const Algorithm = "ECDSA-P256"
+
+// AlgorithmP384 defines the type for the ECDSA signature algorithm
+// based on the NIST P-384 curve.
+const AlgorithmP384 = "ECDSA-P384"
+
+// MediaType defines the media type for a plain ASN.1 encoded ECDSA signature.
+const MediaType = "application/vnd.ocm.signature.ecdsa"
+
+// MediaTypePEM is used if the signature contains the public key certificate chain.
+const MediaTypePEM = signutils.MediaTypePEM
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSigner(Algorithm, NewHandler())
+	signing.DefaultHandlerRegistry().RegisterSigner(AlgorithmP384, NewHandlerFor(P384))
+}
+
+type (
+	PrivateKey = ecdsa.PrivateKey
+	PublicKey  = ecdsa.PublicKey
+)
+
+// Method describes an ECDSA signature algorithm by the
+// elliptic curve required for the keys.
+type Method struct {
+	Algorithm string
+	Curve     elliptic.Curve
+}
+
+var (
+	P256 = &Method{Algorithm: Algorithm, Curve: elliptic.P256()}
+	P384 = &Method{Algorithm: AlgorithmP384, Curve: elliptic.P384()}
+)
+
+// Handler is a signatures.Signer compatible struct to sign with ECDSA
+// and a signatures.Verifier compatible struct to verify ECDSA signatures.
+type Handler struct {
+	method *Method
+}
+
+func NewHandler() signing.SignatureHandler {
+	return NewHandlerFor(P256)
+}
+
+func NewHandlerFor(m *Method) signing.SignatureHandler {
+	return &Handler{method: m}
+}
+
+func (h *Handler) getMethod() *Method {
+	if h.method == nil {
+		return P256
+	}
+	return h.method
+}
+
+func (h *Handler) Algorithm() string {
+	return h.getMethod().Algorithm
+}
+
+func (h *Handler) checkCurve(key *PublicKey) error {
+	if key.Curve != h.getMethod().Curve {
+		return fmt.Errorf("ecdsa key curve %s does not match algorithm %s", key.Curve.Params().Name, h.Algorithm())
+	}
+	return nil
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	privateKey, err := GetPrivateKey(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ecdsa private key")
+	}
+	if err := h.checkCurve(&privateKey.PublicKey); err != nil {
+		return nil, err
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, privateKey, decodedHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+
+	enc, err := signutils.EncodeSignature(h.Algorithm(), MediaType, sig, sctx, func(pub interface{}) error {
+		pubKey, _, err := GetPublicKey(pub)
+		if err != nil {
+			return errors.ErrInvalidWrap(err, "public key")
+		}
+		if !privateKey.PublicKey.Equal(pubKey) {
+			return fmt.Errorf("invalid public key for private key")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &signing.Signature{
+		Value:     enc.Value,
+		MediaType: enc.MediaType,
+		Algorithm: h.Algorithm(),
+		Issuer:    enc.Issuer,
+	}, nil
+}
+
+// Verify checks the signature, returns an error on verification failure.
+func (h *Handler) Verify(digest string, signature *signing.Signature, sctx signing.SigningContext) (err error) {
+	var signatureBytes []byte
+
+	publicKey, name, err := GetPublicKey(sctx.GetPublicKey())
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+	if err := h.checkCurve(publicKey); err != nil {
+		return err
+	}
+
+	switch signature.MediaType {
+	case MediaType:
+		signatureBytes, err = hex.DecodeString(signature.Value)
+		if err != nil {
+			return fmt.Errorf("unable to get signature value: failed decoding hash %s: %w", digest, err)
+		}
+	case signutils.MediaTypePEM:
+		sig, algo, _, err := signutils.GetSignatureFromPem([]byte(signature.Value))
+		if err != nil {
+			return fmt.Errorf("unable to get signature from pem: %w", err)
+		}
+		if algo != "" && algo != h.Algorithm() {
+			return errors.ErrInvalid(signutils.KIND_SIGN_ALGORITHM, algo)
+		}
+		signatureBytes = sig
+	default:
+		return fmt.Errorf("invalid signature mediaType %s", signature.MediaType)
+	}
+
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
+	}
+
+	if name != nil {
+		if signature.Issuer != "" {
+			iss, err := signutils.ParseDN(signature.Issuer)
+			if err != nil {
+				return errors.Wrapf(err, "signature issuer")
+			}
+			if signutils.MatchDN(*iss, *name) != nil {
+				return fmt.Errorf("issuer %s does not match %s", signature.Issuer, name)
+			}
+		}
+	}
+	if !ecdsa.VerifyASN1(publicKey, decodedHash, signatureBytes) {
+		return fmt.Errorf("signature verification failed, invalid signature")
+	}
+
+	return nil
+}
+
+// CreateKeyPair creates a key pair for the curve used by the algorithm
+// of the handler.
+func (h Handler) CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	return CreateKeyPairFor(h.getMethod().Curve)
+}
+
+// CreateKeyPair creates a key pair for the P-256 curve.
+func CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	return CreateKeyPairFor(elliptic.P256())
+}
+
+func CreateKeyPairFor(curve elliptic.Curve) (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, &key.PublicKey, nil
+}
@@ -0,0 +1,136 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+func GetPublicKey(key interface{}) (ed25519.PublicKey, *pkix.Name, error) {
+	var err error
+	if data, ok := key.([]byte); ok {
+		key, err = ParseKey(data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return k, nil, nil
+	case ed25519.PrivateKey:
+		return k.Public().(ed25519.PublicKey), nil, nil
+	case *x509.Certificate:
+		if p, ok := k.PublicKey.(ed25519.PublicKey); ok {
+			return p, &k.Subject, nil
+		}
+		return nil, nil, fmt.Errorf("unknown key public key %T in certificate", k.PublicKey)
+	default:
+		return nil, nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+func GetPrivateKey(key interface{}) (ed25519.PrivateKey, error) {
+	if data, ok := key.([]byte); ok {
+		return ParsePrivateKey(data)
+	}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+func WriteKeyData(key interface{}, w io.Writer) error {
+	if data, ok := key.([]byte); ok {
+		_, err := w.Write(data)
+		return err
+	}
+	block, err := PemBlockForKey(key)
+	if err != nil {
+		return err
+	}
+	return pem.Encode(w, block)
+}
+
+func KeyData(key interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	block, err := PemBlockForKey(key)
+	if err != nil {
+		return nil, err
+	}
+	err = pem.Encode(buf, block)
+	return buf.Bytes(), err
+}
+
+func PemBlockForKey(priv interface{}) (*pem.Block, error) {
+	switch k := priv.(type) {
+	case ed25519.PublicKey:
+		bytes, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "PUBLIC KEY", Bytes: bytes}, nil
+	case ed25519.PrivateKey:
+		bytes, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: bytes}, nil
+	default:
+		return nil, errors.ErrInvalid("key")
+	}
+}
+
+func ParseKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid key format (expected pem block)")
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		return ParsePrivateKey(data)
+	case "CERTIFICATE":
+		return x509.ParseCertificate(block.Bytes)
+	}
+	return ParsePublicKey(data)
+}
+
+func ParsePublicKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key format (expected pem block)")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DER encoded public key: %w", err)
+	}
+	switch pub := pub.(type) {
+	case ed25519.PublicKey:
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unknown type of public key")
+	}
+}
+
+func ParsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid private key format (expected pem block)")
+	}
+	untypedPrivateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing key %w", err)
+	}
+	key, ok := untypedPrivateKey.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("parsed key is not of type ed25519.PrivateKey: %T", untypedPrivateKey)
+	}
+	return key, nil
+}
@@ -0,0 +1,140 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// Algorithm defines the type for the Ed25519 signature algorithm.
+// The digest is signed as message using pure Ed25519.
+const Algorithm = "Ed25519"
+
+// MediaType defines the media type for a plain Ed25519 signature.
+const MediaType = "application/vnd.ocm.signature.ed25519"
+
+// MediaTypePEM is used if the signature contains the public key certificate chain.
+const MediaTypePEM = signutils.MediaTypePEM
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSigner(Algorithm, NewHandler())
+}
+
+type (
+	PrivateKey = ed25519.PrivateKey
+	PublicKey  = ed25519.PublicKey
+)
+
+// Handler is a signatures.Signer compatible struct to sign with Ed25519
+// and a signatures.Verifier compatible struct to verify Ed25519 signatures.
+type Handler struct{}
+
+func NewHandler() signing.SignatureHandler {
+	return &Handler{}
+}
+
+func (h *Handler) Algorithm() string {
+	return Algorithm
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	privateKey, err := GetPrivateKey(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ed25519 private key")
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	sig := ed25519.Sign(privateKey, decodedHash)
+
+	enc, err := signutils.EncodeSignature(h.Algorithm(), MediaType, sig, sctx, func(pub interface{}) error {
+		pubKey, _, err := GetPublicKey(pub)
+		if err != nil {
+			return errors.ErrInvalidWrap(err, "public key")
+		}
+		if !pubKey.Equal(privateKey.Public()) {
+			return fmt.Errorf("invalid public key for private key")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &signing.Signature{
+		Value:     enc.Value,
+		MediaType: enc.MediaType,
+		Algorithm: h.Algorithm(),
+		Issuer:    enc.Issuer,
+	}, nil
+}
+
+// Verify checks the signature, returns an error on verification failure.
+func (h *Handler) Verify(digest string, signature *signing.Signature, sctx signing.SigningContext) (err error) {
+	var signatureBytes []byte
+
+	publicKey, name, err := GetPublicKey(sctx.GetPublicKey())
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	switch signature.MediaType {
+	case MediaType:
+		signatureBytes, err = hex.DecodeString(signature.Value)
+		if err != nil {
+			return fmt.Errorf("unable to get signature value: failed decoding hash %s: %w", digest, err)
+		}
+	case signutils.MediaTypePEM:
+		sig, algo, _, err := signutils.GetSignatureFromPem([]byte(signature.Value))
+		if err != nil {
+			return fmt.Errorf("unable to get signature from pem: %w", err)
+		}
+		if algo != "" && algo != h.Algorithm() {
+			return errors.ErrInvalid(signutils.KIND_SIGN_ALGORITHM, algo)
+		}
+		signatureBytes = sig
+	default:
+		return fmt.Errorf("invalid signature mediaType %s", signature.MediaType)
+	}
+
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
+	}
+
+	if name != nil {
+		if signature.Issuer != "" {
+			iss, err := signutils.ParseDN(signature.Issuer)
+			if err != nil {
+				return errors.Wrapf(err, "signature issuer")
+			}
+			if signutils.MatchDN(*iss, *name) != nil {
+				return fmt.Errorf("issuer %s does not match %s", signature.Issuer, name)
+			}
+		}
+	}
+	if !ed25519.Verify(publicKey, decodedHash, signatureBytes) {
+		return fmt.Errorf("signature verification failed, invalid signature")
+	}
+
+	return nil
+}
+
+func (_ Handler) CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	return CreateKeyPair()
+}
+
+func CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	pubKey, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, pubKey, nil
+}
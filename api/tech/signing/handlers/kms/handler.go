@@ -0,0 +1,151 @@
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/elliptic"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	ecdsahandler "ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	rsahandler "ocm.software/ocm/api/tech/signing/handlers/rsa"
+	rsa_pss "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
+	"ocm.software/ocm/api/tech/signing/kms"
+	_ "ocm.software/ocm/api/tech/signing/kms/aws"
+	_ "ocm.software/ocm/api/tech/signing/kms/azure"
+	_ "ocm.software/ocm/api/tech/signing/kms/gcp"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// Names of the signers using a key managed by a KMS.
+// The created signatures use the standard signature algorithms
+// and can be verified with the standard verifiers.
+const (
+	NAME_RSA        = "kms-rsa"
+	NAME_RSA_PSS    = "kms-rsa-pss"
+	NAME_ECDSA_P256 = "kms-ecdsa-p256"
+)
+
+func init() {
+	for _, m := range []*Method{RSA, RSA_PSS, ECDSA_P256} {
+		signing.DefaultHandlerRegistry().RegisterSigner(m.Name, NewHandlerFor(m))
+	}
+}
+
+// Method describes a signature algorithm executed by a KMS.
+type Method = signutils.Method
+
+var RSA = &Method{
+	Name:      NAME_RSA,
+	Algorithm: rsahandler.Algorithm,
+	MediaType: rsahandler.MediaType,
+	Scheme:    kms.RSA_PKCS1V15,
+}
+
+var RSA_PSS = &Method{
+	Name:      NAME_RSA_PSS,
+	Algorithm: rsa_pss.Algorithm,
+	MediaType: rsa_pss.MediaType,
+	Scheme:    kms.RSA_PSS,
+}
+
+var ECDSA_P256 = &Method{
+	Name:      NAME_ECDSA_P256,
+	Algorithm: ecdsahandler.Algorithm,
+	MediaType: ecdsahandler.MediaType,
+	Scheme:    kms.ECDSA,
+	Curve:     elliptic.P256(),
+}
+
+// SignerName provides the name of the KMS signer for
+// a standard signature algorithm. If there is none,
+// an empty string is returned.
+func SignerName(algo string) string {
+	for _, m := range []*Method{RSA, RSA_PSS, ECDSA_P256} {
+		if m.Algorithm == algo || m.Name == algo {
+			return m.Name
+		}
+	}
+	return ""
+}
+
+// Handler is a signatures.Signer compatible struct to sign
+// with a key managed by a KMS. The private key is given by a
+// key reference (kms://<provider>/<key path>), the signature is
+// created by the backend registered for the provider.
+type Handler struct {
+	method *Method
+}
+
+func NewHandlerFor(m *Method) signing.Signer {
+	return &Handler{method: m}
+}
+
+func (h *Handler) Algorithm() string {
+	return h.method.Algorithm
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	ref, err := kms.GetKeyRef(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.ErrInvalidWrap(err, kms.KIND_KEYREF)
+	}
+	if cctx == nil {
+		cctx = signing.GetCredentialsContext(sctx)
+	}
+
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+
+	// the key material is not available locally. Without a configured
+	// public key, the public key is requested from the KMS to check
+	// it before signing.
+	var kmsKey crypto.PublicKey
+	if sctx.GetPublicKey() == nil {
+		kmsKey, err = kms.GetPublicKey(context.Background(), cctx, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get public key for %s", ref)
+		}
+		if err := h.method.CheckPublicKey(kmsKey); err != nil {
+			return nil, errors.Wrapf(err, "invalid key %s", ref)
+		}
+	}
+
+	sig, err := kms.Sign(context.Background(), cctx, ref, h.method.Scheme, sctx.GetHash(), decodedHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed signing hash with %s", ref)
+	}
+
+	if kmsKey != nil {
+		if err := h.method.Verify(kmsKey, sctx.GetHash(), decodedHash, sig); err != nil {
+			return nil, errors.Wrapf(err, "invalid signature created by %s", ref)
+		}
+	}
+	// otherwise, the created signature is checked against the
+	// configured public key.
+	enc, err := signutils.EncodeSignature(h.Algorithm(), h.method.MediaType, sig, sctx, func(pub interface{}) error {
+		pubKey, err := signutils.GetPublicKey(pub)
+		if err != nil {
+			return errors.ErrInvalidWrap(err, "public key")
+		}
+		if err := h.method.Verify(pubKey, sctx.GetHash(), decodedHash, sig); err != nil {
+			return errors.Wrapf(err, "invalid public key for %s", ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &signing.Signature{
+		Value:     enc.Value,
+		MediaType: enc.MediaType,
+		Algorithm: h.Algorithm(),
+		Issuer:    enc.Issuer,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package kms_test
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"crypto/x509/pkix"
+	"net/url"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	me "ocm.software/ocm/api/tech/signing/handlers/kms"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	rsa_pss "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/kms"
+	"ocm.software/ocm/api/tech/signing/kms/gcp"
+	"ocm.software/ocm/api/tech/signing/kms/kmstest"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+const KEY = "projects/ocm/locations/global/keyRings/release/cryptoKeys/signing/cryptoKeyVersions/1"
+
+var _ = Describe("kms signing", func() {
+	var server *kmstest.Server
+	var cctx credentials.Context
+	var hash string
+	var ref string
+
+	BeforeEach(func() {
+		server = kmstest.NewServer()
+		cctx = credentials.New()
+		cctx.SetCredentialsForConsumer(gcp.GetConsumerId(server.URL, KEY), credentials.CredentialsFromList(gcp.ATTR_TOKEN, kmstest.TOKEN))
+		hasher := signing.DefaultRegistry().GetHasher(sha256.Algorithm)
+		hash, _ = signing.Hash(hasher.Create(), []byte("test"))
+		ref = "kms://gcp/" + KEY + "?endpoint=" + url.QueryEscape(server.URL)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	DescribeTable("signs with kms key", func(m *me.Method, create func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error), verifier signing.Verifier) {
+		priv, pub := Must2(create())
+		server.AddKey(KEY, priv.(crypto.Signer), m.Scheme)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: ref,
+			PublicKey:  pub,
+		}
+		sig := Must(me.NewHandlerFor(m).Sign(cctx, hash, sctx))
+		Expect(server.Requests()).To(Equal(1))
+
+		Expect(sig.Algorithm).To(Equal(m.Algorithm))
+		Expect(sig.MediaType).To(Equal(m.MediaType))
+		MustBeSuccessful(verifier.Verify(hash, sig, &signing.DefaultSigningContext{Hash: crypto.SHA256, PublicKey: pub}))
+	},
+		Entry("rsa", me.RSA, rsa.CreateKeyPair, rsa.NewHandler()),
+		Entry("rsa-pss", me.RSA_PSS, rsa.CreateKeyPair, rsa_pss.NewHandler()),
+		Entry("ecdsa P-256", me.ECDSA_P256, ecdsa.CreateKeyPair, ecdsa.NewHandler()),
+	)
+
+	It("uses credentials context of signing context", func() {
+		priv, _ := Must2(rsa.CreateKeyPair())
+		server.AddKey(KEY, priv.(crypto.Signer), kms.RSA_PKCS1V15)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: Must(kms.ParseKeyRef(ref)),
+			Context:    cctx,
+		}
+		Must(me.NewHandlerFor(me.RSA).Sign(nil, hash, sctx))
+	})
+
+	It("rejects invalid key reference", func() {
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: "kms://gcp/",
+		}
+		ExpectError(me.NewHandlerFor(me.RSA).Sign(cctx, hash, sctx)).To(MatchError(ContainSubstring(`key reference "kms://gcp/" is invalid`)))
+	})
+
+	It("signs with certificate", func() {
+		ca, capriv := Must2(ecdsa.CreateRootCertificate(&pkix.Name{CommonName: "ca"}, time.Hour))
+		cert, certs, priv := Must3(ecdsa.CreateSigningCertificate(&pkix.Name{CommonName: "release"}, ca, ca, capriv, time.Hour))
+		server.AddKey(KEY, priv, kms.ECDSA)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: ref,
+			PublicKey:  certs,
+			RootCerts:  ca,
+			Issuer:     &pkix.Name{CommonName: "release"},
+		}
+		sig := Must(me.NewHandlerFor(me.ECDSA_P256).Sign(cctx, hash, sctx))
+		Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+		Expect(sig.Issuer).To(Equal("CN=release"))
+		MustBeSuccessful(ecdsa.NewHandler().Verify(hash, sig, &signing.DefaultSigningContext{Hash: crypto.SHA256, PublicKey: cert}))
+
+		other, _ := Must2(ecdsa.CreateKeyPair())
+		server.AddKey(KEY, other.(crypto.Signer), kms.ECDSA)
+		ExpectError(me.NewHandlerFor(me.ECDSA_P256).Sign(cctx, hash, sctx)).To(MatchError(ContainSubstring("invalid public key for kms://gcp/")))
+	})
+
+	It("rejects ecdsa key with wrong curve", func() {
+		priv, pub := Must2(ecdsa.CreateKeyPairFor(elliptic.P384()))
+		server.AddKey(KEY, priv.(crypto.Signer), kms.ECDSA)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: ref,
+			PublicKey:  pub,
+		}
+		ExpectError(me.NewHandlerFor(me.ECDSA_P256).Sign(cctx, hash, sctx)).To(MatchError(ContainSubstring("ecdsa key curve P-384 does not match algorithm ECDSA-P256")))
+	})
+
+	It("checks the kms key before signing without public key", func() {
+		priv, _ := Must2(ecdsa.CreateKeyPairFor(elliptic.P384()))
+		server.AddKey(KEY, priv.(crypto.Signer), kms.ECDSA)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: ref,
+		}
+		ExpectError(me.NewHandlerFor(me.ECDSA_P256).Sign(cctx, hash, sctx)).To(MatchError(ContainSubstring("ecdsa key curve P-384 does not match algorithm ECDSA-P256")))
+		Expect(server.Requests()).To(Equal(0))
+
+		priv, pub := Must2(ecdsa.CreateKeyPair())
+		server.AddKey(KEY, priv.(crypto.Signer), kms.ECDSA)
+		sig := Must(me.NewHandlerFor(me.ECDSA_P256).Sign(cctx, hash, sctx))
+		Expect(server.Requests()).To(Equal(1))
+		MustBeSuccessful(ecdsa.NewHandler().Verify(hash, sig, &signing.DefaultSigningContext{Hash: crypto.SHA256, PublicKey: pub}))
+	})
+
+	It("maps signature algorithms", func() {
+		Expect(me.SignerName(rsa.Algorithm)).To(Equal(me.NAME_RSA))
+		Expect(me.SignerName(rsa_pss.Algorithm)).To(Equal(me.NAME_RSA_PSS))
+		Expect(me.SignerName(ecdsa.Algorithm)).To(Equal(me.NAME_ECDSA_P256))
+		Expect(me.SignerName(me.NAME_RSA)).To(Equal(me.NAME_RSA))
+		Expect(me.SignerName(ecdsa.AlgorithmP384)).To(Equal(""))
+	})
+
+	It("is registered", func() {
+		Expect(signing.DefaultRegistry().GetSigner(me.NAME_RSA_PSS).Algorithm()).To(Equal(rsa_pss.Algorithm))
+		Expect(signing.DefaultRegistry().GetSigner(me.NAME_ECDSA_P256).Algorithm()).To(Equal(ecdsa.Algorithm))
+	})
+})
@@ -0,0 +1,222 @@
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	ecdsahandler "ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	rsahandler "ocm.software/ocm/api/tech/signing/handlers/rsa"
+	rsa_pss "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+// Names of the signers using a key stored in a PKCS#11 token.
+// The created signatures use the standard signature algorithms
+// and can be verified with the standard verifiers.
+const (
+	NAME_RSA        = "pkcs11-rsa"
+	NAME_RSA_PSS    = "pkcs11-rsa-pss"
+	NAME_ECDSA_P256 = "pkcs11-ecdsa-p256"
+	NAME_ECDSA_P384 = "pkcs11-ecdsa-p384"
+)
+
+const KIND_PKCS11_KEY = "PKCS#11 key"
+
+func init() {
+	for _, m := range []*Method{RSA, RSA_PSS, ECDSA_P256, ECDSA_P384} {
+		signing.DefaultHandlerRegistry().RegisterSigner(m.Name, NewHandlerFor(m, nil))
+	}
+}
+
+// Key describes a signing key stored in a PKCS#11 token.
+// It is used as private key for the PKCS#11 signers. It does not
+// contain any secret, the PIN for the token is taken from the
+// credentials context (consumer type PKCS11).
+type Key struct {
+	// Module is the path of the PKCS#11 module (shared library).
+	Module string `json:"module"`
+	// Token is the label of the token.
+	Token string `json:"token,omitempty"`
+	// Slot is the slot number of the token (alternatively to Token).
+	Slot *int `json:"slot,omitempty"`
+	// Label is the label of the key pair.
+	Label string `json:"label,omitempty"`
+	// ID is the hex encoded id of the key pair.
+	ID string `json:"id,omitempty"`
+}
+
+func (k *Key) Validate() error {
+	if k.Module == "" {
+		return errors.ErrRequired("PKCS#11 module")
+	}
+	if (k.Token == "") == (k.Slot == nil) {
+		return errors.Newf("exactly one of token or slot required")
+	}
+	if k.Label == "" && k.ID == "" {
+		return errors.ErrRequired("key label or id")
+	}
+	if k.ID != "" {
+		if _, err := hex.DecodeString(k.ID); err != nil {
+			return errors.ErrInvalidWrap(err, "key id", k.ID)
+		}
+	}
+	return nil
+}
+
+// GetKey provides the key specification from a generic private key.
+// It is either given as *Key or as YAML/JSON data.
+func GetKey(k interface{}) (*Key, error) {
+	var key *Key
+	switch t := k.(type) {
+	case *Key:
+		key = t
+	case []byte:
+		key = &Key{}
+		err := runtime.DefaultYAMLEncoding.Unmarshal(t, key)
+		if err != nil {
+			return nil, err
+		}
+	case string:
+		return GetKey([]byte(t))
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+	return key, key.Validate()
+}
+
+// Config is the effective configuration used to access a key.
+type Config struct {
+	Key
+	PIN string
+}
+
+// KeyProvider provides access to the signing key described by a Config.
+// The returned closer must be called after the signing operation.
+type KeyProvider func(cfg *Config) (crypto.Signer, io.Closer, error)
+
+// Method describes a signature algorithm executed by a PKCS#11 token.
+type Method = signutils.Method
+
+var RSA = &Method{
+	Name:      NAME_RSA,
+	Algorithm: rsahandler.Algorithm,
+	MediaType: rsahandler.MediaType,
+	Scheme:    signutils.SCHEME_RSA_PKCS1V15,
+}
+
+var RSA_PSS = &Method{
+	Name:      NAME_RSA_PSS,
+	Algorithm: rsa_pss.Algorithm,
+	MediaType: rsa_pss.MediaType,
+	Scheme:    signutils.SCHEME_RSA_PSS,
+}
+
+var ECDSA_P256 = &Method{
+	Name:      NAME_ECDSA_P256,
+	Algorithm: ecdsahandler.Algorithm,
+	MediaType: ecdsahandler.MediaType,
+	Scheme:    signutils.SCHEME_ECDSA,
+	Curve:     elliptic.P256(),
+}
+
+var ECDSA_P384 = &Method{
+	Name:      NAME_ECDSA_P384,
+	Algorithm: ecdsahandler.AlgorithmP384,
+	MediaType: ecdsahandler.MediaType,
+	Scheme:    signutils.SCHEME_ECDSA,
+	Curve:     elliptic.P384(),
+}
+
+// Handler is a signatures.Signer compatible struct to sign
+// with a key stored in a PKCS#11 token.
+type Handler struct {
+	method   *Method
+	provider KeyProvider
+}
+
+// NewHandlerFor creates a signer for the given method. If no key
+// provider is given, the PKCS#11 module configured for the key is used.
+func NewHandlerFor(m *Method, p KeyProvider) signing.Signer {
+	if p == nil {
+		p = DefaultKeyProvider
+	}
+	return &Handler{method: m, provider: p}
+}
+
+func (h *Handler) Algorithm() string {
+	return h.method.Algorithm
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	key, err := GetKey(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.ErrInvalidWrap(err, KIND_PKCS11_KEY)
+	}
+	if cctx == nil {
+		cctx = signing.GetCredentialsContext(sctx)
+	}
+	pin, err := GetPIN(cctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get PIN for PKCS#11 token")
+	}
+
+	signer, closer, err := h.provider(&Config{Key: *key, PIN: pin})
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	if err := h.method.CheckPublicKey(signer.Public()); err != nil {
+		return nil, errors.Wrapf(err, "token key")
+	}
+
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	sig, err := signer.Sign(rand.Reader, decodedHash, h.method.SignerOpts(sctx.GetHash()))
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+
+	enc, err := signutils.EncodeSignature(h.Algorithm(), h.method.MediaType, sig, sctx, func(pub interface{}) error {
+		pubKey, err := signutils.GetPublicKey(pub)
+		if err != nil {
+			return errors.ErrInvalidWrap(err, "public key")
+		}
+		if !reflect.DeepEqual(signer.Public(), pubKey) {
+			return fmt.Errorf("invalid public key for token key")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &signing.Signature{
+		Value:     enc.Value,
+		MediaType: enc.MediaType,
+		Algorithm: h.Algorithm(),
+		Issuer:    enc.Issuer,
+	}, nil
+}
+
+func keyName(cfg *Config) string {
+	name := cfg.Label
+	if name == "" {
+		name = cfg.ID
+	}
+	if cfg.Token != "" {
+		return cfg.Token + "/" + name
+	}
+	return fmt.Sprintf("%d/%s", *cfg.Slot, name)
+}
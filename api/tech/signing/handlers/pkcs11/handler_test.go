@@ -0,0 +1,151 @@
+package pkcs11_test
+
+import (
+	"crypto"
+	"crypto/x509/pkix"
+	"io"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	me "ocm.software/ocm/api/tech/signing/handlers/pkcs11"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	rsa_pss "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+const KEY = `
+module: /usr/lib/softhsm/libsofthsm2.so
+token: ocm
+label: release
+`
+
+type closer struct {
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return nil
+}
+
+type provider struct {
+	key    crypto.Signer
+	cfg    *me.Config
+	closer closer
+}
+
+func (p *provider) Provide(cfg *me.Config) (crypto.Signer, io.Closer, error) {
+	p.cfg = cfg
+	return p.key, &p.closer, nil
+}
+
+var _ = Describe("pkcs11 signing", func() {
+	var cctx credentials.Context
+	var hash string
+
+	BeforeEach(func() {
+		cctx = credentials.New()
+		hasher := signing.DefaultRegistry().GetHasher(sha256.Algorithm)
+		hash, _ = signing.Hash(hasher.Create(), []byte("test"))
+	})
+
+	Context("key specification", func() {
+		It("parses key", func() {
+			key := Must(me.GetKey([]byte(KEY)))
+			Expect(key).To(Equal(&me.Key{
+				Module: "/usr/lib/softhsm/libsofthsm2.so",
+				Token:  "ocm",
+				Label:  "release",
+			}))
+		})
+
+		It("rejects incomplete keys", func() {
+			ExpectError(me.GetKey([]byte("token: ocm\nlabel: release"))).To(MatchError(`"PKCS#11 module" required`))
+			ExpectError(me.GetKey([]byte("module: m\nlabel: release"))).To(MatchError(`exactly one of token or slot required`))
+			ExpectError(me.GetKey([]byte("module: m\ntoken: ocm\nslot: 1\nlabel: release"))).To(MatchError(`exactly one of token or slot required`))
+			ExpectError(me.GetKey([]byte("module: m\ntoken: ocm"))).To(MatchError(`"key label or id" required`))
+			ExpectError(me.GetKey([]byte("module: m\ntoken: ocm\nid: xx"))).To(MatchError(ContainSubstring(`key id "xx" is invalid`)))
+		})
+
+		It("provides consumer id", func() {
+			slot := 2
+			Expect(me.GetConsumerId(&me.Key{Module: "m", Slot: &slot})).To(Equal(credentials.NewConsumerIdentity(me.CONSUMER_TYPE, me.ID_SLOT, "2")))
+			Expect(me.GetConsumerId(&me.Key{Module: "m", Token: "ocm"})).To(Equal(credentials.NewConsumerIdentity(me.CONSUMER_TYPE, me.ID_TOKEN, "ocm")))
+		})
+	})
+
+	DescribeTable("signs with token key", func(m *me.Method, create func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error), verifier signing.Verifier) {
+		priv, pub := Must2(create())
+		p := &provider{key: priv.(crypto.Signer)}
+
+		cctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity(me.CONSUMER_TYPE, me.ID_TOKEN, "ocm"),
+			credentials.CredentialsFromList(me.ATTR_PIN, "1234"))
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: []byte(KEY),
+			PublicKey:  pub,
+		}
+		sig := Must(me.NewHandlerFor(m, p.Provide).Sign(cctx, hash, sctx))
+		Expect(p.cfg.PIN).To(Equal("1234"))
+		Expect(p.cfg.Label).To(Equal("release"))
+		Expect(p.closer.closed).To(BeTrue())
+
+		Expect(sig.Algorithm).To(Equal(m.Algorithm))
+		Expect(sig.MediaType).To(Equal(m.MediaType))
+		Expect(signing.DefaultRegistry().GetVerifier(sig.Algorithm)).NotTo(BeNil())
+		MustBeSuccessful(verifier.Verify(hash, sig, &signing.DefaultSigningContext{Hash: crypto.SHA256, PublicKey: pub}))
+	},
+		Entry("rsa", me.RSA, rsa.CreateKeyPair, rsa.NewHandler()),
+		Entry("rsa-pss", me.RSA_PSS, rsa.CreateKeyPair, rsa_pss.NewHandler()),
+		Entry("ecdsa P-256", me.ECDSA_P256, ecdsa.CreateKeyPair, ecdsa.NewHandler()),
+	)
+
+	It("rejects key type mismatch", func() {
+		priv, _ := Must2(rsa.CreateKeyPair())
+		p := &provider{key: priv.(crypto.Signer)}
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: []byte(KEY),
+		}
+		ExpectError(me.NewHandlerFor(me.ECDSA_P384, p.Provide).Sign(cctx, hash, sctx)).To(MatchError(ContainSubstring("token key: key is not an ecdsa key")))
+
+		priv, _ = Must2(ecdsa.CreateKeyPair())
+		p = &provider{key: priv.(crypto.Signer)}
+		ExpectError(me.NewHandlerFor(me.ECDSA_P384, p.Provide).Sign(cctx, hash, sctx)).To(MatchError("token key: ecdsa key curve P-256 does not match algorithm ECDSA-P384"))
+	})
+
+	It("signs with certificate", func() {
+		ca, capriv := Must2(ecdsa.CreateRootCertificate(&pkix.Name{CommonName: "ca"}, time.Hour))
+		cert, certs, priv := Must3(ecdsa.CreateSigningCertificate(&pkix.Name{CommonName: "release"}, ca, ca, capriv, time.Hour))
+		p := &provider{key: priv}
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: []byte(KEY),
+			PublicKey:  certs,
+			RootCerts:  ca,
+			Issuer:     &pkix.Name{CommonName: "release"},
+		}
+		sig := Must(me.NewHandlerFor(me.ECDSA_P256, p.Provide).Sign(cctx, hash, sctx))
+		Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+		Expect(sig.Issuer).To(Equal("CN=release"))
+		MustBeSuccessful(ecdsa.NewHandler().Verify(hash, sig, &signing.DefaultSigningContext{Hash: crypto.SHA256, PublicKey: cert}))
+
+		other, _ := Must2(ecdsa.CreateKeyPair())
+		p = &provider{key: other.(crypto.Signer)}
+		ExpectError(me.NewHandlerFor(me.ECDSA_P256, p.Provide).Sign(cctx, hash, sctx)).To(MatchError("invalid public key for token key"))
+	})
+
+	It("is registered", func() {
+		Expect(signing.DefaultRegistry().GetSigner(me.NAME_RSA)).NotTo(BeNil())
+		Expect(signing.DefaultRegistry().GetSigner(me.NAME_ECDSA_P384).Algorithm()).To(Equal(ecdsa.AlgorithmP384))
+	})
+})
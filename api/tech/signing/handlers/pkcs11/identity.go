@@ -0,0 +1,72 @@
+package pkcs11
+
+import (
+	"strconv"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+// CONSUMER_TYPE is the consumer type used to look up the
+// credentials (PIN) for a PKCS#11 token.
+const CONSUMER_TYPE = "PKCS11"
+
+// used identity attributes.
+const (
+	ID_TOKEN = "token"
+	ID_SLOT  = "slot"
+)
+
+// used credential properties.
+const (
+	ATTR_PIN = "pin"
+)
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_PIN, "the user PIN used to log in to the token",
+	})
+	ids := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ID_TOKEN, "the label of the token",
+		ID_SLOT, "the slot number of the token (if selected by slot)",
+	})
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, IdentityMatcher,
+		`PKCS#11 token credential matcher
+
+This matcher matches credentials for a PKCS#11 token (HSM) used
+by the PKCS#11 signing handlers (only available in binaries built
+with cgo).
+It uses the following identity attributes:
+`+ids,
+		attrs)
+}
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return cpi.PartialMatch(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for the token
+// described by the given key specification.
+func GetConsumerId(key *Key) cpi.ConsumerIdentity {
+	id := cpi.NewConsumerIdentity(CONSUMER_TYPE)
+	id.SetNonEmptyValue(ID_TOKEN, key.Token)
+	if key.Slot != nil {
+		id.SetNonEmptyValue(ID_SLOT, strconv.Itoa(*key.Slot))
+	}
+	return id
+}
+
+// GetPIN provides the PIN configured for the token described
+// by the given key specification. If no credentials are found,
+// an empty PIN is returned.
+func GetPIN(cctx credentials.Context, key *Key) (string, error) {
+	if cctx == nil {
+		return "", nil
+	}
+	creds, err := cpi.CredentialsForConsumer(cctx, GetConsumerId(key), IdentityMatcher)
+	if err != nil || creds == nil {
+		return "", err
+	}
+	return creds.GetProperty(ATTR_PIN), nil
+}
@@ -0,0 +1,46 @@
+//go:build cgo
+
+package pkcs11
+
+import (
+	"crypto"
+	"encoding/hex"
+	"io"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/mandelsoft/goutils/errors"
+)
+
+// DefaultKeyProvider provides the key from the PKCS#11 module
+// configured for the key.
+func DefaultKeyProvider(cfg *Config) (crypto.Signer, io.Closer, error) {
+	var id []byte
+	var label []byte
+
+	if cfg.ID != "" {
+		id, _ = hex.DecodeString(cfg.ID)
+	}
+	if cfg.Label != "" {
+		label = []byte(cfg.Label)
+	}
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:              cfg.Module,
+		TokenLabel:        cfg.Token,
+		SlotNumber:        cfg.Slot,
+		Pin:               cfg.PIN,
+		LoginNotSupported: cfg.PIN == "",
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "cannot access PKCS#11 module %q", cfg.Module)
+	}
+	signer, err := ctx.FindKeyPair(id, label)
+	if err != nil {
+		ctx.Close()
+		return nil, nil, errors.Wrapf(err, "cannot find key pair")
+	}
+	if signer == nil {
+		ctx.Close()
+		return nil, nil, errors.ErrNotFound(KIND_PKCS11_KEY, keyName(cfg))
+	}
+	return signer, ctx, nil
+}
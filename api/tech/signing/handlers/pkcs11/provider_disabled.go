@@ -0,0 +1,16 @@
+//go:build !cgo
+
+package pkcs11
+
+import (
+	"crypto"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+// DefaultKeyProvider reports that PKCS#11 support is not available.
+// It requires a build with cgo enabled.
+func DefaultKeyProvider(cfg *Config) (crypto.Signer, io.Closer, error) {
+	return nil, nil, errors.Newf("PKCS#11 support not available (build with CGO_ENABLED=1) for key %s", keyName(cfg))
+}
@@ -0,0 +1,114 @@
+//go:build cgo
+
+package pkcs11_test
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	me "ocm.software/ocm/api/tech/signing/handlers/pkcs11"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// softHSMModule provides the SoftHSM module used for the test.
+// It can be set explicitly with the environment variable SOFTHSM2_MODULE.
+func softHSMModule() string {
+	if m := os.Getenv("SOFTHSM2_MODULE"); m != "" {
+		return m
+	}
+	for _, m := range []string{
+		"/usr/lib/softhsm/libsofthsm2.so",
+		"/usr/lib64/softhsm/libsofthsm2.so",
+		"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+		"/usr/local/lib/softhsm/libsofthsm2.so",
+	} {
+		if _, err := os.Stat(m); err == nil {
+			return m
+		}
+	}
+	return ""
+}
+
+func softhsm(args ...string) {
+	out, err := exec.Command("softhsm2-util", args...).CombinedOutput()
+	Expect(err).To(Succeed(), string(out))
+}
+
+func importKey(dir string, label, id string, priv interface{}) {
+	data := Must(x509.MarshalPKCS8PrivateKey(priv))
+	path := filepath.Join(dir, label+".pem")
+	MustBeSuccessful(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: data}), 0o600))
+	softhsm("--import", path, "--token", "ocm", "--label", label, "--id", id, "--pin", "1234")
+}
+
+var _ = Describe("SoftHSM", func() {
+	var module string
+	var dir string
+	var cctx credentials.Context
+	var hash string
+
+	BeforeEach(func() {
+		module = softHSMModule()
+		if module == "" {
+			Skip("SoftHSM not available")
+		}
+		if _, err := exec.LookPath("softhsm2-util"); err != nil {
+			Skip("softhsm2-util not available")
+		}
+		dir = GinkgoT().TempDir()
+		conf := filepath.Join(dir, "softhsm2.conf")
+		MustBeSuccessful(os.MkdirAll(filepath.Join(dir, "tokens"), 0o700))
+		MustBeSuccessful(os.WriteFile(conf, []byte(fmt.Sprintf("directories.tokendir = %s\nobjectstore.backend = file\n", filepath.Join(dir, "tokens"))), 0o600))
+		GinkgoT().Setenv("SOFTHSM2_CONF", conf)
+
+		softhsm("--init-token", "--free", "--label", "ocm", "--pin", "1234", "--so-pin", "4321")
+
+		cctx = credentials.New()
+		cctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity(me.CONSUMER_TYPE, me.ID_TOKEN, "ocm"),
+			credentials.CredentialsFromList(me.ATTR_PIN, "1234"))
+
+		hasher := signing.DefaultRegistry().GetHasher(sha256.Algorithm)
+		hash, _ = signing.Hash(hasher.Create(), []byte("test"))
+
+	})
+
+	DescribeTable("signs with token key", func(name string, create func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error)) {
+		priv, pub := Must2(create())
+		importKey(dir, "release", "01", priv)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: []byte(fmt.Sprintf("module: %s\ntoken: ocm\nlabel: release\n", module)),
+			PublicKey:  pub,
+		}
+		signer := signing.DefaultRegistry().GetSigner(name)
+		sig := Must(signer.Sign(cctx, hash, sctx))
+		MustBeSuccessful(signing.DefaultRegistry().GetVerifier(sig.Algorithm).Verify(hash, sig, &signing.DefaultSigningContext{Hash: crypto.SHA256, PublicKey: pub}))
+	},
+		Entry("rsa", me.NAME_RSA, rsa.CreateKeyPair),
+		Entry("rsa-pss", me.NAME_RSA_PSS, rsa.CreateKeyPair),
+		Entry("ecdsa", me.NAME_ECDSA_P256, ecdsa.CreateKeyPair),
+	)
+
+	It("fails for unknown key", func() {
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: []byte(fmt.Sprintf("module: %s\ntoken: ocm\nlabel: unknown\n", module)),
+		}
+		ExpectError(signing.DefaultRegistry().GetSigner(me.NAME_RSA).Sign(cctx, hash, sctx)).To(MatchError(`PKCS#11 key "ocm/unknown" not found`))
+	})
+})
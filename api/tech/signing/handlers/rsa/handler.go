@@ -128,7 +128,7 @@ func (h *Handler) Verify(digest string, signature *signing.Signature, sctx signi
 	}
 
 	switch signature.MediaType {
-	case MediaType:
+	case h.method.MediaType:
 		signatureBytes, err = hex.DecodeString(signature.Value)
 		if err != nil {
 			return fmt.Errorf("unable to get signature value: failed decoding hash %s: %w", digest, err)
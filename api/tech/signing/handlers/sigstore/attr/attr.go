@@ -3,8 +3,15 @@ package attr
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/sigstore/sigstore-go/pkg/root"
 
 	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/utils"
 	"ocm.software/ocm/api/utils/runtime"
 )
 
@@ -34,6 +41,16 @@ type Attribute struct {
 	RekorURL     string `json:"rekorURL"`
 	OIDCIssuer   string `json:"OIDCIssuer"`
 	OIDCClientID string `json:"OIDCClientID"`
+
+	// TrustedRoot is the path of a sigstore trusted root file
+	// used for an offline verification.
+	TrustedRoot string `json:"trustedRoot,omitempty"`
+	// IdentityTokenFile is the path of a file containing the OIDC identity token
+	// used for signing.
+	IdentityTokenFile string `json:"identityTokenFile,omitempty"`
+	// IdentityTokenEnv is the name of an environment variable containing the
+	// OIDC identity token used for signing.
+	IdentityTokenEnv string `json:"identityTokenEnv,omitempty"`
 }
 
 // Name returns the attribute name.
@@ -56,6 +73,20 @@ The following fields are used.
 - *<code>rekorURL</code>* *string*  default is https://rekor.sigstore.dev
 - *<code>OIDCIssuer</code>* *string*  default is https://oauth2.sigstore.dev/auth
 - *<code>OIDCClientID</code>* *string*  default is sigstore
+- *<code>trustedRoot</code>* *string*  path of a sigstore trusted root file (JSON)
+
+  If configured, the Fulcio certificate authorities, the Rekor and the
+  certificate transparency log public keys are taken from this file
+  instead of the public sigstore trust root, and signatures are verified
+  offline, only, based on the inclusion proofs and signed entry timestamps
+  stored in the signature. This enables the usage of private sigstore
+  deployments and air-gapped verification.
+- *<code>identityTokenFile</code>* *string*  path of a file containing the OIDC identity token
+- *<code>identityTokenEnv</code>* *string*  environment variable containing the OIDC identity token
+
+  An identity token provided by one of the two last options is used for
+  signing instead of an interactive OIDC flow (for example, for CI
+  environments).
 `
 }
 
@@ -101,6 +132,53 @@ func Get(ctx datacontext.Context) *Attribute {
 	return a
 }
 
+// GetTrustedRoot provides the trusted root configured for the given context.
+// If no trusted root is configured, nil is returned.
+func GetTrustedRoot(ctx datacontext.Context) (*root.TrustedRoot, error) {
+	cfg := Get(ctx)
+	if cfg.TrustedRoot == "" {
+		return nil, nil
+	}
+	path, err := utils.ResolvePath(cfg.TrustedRoot)
+	if err != nil {
+		return nil, err
+	}
+	data, err := vfs.ReadFile(vfsattr.Get(ctx), path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read sigstore trusted root %q: %w", cfg.TrustedRoot, err)
+	}
+	tr, err := root.NewTrustedRootFromJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sigstore trusted root %q: %w", cfg.TrustedRoot, err)
+	}
+	return tr, nil
+}
+
+// GetIdentityToken provides the OIDC identity token configured for the given
+// context. If no token source is configured, an empty string is returned.
+func GetIdentityToken(ctx datacontext.Context) (string, error) {
+	cfg := Get(ctx)
+	if cfg.IdentityTokenFile != "" {
+		path, err := utils.ResolvePath(cfg.IdentityTokenFile)
+		if err != nil {
+			return "", err
+		}
+		data, err := vfs.ReadFile(vfsattr.Get(ctx), path)
+		if err != nil {
+			return "", fmt.Errorf("cannot read identity token file %q: %w", cfg.IdentityTokenFile, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if cfg.IdentityTokenEnv != "" {
+		tok := strings.TrimSpace(os.Getenv(cfg.IdentityTokenEnv))
+		if tok == "" {
+			return "", fmt.Errorf("identity token environment variable %q not set", cfg.IdentityTokenEnv)
+		}
+		return tok, nil
+	}
+	return "", nil
+}
+
 // Set sets the attributes.
 func Set(ctx datacontext.Context, a *Attribute) error {
 	attrs := ctx.GetAttributes()
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag/conv"
@@ -21,8 +22,11 @@ import (
 	"github.com/sigstore/rekor/pkg/generated/models"
 	hashedrekord_v001 "github.com/sigstore/rekor/pkg/types/hashedrekord/v0.0.1"
 	"github.com/sigstore/rekor/pkg/verify"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
 	signatureoptions "github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/sigstore/sigstore/pkg/tuf"
 
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/tech/signing"
@@ -92,11 +96,24 @@ func (h Handler) Sign(cctx credentials.Context, digest string, sctx signing.Sign
 	// get the attributes for the sigstore signer
 	cfg := attr.Get(cctx)
 
+	// get a non-interactive identity token, if configured
+	idToken, err := attr.GetIdentityToken(cctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the trusted root for private sigstore deployments, if configured
+	trustedRoot, err := attr.GetTrustedRoot(cctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// create a fulcio signing client
 	fs, err := fulcio.NewSigner(ctx, options.KeyOpts{
 		FulcioURL:        cfg.FulcioURL,
 		OIDCIssuer:       cfg.OIDCIssuer,
 		OIDCClientID:     cfg.OIDCClientID,
+		IDToken:          idToken,
 		SkipConfirmation: true,
 	}, signer)
 	if err != nil {
@@ -118,7 +135,7 @@ func (h Handler) Sign(cctx credentials.Context, digest string, sctx signing.Sign
 	}
 
 	// get the public key for certificate transparency log
-	pubKeys, err := cosign.GetCTLogPubs(ctx)
+	pubKeys, err := getCTLogPubs(ctx, trustedRoot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cosign CT Log Public Keys: %w", err)
 	}
@@ -201,9 +218,20 @@ func (h Handler) Sign(cctx credentials.Context, digest string, sctx signing.Sign
 }
 
 // Verify checks the signature, returns an error on verification failure.
+// If the signing context provides a context with a configured trusted root,
+// the verification is done completely offline using the trusted root
+// and the inclusion proof and signed entry timestamp stored in the signature.
 func (h Handler) Verify(digest string, sig *signing.Signature, sctx signing.SigningContext) (err error) {
 	ctx := context.Background()
 
+	var trustedRoot *root.TrustedRoot
+	if cctx := signing.GetCredentialsContext(sctx); cctx != nil {
+		trustedRoot, err = attr.GetTrustedRoot(cctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	data, err := base64.StdEncoding.DecodeString(sig.Value)
 	if err != nil {
 		return fmt.Errorf("failed to decode signature: %w", err)
@@ -220,7 +248,7 @@ func (h Handler) Verify(digest string, sig *signing.Signature, sctx signing.Sign
 	}
 
 	for _, entry := range entries {
-		verifier, err := loadVerifier(ctx)
+		verifier, err := loadVerifier(ctx, trustedRoot, entry.LogID)
 		if err != nil {
 			return fmt.Errorf("failed to load rekor verifier: %w", err)
 		}
@@ -271,6 +299,13 @@ func (h Handler) Verify(digest string, sig *signing.Signature, sctx signing.Sign
 			return errors.New("could not verify signature using public key")
 		}
 
+		// verify the Fulcio certificate against the trusted root
+		if trustedRoot != nil {
+			if err := verifyCertificate(ctx, trustedRoot, rekorPublicKeyRaw, entry.IntegratedTime); err != nil {
+				return err
+			}
+		}
+
 		// verify log entry
 		if err := verify.VerifyLogEntry(ctx, &entry, verifier); err != nil {
 			return fmt.Errorf("failed to verify log entry: %w", err)
@@ -279,7 +314,18 @@ func (h Handler) Verify(digest string, sig *signing.Signature, sctx signing.Sign
 	return nil
 }
 
-func loadVerifier(ctx context.Context) (signature.Verifier, error) {
+func loadVerifier(ctx context.Context, trustedRoot *root.TrustedRoot, logID *string) (signature.Verifier, error) {
+	if trustedRoot != nil {
+		if logID == nil {
+			return nil, errors.New("rekor log id missing in log entry")
+		}
+		log := trustedRoot.RekorLogs()[*logID]
+		if log == nil {
+			return nil, fmt.Errorf("rekor log %s not found in trusted root", *logID)
+		}
+		return signature.LoadVerifier(log.PublicKey, hashFunc(log.SignatureHashFunc))
+	}
+
 	publicKeys, err := cosign.GetRekorPubs(ctx)
 	if err != nil {
 		return nil, err
@@ -291,6 +337,78 @@ func loadVerifier(ctx context.Context) (signature.Verifier, error) {
 	return nil, errors.New("no Rekor public key found")
 }
 
+// getCTLogPubs provides the public keys of the certificate transparency logs.
+// They are taken from the trusted root, if given. Otherwise, the public
+// sigstore trust root is used.
+func getCTLogPubs(ctx context.Context, trustedRoot *root.TrustedRoot) (*cosign.TrustedTransparencyLogPubKeys, error) {
+	if trustedRoot == nil {
+		return cosign.GetCTLogPubs(ctx)
+	}
+	keys := cosign.NewTrustedTransparencyLogPubKeys()
+	for _, log := range trustedRoot.CTLogs() {
+		data, err := cryptoutils.MarshalPublicKeyToPEM(log.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := keys.AddTransparencyLogPubKey(data, tuf.Active); err != nil {
+			return nil, err
+		}
+	}
+	if len(keys.Keys) == 0 {
+		return nil, errors.New("no CT log public key found in trusted root")
+	}
+	return &keys, nil
+}
+
+// verifyCertificate verifies a Fulcio certificate (used by sigstore-v2)
+// against the certificate authorities of the trusted root at the time the
+// log entry has been integrated and its embedded signed certificate
+// timestamp against the CT logs of the trusted root.
+// Raw public keys (used by the legacy sigstore algorithm) cannot be
+// verified this way and are accepted based on the log entry, only.
+func verifyCertificate(ctx context.Context, trustedRoot *root.TrustedRoot, data []byte, integratedTime *int64) error {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse Fulcio certificate: %w", err)
+	}
+	if integratedTime == nil {
+		return errors.New("integration time missing in rekor log entry")
+	}
+	observed := time.Unix(*integratedTime, 0)
+
+	var chain []*x509.Certificate
+	for _, ca := range trustedRoot.FulcioCertificateAuthorities() {
+		chains, err := ca.Verify(cert, observed)
+		if err == nil && len(chains) > 0 {
+			chain = chains[0]
+			break
+		}
+	}
+	if chain == nil {
+		return errors.New("Fulcio certificate not issued by a certificate authority of the trusted root")
+	}
+
+	pubKeys, err := getCTLogPubs(ctx, trustedRoot)
+	if err != nil {
+		return err
+	}
+	if err := cosign.VerifyEmbeddedSCT(ctx, chain, pubKeys); err != nil {
+		return fmt.Errorf("failed to verify signed certificate timestamp: %w", err)
+	}
+	return nil
+}
+
+func hashFunc(h crypto.Hash) crypto.Hash {
+	if h == 0 {
+		return crypto.SHA256
+	}
+	return h
+}
+
 // based on: https://github.com/sigstore/cosign/blob/ff648d5fb4ed6d0d1c16eaaceff970411fa969e3/pkg/cosign/tlog.go#L233
 func prepareRekorEntry(digest string, sig, publicKey []byte) hashedrekord_v001.V001Entry {
 	// TODO: this should match the provided hash digest algorithm but
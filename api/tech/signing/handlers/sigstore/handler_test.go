@@ -19,7 +19,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/sigstore/attr"
 )
 
 // Helper function to load data from component descriptor
@@ -250,3 +252,73 @@ func TestVerify_V2Signature(t *testing.T) {
 
 	assert.NoError(t, err, "v2 signature verification should succeed")
 }
+
+// Helper to create a context using a trusted root for offline verification
+func trustedRootContext(t *testing.T, path string) credentials.Context {
+	abs, err := filepath.Abs(path)
+	require.NoError(t, err)
+	ctx := credentials.New()
+	require.NoError(t, attr.Set(ctx, &attr.Attribute{TrustedRoot: abs}))
+	return ctx
+}
+
+// Verify signatures offline with both Sigstore algorithms using a trusted root
+func TestVerify_OfflineWithTrustedRoot(t *testing.T) {
+	ctx := trustedRootContext(t, filepath.Join("testdata", "trusted_root.json"))
+	descriptorYAML := loadTestData(t, "component-descriptor-signed.yaml")
+
+	for _, algo := range []string{Algorithm, AlgorithmV2} {
+		digest, sigValue := getSignatureByAlgorithm(t, descriptorYAML, algo)
+		handler := Handler{algorithm: algo}
+
+		err := handler.Verify(digest, &signing.Signature{
+			Value:     sigValue,
+			MediaType: MediaType,
+			Algorithm: algo,
+		}, &signing.DefaultSigningContext{Context: ctx})
+		assert.NoError(t, err, "offline verification of %s signature should succeed", algo)
+	}
+}
+
+// Verify offline verification fails for logs not contained in the trusted root
+func TestVerify_OfflineUnknownRekorLog(t *testing.T) {
+	var trustedRoot map[string]any
+	require.NoError(t, json.Unmarshal(loadTestData(t, "trusted_root.json"), &trustedRoot))
+	trustedRoot["tlogs"] = []any{}
+	data, err := json.Marshal(trustedRoot)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "trusted_root.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	descriptorYAML := loadTestData(t, "component-descriptor-signed.yaml")
+	digest, sigValue := getSignatureByAlgorithm(t, descriptorYAML, AlgorithmV2)
+	handler := Handler{algorithm: AlgorithmV2}
+
+	err = handler.Verify(digest, &signing.Signature{
+		Value:     sigValue,
+		MediaType: MediaType,
+		Algorithm: AlgorithmV2,
+	}, &signing.DefaultSigningContext{Context: trustedRootContext(t, path)})
+	assert.ErrorContains(t, err, "not found in trusted root")
+}
+
+// Verify identity tokens are read from the configured sources
+func TestIdentityToken(t *testing.T) {
+	ctx := credentials.New()
+	tok, err := attr.GetIdentityToken(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, tok)
+
+	t.Setenv("OCM_TEST_SIGSTORE_TOKEN", "env-token")
+	require.NoError(t, attr.Set(ctx, &attr.Attribute{IdentityTokenEnv: "OCM_TEST_SIGSTORE_TOKEN"}))
+	tok, err = attr.GetIdentityToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "env-token", tok)
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("file-token\n"), 0o600))
+	require.NoError(t, attr.Set(ctx, &attr.Attribute{IdentityTokenFile: path}))
+	tok, err = attr.GetIdentityToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "file-token", tok)
+}
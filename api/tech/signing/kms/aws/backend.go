@@ -0,0 +1,190 @@
+package aws
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing/kms"
+)
+
+// PROVIDER is the provider name used in key references
+// (kms://aws/<region>/<key id>).
+const PROVIDER = "aws"
+
+const (
+	SERVICE               = "kms"
+	TARGET                = "TrentService.Sign"
+	TARGET_GET_PUBLIC_KEY = "TrentService.GetPublicKey"
+	CONTENT_TYPE          = "application/x-amz-json-1.1"
+)
+
+func init() {
+	kms.RegisterBackend(PROVIDER, Backend{})
+}
+
+// Backend signs digests with the Sign operation of the AWS KMS API.
+// The public key is provided by the GetPublicKey operation.
+type Backend struct{}
+
+var _ kms.Backend = Backend{}
+
+// Endpoint provides the default API endpoint for a region.
+func Endpoint(region string) string {
+	return fmt.Sprintf("https://kms.%s.amazonaws.com", region)
+}
+
+// SplitPath splits the key path of a key reference into
+// the region and the key id (or ARN).
+func SplitPath(path string) (string, string, error) {
+	region, keyid, ok := strings.Cut(path, "/")
+	if !ok || region == "" || keyid == "" {
+		return "", "", errors.ErrInvalidWrap(errors.New("<region>/<key id> required"), kms.KIND_KEYREF, path)
+	}
+	return region, keyid, nil
+}
+
+// SigningAlgorithm provides the AWS name of the signing algorithm.
+func SigningAlgorithm(scheme kms.Scheme, hash crypto.Hash) (string, error) {
+	var prefix string
+	switch scheme {
+	case kms.RSA_PKCS1V15:
+		prefix = "RSASSA_PKCS1_V1_5"
+	case kms.RSA_PSS:
+		prefix = "RSASSA_PSS"
+	case kms.ECDSA:
+		prefix = "ECDSA"
+	default:
+		return "", errors.ErrNotSupported("signature scheme", string(scheme), PROVIDER)
+	}
+	h, err := kms.HashName(hash)
+	if err != nil {
+		return "", err
+	}
+	return prefix + "_" + strings.ToUpper(h[:3]) + "_" + h[3:], nil
+}
+
+type signRequest struct {
+	KeyId            string `json:"KeyId"`
+	Message          string `json:"Message"`
+	MessageType      string `json:"MessageType"`
+	SigningAlgorithm string `json:"SigningAlgorithm"`
+}
+
+type signResponse struct {
+	KeyId            string `json:"KeyId"`
+	Signature        string `json:"Signature"`
+	SigningAlgorithm string `json:"SigningAlgorithm"`
+}
+
+type getPublicKeyRequest struct {
+	KeyId string `json:"KeyId"`
+}
+
+type getPublicKeyResponse struct {
+	KeyId     string `json:"KeyId"`
+	PublicKey string `json:"PublicKey"`
+}
+
+// key describes the access to a key using the AWS KMS API.
+type key struct {
+	region   string
+	id       string
+	endpoint string
+	creds    awssdk.Credentials
+}
+
+func getKey(cctx credentials.Context, ref *kms.KeyRef) (*key, error) {
+	region, keyid, err := SplitPath(ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := ref.Endpoint
+	if endpoint == "" {
+		endpoint = Endpoint(region)
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	creds, err := GetCredentials(cctx, endpoint, keyid)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil || creds.GetProperty(ATTR_AWS_ACCESS_KEY_ID) == "" {
+		return nil, errors.ErrNotFound("credentials", GetConsumerId(endpoint, keyid).String())
+	}
+	return &key{
+		region:   region,
+		id:       keyid,
+		endpoint: endpoint,
+		creds: awssdk.Credentials{
+			AccessKeyID:     creds.GetProperty(ATTR_AWS_ACCESS_KEY_ID),
+			SecretAccessKey: creds.GetProperty(ATTR_AWS_SECRET_ACCESS_KEY),
+			SessionToken:    creds.GetProperty(ATTR_TOKEN),
+		},
+	}, nil
+}
+
+// call executes an operation of the AWS KMS API given by its target.
+func (k *key) call(ctx context.Context, target string, req interface{}, resp interface{}) error {
+	return kms.PostJSON(ctx, k.endpoint+"/", CONTENT_TYPE, req, resp, func(r *http.Request, body []byte) error {
+		r.Header.Set("X-Amz-Target", target)
+		sum := sha256.Sum256(body)
+		return v4.NewSigner().SignHTTP(ctx, k.creds, r, hex.EncodeToString(sum[:]), SERVICE, k.region, time.Now())
+	})
+}
+
+func (Backend) Sign(ctx context.Context, cctx credentials.Context, ref *kms.KeyRef, scheme kms.Scheme, hash crypto.Hash, digest []byte) ([]byte, error) {
+	k, err := getKey(cctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	alg, err := SigningAlgorithm(scheme, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &signRequest{
+		KeyId:            k.id,
+		Message:          base64.StdEncoding.EncodeToString(digest),
+		MessageType:      "DIGEST",
+		SigningAlgorithm: alg,
+	}
+	var resp signResponse
+	err = k.call(ctx, TARGET, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid signature returned by AWS KMS")
+	}
+	return sig, nil
+}
+
+func (Backend) GetPublicKey(ctx context.Context, cctx credentials.Context, ref *kms.KeyRef) (crypto.PublicKey, error) {
+	k, err := getKey(cctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var resp getPublicKeyResponse
+	err = k.call(ctx, TARGET_GET_PUBLIC_KEY, &getPublicKeyRequest{KeyId: k.id}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.PublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key returned by AWS KMS")
+	}
+	return kms.ParsePublicKey(data)
+}
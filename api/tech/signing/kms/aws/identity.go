@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+// CONSUMER_TYPE is the consumer type used to look up the
+// credentials for keys managed by AWS KMS.
+const CONSUMER_TYPE = "AWSKMS"
+
+// identity properties.
+const (
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+)
+
+// credential properties.
+const (
+	ATTR_AWS_ACCESS_KEY_ID     = "awsAccessKeyID"
+	ATTR_AWS_SECRET_ACCESS_KEY = "awsSecretAccessKey"
+	ATTR_TOKEN                 = cpi.ATTR_TOKEN
+)
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_AWS_ACCESS_KEY_ID, "AWS access key id",
+		ATTR_AWS_SECRET_ACCESS_KEY, "AWS secret for access key id",
+		ATTR_TOKEN, "AWS session token (optional)",
+	})
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, identityMatcher,
+		`AWS KMS credential matcher
+
+This matcher is a hostpath matcher. The host is the KMS API endpoint
+and the path prefix is the key id used by the KMS signing handlers.`,
+		attrs)
+}
+
+// GetConsumerId provides the consumer identity for a key
+// of the KMS service with the given endpoint.
+func GetConsumerId(endpoint, keyid string) cpi.ConsumerIdentity {
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, endpoint+"/"+keyid)
+}
+
+func GetCredentials(cctx credentials.Context, endpoint, keyid string) (cpi.Credentials, error) {
+	id := GetConsumerId(endpoint, keyid)
+	if id == nil || cctx == nil {
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(cctx, id, identityMatcher)
+}
@@ -0,0 +1,263 @@
+package azure
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/tech/signing/kms"
+)
+
+// PROVIDER is the provider name used in key references
+// (kms://azure/<vault host>/keys/<name>/<version>).
+const PROVIDER = "azure"
+
+const (
+	API_VERSION = "7.4"
+	SCOPE       = "https://vault.azure.net/.default"
+)
+
+func init() {
+	kms.RegisterBackend(PROVIDER, Backend{})
+}
+
+// Backend signs digests with the sign operation of the
+// Azure Key Vault API. The public key is provided by the get key
+// operation.
+type Backend struct{}
+
+var _ kms.Backend = Backend{}
+
+// SplitPath splits the key path of a key reference into
+// the vault host and the key path below the vault.
+func SplitPath(path string) (string, string, error) {
+	vault, key, ok := strings.Cut(path, "/")
+	if !ok || vault == "" || !strings.HasPrefix(key, "keys/") || len(strings.Split(key, "/")) != 3 {
+		return "", "", errors.ErrInvalidWrap(errors.New("<vault host>/keys/<name>/<version> required"), kms.KIND_KEYREF, path)
+	}
+	return vault, key, nil
+}
+
+// SigningAlgorithm provides the JWS name of the signing algorithm
+// used by the Key Vault API.
+func SigningAlgorithm(scheme kms.Scheme, hash crypto.Hash) (string, error) {
+	var prefix string
+	switch scheme {
+	case kms.RSA_PKCS1V15:
+		prefix = "RS"
+	case kms.RSA_PSS:
+		prefix = "PS"
+	case kms.ECDSA:
+		prefix = "ES"
+	default:
+		return "", errors.ErrNotSupported("signature scheme", string(scheme), PROVIDER)
+	}
+	h, err := kms.HashName(hash)
+	if err != nil {
+		return "", err
+	}
+	return prefix + h[3:], nil
+}
+
+type signRequest struct {
+	Alg   string `json:"alg"`
+	Value string `json:"value"`
+}
+
+type signResponse struct {
+	Kid   string `json:"kid"`
+	Value string `json:"value"`
+}
+
+type keyResponse struct {
+	Key jsonWebKey `json:"key"`
+}
+
+// jsonWebKey is the JSON web key representation of a public key
+// returned by the Key Vault API.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// key describes the access to a key using the Key Vault API.
+type key struct {
+	url   string
+	token string
+}
+
+func getKey(ctx context.Context, cctx credentials.Context, ref *kms.KeyRef) (*key, error) {
+	vault, path, err := SplitPath(ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := ref.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + vault
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	creds, err := GetCredentials(cctx, endpoint, path)
+	if err != nil {
+		return nil, err
+	}
+	token, err := getToken(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, errors.ErrNotFound("credentials", GetConsumerId(endpoint, path).String())
+	}
+	return &key{
+		url:   endpoint + "/" + path,
+		token: token,
+	}, nil
+}
+
+func (k *key) authorize(r *http.Request, _ []byte) error {
+	r.Header.Set("Authorization", "Bearer "+k.token)
+	return nil
+}
+
+func (Backend) Sign(ctx context.Context, cctx credentials.Context, ref *kms.KeyRef, scheme kms.Scheme, hash crypto.Hash, digest []byte) ([]byte, error) {
+	k, err := getKey(ctx, cctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	alg, err := SigningAlgorithm(scheme, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &signRequest{
+		Alg:   alg,
+		Value: base64.RawURLEncoding.EncodeToString(digest),
+	}
+	var resp signResponse
+	err = kms.PostJSON(ctx, k.url+"/sign?api-version="+API_VERSION, "application/json", req, &resp, k.authorize)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(resp.Value, "="))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid signature returned by Azure Key Vault")
+	}
+	if scheme == kms.ECDSA {
+		return kms.ASN1Signature(sig)
+	}
+	return sig, nil
+}
+
+func (Backend) GetPublicKey(ctx context.Context, cctx credentials.Context, ref *kms.KeyRef) (crypto.PublicKey, error) {
+	k, err := getKey(ctx, cctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var resp keyResponse
+	err = kms.GetJSON(ctx, k.url+"?api-version="+API_VERSION, &resp, k.authorize)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Key.PublicKey()
+}
+
+// PublicKey converts the JSON web key into a public key.
+func (k *jsonWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch strings.TrimSuffix(k.Kty, "-HSM") {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > math.MaxInt32 {
+			return nil, errors.Newf("invalid rsa public exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.ErrNotSupported("elliptic curve", k.Crv, PROVIDER)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errors.ErrNotSupported("key type", k.Kty, PROVIDER)
+	}
+}
+
+func decodeInt(v string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(v, "="))
+	if err != nil || len(data) == 0 {
+		return nil, errors.Newf("invalid public key returned by Azure Key Vault")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// getToken provides the access token for the Key Vault API. It is either
+// given directly or requested for a service principal.
+func getToken(ctx context.Context, creds cpi.Credentials) (string, error) {
+	if creds == nil {
+		return "", nil
+	}
+	if creds.ExistsProperty(ATTR_TOKEN) {
+		return creds.GetProperty(ATTR_TOKEN), nil
+	}
+	if !creds.ExistsProperty(ATTR_CLIENT_ID) {
+		return "", nil
+	}
+	for _, a := range []string{ATTR_TENANT_ID, ATTR_CLIENT_SECRET} {
+		if !creds.ExistsProperty(a) {
+			return "", errors.ErrRequired("credential property", a, "service principal")
+		}
+	}
+	cred, err := azidentity.NewClientSecretCredential(
+		creds.GetProperty(ATTR_TENANT_ID),
+		creds.GetProperty(ATTR_CLIENT_ID),
+		creds.GetProperty(ATTR_CLIENT_SECRET),
+		nil,
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid service principal")
+	}
+	tok, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{SCOPE}})
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot get access token for service principal")
+	}
+	return tok.Token, nil
+}
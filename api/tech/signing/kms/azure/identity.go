@@ -0,0 +1,67 @@
+package azure
+
+import (
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+// CONSUMER_TYPE is the consumer type used to look up the
+// credentials for keys managed by Azure Key Vault.
+const CONSUMER_TYPE = "AzureKeyVault"
+
+// identity properties.
+const (
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+)
+
+// credential properties.
+const (
+	// ATTR_TOKEN is an OAuth2 access token for the Key Vault resource.
+	ATTR_TOKEN = cpi.ATTR_TOKEN
+	// ATTR_TENANT_ID is the Entra ID tenant of a service principal.
+	ATTR_TENANT_ID = "tenantId"
+	// ATTR_CLIENT_ID is the client id of a service principal.
+	ATTR_CLIENT_ID = "clientId"
+	// ATTR_CLIENT_SECRET is the client secret of a service principal.
+	ATTR_CLIENT_SECRET = "clientSecret"
+)
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_TOKEN, "OAuth2 access token for the Key Vault resource",
+		ATTR_TENANT_ID, "the tenant id of a service principal (alternatively, together with client id and secret)",
+		ATTR_CLIENT_ID, "the client id of a service principal",
+		ATTR_CLIENT_SECRET, "the client secret of a service principal",
+	})
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, identityMatcher,
+		`Azure Key Vault credential matcher
+
+This matcher is a hostpath matcher. The host is the vault host
+and the path prefix is the key path (<code>keys/&lt;name>/&lt;version></code>)
+used by the KMS signing handlers.`,
+		attrs)
+}
+
+// GetConsumerId provides the consumer identity for a key
+// of the vault with the given endpoint.
+func GetConsumerId(endpoint, key string) cpi.ConsumerIdentity {
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, endpoint+"/"+key)
+}
+
+func GetCredentials(cctx credentials.Context, endpoint, key string) (cpi.Credentials, error) {
+	id := GetConsumerId(endpoint, key)
+	if id == nil || cctx == nil {
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(cctx, id, identityMatcher)
+}
@@ -0,0 +1,119 @@
+package gcp
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing/kms"
+)
+
+// PROVIDER is the provider name used in key references
+// (kms://gcp/projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>).
+const PROVIDER = "gcp"
+
+// ENDPOINT is the default API endpoint of Cloud KMS.
+const ENDPOINT = "https://cloudkms.googleapis.com"
+
+func init() {
+	kms.RegisterBackend(PROVIDER, Backend{})
+}
+
+// Backend signs digests with the asymmetricSign operation of the
+// Cloud KMS API. The signature scheme is determined by the algorithm
+// of the key version and therefore not passed to the API.
+// The public key is provided by the getPublicKey operation.
+type Backend struct{}
+
+var _ kms.Backend = Backend{}
+
+type signRequest struct {
+	Digest map[string]string `json:"digest"`
+}
+
+type signResponse struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+}
+
+type publicKeyResponse struct {
+	Name string `json:"name"`
+	Pem  string `json:"pem"`
+}
+
+// key describes the access to a crypto key version using the Cloud KMS API.
+type key struct {
+	url   string
+	token string
+}
+
+func getKey(cctx credentials.Context, ref *kms.KeyRef) (*key, error) {
+	if !strings.HasPrefix(ref.Path, "projects/") || !strings.Contains(ref.Path, "/cryptoKeyVersions/") {
+		return nil, errors.ErrInvalidWrap(errors.New("crypto key version resource name required"), kms.KIND_KEYREF, ref.Path)
+	}
+	endpoint := ref.Endpoint
+	if endpoint == "" {
+		endpoint = ENDPOINT
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	creds, err := GetCredentials(cctx, endpoint, ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil || creds.GetProperty(ATTR_TOKEN) == "" {
+		return nil, errors.ErrNotFound("credentials", GetConsumerId(endpoint, ref.Path).String())
+	}
+	return &key{
+		url:   endpoint + "/v1/" + ref.Path,
+		token: creds.GetProperty(ATTR_TOKEN),
+	}, nil
+}
+
+func (k *key) authorize(r *http.Request, _ []byte) error {
+	r.Header.Set("Authorization", "Bearer "+k.token)
+	return nil
+}
+
+func (Backend) Sign(ctx context.Context, cctx credentials.Context, ref *kms.KeyRef, scheme kms.Scheme, hash crypto.Hash, digest []byte) ([]byte, error) {
+	k, err := getKey(cctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	h, err := kms.HashName(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &signRequest{
+		Digest: map[string]string{h: base64.StdEncoding.EncodeToString(digest)},
+	}
+	var resp signResponse
+	err = kms.PostJSON(ctx, k.url+":asymmetricSign", "application/json", req, &resp, k.authorize)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid signature returned by Cloud KMS")
+	}
+	return sig, nil
+}
+
+func (Backend) GetPublicKey(ctx context.Context, cctx credentials.Context, ref *kms.KeyRef) (crypto.PublicKey, error) {
+	k, err := getKey(cctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var resp publicKeyResponse
+	err = kms.GetJSON(ctx, k.url+"/publicKey", &resp, k.authorize)
+	if err != nil {
+		return nil, err
+	}
+	return kms.ParsePublicKey([]byte(resp.Pem))
+}
@@ -0,0 +1,58 @@
+package gcp
+
+import (
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+// CONSUMER_TYPE is the consumer type used to look up the
+// credentials for keys managed by GCP Cloud KMS.
+const CONSUMER_TYPE = "GCPCloudKMS"
+
+// identity properties.
+const (
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+)
+
+// credential properties.
+const (
+	ATTR_TOKEN = cpi.ATTR_TOKEN
+)
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_TOKEN, "OAuth2 access token",
+	})
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, identityMatcher,
+		`GCP Cloud KMS credential matcher
+
+This matcher is a hostpath matcher. The host is the Cloud KMS API endpoint
+and the path prefix is the resource name of the crypto key version
+(<code>projects/&lt;project>/locations/&lt;location>/keyRings/...</code>)
+used by the KMS signing handlers.`,
+		attrs)
+}
+
+// GetConsumerId provides the consumer identity for a crypto key version
+// of the Cloud KMS service with the given endpoint.
+func GetConsumerId(endpoint, name string) cpi.ConsumerIdentity {
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, endpoint+"/"+name)
+}
+
+func GetCredentials(cctx credentials.Context, endpoint, name string) (cpi.Credentials, error) {
+	id := GetConsumerId(endpoint, name)
+	if id == nil || cctx == nil {
+		return nil, nil
+	}
+	return cpi.CredentialsForConsumer(cctx, id, identityMatcher)
+}
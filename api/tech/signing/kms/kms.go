@@ -0,0 +1,267 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// SCHEME is the URL scheme used for key references.
+const SCHEME = "kms"
+
+const (
+	KIND_KEYREF       = "key reference"
+	KIND_KMS_PROVIDER = "KMS provider"
+)
+
+// DEFAULT_TIMEOUT is the timeout used for requests to a KMS.
+const DEFAULT_TIMEOUT = 30 * time.Second
+
+// client is the HTTP client used for KMS requests. In addition to the
+// timeout, requests are canceled with the context of a request.
+var client = &http.Client{Timeout: DEFAULT_TIMEOUT}
+
+// Scheme describes the signature scheme executed by the KMS.
+type Scheme = signutils.Scheme
+
+const (
+	RSA_PKCS1V15 = signutils.SCHEME_RSA_PKCS1V15
+	RSA_PSS      = signutils.SCHEME_RSA_PSS
+	ECDSA        = signutils.SCHEME_ECDSA
+)
+
+// KeyRef describes a key managed by a KMS. It is given by a URL
+// of the form kms://<provider>/<provider specific key path>.
+// The optional query parameter endpoint can be used to override
+// the default API endpoint of the provider.
+type KeyRef struct {
+	Provider string
+	Path     string
+	Endpoint string
+}
+
+// ParseKeyRef parses a key reference of the form
+// kms://<provider>/<key path>[?endpoint=<url>].
+func ParseKeyRef(ref string) (*KeyRef, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, errors.ErrInvalidWrap(err, KIND_KEYREF, ref)
+	}
+	if u.Scheme != SCHEME {
+		return nil, errors.ErrInvalidWrap(errors.Newf("scheme %s required", SCHEME), KIND_KEYREF, ref)
+	}
+	if u.Host == "" {
+		return nil, errors.ErrInvalidWrap(errors.New("provider required"), KIND_KEYREF, ref)
+	}
+	path := strings.Trim(u.Path, "/")
+	if path == "" {
+		return nil, errors.ErrInvalidWrap(errors.New("key path required"), KIND_KEYREF, ref)
+	}
+	return &KeyRef{
+		Provider: u.Host,
+		Path:     path,
+		Endpoint: u.Query().Get("endpoint"),
+	}, nil
+}
+
+// IsKeyRef checks whether the given string is a key reference.
+func IsKeyRef(ref string) bool {
+	return strings.HasPrefix(ref, SCHEME+"://")
+}
+
+func (r *KeyRef) String() string {
+	s := SCHEME + "://" + r.Provider + "/" + r.Path
+	if r.Endpoint != "" {
+		s += "?endpoint=" + url.QueryEscape(r.Endpoint)
+	}
+	return s
+}
+
+// GetKeyRef provides the key reference from a generic private key.
+// It is either given as *KeyRef or as string/byte sequence.
+func GetKeyRef(k interface{}) (*KeyRef, error) {
+	switch t := k.(type) {
+	case *KeyRef:
+		return t, nil
+	case []byte:
+		return ParseKeyRef(strings.TrimSpace(string(t)))
+	case string:
+		return ParseKeyRef(strings.TrimSpace(t))
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
+
+// Backend signs digests using the API of a dedicated KMS provider.
+// The returned signature must be encoded the standard way for the
+// scheme: the plain signature for RSA and an ASN.1 DER encoded
+// signature for ECDSA. Additionally, it provides the public key of
+// a key to check it before signing.
+type Backend interface {
+	Sign(ctx context.Context, cctx credentials.Context, ref *KeyRef, scheme Scheme, hash crypto.Hash, digest []byte) ([]byte, error)
+	GetPublicKey(ctx context.Context, cctx credentials.Context, ref *KeyRef) (crypto.PublicKey, error)
+}
+
+var (
+	lock     sync.RWMutex
+	backends = map[string]Backend{}
+)
+
+// RegisterBackend registers a backend for a KMS provider name.
+func RegisterBackend(provider string, b Backend) {
+	lock.Lock()
+	defer lock.Unlock()
+	backends[provider] = b
+}
+
+func GetBackend(provider string) Backend {
+	lock.RLock()
+	defer lock.RUnlock()
+	return backends[provider]
+}
+
+// Providers provides the names of the registered KMS providers.
+func Providers() []string {
+	lock.RLock()
+	defer lock.RUnlock()
+	var list []string
+	for n := range backends {
+		list = append(list, n)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Sign signs the digest with the referenced key using the
+// backend registered for the provider of the key reference.
+func Sign(ctx context.Context, cctx credentials.Context, ref *KeyRef, scheme Scheme, hash crypto.Hash, digest []byte) ([]byte, error) {
+	b := GetBackend(ref.Provider)
+	if b == nil {
+		return nil, errors.ErrUnknown(KIND_KMS_PROVIDER, ref.Provider)
+	}
+	return b.Sign(ctx, cctx, ref, scheme, hash, digest)
+}
+
+// GetPublicKey provides the public key of the referenced key using the
+// backend registered for the provider of the key reference.
+func GetPublicKey(ctx context.Context, cctx credentials.Context, ref *KeyRef) (crypto.PublicKey, error) {
+	b := GetBackend(ref.Provider)
+	if b == nil {
+		return nil, errors.ErrUnknown(KIND_KMS_PROVIDER, ref.Provider)
+	}
+	return b.GetPublicKey(ctx, cctx, ref)
+}
+
+// ParsePublicKey parses a DER encoded public key (PKIX, ASN.1 DER).
+// Alternatively it may be PEM encoded.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	key, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key")
+	}
+	return key, nil
+}
+
+// HashName provides the lower case name of a hash function
+// used by the KMS APIs (sha256, sha384 or sha512).
+func HashName(hash crypto.Hash) (string, error) {
+	switch hash {
+	case crypto.SHA256:
+		return "sha256", nil
+	case crypto.SHA384:
+		return "sha384", nil
+	case crypto.SHA512:
+		return "sha512", nil
+	default:
+		return "", errors.ErrNotSupported("hash algorithm", hash.String())
+	}
+}
+
+// ASN1Signature converts an ECDSA signature given as concatenation
+// of the big-endian encoded values r and s (as used by JWS) into its
+// ASN.1 DER encoding.
+func ASN1Signature(raw []byte) ([]byte, error) {
+	if len(raw) == 0 || len(raw)%2 != 0 {
+		return nil, fmt.Errorf("invalid raw ecdsa signature length %d", len(raw))
+	}
+	n := len(raw) / 2
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(raw[:n]),
+		S: new(big.Int).SetBytes(raw[n:]),
+	})
+}
+
+// PostJSON sends a JSON request and decodes the JSON response into
+// the given result. The optional prepare function can be used to add
+// authentication information to the request.
+func PostJSON(ctx context.Context, u string, contentType string, body interface{}, result interface{}, prepare func(r *http.Request, body []byte) error) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return doJSON(req, data, result, prepare)
+}
+
+// GetJSON sends a GET request and decodes the JSON response into
+// the given result. The optional prepare function can be used to add
+// authentication information to the request.
+func GetJSON(ctx context.Context, u string, result interface{}, prepare func(r *http.Request, body []byte) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	return doJSON(req, nil, result, prepare)
+}
+
+func doJSON(req *http.Request, data []byte, result interface{}, prepare func(r *http.Request, body []byte) error) error {
+	if prepare != nil {
+		if err := prepare(req, data); err != nil {
+			return err
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(data))
+		if len(msg) > 200 {
+			msg = msg[:200] + "..."
+		}
+		return errors.Newf("KMS request %s failed: %s: %s", req.URL, resp.Status, msg)
+	}
+	return json.Unmarshal(data, result)
+}
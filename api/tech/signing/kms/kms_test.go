@@ -0,0 +1,258 @@
+package kms_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"net/url"
+	"strings"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	me "ocm.software/ocm/api/tech/signing/kms"
+	"ocm.software/ocm/api/tech/signing/kms/aws"
+	"ocm.software/ocm/api/tech/signing/kms/azure"
+	"ocm.software/ocm/api/tech/signing/kms/gcp"
+	"ocm.software/ocm/api/tech/signing/kms/kmstest"
+)
+
+const (
+	AWS_KEY   = "1234abcd-12ab-34cd-56ef-1234567890ab"
+	GCP_KEY   = "projects/ocm/locations/global/keyRings/release/cryptoKeys/signing/cryptoKeyVersions/1"
+	AZURE_KEY = "keys/signing/0123456789abcdef"
+)
+
+var _ = Describe("KMS signing", func() {
+	Context("key references", func() {
+		It("parses key reference", func() {
+			ref := Must(me.ParseKeyRef("kms://aws/eu-west-1/" + AWS_KEY))
+			Expect(ref).To(Equal(&me.KeyRef{Provider: "aws", Path: "eu-west-1/" + AWS_KEY}))
+			Expect(ref.String()).To(Equal("kms://aws/eu-west-1/" + AWS_KEY))
+		})
+
+		It("parses key reference with endpoint", func() {
+			ref := Must(me.ParseKeyRef("kms://gcp/" + GCP_KEY + "?endpoint=" + url.QueryEscape("http://localhost:8080")))
+			Expect(ref).To(Equal(&me.KeyRef{Provider: "gcp", Path: GCP_KEY, Endpoint: "http://localhost:8080"}))
+			Expect(Must(me.ParseKeyRef(ref.String()))).To(Equal(ref))
+		})
+
+		It("rejects invalid key references", func() {
+			ExpectError(me.ParseKeyRef("https://aws/key")).To(MatchError(`key reference "https://aws/key" is invalid: scheme kms required`))
+			ExpectError(me.ParseKeyRef("kms:///key")).To(MatchError(`key reference "kms:///key" is invalid: provider required`))
+			ExpectError(me.ParseKeyRef("kms://aws/")).To(MatchError(`key reference "kms://aws/" is invalid: key path required`))
+		})
+
+		It("lists providers", func() {
+			Expect(me.Providers()).To(ContainElements("aws", "azure", "gcp"))
+		})
+
+		It("rejects unknown providers", func() {
+			ref := Must(me.ParseKeyRef("kms://vault/key"))
+			ExpectError(me.Sign(context.Background(), nil, ref, me.RSA_PKCS1V15, crypto.SHA256, nil)).To(MatchError(`KMS provider "vault" is unknown`))
+			ExpectError(me.GetPublicKey(context.Background(), nil, ref)).To(MatchError(`KMS provider "vault" is unknown`))
+		})
+	})
+
+	Context("backends", func() {
+		var server *kmstest.Server
+		var cctx credentials.Context
+		var digest []byte
+
+		var rsaKey *rsa.PrivateKey
+		var ecKey *ecdsa.PrivateKey
+
+		BeforeEach(func() {
+			server = kmstest.NewServer()
+			cctx = credentials.New()
+			sum := sha256.Sum256([]byte("test"))
+			digest = sum[:]
+			rsaKey = Must(rsa.GenerateKey(rand.Reader, 2048))
+			ecKey = Must(ecdsa.GenerateKey(elliptic.P256(), rand.Reader))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		ref := func(provider, path string) *me.KeyRef {
+			return &me.KeyRef{Provider: provider, Path: path, Endpoint: server.URL}
+		}
+
+		verify := func(scheme me.Scheme, sig []byte) {
+			switch scheme {
+			case me.RSA_PKCS1V15:
+				MustBeSuccessful(rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest, sig))
+			case me.RSA_PSS:
+				MustBeSuccessful(rsa.VerifyPSS(&rsaKey.PublicKey, crypto.SHA256, digest, sig, nil))
+			case me.ECDSA:
+				Expect(ecdsa.VerifyASN1(&ecKey.PublicKey, digest, sig)).To(BeTrue())
+			}
+		}
+
+		signer := func(scheme me.Scheme) crypto.Signer {
+			if scheme == me.ECDSA {
+				return ecKey
+			}
+			return rsaKey
+		}
+
+		Context("aws", func() {
+			BeforeEach(func() {
+				cctx.SetCredentialsForConsumer(aws.GetConsumerId(server.URL, AWS_KEY),
+					credentials.CredentialsFromList(
+						aws.ATTR_AWS_ACCESS_KEY_ID, kmstest.ACCESS_KEY_ID,
+						aws.ATTR_AWS_SECRET_ACCESS_KEY, kmstest.SECRET_ACCESS_KEY,
+					))
+			})
+
+			DescribeTable("signs", func(scheme me.Scheme) {
+				server.AddKey(AWS_KEY, signer(scheme), scheme)
+				sig := Must(me.Sign(context.Background(), cctx, ref(aws.PROVIDER, "eu-west-1/"+AWS_KEY), scheme, crypto.SHA256, digest))
+				verify(scheme, sig)
+				Expect(server.Requests()).To(Equal(1))
+			},
+				Entry("rsa", me.RSA_PKCS1V15),
+				Entry("rsa-pss", me.RSA_PSS),
+				Entry("ecdsa", me.ECDSA),
+			)
+
+			DescribeTable("provides public key", func(scheme me.Scheme) {
+				server.AddKey(AWS_KEY, signer(scheme), scheme)
+				pub := Must(me.GetPublicKey(context.Background(), cctx, ref(aws.PROVIDER, "eu-west-1/"+AWS_KEY)))
+				Expect(pub).To(Equal(signer(scheme).Public()))
+			},
+				Entry("rsa", me.RSA_PKCS1V15),
+				Entry("ecdsa", me.ECDSA),
+			)
+
+			It("maps signing algorithms", func() {
+				Expect(aws.SigningAlgorithm(me.RSA_PKCS1V15, crypto.SHA256)).To(Equal("RSASSA_PKCS1_V1_5_SHA_256"))
+				Expect(aws.SigningAlgorithm(me.RSA_PSS, crypto.SHA512)).To(Equal("RSASSA_PSS_SHA_512"))
+				Expect(aws.SigningAlgorithm(me.ECDSA, crypto.SHA384)).To(Equal("ECDSA_SHA_384"))
+			})
+
+			It("uses the default endpoint", func() {
+				Expect(aws.Endpoint("eu-west-1")).To(Equal("https://kms.eu-west-1.amazonaws.com"))
+			})
+
+			It("requires credentials", func() {
+				server.AddKey(AWS_KEY, rsaKey, me.RSA_PKCS1V15)
+				ExpectError(me.Sign(context.Background(), credentials.New(), ref(aws.PROVIDER, "eu-west-1/"+AWS_KEY), me.RSA_PKCS1V15, crypto.SHA256, digest)).
+					To(MatchError(ContainSubstring("not found")))
+			})
+
+			It("rejects invalid key path", func() {
+				ExpectError(me.Sign(context.Background(), cctx, ref(aws.PROVIDER, AWS_KEY), me.RSA_PKCS1V15, crypto.SHA256, digest)).
+					To(MatchError(ContainSubstring("<region>/<key id> required")))
+			})
+
+			It("reports KMS errors", func() {
+				server.AddKey(AWS_KEY, rsaKey, me.RSA_PSS)
+				ExpectError(me.Sign(context.Background(), cctx, ref(aws.PROVIDER, "eu-west-1/"+AWS_KEY), me.RSA_PKCS1V15, crypto.SHA256, digest)).
+					To(MatchError(ContainSubstring("400 Bad Request: signature scheme RSA-PKCS1-V1_5 not supported")))
+			})
+		})
+
+		Context("gcp", func() {
+			BeforeEach(func() {
+				u := Must(url.Parse(server.URL))
+				cctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity(gcp.CONSUMER_TYPE,
+					gcp.ID_HOSTNAME, u.Hostname(),
+					gcp.ID_PATHPREFIX, strings.Join(strings.Split(GCP_KEY, "/")[:6], "/"),
+				),
+					credentials.CredentialsFromList(gcp.ATTR_TOKEN, kmstest.TOKEN))
+			})
+
+			DescribeTable("signs", func(scheme me.Scheme) {
+				server.AddKey(GCP_KEY, signer(scheme), scheme)
+				sig := Must(me.Sign(context.Background(), cctx, ref(gcp.PROVIDER, GCP_KEY), scheme, crypto.SHA256, digest))
+				verify(scheme, sig)
+			},
+				Entry("rsa", me.RSA_PKCS1V15),
+				Entry("rsa-pss", me.RSA_PSS),
+				Entry("ecdsa", me.ECDSA),
+			)
+
+			DescribeTable("provides public key", func(scheme me.Scheme) {
+				server.AddKey(GCP_KEY, signer(scheme), scheme)
+				pub := Must(me.GetPublicKey(context.Background(), cctx, ref(gcp.PROVIDER, GCP_KEY)))
+				Expect(pub).To(Equal(signer(scheme).Public()))
+			},
+				Entry("rsa", me.RSA_PKCS1V15),
+				Entry("ecdsa", me.ECDSA),
+			)
+
+			It("rejects invalid key path", func() {
+				ExpectError(me.Sign(context.Background(), cctx, ref(gcp.PROVIDER, "keyRings/release"), me.RSA_PKCS1V15, crypto.SHA256, digest)).
+					To(MatchError(ContainSubstring("crypto key version resource name required")))
+			})
+
+			It("fails for invalid token", func() {
+				server.AddKey(GCP_KEY, rsaKey, me.RSA_PKCS1V15)
+				cctx.SetCredentialsForConsumer(gcp.GetConsumerId(server.URL, GCP_KEY), credentials.CredentialsFromList(gcp.ATTR_TOKEN, "other"))
+				ExpectError(me.Sign(context.Background(), cctx, ref(gcp.PROVIDER, GCP_KEY), me.RSA_PKCS1V15, crypto.SHA256, digest)).
+					To(MatchError(ContainSubstring("401 Unauthorized")))
+			})
+		})
+
+		Context("azure", func() {
+			BeforeEach(func() {
+				cctx.SetCredentialsForConsumer(azure.GetConsumerId(server.URL, AZURE_KEY),
+					credentials.CredentialsFromList(azure.ATTR_TOKEN, kmstest.TOKEN))
+			})
+
+			DescribeTable("signs", func(scheme me.Scheme) {
+				server.AddKey(AZURE_KEY, signer(scheme), scheme)
+				sig := Must(me.Sign(context.Background(), cctx, ref(azure.PROVIDER, "ocm.vault.azure.net/"+AZURE_KEY), scheme, crypto.SHA256, digest))
+				verify(scheme, sig)
+			},
+				Entry("rsa", me.RSA_PKCS1V15),
+				Entry("rsa-pss", me.RSA_PSS),
+				Entry("ecdsa", me.ECDSA),
+			)
+
+			DescribeTable("provides public key", func(scheme me.Scheme) {
+				server.AddKey(AZURE_KEY, signer(scheme), scheme)
+				pub := Must(me.GetPublicKey(context.Background(), cctx, ref(azure.PROVIDER, "ocm.vault.azure.net/"+AZURE_KEY)))
+				Expect(pub).To(Equal(signer(scheme).Public()))
+			},
+				Entry("rsa", me.RSA_PKCS1V15),
+				Entry("ecdsa", me.ECDSA),
+			)
+
+			It("maps signing algorithms", func() {
+				Expect(azure.SigningAlgorithm(me.RSA_PKCS1V15, crypto.SHA256)).To(Equal("RS256"))
+				Expect(azure.SigningAlgorithm(me.RSA_PSS, crypto.SHA384)).To(Equal("PS384"))
+				Expect(azure.SigningAlgorithm(me.ECDSA, crypto.SHA512)).To(Equal("ES512"))
+			})
+
+			It("rejects invalid key path", func() {
+				ExpectError(me.Sign(context.Background(), cctx, ref(azure.PROVIDER, "ocm.vault.azure.net/secrets/signing"), me.RSA_PKCS1V15, crypto.SHA256, digest)).
+					To(MatchError(ContainSubstring("<vault host>/keys/<name>/<version> required")))
+			})
+
+			It("requires complete service principal", func() {
+				cctx.SetCredentialsForConsumer(azure.GetConsumerId(server.URL, AZURE_KEY),
+					credentials.CredentialsFromList(azure.ATTR_CLIENT_ID, "client"))
+				ExpectError(me.Sign(context.Background(), cctx, ref(azure.PROVIDER, "ocm.vault.azure.net/"+AZURE_KEY), me.RSA_PKCS1V15, crypto.SHA256, digest)).
+					To(MatchError(ContainSubstring(`credential property "tenantId" required`)))
+			})
+		})
+	})
+
+	It("converts raw ecdsa signatures", func() {
+		raw := make([]byte, 64)
+		raw[31] = 1
+		raw[63] = 2
+		sig := Must(me.ASN1Signature(raw))
+		Expect(sig).To(Equal([]byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x02}))
+		ExpectError(me.ASN1Signature([]byte{1, 2, 3})).To(MatchError("invalid raw ecdsa signature length 3"))
+	})
+})
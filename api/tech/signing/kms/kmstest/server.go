@@ -0,0 +1,419 @@
+package kmstest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+
+	"ocm.software/ocm/api/tech/signing/kms"
+)
+
+const (
+	// ACCESS_KEY_ID is the AWS access key id accepted by the server.
+	ACCESS_KEY_ID = "AKIDEXAMPLE"
+	// SECRET_ACCESS_KEY is a secret for the AWS access key id.
+	SECRET_ACCESS_KEY = "secret"
+	// TOKEN is the bearer token accepted by the GCP and Azure APIs.
+	TOKEN = "token"
+)
+
+type key struct {
+	signer crypto.Signer
+	scheme kms.Scheme
+}
+
+// Server is a minimal in-memory stand-in for the sign and public key
+// operations of the AWS KMS, GCP Cloud KMS and Azure Key Vault REST APIs.
+// Keys are registered with their provider specific key id:
+// the key id for AWS, the crypto key version resource name for GCP
+// and the key path (keys/<name>/<version>) for Azure.
+type Server struct {
+	*httptest.Server
+	lock     sync.Mutex
+	keys     map[string]*key
+	requests int
+}
+
+func NewServer() *Server {
+	s := &Server{
+		keys: map[string]*key{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// AddKey registers a key usable with the given signature scheme.
+func (s *Server) AddKey(id string, signer crypto.Signer, scheme kms.Scheme) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keys[id] = &key{signer: signer, scheme: scheme}
+}
+
+// Requests provides the number of successful sign requests.
+func (s *Server) Requests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1/") && strings.HasSuffix(r.URL.Path, "/publicKey"):
+			s.serveGCPPublicKey(w, r)
+		case strings.HasPrefix(r.URL.Path, "/keys/"):
+			s.serveAzurePublicKey(w, r)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch {
+	case r.Header.Get("X-Amz-Target") != "":
+		s.serveAWS(w, r)
+	case strings.HasPrefix(r.URL.Path, "/v1/") && strings.HasSuffix(r.URL.Path, ":asymmetricSign"):
+		s.serveGCP(w, r)
+	case strings.HasPrefix(r.URL.Path, "/keys/") && strings.HasSuffix(r.URL.Path, "/sign"):
+		s.serveAzure(w, r)
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+func (s *Server) serveAWS(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential="+ACCESS_KEY_ID+"/") || r.Header.Get("X-Amz-Date") == "" {
+		http.Error(w, "access denied", http.StatusForbidden)
+		return
+	}
+	switch r.Header.Get("X-Amz-Target") {
+	case "TrentService.Sign":
+		s.serveAWSSign(w, r)
+	case "TrentService.GetPublicKey":
+		s.serveAWSPublicKey(w, r)
+	default:
+		http.Error(w, "unknown operation", http.StatusBadRequest)
+	}
+}
+
+func (s *Server) serveAWSPublicKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		KeyId string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pub, status, err := s.publicKey(req.KeyId)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	data, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reply(w, map[string]string{
+		"KeyId":     req.KeyId,
+		"PublicKey": base64.StdEncoding.EncodeToString(data),
+	})
+}
+
+func (s *Server) serveAWSSign(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		KeyId            string
+		Message          string
+		MessageType      string
+		SigningAlgorithm string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.MessageType != "DIGEST" {
+		http.Error(w, "message type DIGEST expected", http.StatusBadRequest)
+		return
+	}
+	var scheme kms.Scheme
+	alg := req.SigningAlgorithm
+	switch {
+	case strings.HasPrefix(alg, "RSASSA_PKCS1_V1_5_"):
+		scheme = kms.RSA_PKCS1V15
+	case strings.HasPrefix(alg, "RSASSA_PSS_"):
+		scheme = kms.RSA_PSS
+	case strings.HasPrefix(alg, "ECDSA_"):
+		scheme = kms.ECDSA
+	}
+	var hash crypto.Hash
+	if len(alg) > 7 {
+		// algorithm names end with SHA_<size>
+		hash = hashFor(strings.ReplaceAll(alg[len(alg)-7:], "_", ""))
+	}
+	digest, err := base64.StdEncoding.DecodeString(req.Message)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sig, status, err := s.sign(req.KeyId, scheme, hash, digest)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	reply(w, map[string]string{
+		"KeyId":            req.KeyId,
+		"Signature":        base64.StdEncoding.EncodeToString(sig),
+		"SigningAlgorithm": alg,
+	})
+}
+
+func (s *Server) serveGCP(w http.ResponseWriter, r *http.Request) {
+	if !checkBearer(w, r) {
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/"), ":asymmetricSign")
+	var req struct {
+		Digest map[string]string `json:"digest"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Digest) != 1 {
+		http.Error(w, "exactly one digest required", http.StatusBadRequest)
+		return
+	}
+	var hash crypto.Hash
+	var digest []byte
+	for h, v := range req.Digest {
+		var err error
+		hash = hashFor(h)
+		digest, err = base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	// the scheme is a property of the key version
+	sig, status, err := s.sign(name, "", hash, digest)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	reply(w, map[string]string{
+		"name":      name,
+		"signature": base64.StdEncoding.EncodeToString(sig),
+	})
+}
+
+func (s *Server) serveGCPPublicKey(w http.ResponseWriter, r *http.Request) {
+	if !checkBearer(w, r) {
+		return
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/"), "/publicKey")
+	pub, status, err := s.publicKey(name)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	data, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reply(w, map[string]string{
+		"name": name,
+		"pem":  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: data})),
+	})
+}
+
+func (s *Server) serveAzurePublicKey(w http.ResponseWriter, r *http.Request) {
+	if !checkBearer(w, r) {
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/")
+	pub, status, err := s.publicKey(id)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	jwk := map[string]string{
+		"kid": s.URL + "/" + id,
+	}
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		jwk["kty"] = "RSA"
+		jwk["n"] = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		jwk["kty"] = "EC"
+		jwk["crv"] = k.Curve.Params().Name
+		jwk["x"] = base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, size)))
+		jwk["y"] = base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, size)))
+	default:
+		http.Error(w, fmt.Sprintf("unsupported key type %T", pub), http.StatusInternalServerError)
+		return
+	}
+	reply(w, map[string]interface{}{
+		"key": jwk,
+	})
+}
+
+func (s *Server) serveAzure(w http.ResponseWriter, r *http.Request) {
+	if !checkBearer(w, r) {
+		return
+	}
+	if r.URL.Query().Get("api-version") == "" {
+		http.Error(w, "api-version required", http.StatusBadRequest)
+		return
+	}
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/sign")
+	var req struct {
+		Alg   string `json:"alg"`
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Alg) != 5 {
+		http.Error(w, "invalid algorithm", http.StatusBadRequest)
+		return
+	}
+	var scheme kms.Scheme
+	switch req.Alg[:2] {
+	case "RS":
+		scheme = kms.RSA_PKCS1V15
+	case "PS":
+		scheme = kms.RSA_PSS
+	case "ES":
+		scheme = kms.ECDSA
+	}
+	digest, err := base64.RawURLEncoding.DecodeString(req.Value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sig, status, err := s.sign(id, scheme, hashFor("sha"+req.Alg[2:]), digest)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if scheme == kms.ECDSA {
+		// Key Vault returns the JWS encoding (r||s)
+		sig, err = rawSignature(sig, s.keys[id].signer.Public().(*ecdsa.PublicKey))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	reply(w, map[string]string{
+		"kid":   s.URL + "/" + id,
+		"value": base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+// publicKey provides the public key of a registered key.
+func (s *Server) publicKey(id string) (crypto.PublicKey, int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	k := s.keys[id]
+	if k == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("key %q not found", id)
+	}
+	return k.signer.Public(), 0, nil
+}
+
+// sign signs a digest with a registered key. If no scheme is given,
+// the scheme of the key is used.
+func (s *Server) sign(id string, scheme kms.Scheme, hash crypto.Hash, digest []byte) ([]byte, int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	k := s.keys[id]
+	if k == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("key %q not found", id)
+	}
+	if scheme == "" {
+		scheme = k.scheme
+	}
+	if scheme != k.scheme {
+		return nil, http.StatusBadRequest, fmt.Errorf("signature scheme %s not supported by key %q", scheme, id)
+	}
+	if hash == 0 || len(digest) != hash.Size() {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid digest")
+	}
+	var opts crypto.SignerOpts = hash
+	if scheme == kms.RSA_PSS {
+		if _, ok := k.signer.(*rsa.PrivateKey); !ok {
+			return nil, http.StatusBadRequest, fmt.Errorf("rsa key required")
+		}
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash}
+	}
+	sig, err := k.signer.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	s.requests++
+	return sig, 0, nil
+}
+
+func checkBearer(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Authorization") != "Bearer "+TOKEN {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func hashFor(name string) crypto.Hash {
+	switch strings.ToLower(name) {
+	case "sha256":
+		return crypto.SHA256
+	case "sha384":
+		return crypto.SHA384
+	case "sha512":
+		return crypto.SHA512
+	default:
+		return 0
+	}
+}
+
+func reply(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+// rawSignature converts an ASN.1 DER encoded ECDSA signature
+// into the concatenation of r and s.
+func rawSignature(sig []byte, pub *ecdsa.PublicKey) ([]byte, error) {
+	var r, s big.Int
+	var inner cryptobyte.String
+	input := cryptobyte.String(sig)
+	if !input.ReadASN1(&inner, asn1.SEQUENCE) || !inner.ReadASN1Integer(&r) || !inner.ReadASN1Integer(&s) {
+		return nil, fmt.Errorf("invalid ecdsa signature")
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	raw := make([]byte, 2*size)
+	r.FillBytes(raw[:size])
+	s.FillBytes(raw[size:])
+	return raw, nil
+}
@@ -1,15 +1,22 @@
 package signing_test
 
 import (
+	"crypto/x509"
 	"crypto/x509/pkix"
+	"time"
 
+	. "github.com/mandelsoft/goutils/testutils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	"ocm.software/ocm/api/tech/signing/handlers/ed25519"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	rsa_pss "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
 	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/signutils"
 )
 
 var registry = signing.DefaultRegistry()
@@ -52,4 +59,86 @@ var _ = Describe("signing", func() {
 		hash = "A" + hash[1:]
 		Expect(registry.GetVerifier(rsa.Algorithm).Verify(hash, sig, sctx)).To(HaveOccurred())
 	})
+
+	DescribeTable("uses signer", func(algo, media string, gen signing.KeyPairGenerator) {
+		hasher := registry.GetHasher(sha256.Algorithm)
+		hash, _ := signing.Hash(hasher.Create(), []byte("test"))
+
+		priv, pub := Must2(gen.CreateKeyPair())
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+			PublicKey:  pub,
+			Issuer:     ISSUER,
+		}
+		sig := Must(registry.GetSigner(algo).Sign(defaultContext, hash, sctx))
+		Expect(sig.Algorithm).To(Equal(algo))
+		Expect(sig.MediaType).To(Equal(media))
+
+		MustBeSuccessful(registry.GetVerifier(algo).Verify(hash, sig, sctx))
+		hash = "A" + hash[1:]
+		Expect(registry.GetVerifier(algo).Verify(hash, sig, sctx)).To(HaveOccurred())
+	},
+		Entry("rsa", rsa.Algorithm, rsa.MediaType, rsa.Handler{}),
+		Entry("rsa-pss", rsa_pss.Algorithm, rsa_pss.MediaType, rsa.Handler{}),
+		Entry("ecdsa P-256", ecdsa.Algorithm, ecdsa.MediaType, ecdsa.NewHandler()),
+		Entry("ecdsa P-384", ecdsa.AlgorithmP384, ecdsa.MediaType, ecdsa.NewHandlerFor(ecdsa.P384)),
+		Entry("ed25519", ed25519.Algorithm, ed25519.MediaType, ed25519.NewHandler()),
+	)
+
+	It("rejects ecdsa key with wrong curve", func() {
+		priv, _ := Must2(ecdsa.CreateKeyPair())
+		sctx := &signing.DefaultSigningContext{
+			PrivateKey: priv,
+		}
+		ExpectError(registry.GetSigner(ecdsa.AlgorithmP384).Sign(defaultContext, "0123", sctx)).To(MatchError("ecdsa key curve P-256 does not match algorithm ECDSA-P384"))
+	})
+
+	DescribeTable("uses certificate chain", func(algo string, create func(sub *pkix.Name) (*x509.Certificate, []byte, interface{})) {
+		hasher := registry.GetHasher(sha256.Algorithm)
+		hash, _ := signing.Hash(hasher.Create(), []byte("test"))
+
+		ca, certs, priv := create(ISSUER)
+		pool := x509.NewCertPool()
+		pool.AddCert(ca)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+			PublicKey:  certs,
+			RootCerts:  pool,
+			Issuer:     ISSUER,
+		}
+		sig := Must(registry.GetSigner(algo).Sign(defaultContext, hash, sctx))
+		Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+		Expect(sig.Issuer).To(Equal("CN=mandelsoft"))
+
+		_, algorithm, chain := Must3(signutils.GetSignatureFromPem([]byte(sig.Value)))
+		Expect(algorithm).To(Equal(algo))
+		Expect(len(chain)).To(Equal(2))
+
+		vctx := &signing.DefaultSigningContext{
+			Hash:      hasher.Crypto(),
+			PublicKey: chain[0],
+		}
+		MustBeSuccessful(registry.GetVerifier(algo).Verify(hash, sig, vctx))
+
+		sig.Issuer = "CN=other"
+		ExpectError(registry.GetVerifier(algo).Verify(hash, sig, vctx)).To(MatchError("issuer CN=other does not match CN=mandelsoft"))
+
+		sctx.Issuer = &pkix.Name{CommonName: "other"}
+		ExpectError(registry.GetSigner(algo).Sign(defaultContext, hash, sctx)).To(HaveOccurred())
+	},
+		Entry("ecdsa", ecdsa.Algorithm, func(sub *pkix.Name) (*x509.Certificate, []byte, interface{}) {
+			ca, capriv := Must2(ecdsa.CreateRootCertificate(&pkix.Name{CommonName: "ca"}, time.Hour))
+			_, data, priv := Must3(ecdsa.CreateSigningCertificate(sub, ca, ca, capriv, time.Hour))
+			return ca, data, priv
+		}),
+		Entry("ed25519", ed25519.Algorithm, func(sub *pkix.Name) (*x509.Certificate, []byte, interface{}) {
+			ca, capriv := Must2(ed25519.CreateRootCertificate(&pkix.Name{CommonName: "ca"}, time.Hour))
+			_, data, priv := Must3(ed25519.CreateSigningCertificate(sub, ca, ca, capriv, time.Hour))
+			return ca, data, priv
+		}),
+	)
 })
@@ -212,13 +212,21 @@ func CreateCertificate(spec *Specification) (*x509.Certificate, []byte, error) {
 }
 
 func VerifyCertificate(cert *x509.Certificate, intermediates GenericCertificateChain, rootCerts GenericCertificatePool, name *pkix.Name, ts ...*time.Time) error {
+	_, err := VerifyCertificateChain(cert, intermediates, rootCerts, name, ts...)
+	return err
+}
+
+// VerifyCertificateChain verifies a certificate like VerifyCertificate and
+// additionally returns the verified certificate chain starting with the
+// given certificate and ending with the root certificate.
+func VerifyCertificateChain(cert *x509.Certificate, intermediates GenericCertificateChain, rootCerts GenericCertificatePool, name *pkix.Name, ts ...*time.Time) ([]*x509.Certificate, error) {
 	rootPool, err := GetCertPool(rootCerts, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	interPool, err := GetCertPool(intermediates, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	timestamp := cert.NotBefore
 	if ts := utils.Optional(ts...); ts != nil && !ts.IsZero() {
@@ -232,12 +240,29 @@ func VerifyCertificate(cert *x509.Certificate, intermediates GenericCertificateC
 		MaxConstraintComparisions: 0,
 	}
 
-	_, err = cert.Verify(opts)
+	chains, err := cert.Verify(opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if name != nil {
-		return errors.Wrapf(MatchDN(cert.Subject, *name), "issuer mismatch in public key certificate")
+		err = MatchDN(cert.Subject, *name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "issuer mismatch in public key certificate")
+		}
+	}
+	return chains[0], nil
+}
+
+// CheckValidityAt checks whether all given certificates are
+// valid at the given point in time.
+func CheckValidityAt(t time.Time, certs ...*x509.Certificate) error {
+	for _, c := range certs {
+		if t.Before(c.NotBefore) {
+			return errors.Newf("certificate %q not yet valid at %s (valid from %s)", c.Subject.String(), t.UTC().Format(time.RFC3339), c.NotBefore.UTC().Format(time.RFC3339))
+		}
+		if t.After(c.NotAfter) {
+			return errors.Newf("certificate %q expired at %s before %s", c.Subject.String(), c.NotAfter.UTC().Format(time.RFC3339), t.UTC().Format(time.RFC3339))
+		}
 	}
 	return nil
 }
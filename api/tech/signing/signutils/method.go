@@ -0,0 +1,82 @@
+package signutils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+// Scheme describes a signature scheme of a standard signature algorithm.
+type Scheme string
+
+const (
+	SCHEME_RSA_PKCS1V15 Scheme = "RSA-PKCS1-V1_5"
+	SCHEME_RSA_PSS      Scheme = "RSA-PSS"
+	SCHEME_ECDSA        Scheme = "ECDSA"
+)
+
+// Method describes a standard signature algorithm executed with a key,
+// which is not locally available, for example a key managed by a KMS
+// or stored in a PKCS#11 token. The created signatures can be verified
+// with the standard verifiers for the algorithm.
+type Method struct {
+	Name      string
+	Algorithm string
+	MediaType string
+	Scheme    Scheme
+	// Curve is the elliptic curve required by ECDSA algorithms.
+	Curve elliptic.Curve
+}
+
+// CheckPublicKey checks whether the public key can be used
+// with the signature algorithm.
+func (m *Method) CheckPublicKey(pub interface{}) error {
+	switch m.Scheme {
+	case SCHEME_RSA_PKCS1V15, SCHEME_RSA_PSS:
+		if _, ok := pub.(*rsa.PublicKey); !ok {
+			return fmt.Errorf("key is not an rsa key (%T)", pub)
+		}
+	case SCHEME_ECDSA:
+		k, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not an ecdsa key (%T)", pub)
+		}
+		if m.Curve != nil && k.Curve != m.Curve {
+			return fmt.Errorf("ecdsa key curve %s does not match algorithm %s", k.Curve.Params().Name, m.Algorithm)
+		}
+	default:
+		return errors.ErrNotSupported("signature scheme", string(m.Scheme))
+	}
+	return nil
+}
+
+// SignerOpts provides the options for a crypto.Signer
+// creating a signature for the given hash.
+func (m *Method) SignerOpts(hash crypto.Hash) crypto.SignerOpts {
+	if m.Scheme == SCHEME_RSA_PSS {
+		return &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash}
+	}
+	return hash
+}
+
+// Verify checks a created signature against the given public key.
+func (m *Method) Verify(pub interface{}, hash crypto.Hash, digest, sig []byte) error {
+	if err := m.CheckPublicKey(pub); err != nil {
+		return err
+	}
+	switch m.Scheme {
+	case SCHEME_RSA_PKCS1V15:
+		return rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), hash, digest, sig)
+	case SCHEME_RSA_PSS:
+		return rsa.VerifyPSS(pub.(*rsa.PublicKey), hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: hash})
+	default:
+		if !ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), digest, sig) {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package signutils
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	KIND_CRL           = "certificate revocation list"
+	KIND_OCSP_RESPONSE = "OCSP response"
+
+	CRLPEMBlockType          = "X509 CRL"
+	OCSPResponsePEMBlockType = "OCSP RESPONSE"
+)
+
+const (
+	REVOCATION_SOURCE_CRL  = "CRL"
+	REVOCATION_SOURCE_OCSP = "OCSP"
+)
+
+// RevocationInfo provides revocation information for certificates
+// supplied offline in form of certificate revocation lists (CRL)
+// and OCSP responses.
+type RevocationInfo struct {
+	CRLs          []*x509.RevocationList
+	OCSPResponses [][]byte
+}
+
+// NewRevocationInfo creates a new empty revocation info.
+func NewRevocationInfo() *RevocationInfo {
+	return &RevocationInfo{}
+}
+
+// IsEmpty checks whether any revocation information is available.
+func (r *RevocationInfo) IsEmpty() bool {
+	return r == nil || (len(r.CRLs) == 0 && len(r.OCSPResponses) == 0)
+}
+
+// AddCRL adds a PEM or DER encoded certificate revocation list.
+func (r *RevocationInfo) AddCRL(data []byte) error {
+	data, err := decodeRevocationPEM(data, CRLPEMBlockType, KIND_CRL)
+	if err != nil {
+		return err
+	}
+	crl, err := x509.ParseRevocationList(data)
+	if err != nil {
+		return errors.ErrInvalidWrap(err, KIND_CRL)
+	}
+	r.CRLs = append(r.CRLs, crl)
+	return nil
+}
+
+// AddOCSPResponse adds a PEM or DER encoded OCSP response.
+func (r *RevocationInfo) AddOCSPResponse(data []byte) error {
+	data, err := decodeRevocationPEM(data, OCSPResponsePEMBlockType, KIND_OCSP_RESPONSE)
+	if err != nil {
+		return err
+	}
+	_, err = ocsp.ParseResponse(data, nil)
+	if err != nil {
+		return errors.ErrInvalidWrap(err, KIND_OCSP_RESPONSE)
+	}
+	r.OCSPResponses = append(r.OCSPResponses, data)
+	return nil
+}
+
+func decodeRevocationPEM(data []byte, typ string, kind string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		// assume DER encoding
+		return data, nil
+	}
+	if block.Type != typ {
+		return nil, errors.ErrInvalid(kind+" PEM block type", block.Type)
+	}
+	return block.Bytes, nil
+}
+
+// errNoMatchingOCSPResponse is the error provided by the ocsp package
+// if a response does not describe the requested certificate.
+const errNoMatchingOCSPResponse = ocsp.ParseError("no response matching the supplied certificate")
+
+// RevocationStatus describes the revocation state of a certificate
+// found in the revocation information.
+type RevocationStatus struct {
+	// Source describes the kind of revocation information
+	// (REVOCATION_SOURCE_CRL or REVOCATION_SOURCE_OCSP).
+	Source string
+	// Revoked is true, if the certificate has been revoked.
+	Revoked bool
+	// RevokedAt is the revocation time for a revoked certificate.
+	RevokedAt time.Time
+	// Reason is the revocation reason code (RFC 5280) for a
+	// revoked certificate.
+	Reason int
+}
+
+// KeyMayBeCompromised checks whether the revocation reason of a revoked
+// certificate does not exclude a compromised key. This is the case for
+// the reasons keyCompromise and cACompromise, and if no reason
+// (unspecified) is given.
+func (s *RevocationStatus) KeyMayBeCompromised() bool {
+	if s == nil || !s.Revoked {
+		return false
+	}
+	switch s.Reason {
+	case ocsp.Unspecified, ocsp.KeyCompromise, ocsp.CACompromise:
+		return true
+	default:
+		return false
+	}
+}
+
+// Check checks the current revocation state of a certificate issued by
+// the given issuer certificate. If no revocation information is found for
+// the certificate, nil is returned. Revocation information not signed by
+// the issuer or outdated revocation information is rejected.
+func (r *RevocationInfo) Check(cert, issuer *x509.Certificate) (*RevocationStatus, error) {
+	return r.CheckAt(time.Now(), cert, issuer)
+}
+
+// CheckAt checks the revocation state of a certificate like Check,
+// but uses the revocation information valid at the given time.
+func (r *RevocationInfo) CheckAt(t time.Time, cert, issuer *x509.Certificate) (*RevocationStatus, error) {
+	var status *RevocationStatus
+
+	if r == nil {
+		return nil, nil
+	}
+	for _, crl := range r.CRLs {
+		if !bytes.Equal(crl.RawIssuer, issuer.RawSubject) {
+			continue
+		}
+		if err := crl.CheckSignatureFrom(issuer); err != nil {
+			return nil, errors.Wrapf(err, "%s of %q", KIND_CRL, issuer.Subject.String())
+		}
+		if err := checkUpdate(t, crl.ThisUpdate, crl.NextUpdate); err != nil {
+			return nil, errors.Wrapf(err, "%s of %q", KIND_CRL, issuer.Subject.String())
+		}
+		status = &RevocationStatus{Source: REVOCATION_SOURCE_CRL}
+		for _, e := range crl.RevokedCertificateEntries {
+			if e.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return &RevocationStatus{Source: REVOCATION_SOURCE_CRL, Revoked: true, RevokedAt: e.RevocationTime, Reason: e.ReasonCode}, nil
+			}
+		}
+	}
+	for _, data := range r.OCSPResponses {
+		resp, err := ocsp.ParseResponseForCert(data, cert, issuer)
+		if err != nil {
+			var perr ocsp.ParseError
+			if errors.As(err, &perr) && perr == errNoMatchingOCSPResponse {
+				// response for another certificate
+				continue
+			}
+			return nil, errors.Wrapf(err, "%s for %q", KIND_OCSP_RESPONSE, cert.Subject.String())
+		}
+		if err := checkUpdate(t, resp.ThisUpdate, resp.NextUpdate); err != nil {
+			return nil, errors.Wrapf(err, "%s for %q", KIND_OCSP_RESPONSE, cert.Subject.String())
+		}
+		switch resp.Status {
+		case ocsp.Revoked:
+			return &RevocationStatus{Source: REVOCATION_SOURCE_OCSP, Revoked: true, RevokedAt: resp.RevokedAt, Reason: resp.RevocationReason}, nil
+		case ocsp.Good:
+			if status == nil {
+				status = &RevocationStatus{Source: REVOCATION_SOURCE_OCSP}
+			}
+		}
+	}
+	return status, nil
+}
+
+// checkUpdate checks whether revocation information with the given
+// update times is valid at the given time. Without next update time
+// it is valid from its update time on.
+func checkUpdate(t, thisUpdate, nextUpdate time.Time) error {
+	if thisUpdate.After(t) {
+		return errors.Newf("not yet valid (this update %s)", thisUpdate.UTC().Format(time.RFC3339))
+	}
+	if !nextUpdate.IsZero() && nextUpdate.Before(t) {
+		return errors.Newf("outdated (next update %s)", nextUpdate.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package signutils_test
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ocsp"
+
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+var _ = Describe("revocation", func() {
+	capriv, _ := Must2(rsa.CreateKeyPair())
+	ca, _ := Must2(signutils.CreateCertificate(&signutils.Specification{
+		Subject:      *signutils.CommonName("ca-authority"),
+		Validity:     10 * time.Hour,
+		CAPrivateKey: capriv,
+		IsCA:         true,
+		Usages:       []interface{}{x509.ExtKeyUsageCodeSigning, x509.KeyUsageDigitalSignature, x509.KeyUsageCRLSign},
+	}))
+	cert, _, _ := Must3(rsa.CreateSigningCertificate(signutils.CommonName("mandelsoft"), ca, ca, capriv, time.Hour))
+	other, _, _ := Must3(rsa.CreateSigningCertificate(signutils.CommonName("acme.org"), ca, ca, capriv, time.Hour))
+
+	revoked := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	crlAt := func(next time.Time, reason int, serials ...*big.Int) []byte {
+		var entries []x509.RevocationListEntry
+		for _, s := range serials {
+			entries = append(entries, x509.RevocationListEntry{SerialNumber: s, RevocationTime: revoked, ReasonCode: reason})
+		}
+		return Must(x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:                    big.NewInt(1),
+			ThisUpdate:                next.Add(-2 * time.Hour),
+			NextUpdate:                next,
+			RevokedCertificateEntries: entries,
+		}, ca, capriv.(*rsa.PrivateKey)))
+	}
+
+	crl := func(serials ...*big.Int) []byte {
+		return crlAt(time.Now().Add(time.Hour), ocsp.Unspecified, serials...)
+	}
+
+	ocspResponseAt := func(next time.Time, c *x509.Certificate, status int, reason int) []byte {
+		return Must(ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:           status,
+			SerialNumber:     c.SerialNumber,
+			ThisUpdate:       next.Add(-2 * time.Hour),
+			NextUpdate:       next,
+			RevokedAt:        revoked,
+			RevocationReason: reason,
+		}, capriv.(*rsa.PrivateKey)))
+	}
+
+	ocspResponse := func(c *x509.Certificate, status int) []byte {
+		return ocspResponseAt(time.Now().Add(time.Hour), c, status, ocsp.Unspecified)
+	}
+
+	It("handles empty info", func() {
+		info := signutils.NewRevocationInfo()
+		Expect(info.IsEmpty()).To(BeTrue())
+		Expect(Must(info.Check(cert, ca))).To(BeNil())
+	})
+
+	Context("crl", func() {
+		It("detects revoked certificate", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddCRL(pem.EncodeToMemory(&pem.Block{Type: signutils.CRLPEMBlockType, Bytes: crl(cert.SerialNumber)})))
+			Expect(info.IsEmpty()).To(BeFalse())
+
+			status := Must(info.Check(cert, ca))
+			Expect(status).To(Equal(&signutils.RevocationStatus{Source: signutils.REVOCATION_SOURCE_CRL, Revoked: true, RevokedAt: revoked.UTC()}))
+		})
+
+		It("accepts unrevoked certificate", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddCRL(crl(other.SerialNumber)))
+
+			status := Must(info.Check(cert, ca))
+			Expect(status).To(Equal(&signutils.RevocationStatus{Source: signutils.REVOCATION_SOURCE_CRL}))
+		})
+
+		It("ignores CRL of other issuer", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddCRL(crl(cert.SerialNumber)))
+
+			Expect(Must(info.Check(other, cert))).To(BeNil())
+		})
+
+		It("rejects outdated CRL", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddCRL(crlAt(time.Now().Add(-time.Minute), ocsp.Unspecified, other.SerialNumber)))
+
+			ExpectError(info.Check(cert, ca)).To(MatchError(ContainSubstring(`certificate revocation list of "CN=ca-authority": outdated (next update`)))
+			Expect(Must(info.CheckAt(time.Now().Add(-time.Hour), cert, ca))).To(Equal(&signutils.RevocationStatus{Source: signutils.REVOCATION_SOURCE_CRL}))
+			ExpectError(info.CheckAt(time.Now().Add(-3*time.Hour), cert, ca)).To(MatchError(ContainSubstring("not yet valid (this update")))
+		})
+
+		It("provides revocation reason", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddCRL(crlAt(time.Now().Add(time.Hour), ocsp.Superseded, cert.SerialNumber)))
+
+			status := Must(info.Check(cert, ca))
+			Expect(status).To(Equal(&signutils.RevocationStatus{Source: signutils.REVOCATION_SOURCE_CRL, Revoked: true, RevokedAt: revoked.UTC(), Reason: ocsp.Superseded}))
+			Expect(status.KeyMayBeCompromised()).To(BeFalse())
+		})
+
+		It("rejects invalid data", func() {
+			info := signutils.NewRevocationInfo()
+			ExpectError(info.AddCRL(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}))).To(MatchError(`certificate revocation list PEM block type "CERTIFICATE" is invalid`))
+		})
+	})
+
+	Context("ocsp", func() {
+		It("detects revoked certificate", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddOCSPResponse(ocspResponse(cert, ocsp.Revoked)))
+
+			status := Must(info.Check(cert, ca))
+			Expect(status).To(Equal(&signutils.RevocationStatus{Source: signutils.REVOCATION_SOURCE_OCSP, Revoked: true, RevokedAt: revoked.UTC()}))
+		})
+
+		It("accepts good certificate", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddOCSPResponse(ocspResponse(other, ocsp.Revoked)))
+			MustBeSuccessful(info.AddOCSPResponse(ocspResponse(cert, ocsp.Good)))
+
+			status := Must(info.Check(cert, ca))
+			Expect(status).To(Equal(&signutils.RevocationStatus{Source: signutils.REVOCATION_SOURCE_OCSP}))
+		})
+
+		It("rejects outdated response", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddOCSPResponse(ocspResponseAt(time.Now().Add(-time.Minute), cert, ocsp.Good, ocsp.Unspecified)))
+
+			ExpectError(info.Check(cert, ca)).To(MatchError(ContainSubstring(`OCSP response for "CN=mandelsoft": outdated (next update`)))
+		})
+
+		It("provides revocation reason", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddOCSPResponse(ocspResponseAt(time.Now().Add(time.Hour), cert, ocsp.Revoked, ocsp.KeyCompromise)))
+
+			status := Must(info.Check(cert, ca))
+			Expect(status.Reason).To(Equal(ocsp.KeyCompromise))
+			Expect(status.KeyMayBeCompromised()).To(BeTrue())
+		})
+
+		It("rejects response with wrong issuer", func() {
+			info := signutils.NewRevocationInfo()
+			MustBeSuccessful(info.AddOCSPResponse(ocspResponse(cert, ocsp.Good)))
+
+			ExpectError(info.Check(cert, other)).To(HaveOccurred())
+		})
+	})
+
+	Context("validity", func() {
+		It("checks validity period", func() {
+			MustBeSuccessful(signutils.CheckValidityAt(time.Now(), cert, ca))
+			ExpectError(signutils.CheckValidityAt(time.Now().Add(2*time.Hour), cert, ca)).To(MatchError(ContainSubstring(`certificate "CN=mandelsoft" expired at`)))
+			ExpectError(signutils.CheckValidityAt(time.Now().Add(-2*time.Hour), cert, ca)).To(MatchError(ContainSubstring(`certificate "CN=mandelsoft" not yet valid at`)))
+		})
+	})
+})
@@ -2,8 +2,12 @@ package signutils
 
 import (
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
 )
 
 // MediaTypePEM defines the media type for PEM formatted signature data.
@@ -61,3 +65,55 @@ func SignatureBytesToPem(algo string, data []byte, certs ...*x509.Certificate) [
 	}
 	return append(pem.EncodeToMemory(block), CertificateChainToPem(certs)...)
 }
+
+// PublicKeyContext provides the public key or certificate chain
+// configured for a signing operation together with the settings
+// required to verify a certificate.
+type PublicKeyContext interface {
+	GetPublicKey() GenericPublicKey
+	GetRootCerts() GenericCertificatePool
+	GetIssuer() *pkix.Name
+}
+
+// EncodedSignature is the serialized form of a signature.
+type EncodedSignature struct {
+	Value     string
+	MediaType string
+	Issuer    string
+}
+
+// EncodeSignature provides the serialized form of signature bytes created
+// for the given algorithm. Without configured public key, the signature is
+// hex encoded and uses the given media type.
+// If the public key is given as certificate chain, the certificate is
+// verified against the root certificates and the expected issuer.
+// The signature is then PEM encoded together with the certificate chain
+// and the certificate subject is used as issuer.
+// The public key (taken from the certificate or given as it is) is passed
+// to the check function, which must validate it against the key used to
+// create the signature.
+func EncodeSignature(algo, mediaType string, sig []byte, ctx PublicKeyContext, check func(pub interface{}) error) (*EncodedSignature, error) {
+	result := &EncodedSignature{
+		Value:     hex.EncodeToString(sig),
+		MediaType: mediaType,
+	}
+	pub := ctx.GetPublicKey()
+	if pub == nil {
+		return result, nil
+	}
+	certs, err := GetCertificateChain(pub, false)
+	if err == nil && len(certs) > 0 {
+		err = VerifyCertificate(certs[0], certs[1:], ctx.GetRootCerts(), ctx.GetIssuer())
+		if err != nil {
+			return nil, errors.Wrapf(err, "public key certificate")
+		}
+		result.MediaType = MediaTypePEM
+		result.Value = string(SignatureBytesToPem(algo, sig, certs...))
+		result.Issuer = certs[0].Subject.String()
+		pub = certs[0].PublicKey
+	}
+	if err := check(pub); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
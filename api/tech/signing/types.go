@@ -20,15 +20,36 @@ type SigningContext interface {
 	GetIssuer() *pkix.Name
 }
 
+// CredentialsContextProvider is an optional interface of a SigningContext
+// providing the context used for a signing or verification operation.
+// It can be used by handlers to access configuration settings
+// for a verification.
+type CredentialsContextProvider interface {
+	GetCredentialsContext() credentials.Context
+}
+
+// GetCredentialsContext provides the credentials context of a SigningContext,
+// if it is provided. Otherwise, nil is returned.
+func GetCredentialsContext(sctx SigningContext) credentials.Context {
+	if p, ok := sctx.(CredentialsContextProvider); ok {
+		return p.GetCredentialsContext()
+	}
+	return nil
+}
+
 type DefaultSigningContext struct {
 	Hash       crypto.Hash
 	PrivateKey signutils.GenericPrivateKey
 	PublicKey  signutils.GenericPublicKey
 	RootCerts  signutils.GenericCertificatePool
 	Issuer     *pkix.Name
+	Context    credentials.Context
 }
 
-var _ SigningContext = (*DefaultSigningContext)(nil)
+var (
+	_ SigningContext             = (*DefaultSigningContext)(nil)
+	_ CredentialsContextProvider = (*DefaultSigningContext)(nil)
+)
 
 func (d *DefaultSigningContext) GetHash() crypto.Hash {
 	return d.Hash
@@ -50,6 +71,10 @@ func (d *DefaultSigningContext) GetIssuer() *pkix.Name {
 	return d.Issuer
 }
 
+func (d *DefaultSigningContext) GetCredentialsContext() credentials.Context {
+	return d.Context
+}
+
 type Signature struct {
 	Value     string
 	MediaType string
@@ -98,6 +123,12 @@ type SignatureHandler interface {
 	Verifier
 }
 
+// KeyPairGenerator is an optional interface of a SignatureHandler
+// able to create key pairs suitable for its signature algorithm.
+type KeyPairGenerator interface {
+	CreateKeyPair() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error)
+}
+
 // Hasher creates a new hash.Hash interface.
 type Hasher interface {
 	Algorithm() string
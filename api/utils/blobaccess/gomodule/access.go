@@ -0,0 +1,24 @@
+package gomodule
+
+import (
+	"ocm.software/ocm/api/utils/blobaccess/bpi"
+)
+
+func DataAccess(proxy string, module, version string, opts ...Option) (bpi.DataAccess, error) {
+	return BlobAccess(proxy, module, version, opts...)
+}
+
+func BlobAccess(proxy string, module, version string, opts ...Option) (bpi.BlobAccess, error) {
+	s, err := NewModuleSpec(proxy, module, version, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetBlobAccess()
+}
+
+func Provider(proxy string, module, version string, opts ...Option) bpi.BlobAccessProvider {
+	return bpi.BlobAccessProviderFunction(func() (bpi.BlobAccess, error) {
+		b, err := BlobAccess(proxy, module, version, opts...)
+		return b, err
+	})
+}
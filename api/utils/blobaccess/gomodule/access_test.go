@@ -0,0 +1,72 @@
+package gomodule_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	techgomodule "ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/tech/gomodule/gomoduletest"
+	"ocm.software/ocm/api/tech/gomodule/identity"
+	"ocm.software/ocm/api/utils/blobaccess/gomodule"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	MODULE  = "acme.org/hello"
+	VERSION = "v1.0.0"
+)
+
+var _ = Describe("Method", func() {
+	var server *gomoduletest.Server
+	var zipHash, modHash string
+
+	BeforeEach(func() {
+		server = gomoduletest.NewServer()
+		zipHash, modHash = server.Add(MODULE, VERSION, map[string][]byte{"hello.go": []byte("package hello\n")})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("FileUrl()", func() {
+		acc := Must(gomodule.NewModuleSpec("", "github.com/Acme/Hello", VERSION))
+		Expect(acc.FileUrl()).To(Equal("https://proxy.golang.org/github.com/!acme/!hello/@v/v1.0.0.zip"))
+		acc = Must(gomodule.NewModuleSpec("https://goproxy.acme.org/", MODULE, VERSION, gomodule.WithExtension(techgomodule.EXT_MOD)))
+		Expect(acc.FileUrl()).To(Equal("https://goproxy.acme.org/acme.org/hello/@v/v1.0.0.mod"))
+		ExpectError(gomodule.NewModuleSpec("", MODULE, VERSION, gomodule.WithExtension(techgomodule.EXT_INFO), gomodule.WithHash(zipHash))).To(MatchError("hash verification not possible for go module info file"))
+	})
+
+	It("accesses module zip with hash verification", func() {
+		acc := Must(gomodule.BlobAccess(server.URL, MODULE, VERSION, gomodule.WithHash(zipHash)))
+		defer acc.Close()
+		Expect(acc.MimeType()).To(Equal(mime.MIME_ZIP))
+		Expect(acc.Get()).To(Equal(server.Get(MODULE, VERSION, techgomodule.EXT_ZIP)))
+	})
+
+	It("accesses go.mod", func() {
+		acc := Must(gomodule.BlobAccess(server.URL, MODULE, VERSION, gomodule.WithExtension(techgomodule.EXT_MOD), gomodule.WithHash(modHash)))
+		defer acc.Close()
+		Expect(acc.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(acc.Get()).To(Equal([]byte("module " + MODULE + "\n\ngo 1.22\n")))
+	})
+
+	It("uses credentials", func() {
+		server.RequireAuth("user", "pass")
+		acc := Must(gomodule.BlobAccess(server.URL, MODULE, VERSION, gomodule.WithExtension(techgomodule.EXT_INFO),
+			gomodule.WithCredentials(credentials.DirectCredentials{identity.ATTR_USERNAME: "user", identity.ATTR_PASSWORD: "pass"})))
+		defer acc.Close()
+		Expect(acc.MimeType()).To(Equal(mime.MIME_JSON_OFFICIAL))
+		Expect(acc.Get()).To(Equal(server.Get(MODULE, VERSION, techgomodule.EXT_INFO)))
+	})
+
+	It("detects hash mismatch", func() {
+		zip := Must(techgomodule.CreateZip(MODULE, VERSION, map[string][]byte{"go.mod": []byte("module " + MODULE + "\n")}))
+		server.Replace(MODULE, VERSION, techgomodule.EXT_ZIP, zip)
+		acc := Must(gomodule.BlobAccess(server.URL, MODULE, VERSION, gomodule.WithHash(zipHash)))
+		defer acc.Close()
+		ExpectError(acc.Get()).To(MatchError(ContainSubstring("go module hash mismatch: expected " + zipHash)))
+	})
+})
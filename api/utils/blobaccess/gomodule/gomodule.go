@@ -0,0 +1,117 @@
+package gomodule
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+type ModuleSpec struct {
+	// proxy is the base URL of the Go module proxy.
+	proxy string
+	// module is the module path.
+	module string
+	// version of the module.
+	version string
+
+	options *Options
+}
+
+// NewModuleSpec creates a new access specification for a file
+// of a Go module version provided by a Go module proxy.
+func NewModuleSpec(proxy, module, version string, opts ...Option) (*ModuleSpec, error) {
+	if module == "" {
+		return nil, errors.ErrRequired("module")
+	}
+	if version == "" {
+		return nil, errors.ErrRequired("version")
+	}
+	if proxy == "" {
+		proxy = gomodule.DEFAULT_PROXY
+	}
+	var eff Options
+	for _, opt := range opts {
+		if opt != nil {
+			opt.ApplyTo(&eff)
+		}
+	}
+	if eff.Extension == "" {
+		eff.Extension = gomodule.EXT_ZIP
+	}
+	if err := gomodule.CheckExtension(eff.Extension); err != nil {
+		return nil, err
+	}
+	if eff.Hash != "" && eff.Extension == gomodule.EXT_INFO {
+		return nil, errors.Newf("hash verification not possible for go module info file")
+	}
+	return &ModuleSpec{
+		proxy:   proxy,
+		module:  module,
+		version: version,
+		options: &eff,
+	}, nil
+}
+
+// FileUrl returns the URL of the module file.
+func (a *ModuleSpec) FileUrl() (string, error) {
+	return gomodule.URL(a.proxy, a.module, a.version, a.options.Extension)
+}
+
+func (a *ModuleSpec) GetBlobAccess() (blobaccess.BlobAccess, error) {
+	u, err := a.FileUrl()
+	if err != nil {
+		return nil, err
+	}
+	acc := blobaccess.DataAccessForReaderFunction(a.reader, u)
+	return accessobj.CachedBlobAccessForWriterWithCache(a.options.Cache(), MimeType(a.options.Extension), accessio.NewDataAccessWriter(acc)), nil
+}
+
+func (a *ModuleSpec) reader() (io.ReadCloser, error) {
+	creds, err := a.options.GetCredentials(a.proxy, a.module)
+	if err != nil {
+		return nil, err
+	}
+	log := a.options.Logger("proxy", a.proxy, "module", a.module, "version", a.version, "file", a.options.Extension)
+	log.Debug("fetch Go module file")
+
+	r, err := gomodule.Fetch(context.Background(), nil, a.proxy, a.module, a.version, a.options.Extension, creds)
+	if err != nil {
+		return nil, err
+	}
+	if a.options.Hash == "" {
+		return r, nil
+	}
+	// the h1 dirhash is calculated on the content of the complete file,
+	// therefore, it must be read completely before it can be verified.
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	err = gomodule.Verify(a.options.Extension, data, a.options.Hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s@%s", a.module, a.version)
+	}
+	log.Debug("verified Go module file", "hash", a.options.Hash)
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// MimeType provides the mime type of a module file.
+func MimeType(ext string) string {
+	switch ext {
+	case gomodule.EXT_MOD:
+		return mime.MIME_TEXT
+	case gomodule.EXT_INFO:
+		return mime.MIME_JSON_OFFICIAL
+	default:
+		return mime.MIME_ZIP
+	}
+}
@@ -0,0 +1,24 @@
+package pypi
+
+import (
+	"ocm.software/ocm/api/utils/blobaccess/bpi"
+)
+
+func DataAccess(index string, pkg, version string, opts ...Option) (bpi.DataAccess, error) {
+	return BlobAccess(index, pkg, version, opts...)
+}
+
+func BlobAccess(index string, pkg, version string, opts ...Option) (bpi.BlobAccess, error) {
+	s, err := NewPackageSpec(index, pkg, version, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetBlobAccess()
+}
+
+func Provider(index string, pkg, version string, opts ...Option) bpi.BlobAccessProvider {
+	return bpi.BlobAccessProviderFunction(func() (bpi.BlobAccess, error) {
+		b, err := BlobAccess(index, pkg, version, opts...)
+		return b, err
+	})
+}
@@ -0,0 +1,70 @@
+package pypi_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/pypi/identity"
+	"ocm.software/ocm/api/tech/pypi/pypitest"
+	"ocm.software/ocm/api/utils/blobaccess/pypi"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+var _ = Describe("Method", func() {
+	var server *pypitest.Server
+	var sdist, wheel []byte
+
+	BeforeEach(func() {
+		server = pypitest.NewServer()
+		sdist = pypitest.SDist("hello", "1.0.0")
+		wheel = pypitest.Wheel("hello", "1.0.0")
+		server.Add("hello", "hello-1.0.0.tar.gz", sdist)
+		server.Add("hello", "hello-1.0.0-py3-none-any.whl", wheel)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("ProjectUrl()", func() {
+		acc := Must(pypi.NewPackageSpec("https://pypi.org/simple", "Hello_World", "1.0.0"))
+		Expect(acc.ProjectUrl()).To(Equal("https://pypi.org/simple/hello-world/"))
+		acc = Must(pypi.NewPackageSpec("", "hello", "1.0.0"))
+		Expect(acc.ProjectUrl()).To(Equal("https://pypi.org/simple/hello/"))
+	})
+
+	It("accesses source distribution", func() {
+		acc := Must(pypi.BlobAccess(server.IndexURL(), "Hello", "1.0.0"))
+		defer acc.Close()
+		Expect(acc.MimeType()).To(Equal(mime.MIME_TGZ))
+		Expect(acc.Get()).To(Equal(sdist))
+	})
+
+	It("accesses wheel", func() {
+		acc := Must(pypi.BlobAccess(server.IndexURL(), "hello", "1.0.0", pypi.WithFilename("hello-1.0.0-py3-none-any.whl")))
+		defer acc.Close()
+		Expect(acc.MimeType()).To(Equal(mime.MIME_ZIP))
+		Expect(acc.Get()).To(Equal(wheel))
+	})
+
+	It("uses credentials", func() {
+		server.RequireAuth(identity.TOKEN_USERNAME, "token")
+		acc := Must(pypi.BlobAccess(server.IndexURL(), "hello", "1.0.0", pypi.WithCredentials(credentials.DirectCredentials{identity.ATTR_TOKEN: "token"})))
+		defer acc.Close()
+		Expect(acc.Get()).To(Equal(sdist))
+	})
+
+	It("detects digest mismatch", func() {
+		server.Add("other", "other-1.0.0.tar.gz", sdist)
+		server.Replace("other-1.0.0.tar.gz", []byte("modified"))
+		acc := Must(pypi.BlobAccess(server.IndexURL(), "other", "1.0.0"))
+		defer acc.Close()
+		ExpectError(acc.Get()).To(MatchError(ContainSubstring("SHA-256 digest mismatch")))
+	})
+
+	It("fails for unknown version", func() {
+		ExpectError(pypi.BlobAccess(server.IndexURL(), "hello", "2.0.0")).To(MatchError(ContainSubstring(`distribution file "2.0.0" not found`)))
+	})
+})
@@ -0,0 +1,120 @@
+package pypi
+
+import (
+	"github.com/mandelsoft/logging"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/tech/pypi"
+	"ocm.software/ocm/api/tech/pypi/identity"
+	ocmlog "ocm.software/ocm/api/utils/logging"
+	"ocm.software/ocm/api/utils/stdopts"
+)
+
+type Option interface {
+	ApplyTo(opts *Options)
+}
+
+type OptionFunc func(opts *Options)
+
+func (f OptionFunc) ApplyTo(opts *Options) {
+	f(opts)
+}
+
+type Options struct {
+	stdopts.StandardContexts
+	// Filename selects a dedicated distribution file of the package version.
+	Filename string
+}
+
+func (o *Options) Logger(keyValuePairs ...interface{}) logging.Logger {
+	return ocmlog.LogContext(
+		o.LoggingContext.Value,
+		o.CredentialContext.Value,
+		o.CachingContext.Value,
+	).Logger(pypi.REALM).WithValues(keyValuePairs...)
+}
+
+func (o *Options) GetCredentials(index string, pkg string) (cpi.Credentials, error) {
+	switch {
+	case o.Credentials.Value != nil:
+		return o.Credentials.Value, nil
+	case o.CredentialContext.Value != nil:
+		return identity.GetCredentials(o.CredentialContext.Value, index, pkg)
+	default:
+		return nil, nil
+	}
+}
+
+func (o *Options) ApplyTo(opts *Options) {
+	if opts == nil {
+		return
+	}
+	if o.CredentialContext.Value != nil {
+		opts.CredentialContext = o.CredentialContext
+	}
+	if o.LoggingContext.Value != nil {
+		opts.LoggingContext = o.LoggingContext
+	}
+	if o.CachingFileSystem.Value != nil {
+		opts.CachingFileSystem = o.CachingFileSystem
+	}
+	if o.Credentials.Value != nil {
+		opts.Credentials = o.Credentials
+	}
+	if o.Filename != "" {
+		opts.Filename = o.Filename
+	}
+}
+
+// //////////////////////////////////////////////////////////////////////////////
+// Option constructors
+
+func WithFilename(name string) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.Filename = name
+	})
+}
+
+func WithCredentialContext(ctx credentials.ContextProvider) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetCredentialContext(ctx.CredentialsContext())
+	})
+}
+
+func WithLoggingContext(ctx logging.ContextProvider) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetLoggingContext(ctx.LoggingContext())
+	})
+}
+
+func WithCachingContext(ctx datacontext.Context) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetCachingContext(ctx)
+	})
+}
+
+func WithCredentials(c credentials.Credentials) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetCredentials(c)
+	})
+}
+
+// //////////////////////////////////////////////////////////////////////////////
+// DataContext integration
+
+func (o *Options) SetDataContext(ctx datacontext.Context) {
+	if c, ok := ctx.(credentials.ContextProvider); ok {
+		o.SetCredentialContext(c.CredentialsContext())
+	}
+	o.SetCachingContext(ctx.AttributesContext())
+}
+
+var _ stdopts.DataContextOptionBag = (*Options)(nil)
+
+func WithDataContext(ctx datacontext.Context) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetDataContext(ctx)
+	})
+}
@@ -0,0 +1,151 @@
+package pypi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/tech/pypi"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/iotools"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+type PackageSpec struct {
+	// index is the base URL of the simple API of the package index.
+	index string
+	// pkg is the name of the Python package.
+	pkg string
+	// version of the Python package.
+	version string
+
+	options *Options
+}
+
+// NewPackageSpec creates a new access specification for a distribution
+// file of a Python package version.
+func NewPackageSpec(index, pkg, version string, opts ...Option) (*PackageSpec, error) {
+	if pkg == "" {
+		return nil, errors.ErrRequired("package")
+	}
+	if version == "" {
+		return nil, errors.ErrRequired("version")
+	}
+	if index == "" {
+		index = pypi.DEFAULT_INDEX
+	}
+	var eff Options
+	for _, opt := range opts {
+		if opt != nil {
+			opt.ApplyTo(&eff)
+		}
+	}
+	return &PackageSpec{
+		index:   index,
+		pkg:     pkg,
+		version: version,
+		options: &eff,
+	}, nil
+}
+
+// ProjectUrl returns the simple API URL of the Python package.
+func (a *PackageSpec) ProjectUrl() string {
+	return pypi.ProjectURL(a.index, a.pkg)
+}
+
+// GetFile resolves the distribution file of the package version
+// using the simple API of the index.
+func (a *PackageSpec) GetFile() (*pypi.File, error) {
+	log := a.options.Logger("index", a.index)
+	log.Debug("query index for Python package", "package", a.pkg, "version", a.version)
+
+	creds, err := a.options.GetCredentials(a.index, a.pkg)
+	if err != nil {
+		return nil, err
+	}
+	project, err := pypi.GetProject(context.Background(), nil, a.index, a.pkg, creds)
+	if err != nil {
+		return nil, err
+	}
+	f, err := project.FindFile(a.version, a.options.Filename)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("found Python package", "file", f.Filename, "url", f.URL, "hashes", f.Hashes)
+	return f, nil
+}
+
+func (a *PackageSpec) GetBlobAccess() (blobaccess.BlobAccess, error) {
+	file, err := a.GetFile()
+	if err != nil {
+		return nil, err
+	}
+	return a.BlobAccessForFile(file)
+}
+
+// BlobAccessForFile provides the blob access for a distribution file
+// previously resolved with GetFile. The content is verified against the
+// strongest digest provided by the index.
+func (a *PackageSpec) BlobAccessForFile(file *pypi.File) (blobaccess.BlobAccess, error) {
+	algo, digest, err := file.Digest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "distribution file %s", file.Filename)
+	}
+	f := func() (io.ReadCloser, error) {
+		return a.reader(file.URL)
+	}
+	if digest != "" {
+		tf := f
+		f = func() (io.ReadCloser, error) {
+			r, err := tf()
+			if err != nil {
+				return nil, err
+			}
+			return iotools.VerifyingReaderWithHash(r, algo, digest), nil
+		}
+	}
+	acc := blobaccess.DataAccessForReaderFunction(f, file.URL)
+	return accessobj.CachedBlobAccessForWriterWithCache(a.options.Cache(), MimeType(file.Filename), accessio.NewDataAccessWriter(acc)), nil
+}
+
+func (a *PackageSpec) reader(url string) (io.ReadCloser, error) {
+	creds, err := a.options.GetCredentials(a.index, a.pkg)
+	if err != nil {
+		return nil, err
+	}
+	log := a.options.Logger("url", url)
+
+	log.Debug("download Python package")
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	pypi.SetAuth(req, creds)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		buf, _ := io.ReadAll(io.LimitReader(resp.Body, 2000))
+		return nil, errors.Newf("distribution file request %s provides %s: %s", url, resp.Status, string(buf))
+	}
+	return resp.Body, nil
+}
+
+// MimeType provides the mime type of a distribution file.
+func MimeType(filename string) string {
+	switch {
+	case strings.HasSuffix(filename, pypi.EXT_SDIST):
+		return mime.MIME_TGZ
+	case strings.HasSuffix(filename, pypi.EXT_WHEEL), strings.HasSuffix(filename, pypi.EXT_SDIST_ZIP):
+		return mime.MIME_ZIP
+	default:
+		return mime.MIME_OCTET
+	}
+}
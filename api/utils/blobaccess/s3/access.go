@@ -0,0 +1,52 @@
+package s3
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessio/downloader/s3"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess/bpi"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+func DataAccess(bucket, key string, opts ...Option) (bpi.DataAccess, error) {
+	return BlobAccess(bucket, key, opts...)
+}
+
+// BlobAccess provides a blob access for the object with the given key
+// stored in an S3 bucket. The object is downloaded on first access.
+func BlobAccess(bucket, key string, opts ...Option) (bpi.BlobAccess, error) {
+	if bucket == "" {
+		return nil, errors.ErrRequired("bucket")
+	}
+	if key == "" {
+		return nil, errors.ErrRequired("key")
+	}
+	var eff Options
+	for _, opt := range opts {
+		if opt != nil {
+			opt.ApplyTo(&eff)
+		}
+	}
+
+	creds, err := eff.GetCredentials(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	d := s3.NewDownloader(eff.Region, bucket, key, eff.Version, identity.AWSCredentials(creds)).WithEndpoint(eff.Endpoint)
+
+	mimeType := eff.MimeType
+	if mimeType == "" {
+		mimeType = mime.MIME_OCTET
+	}
+	return accessobj.CachedBlobAccessForWriterWithCache(eff.Cache(), mimeType, accessio.NewWriteAtWriter(d.Download)), nil
+}
+
+func Provider(bucket, key string, opts ...Option) bpi.BlobAccessProvider {
+	return bpi.BlobAccessProviderFunction(func() (bpi.BlobAccess, error) {
+		b, err := BlobAccess(bucket, key, opts...)
+		return b, err
+	})
+}
@@ -0,0 +1,59 @@
+package s3_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	"ocm.software/ocm/api/tech/s3/s3test"
+	"ocm.software/ocm/api/utils/blobaccess/s3"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const BUCKET = "artifacts"
+
+var _ = Describe("s3 blob access", func() {
+	var env *Builder
+	var server *s3test.Server
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		server = s3test.NewServer(BUCKET)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("requires bucket and key", func() {
+		ExpectError(s3.BlobAccess("", "key")).To(MatchError(`"bucket" required`))
+		ExpectError(s3.BlobAccess(BUCKET, "")).To(MatchError(`"key" required`))
+	})
+
+	It("accesses objects", func() {
+		version := server.Put(BUCKET, "bin/tool", []byte("some binary content"), mime.MIME_OCTET)
+		server.Put(BUCKET, "bin/tool", []byte("newer content"), mime.MIME_OCTET)
+
+		acc := Must(s3.BlobAccess(BUCKET, "bin/tool",
+			s3.WithDataContext(env.OCMContext()),
+			s3.WithCredentials(credentials.DirectCredentials{
+				identity.ATTR_AWS_ACCESS_KEY_ID:     "minioadmin",
+				identity.ATTR_AWS_SECRET_ACCESS_KEY: "minioadmin",
+			}),
+			s3.WithEndpoint(server.URL),
+			s3.WithVersion(version),
+		))
+		defer Close(acc)
+		Expect(acc.MimeType()).To(Equal(mime.MIME_OCTET))
+		Expect(acc.Get()).To(Equal([]byte("some binary content")))
+
+		acc = Must(s3.BlobAccess(BUCKET, "bin/tool", s3.WithEndpoint(server.URL), s3.WithMimeType(mime.MIME_TEXT)))
+		defer Close(acc)
+		Expect(acc.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(acc.Get()).To(Equal([]byte("newer content")))
+	})
+})
@@ -0,0 +1,147 @@
+package s3
+
+import (
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	"ocm.software/ocm/api/tech/s3"
+	"ocm.software/ocm/api/utils/stdopts"
+)
+
+type Option interface {
+	ApplyTo(opts *Options)
+}
+
+type OptionFunc func(opts *Options)
+
+func (f OptionFunc) ApplyTo(opts *Options) {
+	f(opts)
+}
+
+type Options struct {
+	stdopts.StandardContexts
+
+	// Region of the bucket. If not set, it is determined from the bucket.
+	Region string
+	// Version of the object. If not set, the latest version is used.
+	Version string
+	// Endpoint is the URL of an S3-compatible storage service.
+	Endpoint string
+	// MimeType of the object.
+	MimeType string
+}
+
+func (o *Options) GetCredentials(bucket, key string) (cpi.Credentials, error) {
+	switch {
+	case o.Credentials.Value != nil:
+		return o.Credentials.Value, nil
+	case o.CredentialContext.Value != nil:
+		host, err := s3.EndpointHost(o.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return identity.GetCredentials(o.CredentialContext.Value, host, bucket, key, o.Version)
+	default:
+		return nil, nil
+	}
+}
+
+func (o *Options) ApplyTo(opts *Options) {
+	if opts == nil {
+		return
+	}
+	if o.CredentialContext.Value != nil {
+		opts.CredentialContext = o.CredentialContext
+	}
+	if o.LoggingContext.Value != nil {
+		opts.LoggingContext = o.LoggingContext
+	}
+	if o.CachingContext.Value != nil {
+		opts.CachingContext = o.CachingContext
+	}
+	if o.CachingFileSystem.Value != nil {
+		opts.CachingFileSystem = o.CachingFileSystem
+	}
+	if o.CachingPath.Value != "" {
+		opts.CachingPath = o.CachingPath
+	}
+	if o.Credentials.Value != nil {
+		opts.Credentials = o.Credentials
+	}
+	if o.Region != "" {
+		opts.Region = o.Region
+	}
+	if o.Version != "" {
+		opts.Version = o.Version
+	}
+	if o.Endpoint != "" {
+		opts.Endpoint = o.Endpoint
+	}
+	if o.MimeType != "" {
+		opts.MimeType = o.MimeType
+	}
+}
+
+// //////////////////////////////////////////////////////////////////////////////
+// Option constructors
+
+func WithCredentialContext(ctx credentials.ContextProvider) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetCredentialContext(ctx.CredentialsContext())
+	})
+}
+
+func WithCachingContext(ctx datacontext.Context) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetCachingContext(ctx)
+	})
+}
+
+func WithCredentials(c credentials.Credentials) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetCredentials(c)
+	})
+}
+
+func WithRegion(region string) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.Region = region
+	})
+}
+
+func WithVersion(version string) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.Version = version
+	})
+}
+
+func WithEndpoint(endpoint string) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.Endpoint = endpoint
+	})
+}
+
+func WithMimeType(mimeType string) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.MimeType = mimeType
+	})
+}
+
+// //////////////////////////////////////////////////////////////////////////////
+// DataContext integration
+
+func (o *Options) SetDataContext(ctx datacontext.Context) {
+	if c, ok := ctx.(credentials.ContextProvider); ok {
+		o.SetCredentialContext(c.CredentialsContext())
+	}
+	o.SetCachingContext(ctx.AttributesContext())
+}
+
+var _ stdopts.DataContextOptionBag = (*Options)(nil)
+
+func WithDataContext(ctx datacontext.Context) Option {
+	return OptionFunc(func(opts *Options) {
+		opts.SetDataContext(ctx)
+	})
+}
@@ -24,6 +24,7 @@ const (
 	MIME_TGZ_ALT = MIME_TAR + "+gzip"
 
 	MIME_JAR = "application/x-jar"
+	MIME_ZIP = "application/zip"
 )
 
 func init() {
@@ -49,6 +49,7 @@ import (
 	"ocm.software/ocm/cmds/ocm/commands/verbs/controller"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/create"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/describe"
+	"ocm.software/ocm/cmds/ocm/commands/verbs/diff"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/download"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/execute"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/get"
@@ -225,6 +226,7 @@ func newCliCommand(opts *CLIOptions, mod ...func(clictx.Context, *cobra.Command)
 	cmd.AddCommand(NewVersionCommand(opts.Context))
 
 	cmd.AddCommand(check.NewCommand(opts.Context))
+	cmd.AddCommand(diff.NewCommand(opts.Context))
 	cmd.AddCommand(get.NewCommand(opts.Context))
 	cmd.AddCommand(set.NewCommand(opts.Context))
 	cmd.AddCommand(list.NewCommand(opts.Context))
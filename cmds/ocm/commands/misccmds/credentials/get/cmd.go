@@ -2,6 +2,7 @@ package get
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/mandelsoft/goutils/errors"
@@ -26,11 +27,13 @@ var (
 type Command struct {
 	utils.BaseCommand
 
-	Consumer credentials.ConsumerIdentity
-	Matcher  credentials.IdentityMatcher
+	Consumer    credentials.ConsumerIdentity
+	Matcher     credentials.IdentityMatcher
+	MatcherName string
 
-	Type   string
-	Sloppy bool
+	Type    string
+	Sloppy  bool
+	Explain bool
 }
 
 var _ utils.OCMCommand = (*Command)(nil)
@@ -67,6 +70,16 @@ The following standard identity matchers are supported:
 The used matcher is derived from the consumer attribute <code>type</code>.
 For all other consumer types a matcher matching all attributes will be used.
 The usage of a dedicated matcher can be enforced by the option <code>--matcher</code>.
+
+With option <code>--explain</code> all configured consumer identities
+matching the given consumer specification are listed together with the
+provider offering them and the source of the credentials. The
+column <code>STATUS</code> shows, whether a candidate was finally
+<code>used</code>, was <code>superseded</code> by a better match found later on,
+or was <code>ignored</code>, because a better match was already found.
+The candidates are listed in evaluation order. The column <code>SCORE</code>
+shows the number of identity attributes matched by a candidate. Identity
+matchers typically prefer candidates with a higher score.
 `,
 	}
 }
@@ -74,6 +87,7 @@ The usage of a dedicated matcher can be enforced by the option <code>--matcher</
 func (o *Command) AddFlags(set *pflag.FlagSet) {
 	set.StringVarP(&o.Type, "matcher", "m", "", "matcher type override")
 	set.BoolVarP(&o.Sloppy, "sloppy", "s", false, "sloppy matching of consumer type")
+	set.BoolVarP(&o.Explain, "explain", "", false, "explain the evaluation of matching consumer identities")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -83,6 +97,7 @@ func (o *Command) Complete(args []string) error {
 			return errors.ErrUnknown("identity matcher", o.Type)
 		}
 		o.Matcher = m
+		o.MatcherName = o.Type
 	}
 	o.Consumer = credentials.ConsumerIdentity{}
 	for _, s := range args {
@@ -101,10 +116,12 @@ func (o *Command) Complete(args []string) error {
 		m := o.CredentialsContext().ConsumerIdentityMatchers().Get(t)
 		if m != nil {
 			o.Matcher = m
+			o.MatcherName = t
 		}
 	}
 	if o.Matcher == nil {
 		o.Matcher = credentials.PartialMatch
+		o.MatcherName = "partial"
 	}
 	return nil
 }
@@ -118,6 +135,13 @@ func (o *Command) Run() error {
 		}
 	}
 
+	if o.Explain {
+		err := o.explain()
+		if err != nil {
+			return err
+		}
+	}
+
 	creds, err := credentials.RequiredCredentialsForConsumer(o.CredentialsContext(), o.Consumer, o.Matcher)
 	if err != nil {
 		return err
@@ -131,3 +155,42 @@ func (o *Command) Run() error {
 	output.FormatTable(o, "", append([][]string{{"ATTRIBUTE", "VALUE"}}, list...))
 	return nil
 }
+
+func (o *Command) explain() error {
+	prov, _, err := credentials.ExplainCredentialsForConsumer(o.CredentialsContext(), o.Consumer, o.Matcher)
+	if err != nil && !errors.IsErrUnknown(err) {
+		return err
+	}
+
+	out.Outf(o, "Consumer: %s\n", o.Consumer)
+	out.Outf(o, "Matcher:  %s\n", o.MatcherName)
+	if len(prov.Candidates) == 0 {
+		out.Outf(o, "no matching consumer identity found\n")
+	} else {
+		list := [][]string{{"STATUS", "PROVIDER", "CONSUMER IDENTITY", "MATCH", "SCORE", "SOURCE"}}
+		for _, c := range prov.Candidates {
+			status := "ignored"
+			switch {
+			case c == prov.Winner:
+				status = "used"
+			case c.Selected:
+				status = "superseded"
+			}
+			provider := string(c.Provider)
+			if provider == "" {
+				provider = "<context>"
+			}
+			match := "partial"
+			if c.Exact {
+				match = "exact"
+			}
+			list = append(list, []string{status, provider, c.Identity.String(), match, strconv.Itoa(c.Score), credentials.DescribeCredentialsSource(o, c.Source)})
+		}
+		output.FormatTable(o, "", list)
+	}
+	if prov.Default {
+		out.Outf(o, "using credentials configured for the empty consumer identity\n")
+	}
+	out.Outf(o, "\n")
+	return nil
+}
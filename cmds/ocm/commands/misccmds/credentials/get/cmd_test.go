@@ -67,6 +67,37 @@ user      testuser
 ATTRIBUTE VALUE
 password  testpass
 username  testuser
+`))
+	})
+
+	It("explains oci type with oci matcher", func() {
+		cctx := env.CLI.CredentialsContext()
+		cctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME, "ghcr.io",
+		), credentials.DirectCredentials{"username": "other"})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("get", "credentials", "--explain", cpi.ID_TYPE+"="+identity.CONSUMER_TYPE, identity.ID_HOSTNAME+"=ghcr.io", identity.ID_PATHPREFIX+"=a/b")).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`Consumer: {"hostname":"ghcr.io","pathprefix":"a/b","type":"OCIRegistry"}
+Matcher:  OCIRegistry
+STATUS`))
+		Expect(buf.String()).To(MatchRegexp(`(?m)^used +<context> +{"hostname":"ghcr.io","pathprefix":"a","type":"OCIRegistry"} +partial +3 +direct\n` +
+			`ignored +<context> +{"hostname":"ghcr.io","type":"OCIRegistry"} +partial +2\s*$`))
+		Expect(buf.String()).To(ContainSubstring(`
+ATTRIBUTE VALUE
+password  testpass
+username  testuser
+`))
+	})
+
+	It("explains missing credentials", func() {
+		buf := bytes.NewBuffer(nil)
+		err := env.CatchOutput(buf).Execute("get", "credentials", "--explain", cpi.ID_TYPE+"=test", identity.ID_HOSTNAME+"=gcr.io")
+		Expect(err).To(HaveOccurred())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+Consumer: {"hostname":"gcr.io","type":"test"}
+Matcher:  partial
+no matching consumer identity found
 `))
 	})
 })
@@ -1,10 +1,12 @@
 package rsakeypair
 
 import (
+	"bytes"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -18,11 +20,15 @@ import (
 	"ocm.software/ocm/api/datacontext/attrs/rootcertsattr"
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
 	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	"ocm.software/ocm/api/tech/signing/handlers/ed25519"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	rsa_pss "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
 	"ocm.software/ocm/api/tech/signing/signutils"
 	utils2 "ocm.software/ocm/api/utils"
 	"ocm.software/ocm/api/utils/cobrautils/flag"
 	"ocm.software/ocm/api/utils/encrypt"
+	"ocm.software/ocm/api/utils/listformat"
 	"ocm.software/ocm/api/utils/out"
 	"ocm.software/ocm/cmds/ocm/commands/misccmds/names"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
@@ -35,11 +41,24 @@ var (
 	Verb  = verbs.Create
 )
 
+// keyTypes maps the signature algorithms to the key type name
+// used for default file names and the output.
+var keyTypes = map[string]string{
+	rsa.Algorithm:       "rsa",
+	rsa_pss.Algorithm:   "rsa",
+	ecdsa.Algorithm:     "ecdsa",
+	ecdsa.AlgorithmP384: "ecdsa",
+	ed25519.Algorithm:   "ed25519",
+}
+
 type Command struct {
 	utils.BaseCommand
 
 	Subject     *pkix.Name
 	MoreIssuers []string
+	algorithm   string
+	keyType     string
+	generator   signing.KeyPairGenerator
 	priv        string
 	pub         string
 	ekey        string
@@ -70,11 +89,15 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 func (o *Command) ForName(name string) *cobra.Command {
 	return &cobra.Command{
 		Use:   "[<private key file> [<public key file>]] {<subject-attribute>=<value>}",
-		Short: "create RSA public key pair",
+		Short: "create public key pair",
 		Long: `
-Create an RSA public key pair and save to files.
-
-The default for the filename to store the private key is <code>rsa.priv</code>.
+Create a public key pair and save to files. By default, an RSA key pair
+is created. With option <code>--algorithm</code> the key pair can be
+created for any signature algorithm supporting key generation:
+` + listformat.FormatList(rsa.Algorithm, keyPairAlgorithms()...) + `
+The default for the filename to store the private key is <code>rsa.priv</code>
+(or <code>ecdsa.priv</code>, <code>ed25519.priv</code> for the appropriate
+algorithms).
 If no public key file is specified, its name will be derived from the filename for
 the private key (suffix <code>.pub</code> for public key or <code>.cert</code>
 for certificate). If a certificate authority is given (<code>--ca-cert</code>)
@@ -101,12 +124,14 @@ For signing the public key the following subject attributes are supported:
 	`,
 		Example: `
 $ ocm create rsakeypair mandelsoft.priv mandelsoft.cert issuer=mandelsoft
+$ ocm create rsakeypair --algorithm ECDSA-P256 mandelsoft.priv mandelsoft.cert issuer=mandelsoft
 `,
 		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
 	}
 }
 
 func (o *Command) AddFlags(set *pflag.FlagSet) {
+	set.StringVarP(&o.algorithm, "algorithm", "S", rsa.Algorithm, "signature algorithm the key pair is created for")
 	set.BoolVarP(&o.ca, "ca", "", false, "create certificate for a signing authority")
 	set.StringVarP(&o.rootcerts, "root-certs", "", "", "root certificates used to validate used certificate authority")
 	set.StringVarP(&o.cacert, "ca-cert", "", "", "certificate authority to sign public key")
@@ -134,6 +159,23 @@ func (o *Command) Complete(args []string) error {
 		return errors.Newf("only one of --encrypt or --encryptionKey is possible")
 	}
 
+	if o.algorithm == "" {
+		o.algorithm = rsa.Algorithm
+	}
+	signer := signingattr.Get(o.Context.OCMContext()).GetSigner(o.algorithm)
+	if signer == nil {
+		return errors.ErrUnknown(signutils.KIND_SIGN_ALGORITHM, o.algorithm)
+	}
+	gen, ok := signer.(signing.KeyPairGenerator)
+	if !ok {
+		return errors.Newf("signature algorithm %q does not support key generation", o.algorithm)
+	}
+	o.generator = gen
+	o.keyType = keyTypes[o.algorithm]
+	if o.keyType == "" {
+		o.keyType = "key"
+	}
+
 	if o.rootcerts != "" {
 		pool, err := signutils.GetCertPool(o.rootcerts, false)
 		if err != nil {
@@ -255,7 +297,7 @@ func (o *Command) Complete(args []string) error {
 	if len(args) > 0 {
 		o.priv = args[0]
 	} else {
-		o.priv = "rsa.priv"
+		o.priv = o.keyType + ".priv"
 	}
 	if len(args) > 1 {
 		o.pub = args[1]
@@ -281,7 +323,7 @@ func (o *Command) Complete(args []string) error {
 func (o *Command) Run() error {
 	raw := false
 
-	priv, pub, err := rsa.Handler{}.CreateKeyPair()
+	priv, pub, err := o.generator.CreateKeyPair()
 	if err != nil {
 		return err
 	}
@@ -333,7 +375,7 @@ func (o *Command) Run() error {
 		}
 	}
 	if key != nil {
-		data, err := rsa.KeyData(priv)
+		data, err := KeyData(priv)
 		if err != nil {
 			return err
 		}
@@ -366,7 +408,7 @@ func (o *Command) Run() error {
 			add = "[" + o.ekey + "]"
 		}
 	}
-	out.Outf(o.Context, "created%s rsa key pair %s[%s]%s\n", msg, o.priv, o.pub, add)
+	out.Outf(o.Context, "created%s %s key pair %s[%s]%s\n", msg, o.keyType, o.priv, o.pub, add)
 	return nil
 }
 
@@ -384,7 +426,7 @@ func (o *Command) WriteKey(key interface{}, path string, raw bool) error {
 			err = pem.Encode(fd, block)
 		}
 	} else {
-		err = rsa.WriteKeyData(key, fd)
+		err = WriteKeyData(key, fd)
 	}
 	if err != nil {
 		fd.Close()
@@ -397,3 +439,35 @@ func (o *Command) WriteKey(key interface{}, path string, raw bool) error {
 	}
 	return o.FileSystem().Chmod(path, 0o400)
 }
+
+// WriteKeyData writes the PEM encoded key data using the format
+// appropriate for the key type.
+func WriteKeyData(key interface{}, w io.Writer) error {
+	switch key.(type) {
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		return ecdsa.WriteKeyData(key, w)
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return ed25519.WriteKeyData(key, w)
+	default:
+		return rsa.WriteKeyData(key, w)
+	}
+}
+
+// KeyData provides the PEM encoded key data using the format
+// appropriate for the key type.
+func KeyData(key interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	err := WriteKeyData(key, buf)
+	return buf.Bytes(), err
+}
+
+func keyPairAlgorithms() []string {
+	var list []string
+	reg := signing.DefaultRegistry()
+	for _, n := range reg.SignerNames() {
+		if _, ok := reg.GetSigner(n).(signing.KeyPairGenerator); ok {
+			list = append(list, n)
+		}
+	}
+	return list
+}
@@ -15,6 +15,8 @@ import (
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
 	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	"ocm.software/ocm/api/tech/signing/handlers/ed25519"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
 	"ocm.software/ocm/api/tech/signing/signutils"
 	"ocm.software/ocm/api/utils/encrypt"
@@ -189,4 +191,54 @@ created rsa key pair key.priv[key.cert]
 			ExpectError(signing.VerifyCertDN(chain[1:], root, &pkix.Name{CommonName: "mandelsoft", Country: []string{"US"}}, chain[0])).To(MatchError(`country "US" not found`))
 		})
 	})
+
+	Context("algorithms", func() {
+		It("creates self-signed ecdsa key pair", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("create", "rsakeypair", "--algorithm", ecdsa.AlgorithmP384, "CN=mandelsoft")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created ecdsa key pair ecdsa.priv[ecdsa.cert]
+`))
+			priv := Must(env.ReadFile("ecdsa.priv"))
+			pub := Must(env.ReadFile("ecdsa.cert"))
+
+			sctx := &signing.DefaultSigningContext{
+				PrivateKey: priv,
+				PublicKey:  pub,
+				RootCerts:  pub,
+				Issuer:     ISSUER,
+			}
+			d := digest.FromBytes([]byte("digest"))
+			handler := signingattr.Get(env).GetSigner(ecdsa.AlgorithmP384).(signing.SignatureHandler)
+			sig := Must(handler.Sign(defaultContext, d.Hex(), sctx))
+			Expect(sig.Algorithm).To(Equal(ecdsa.AlgorithmP384))
+			Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+			Expect(sig.Issuer).To(Equal("CN=mandelsoft"))
+
+			MustBeSuccessful(handler.Verify(d.Hex(), sig, &signing.DefaultSigningContext{PublicKey: pub}))
+		})
+
+		It("creates ed25519 key pair", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("create", "rsakeypair", "-S", ed25519.Algorithm, "key.priv")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created ed25519 key pair key.priv[key.pub]
+`))
+			priv := Must(env.ReadFile("key.priv"))
+			pub := Must(env.ReadFile("key.pub"))
+
+			sctx := &signing.DefaultSigningContext{
+				PrivateKey: priv,
+				PublicKey:  pub,
+			}
+			d := digest.FromBytes([]byte("digest"))
+			sig := Must(ed25519.NewHandler().Sign(defaultContext, d.Hex(), sctx))
+			Expect(sig.MediaType).To(Equal(ed25519.MediaType))
+			MustBeSuccessful(ed25519.NewHandler().Verify(d.Hex(), sig, &signing.DefaultSigningContext{PublicKey: pub}))
+		})
+
+		It("rejects algorithm without key generation", func() {
+			ExpectError(env.Execute("create", "rsakeypair", "-S", "sigstore")).To(MatchError(`signature algorithm "sigstore" does not support key generation`))
+		})
+	})
 })
@@ -25,6 +25,13 @@ var (
 	PackageVersionOption = options.NPMVersionOption
 
 	IdentityPathOption = options.IdentityPathOption
+
+	RegionOption   = options.RegionOption
+	BucketOption   = options.BucketOption
+	EndpointOption = options.EndpointOption
+
+	ModuleOption = options.ModuleOption
+	HashOption   = options.HashOption
 )
 
 // string options.
@@ -32,6 +39,8 @@ var (
 	VersionOption        = flagsets.NewStringOptionType("inputVersion", "version info for inputs")
 	TextOption           = flagsets.NewStringOptionType("inputText", "utf8 text")
 	HelmRepositoryOption = flagsets.NewStringOptionType("inputHelmRepository", "helm repository base URL")
+	ObjectKeyOption      = flagsets.NewStringOptionType("inputObjectKey", "object key for inputs")
+	FilenameOption       = flagsets.NewStringOptionType("inputFilename", "file name for inputs")
 )
 
 var (
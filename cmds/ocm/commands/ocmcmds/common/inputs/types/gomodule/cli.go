@@ -0,0 +1,26 @@
+package gomodule
+
+import (
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		TYPE, AddConfig,
+		options.RepositoryOption,
+		options.ModuleOption,
+		options.VersionOption,
+		options.ExtensionOption,
+		options.HashOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "proxy")
+	flagsets.AddFieldByOptionP(opts, options.ModuleOption, config, "module")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.ExtensionOption, config, "extension")
+	flagsets.AddFieldByOptionP(opts, options.HashOption, config, "hash")
+	return nil
+}
@@ -0,0 +1,96 @@
+package gomodule_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/testutils"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
+	digester "ocm.software/ocm/api/ocm/extensions/digester/digesters/gomodule"
+	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+	techgomodule "ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/tech/gomodule/gomoduletest"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/gomodule"
+)
+
+const (
+	ARCH    = "test.ca"
+	VERSION = "v1"
+
+	MODULE         = "acme.org/hello"
+	MODULE_VERSION = "v1.0.0"
+)
+
+var _ = Describe("Input Type", func() {
+	var env *InputTest
+
+	BeforeEach(func() {
+		env = NewInputTest(gomodule.TYPE)
+	})
+
+	It("simple decode", func() {
+		env.Set(options.RepositoryOption, "https://proxy.golang.org")
+		env.Set(options.ModuleOption, MODULE)
+		env.Set(options.VersionOption, MODULE_VERSION)
+		env.Set(options.ExtensionOption, "mod")
+		env.Set(options.HashOption, "h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=")
+		env.Check(&gomodule.Spec{
+			InputSpecBase: inputs.InputSpecBase{},
+			Proxy:         "https://proxy.golang.org",
+			Module:        MODULE,
+			Version:       MODULE_VERSION,
+			Extension:     "mod",
+			Hash:          "h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=",
+		})
+	})
+})
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+	var server *gomoduletest.Server
+	var zipHash string
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		server = gomoduletest.NewServer()
+		zipHash, _ = server.Add(MODULE, MODULE_VERSION, map[string][]byte{"hello.go": []byte("package hello\n")})
+		Expect(env.Execute("create", "ca", "-ft", "directory", "test.de/x", VERSION, "--provider", "mandelsoft", "--file", ARCH)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("add go module described by cli options", func() {
+		meta := `
+name: testdata
+type: goModule
+`
+		Expect(env.Execute("add", "resources", "--file", ARCH, "--resource", meta, "--inputType", "gomodule",
+			"--inputRepository", server.URL, "--module", MODULE, "--inputVersion", MODULE_VERSION, "--hash", zipHash)).To(Succeed())
+		data := Must(env.ReadFile(env.Join(ARCH, comparch.ComponentDescriptorFileName)))
+		cd := Must(compdesc.Decode(data))
+		Expect(len(cd.Resources)).To(Equal(1))
+		Expect(cd.Resources[0].Digest.NormalisationAlgorithm).To(Equal(digester.GoModuleDigestV1))
+		access := Must(env.Context.OCMContext().AccessSpecForSpec(cd.Resources[0].Access)).(*localblob.AccessSpec)
+		Expect(access.MediaType).To(Equal(mime.MIME_ZIP))
+		Expect(access.ReferenceName).To(Equal(MODULE + "@" + MODULE_VERSION))
+		Expect(env.ReadFile(env.Join(ARCH, "blobs", access.LocalReference))).To(Equal(server.Get(MODULE, MODULE_VERSION, techgomodule.EXT_ZIP)))
+	})
+
+	It("fails for hash mismatch", func() {
+		meta := `
+name: testdata
+type: goModule
+`
+		Expect(env.Execute("add", "resources", "--file", ARCH, "--resource", meta, "--inputType", "gomodule",
+			"--inputRepository", server.URL, "--module", MODULE, "--inputVersion", MODULE_VERSION, "--hash", "h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=")).To(MatchError(ContainSubstring("go module hash mismatch")))
+	})
+})
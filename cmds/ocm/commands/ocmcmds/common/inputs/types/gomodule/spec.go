@@ -0,0 +1,74 @@
+package gomodule
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"ocm.software/ocm/api/tech/gomodule"
+	"ocm.software/ocm/api/utils/blobaccess"
+	gomoduleblob "ocm.software/ocm/api/utils/blobaccess/gomodule"
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+type Spec struct {
+	inputs.InputSpecBase `json:",inline"`
+	// Proxy is the base URL of the Go module proxy.
+	Proxy string `json:"proxy,omitempty"`
+	// Module is the module path.
+	Module string `json:"module"`
+	// Version of the module.
+	Version string `json:"version"`
+	// Extension selects the file of the module version (zip, mod or info).
+	Extension string `json:"extension,omitempty"`
+	// Hash is the expected h1 hash of the file as found in go.sum files.
+	Hash string `json:"hash,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(proxy, module, version string) *Spec {
+	return &Spec{
+		InputSpecBase: inputs.InputSpecBase{
+			ObjectVersionedType: runtime.ObjectVersionedType{
+				Type: TYPE,
+			},
+		},
+		Proxy:   proxy,
+		Module:  module,
+		Version: version,
+	}
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.Module == "" {
+		pathField := fldPath.Child("module")
+		allErrs = append(allErrs, field.Invalid(pathField, s.Module, "no module"))
+	}
+	if s.Version == "" {
+		pathField := fldPath.Child("version")
+		allErrs = append(allErrs, field.Invalid(pathField, s.Version, "no version"))
+	}
+	if s.Extension != "" {
+		if err := gomodule.CheckExtension(s.Extension); err != nil {
+			pathField := fldPath.Child("extension")
+			allErrs = append(allErrs, field.Invalid(pathField, s.Extension, "must be zip, mod or info"))
+		}
+	}
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	access, err := gomoduleblob.BlobAccess(s.Proxy, s.Module, s.Version,
+		gomoduleblob.WithExtension(s.Extension),
+		gomoduleblob.WithHash(s.Hash),
+		gomoduleblob.WithDataContext(ctx.OCMContext()),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create access method for go module: %w", err)
+	}
+	return access, s.Module + "@" + s.Version, nil
+}
@@ -0,0 +1,50 @@
+package gomodule
+
+import (
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+const (
+	TYPE          = "gomodule"
+	TypeV1        = TYPE + runtime.VersionSeparator + "v1"
+	UPPER_TYPE    = "GoModule"
+	UPPER_TYPE_V1 = UPPER_TYPE + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TYPE, &Spec{}, usage, ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TypeV1, &Spec{}, "", ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(UPPER_TYPE, &Spec{}, "", ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(UPPER_TYPE_V1, &Spec{}, "", ConfigHandler()))
+}
+
+const usage = `
+A file of a Go module version is downloaded from a Go module proxy
+(GOPROXY protocol).
+
+This blob type specification supports the following fields:
+- **<code>proxy</code>** *string*
+
+  This OPTIONAL property describes the url of the Go module proxy.
+  Default is <code>https://proxy.golang.org</code>.
+
+- **<code>module</code>** *string*
+
+  This REQUIRED property describes the path of the module to download.
+
+- **<code>version</code>** *string*
+
+  This REQUIRED property describes the version of the module to download.
+
+- **<code>extension</code>** *string*
+
+  This OPTIONAL property describes the file of the module version:
+  <code>zip</code> (default), <code>mod</code> or <code>info</code>.
+
+- **<code>hash</code>** *string*
+
+  This OPTIONAL property describes the expected <code>h1:</code> hash of the
+  file as found in <code>go.sum</code> files. If given, the downloaded
+  content is verified against it.
+`
@@ -7,11 +7,14 @@ import (
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/dockermulti"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/file"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/git"
+	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/gomodule"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/helm"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/maven"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/npm"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/ociartifact"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/ocm"
+	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/pypi"
+	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/s3"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/spiff"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/utf8"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/wget"
@@ -0,0 +1,24 @@
+package pypi
+
+import (
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		TYPE, AddConfig,
+		options.RepositoryOption,
+		options.PackageOption,
+		options.VersionOption,
+		options.FilenameOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "indexUrl")
+	flagsets.AddFieldByOptionP(opts, options.PackageOption, config, "package")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.FilenameOption, config, "filename")
+	return nil
+}
@@ -0,0 +1,80 @@
+package pypi_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/testutils"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
+	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+	"ocm.software/ocm/api/tech/pypi/pypitest"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/pypi"
+)
+
+const (
+	ARCH    = "test.ca"
+	VERSION = "v1"
+)
+
+var _ = Describe("Input Type", func() {
+	var env *InputTest
+
+	BeforeEach(func() {
+		env = NewInputTest(pypi.TYPE)
+	})
+
+	It("simple decode", func() {
+		env.Set(options.RepositoryOption, "https://pypi.org/simple/")
+		env.Set(options.PackageOption, "requests")
+		env.Set(options.VersionOption, "2.31.0")
+		env.Set(options.FilenameOption, "requests-2.31.0-py3-none-any.whl")
+		env.Check(&pypi.Spec{
+			InputSpecBase: inputs.InputSpecBase{},
+			IndexURL:      "https://pypi.org/simple/",
+			Package:       "requests",
+			Version:       "2.31.0",
+			Filename:      "requests-2.31.0-py3-none-any.whl",
+		})
+	})
+})
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+	var server *pypitest.Server
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		server = pypitest.NewServer()
+		Expect(env.Execute("create", "ca", "-ft", "directory", "test.de/x", VERSION, "--provider", "mandelsoft", "--file", ARCH)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("add python package described by cli options", func() {
+		wheel := pypitest.Wheel("hello", "1.0.0")
+		server.Add("hello", "hello-1.0.0-py3-none-any.whl", wheel)
+
+		meta := `
+name: testdata
+type: pythonPackage
+`
+		Expect(env.Execute("add", "resources", "--file", ARCH, "--resource", meta, "--inputType", "pypi",
+			"--inputRepository", server.IndexURL(), "--package", "hello", "--inputVersion", "1.0.0")).To(Succeed())
+		data := Must(env.ReadFile(env.Join(ARCH, comparch.ComponentDescriptorFileName)))
+		cd := Must(compdesc.Decode(data))
+		Expect(len(cd.Resources)).To(Equal(1))
+		access := Must(env.Context.OCMContext().AccessSpecForSpec(cd.Resources[0].Access)).(*localblob.AccessSpec)
+		Expect(access.MediaType).To(Equal(mime.MIME_ZIP))
+		Expect(access.ReferenceName).To(Equal("hello-1.0.0-py3-none-any.whl"))
+		Expect(env.ReadFile(env.Join(ARCH, "blobs", access.LocalReference))).To(Equal(wheel))
+	})
+})
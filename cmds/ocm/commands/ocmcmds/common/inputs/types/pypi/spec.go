@@ -0,0 +1,70 @@
+package pypi
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"ocm.software/ocm/api/utils/blobaccess"
+	"ocm.software/ocm/api/utils/blobaccess/pypi"
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+type Spec struct {
+	inputs.InputSpecBase `json:",inline"`
+	// IndexURL is the base URL of the simple API of the package index.
+	IndexURL string `json:"indexUrl,omitempty"`
+	// Package is the name of the Python package.
+	Package string `json:"package"`
+	// Version of the Python package.
+	Version string `json:"version"`
+	// Filename is the name of a dedicated distribution file.
+	Filename string `json:"filename,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(index, pkg, version, filename string) *Spec {
+	return &Spec{
+		InputSpecBase: inputs.InputSpecBase{
+			ObjectVersionedType: runtime.ObjectVersionedType{
+				Type: TYPE,
+			},
+		},
+		IndexURL: index,
+		Package:  pkg,
+		Version:  version,
+		Filename: filename,
+	}
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.Package == "" {
+		pathField := fldPath.Child("package")
+		allErrs = append(allErrs, field.Invalid(pathField, s.Package, "no package"))
+	}
+	if s.Version == "" {
+		pathField := fldPath.Child("version")
+		allErrs = append(allErrs, field.Invalid(pathField, s.Version, "no version"))
+	}
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	spec, err := pypi.NewPackageSpec(s.IndexURL, s.Package, s.Version, pypi.WithFilename(s.Filename), pypi.WithDataContext(ctx.OCMContext()))
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := spec.GetFile()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve python package: %w", err)
+	}
+	blob, err := spec.BlobAccessForFile(file)
+	if err != nil {
+		return nil, "", err
+	}
+	return blob, file.Filename, nil
+}
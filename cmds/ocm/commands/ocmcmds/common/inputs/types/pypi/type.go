@@ -0,0 +1,46 @@
+package pypi
+
+import (
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+const (
+	TYPE          = "pypi"
+	TypeV1        = TYPE + runtime.VersionSeparator + "v1"
+	UPPER_TYPE    = "PyPI"
+	UPPER_TYPE_V1 = UPPER_TYPE + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TYPE, &Spec{}, usage, ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TypeV1, &Spec{}, "", ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(UPPER_TYPE, &Spec{}, "", ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(UPPER_TYPE_V1, &Spec{}, "", ConfigHandler()))
+}
+
+const usage = `
+The distribution file of a Python package is downloaded from a Python
+package index using the simple repository API and verified against the
+sha256 digest provided by the index.
+
+This blob type specification supports the following fields:
+- **<code>indexUrl</code>** *string*
+
+  This OPTIONAL property describes the url of the simple repository API of
+  the package index. Default is <code>https://pypi.org/simple/</code>.
+
+- **<code>package</code>** *string*
+
+  This REQUIRED property describes the name of the package to download.
+
+- **<code>version</code>** *string*
+
+  This REQUIRED property describes the version of the package to download.
+
+- **<code>filename</code>** *string*
+
+  This OPTIONAL property describes the name of the distribution file to
+  download. If not defined, the source distribution is used, or a pure
+  Python wheel, if no source distribution is available.
+`
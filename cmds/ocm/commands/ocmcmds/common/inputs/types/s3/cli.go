@@ -0,0 +1,28 @@
+package s3
+
+import (
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		TYPE, AddConfig,
+		options.BucketOption,
+		options.ObjectKeyOption,
+		options.RegionOption,
+		options.VersionOption,
+		options.EndpointOption,
+		options.MediaTypeOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.BucketOption, config, "bucket")
+	flagsets.AddFieldByOptionP(opts, options.ObjectKeyOption, config, "key")
+	flagsets.AddFieldByOptionP(opts, options.RegionOption, config, "region")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
+	flagsets.AddFieldByOptionP(opts, options.MediaTypeOption, config, "mediaType")
+	return nil
+}
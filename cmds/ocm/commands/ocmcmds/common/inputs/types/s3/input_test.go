@@ -0,0 +1,92 @@
+package s3_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/testutils"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/s3/identity"
+	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+	techs3 "ocm.software/ocm/api/tech/s3"
+	"ocm.software/ocm/api/tech/s3/s3test"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/s3"
+)
+
+const (
+	ARCH    = "test.ca"
+	VERSION = "v1"
+	BUCKET  = "artifacts"
+)
+
+var _ = Describe("Input Type", func() {
+	var env *InputTest
+
+	BeforeEach(func() {
+		env = NewInputTest(s3.TYPE)
+	})
+
+	It("simple decode", func() {
+		env.Set(options.BucketOption, BUCKET)
+		env.Set(options.ObjectKeyOption, "bin/tool")
+		env.Set(options.RegionOption, "eu-west-1")
+		env.Set(options.VersionOption, "v1")
+		env.Set(options.EndpointOption, "http://127.0.0.1:9000")
+		env.Set(options.MediaTypeOption, mime.MIME_OCTET)
+		env.Check(&s3.Spec{
+			InputSpecBase: inputs.InputSpecBase{},
+			Bucket:        BUCKET,
+			Key:           "bin/tool",
+			Region:        "eu-west-1",
+			Version:       "v1",
+			Endpoint:      "http://127.0.0.1:9000",
+			MediaType:     mime.MIME_OCTET,
+		})
+	})
+})
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+	var server *s3test.Server
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		server = s3test.NewServer(BUCKET)
+		env.CredentialsContext().SetCredentialsForConsumer(identity.GetConsumerId(Must(techs3.EndpointHost(server.URL)), BUCKET, "", ""), credentials.DirectCredentials{
+			identity.ATTR_AWS_ACCESS_KEY_ID:     "minioadmin",
+			identity.ATTR_AWS_SECRET_ACCESS_KEY: "minioadmin",
+		})
+		Expect(env.Execute("create", "ca", "-ft", "directory", "test.de/x", VERSION, "--provider", "mandelsoft", "--file", ARCH)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		env.Cleanup()
+	})
+
+	It("add s3 object described by cli options", func() {
+		version := server.Put(BUCKET, "bin/tool", []byte("some binary content"), mime.MIME_OCTET)
+		server.Put(BUCKET, "bin/tool", []byte("newer content"), mime.MIME_OCTET)
+
+		meta := `
+name: testdata
+type: executable
+`
+		Expect(env.Execute("add", "resources", "--file", ARCH, "--resource", meta, "--inputType", "s3",
+			"--bucket", BUCKET, "--inputObjectKey", "bin/tool", "--inputVersion", version,
+			"--endpoint", server.URL, "--mediaType", mime.MIME_TEXT)).To(Succeed())
+		data := Must(env.ReadFile(env.Join(ARCH, comparch.ComponentDescriptorFileName)))
+		cd := Must(compdesc.Decode(data))
+		Expect(len(cd.Resources)).To(Equal(1))
+		access := Must(env.Context.OCMContext().AccessSpecForSpec(cd.Resources[0].Access)).(*localblob.AccessSpec)
+		Expect(access.MediaType).To(Equal(mime.MIME_TEXT))
+		Expect(env.ReadFile(env.Join(ARCH, "blobs", access.LocalReference))).To(Equal([]byte("some binary content")))
+	})
+})
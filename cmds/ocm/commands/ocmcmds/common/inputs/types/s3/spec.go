@@ -0,0 +1,75 @@
+package s3
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"ocm.software/ocm/api/utils/blobaccess"
+	"ocm.software/ocm/api/utils/blobaccess/s3"
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+type Spec struct {
+	inputs.InputSpecBase `json:",inline"`
+	// Bucket is the name of the bucket containing the object.
+	Bucket string `json:"bucket"`
+	// Key is the key of the object.
+	Key string `json:"key"`
+	// Region is the region of the bucket.
+	Region string `json:"region,omitempty"`
+	// Version of the object.
+	Version string `json:"version,omitempty"`
+	// Endpoint is the URL of an S3-compatible storage service.
+	Endpoint string `json:"endpoint,omitempty"`
+	// MediaType is the media type of the object.
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(region, bucket, key, version, mediaType string) *Spec {
+	return &Spec{
+		InputSpecBase: inputs.InputSpecBase{
+			ObjectVersionedType: runtime.ObjectVersionedType{
+				Type: TYPE,
+			},
+		},
+		Region:    region,
+		Bucket:    bucket,
+		Key:       key,
+		Version:   version,
+		MediaType: mediaType,
+	}
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.Bucket == "" {
+		pathField := fldPath.Child("bucket")
+		allErrs = append(allErrs, field.Invalid(pathField, s.Bucket, "no bucket"))
+	}
+
+	if s.Key == "" {
+		pathField := fldPath.Child("key")
+		allErrs = append(allErrs, field.Invalid(pathField, s.Key, "no key"))
+	}
+
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	access, err := s3.BlobAccess(s.Bucket, s.Key,
+		s3.WithDataContext(ctx.OCMContext()),
+		s3.WithRegion(s.Region),
+		s3.WithVersion(s.Version),
+		s3.WithEndpoint(s.Endpoint),
+		s3.WithMimeType(s.MediaType),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create blob access for s3: %w", err)
+	}
+	return access, "", nil
+}
@@ -0,0 +1,56 @@
+package s3
+
+import (
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+const (
+	TYPE          = "s3"
+	TypeV1        = TYPE + runtime.VersionSeparator + "v1"
+	UPPER_TYPE    = "S3"
+	UPPER_TYPE_V1 = UPPER_TYPE + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TYPE, &Spec{}, usage, ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TypeV1, &Spec{}, "", ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(UPPER_TYPE, &Spec{}, "", ConfigHandler()))
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(UPPER_TYPE_V1, &Spec{}, "", ConfigHandler()))
+}
+
+const usage = `
+The <code>bucket</code> and <code>key</code> describe an object stored in an S3
+bucket, which is downloaded and used as resource blob. Credentials are taken
+from the credentials context using the consumer type <code>S3</code>.
+
+This blob type specification supports the following fields:
+- **<code>bucket</code>** *string*
+
+  This REQUIRED property describes the name of the bucket containing the object.
+
+- **<code>key</code>** *string*
+
+  This REQUIRED property describes the key of the object.
+
+- **<code>region</code>** *string*
+
+  This OPTIONAL property describes the region of the bucket. If omitted, the
+  region is determined from the bucket.
+
+- **<code>version</code>** *string*
+
+  This OPTIONAL property describes the version of the object. If omitted, the
+  latest version is used.
+
+- **<code>endpoint</code>** *string*
+
+  This OPTIONAL property describes the URL of an S3-compatible storage service,
+  for example a MinIO instance. If omitted, AWS S3 is used.
+
+- **<code>mediaType</code>** *string*
+
+  This OPTIONAL property describes the media type of the object. If omitted,
+  it is defaulted to ` + mime.MIME_OCTET + `.
+`
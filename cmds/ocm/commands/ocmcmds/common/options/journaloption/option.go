@@ -0,0 +1,71 @@
+package journaloption
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/cmds/ocm/common/options"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	standard.TransferOptionsCreator
+	JournalFile string
+	ResumeFile  string
+	Journal     *journal.Journal
+}
+
+var _ transferhandler.TransferOption = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.JournalFile, "journal", "", "", "record transfer progress in checkpoint journal file")
+	fs.StringVarP(&o.ResumeFile, "resume", "", "", "resume transfer recorded in checkpoint journal file")
+}
+
+func (o *Option) Configure(ctx clictx.Context) error {
+	var err error
+	switch {
+	case o.JournalFile != "" && o.ResumeFile != "":
+		return fmt.Errorf("only one of --journal or --resume can be used")
+	case o.JournalFile != "":
+		o.Journal, err = journal.Create(ctx.FileSystem(), o.JournalFile)
+	case o.ResumeFile != "":
+		o.Journal, err = journal.Open(ctx.FileSystem(), o.ResumeFile)
+	}
+	return err
+}
+
+func (o *Option) Usage() string {
+	s := `
+With the option <code>--journal</code> the progress of the transfer is recorded
+in a checkpoint journal file. It describes the component versions completely
+transferred and the resources and sources already uploaded to external
+locations. If a transfer is interrupted, it can be continued with the option
+<code>--resume</code> using the same journal file. Component versions and
+artifacts recorded in the journal are skipped, the journal is updated with the
+progress of the resumed transfer. A journal is bound to the target repository
+of the transfer it has been created for, it is rejected for other targets.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if o.Journal != nil {
+		return standard.Journal(o.Journal).ApplyTransferOption(opts)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package referrersoption
+
+import (
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/cmds/ocm/common/options"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	standard.TransferOptionsCreator
+
+	Referrers     bool
+	ArtifactTypes []string
+}
+
+var _ transferhandler.TransferOption = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&o.Referrers, "referrers", "", false, "transfer OCI referrers (signatures, attestations) of transferred OCI artifacts")
+	fs.StringSliceVarP(&o.ArtifactTypes, "referrer-types", "", nil, "restrict transferred OCI referrers to artifact types (implies --referrers)")
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if !o.Referrers && len(o.ArtifactTypes) == 0 {
+		return nil
+	}
+	o.Referrers = true
+	return standard.Referrers(o.ArtifactTypes...).ApplyTransferOption(opts)
+}
+
+func (o *Option) Usage() string {
+	s := `
+With the option <code>--referrers</code> the artifacts referring to transferred
+OCI artifacts (OCI 1.1 referrers, for example signatures or SBOM attestations)
+are transferred, also. The option <code>--referrer-types</code> restricts the
+transferred referrers to the given artifact types. Without these options the
+attribute <code>` + referrersattr.ATTR_KEY + `</code> is used.
+`
+	return s
+}
@@ -13,10 +13,15 @@ import (
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
 	ocmsign "ocm.software/ocm/api/ocm/tools/signing"
 	"ocm.software/ocm/api/tech/signing"
+	kmshandler "ocm.software/ocm/api/tech/signing/handlers/kms"
+	"ocm.software/ocm/api/tech/signing/handlers/pkcs11"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
 	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/kms"
 	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/utils"
 	"ocm.software/ocm/api/utils/listformat"
+	"ocm.software/ocm/api/utils/runtime"
 	"ocm.software/ocm/cmds/ocm/commands/common/options/keyoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/hashoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/storeoption"
@@ -56,10 +61,30 @@ type Option struct {
 	UseTSA bool
 	TSAUrl string
 
+	// KeyRefs are references to keys managed by a KMS
+	// ([<name>=]kms://<provider>/<key path>).
+	KeyRefs []string
+
+	// Countersign is the name of the signature to countersign
+	Countersign string
+
+	// CRLFiles and OCSPFiles are paths of revocation information
+	// used to check certificates during verification.
+	CRLFiles   []string
+	OCSPFiles  []string
+	Revocation *signutils.RevocationInfo
+
 	Hash hashoption.Option
 
 	Keyless bool
 
+	// Incremental reuses already verified resource digests
+	Incremental bool
+
+	// PolicyFile is the path of a verification policy
+	PolicyFile string
+	Policy     *ocmsign.Policy
+
 	Verified storeoption.Option
 }
 
@@ -76,8 +101,14 @@ func (o *Option) AddFlags(fs *pflag.FlagSet) {
 		fs.BoolVarP(&o.Recursively, "recursive", "R", false, "recursively sign component versions")
 		fs.BoolVarP(&o.UseTSA, "tsa", "", false, fmt.Sprintf("use timestamp authority (default server: %s)", signing.DEFAULT_TSA_URL))
 		fs.StringVarP(&o.TSAUrl, "tsa-url", "", "", "TSA server URL")
+		fs.StringArrayVarP(&o.KeyRefs, "keyref", "", nil, "reference to a key managed by a KMS ([<name>=]kms://<provider>/<key path>)")
+		fs.StringVarP(&o.Countersign, "countersign", "", "", "name of signature to countersign")
 	} else {
 		fs.BoolVarP(&o.local, "local", "L", false, "verification based on information found in component versions, only")
+		fs.BoolVarP(&o.Incremental, "incremental", "", false, "reuse resource digests already verified by the verification store")
+		fs.StringVarP(&o.PolicyFile, "policy", "", "", "verification policy file")
+		fs.StringArrayVarP(&o.CRLFiles, "crl", "", nil, "certificate revocation list file")
+		fs.StringArrayVarP(&o.OCSPFiles, "ocsp", "", nil, "OCSP response file")
 	}
 	fs.BoolVarP(&o.Verify, "verify", "V", o.SignMode, "verify existing digests")
 	fs.BoolVar(&o.Keyless, "keyless", false, "use keyless signing")
@@ -109,6 +140,13 @@ func (o *Option) Configure(ctx clictx.Context) error {
 		if o.signAlgorithm == "" {
 			o.signAlgorithm = rsa.Algorithm
 		}
+		if o.hasKeyRef(o.DefaultName) {
+			name := kmshandler.SignerName(o.signAlgorithm)
+			if name == "" {
+				return errors.Newf("signature algorithm %q not supported for KMS keys", o.signAlgorithm)
+			}
+			o.signAlgorithm = name
+		}
 		o.Signer = signingattr.Get(ctx).GetSigner(o.signAlgorithm)
 		if o.Signer == nil {
 			return errors.ErrUnknown(compdesc.KIND_SIGN_ALGORITHM, o.signAlgorithm)
@@ -121,10 +159,103 @@ func (o *Option) Configure(ctx clictx.Context) error {
 	if err != nil {
 		return err
 	}
+	err = o.handleKeyRefs()
+	if err != nil {
+		return err
+	}
+
+	if o.PolicyFile != "" {
+		data, err := utils.ReadFile(o.PolicyFile, ctx.FileSystem())
+		if err != nil {
+			return errors.Wrapf(err, "cannot read verification policy %q", o.PolicyFile)
+		}
+		var cfg ocmsign.Config
+		err = runtime.DefaultYAMLEncoding.Unmarshal(data, &cfg)
+		if err != nil {
+			return errors.Wrapf(err, "invalid verification policy %q", o.PolicyFile)
+		}
+		if err = cfg.Policy.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid verification policy %q", o.PolicyFile)
+		}
+		o.Policy = &cfg.Policy
+	}
+
+	err = o.handleRevocationInfo(ctx)
+	if err != nil {
+		return err
+	}
 
+	if o.Incremental {
+		o.Verified.RememberVerification = true
+	}
 	return o.Verified.Configure(ctx)
 }
 
+// splitKeyRef splits a key reference argument into the signature
+// name and the key reference.
+func (o *Option) splitKeyRef(k string) (string, string) {
+	// the name must not contain a colon to be distinguishable from the URL
+	if sep := strings.Index(k, "="); sep > 0 && !strings.Contains(k[:sep], ":") {
+		return k[:sep], k[sep+1:]
+	}
+	return o.DefaultName, k
+}
+
+// hasKeyRef checks whether a KMS key reference is given for the
+// signature name.
+func (o *Option) hasKeyRef(name string) bool {
+	for _, k := range o.KeyRefs {
+		if n, _ := o.splitKeyRef(k); n != "" && n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// handleKeyRefs registers the KMS key references as private keys.
+func (o *Option) handleKeyRefs() error {
+	for _, k := range o.KeyRefs {
+		name, ref := o.splitKeyRef(k)
+		r, err := kms.ParseKeyRef(ref)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return errors.Newf("key reference: key name required")
+		}
+		o.Keys.RegisterPrivateKey(name, r)
+	}
+	return nil
+}
+
+// handleRevocationInfo reads the given CRL and OCSP response files.
+func (o *Option) handleRevocationInfo(ctx clictx.Context) error {
+	if len(o.CRLFiles) == 0 && len(o.OCSPFiles) == 0 {
+		return nil
+	}
+	info := signutils.NewRevocationInfo()
+	for _, f := range o.CRLFiles {
+		data, err := utils.ReadFile(f, ctx.FileSystem())
+		if err != nil {
+			return errors.Wrapf(err, "cannot read CRL %q", f)
+		}
+		if err = info.AddCRL(data); err != nil {
+			return errors.Wrapf(err, "CRL %q", f)
+		}
+	}
+	for _, f := range o.OCSPFiles {
+		data, err := utils.ReadFile(f, ctx.FileSystem())
+		if err != nil {
+			return errors.Wrapf(err, "cannot read OCSP response %q", f)
+		}
+		if err = info.AddOCSPResponse(data); err != nil {
+			return errors.Wrapf(err, "OCSP response %q", f)
+		}
+	}
+	o.Revocation = info
+	return nil
+}
+
 func (o *Option) Usage() string {
 	s := `
 The <code>--public-key</code> and <code>--private-key</code> options can be
@@ -142,6 +273,42 @@ start with the prefix <code>!</code> or as direct string with the prefix
 		s += `
 If in signing mode a public key is specified, existing signatures for the
 given signature name will be verified, instead of recreated.
+
+With option <code>--keyref</code> a key managed by a KMS can be used instead
+of a private key. The argument has the form
+<code>[&lt;name>=]kms://&lt;provider>/&lt;key path></code>. The optional
+query parameter <code>endpoint</code> overrides the default API endpoint
+of the provider. The signature is created by the KMS, the credentials
+are taken from the credentials context. The following providers are supported:
+` + listformat.FormatList("", kms.Providers()...) + `
+The key path depends on the provider:
+- <code>aws</code>: <code>&lt;region>/&lt;key id or ARN></code> (consumer type <code>AWSKMS</code>)
+- <code>gcp</code>: the resource name of the crypto key version
+  (<code>projects/&lt;project>/locations/&lt;location>/keyRings/&lt;ring>/cryptoKeys/&lt;key>/cryptoKeyVersions/&lt;version></code>,
+  consumer type <code>GCPCloudKMS</code>)
+- <code>azure</code>: <code>&lt;vault host>/keys/&lt;name>/&lt;version></code> (consumer type <code>AzureKeyVault</code>)
+
+If a key reference is given for the signature to create (the first
+signature name), the signature algorithm is mapped to the appropriate
+KMS signer (<code>` + kmshandler.NAME_RSA + `</code>, <code>` + kmshandler.NAME_RSA_PSS + `</code> or
+<code>` + kmshandler.NAME_ECDSA_P256 + `</code>).
+
+With the signing types <code>` + pkcs11.NAME_RSA + `</code>, <code>` + pkcs11.NAME_RSA_PSS + `</code>,
+<code>` + pkcs11.NAME_ECDSA_P256 + `</code> and <code>` + pkcs11.NAME_ECDSA_P384 + `</code> a key
+stored in a PKCS#11 token is used. Instead of a private key, the option
+<code>--private-key</code> describes the key by a YAML document with the fields
+<code>module</code>, <code>token</code> or <code>slot</code>, and
+<code>label</code> and/or <code>id</code>. The PIN is taken from the credentials
+context (consumer type <code>` + pkcs11.CONSUMER_TYPE + `</code>).
+PKCS#11 support is not part of the default build: it requires a binary
+built with cgo (<code>CGO_ENABLED=1</code>). The released binaries are built
+without cgo and report PKCS#11 support as not available.
+
+With option <code>--countersign</code> the signature with the given name
+is signed instead of the component descriptor (countersignature). This can
+be used for a multi-party approval. The new signature (option
+<code>--signature</code>) covers the countersigned signature, which
+must already exist and match the component descriptor.
 `
 		s += `
 
@@ -159,6 +326,36 @@ The following hash modes are supported with option <code>--hash</code>:
 ` + listformat.FormatList(sha256.Algorithm, signing.DefaultRegistry().HasherNames()...)
 
 		signing.DefaultRegistry().HasherNames()
+	} else {
+		s += `
+With option <code>--incremental</code> the verification store is used to
+speed up the verification. Resources of component versions found in the
+verification store are not digested again, if their access specification
+and digest have not been changed since their last successful verification.
+The outcome of the verification is recorded in the verification store.
+
+With option <code>--policy</code> a verification policy can be specified.
+It is a YAML file with a list of <code>rules</code> (or a config of type
+<code>` + ocmsign.ConfigType + `</code>). Every rule is evaluated
+for all component versions matching its component patterns and the result
+is reported per rule. If no policy is given, a policy configured in the
+OCM configuration is used.
+
+For signatures based on certificates, the validity of the certificate chain
+is checked for the signing time, if the signature provides a timestamp
+of a timestamp authority (TSA). With the options <code>--crl</code> and
+<code>--ocsp</code> files with certificate revocation lists (PEM or DER)
+and OCSP responses (PEM or DER) can be given to check the certificates for
+revocation. Revocation lists and OCSP responses, which are outdated or not
+yet valid, are rejected. A certificate revoked after the timestamped signing
+time is accepted, unless the revocation reason is <code>keyCompromise</code>,
+<code>cACompromise</code> or <code>unspecified</code>. The outcome of the
+checks is reported.
+
+Countersignatures (signatures covering another signature) are verified
+like regular signatures. Additionally, it is checked, that the
+countersigned signatures are unchanged and match the component descriptor.
+`
 	}
 	return s
 }
@@ -201,5 +398,16 @@ func (o *Option) ApplySigningOption(opts *ocmsign.Options) {
 	opts.Update = o.Update
 	opts.Keyless = o.Keyless
 
+	if o.Countersign != "" {
+		opts.Countersign = o.Countersign
+	}
+	if o.Revocation != nil {
+		opts.Revocation = o.Revocation
+	}
+
 	opts.VerifiedStore = o.Verified.Store
+	opts.Incremental = o.Incremental
+	if o.Policy != nil {
+		opts.SetVerificationPolicy(o.Policy)
+	}
 }
@@ -6,6 +6,7 @@ import (
 	clictx "ocm.software/ocm/api/cli"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/add"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/check"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/diff"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/download"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/get"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/hash"
@@ -38,4 +39,5 @@ func AddCommands(ctx clictx.Context, cmd *cobra.Command) {
 	cmd.AddCommand(verify.NewCommand(ctx, verify.Verb))
 	cmd.AddCommand(download.NewCommand(ctx, download.Verb))
 	cmd.AddCommand(check.NewCommand(ctx, check.Verb))
+	cmd.AddCommand(diff.NewCommand(ctx, diff.Verb))
 }
@@ -0,0 +1,170 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/optionutils"
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/ocmutils/diff"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/out"
+	ocmcommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/output"
+	"ocm.software/ocm/cmds/ocm/common/processing"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Components
+	Verb  = verbs.Diff
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Refs []string
+}
+
+// NewCommand creates a new diff command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(
+		&Command{
+			BaseCommand: utils.NewBaseCommand(ctx,
+				repooption.New(),
+				output.OutputOptions(outputs,
+					NewOption(),
+				),
+			),
+		},
+		utils.Names(Names, names...)...,
+	)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <component-reference> <component-reference>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Compare two component versions",
+		Long: `
+This command compares two component versions and shows the added, removed
+and changed resources, sources, references, labels, access specifications
+and digests. The component versions may be different versions of
+a component or the same version found in different repositories,
+for example after a transfer.
+
+All changes are described from the perspective of the first component version.
+`,
+		Example: `
+$ ocm diff componentversion ghcr.io/acme//acme.org/app:1.0.0 ghcr.io/acme//acme.org/app:1.1.0
+$ ocm diff componentversion --recursive -o yaml ghcr.io/acme//acme.org/app:1.0.0 registry.acme.org/mirror//acme.org/app:1.0.0
+`,
+		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Refs = args
+	return nil
+}
+
+func (o *Command) Run() error {
+	session := ocm.NewSession(nil)
+	defer session.Close()
+
+	err := o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+	handler := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository)
+
+	var cvs [2]ocm.ComponentVersionAccess
+	for i, ref := range o.Refs {
+		objs, err := handler.Get(utils.StringSpec(ref))
+		if err != nil {
+			return errors.Wrapf(err, "error processing %q", ref)
+		}
+		if len(objs) != 1 {
+			return errors.Newf("%q must describe exactly one component version, but found %d", ref, len(objs))
+		}
+		cvs[i] = comphdlr.Elem(objs[0])
+	}
+
+	result, err := optionutils.EvalOptions(diff.Option(From(o))).For(cvs[0], cvs[1])
+	if err != nil {
+		return err
+	}
+
+	opts := output.From(o)
+	err = opts.Output.Add(result)
+	if err != nil {
+		return err
+	}
+	err = opts.Output.Close()
+	if err != nil {
+		return err
+	}
+	return opts.Output.Out()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var outputs = output.NewOutputs(getRegular).AddChainedManifestOutputs(chain)
+
+func chain(opts *output.Options) processing.ProcessChain {
+	return processing.Chain(opts.LogContext())
+}
+
+func getRegular(opts *output.Options) output.Output {
+	return output.NewProcessingFunctionOutput(opts, chain(opts), outResult)
+}
+
+func outResult(ctx out.Context, e interface{}) {
+	printResult(common.NewPrinter(ctx.StdOut()), e.(*diff.Result))
+}
+
+func printResult(p common.Printer, r *diff.Result) {
+	p.Printf("comparing %s with %s\n", r.ComponentVersion, r.Other)
+	p = p.AddGap("  ")
+	if len(r.Changes) == 0 {
+		p.Printf("no differences\n")
+	}
+	for _, c := range r.Changes {
+		elem := c.Kind
+		if c.Element != "" {
+			elem += " " + c.Element
+		}
+		switch {
+		case c.Field == "":
+			p.Printf("%s: %s\n", elem, c.Type)
+		case c.Type == diff.ADDED:
+			p.Printf("%s: %s added: %s\n", elem, c.Field, format(c.New))
+		case c.Type == diff.REMOVED:
+			p.Printf("%s: %s removed: %s\n", elem, c.Field, format(c.Old))
+		default:
+			p.Printf("%s: %s changed: %s -> %s\n", elem, c.Field, format(c.Old), format(c.New))
+		}
+	}
+	for _, n := range r.References {
+		printResult(p, n)
+	}
+}
+
+func format(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
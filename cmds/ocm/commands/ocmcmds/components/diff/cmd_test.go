@@ -0,0 +1,123 @@
+package diff_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm"
+	v1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/utils/accessio"
+)
+
+const (
+	ARCH1   = "/tmp/ca1"
+	ARCH2   = "/tmp/ca2"
+	VERSION = "v1"
+	COMP    = "test.de/x"
+	COMP2   = "test.de/y"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.ModificationOptions(ocm.SkipDigest())
+
+		env.OCMCommonTransport(ARCH1, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("ghcr.io/acme/image:v1"))
+				})
+				env.Reference("ref", COMP2, VERSION)
+			})
+			env.ComponentVersion(COMP2, VERSION)
+		})
+		env.OCMCommonTransport(ARCH2, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("registry.acme.org/acme/image:v1"))
+				})
+				env.Resource("added", VERSION, resourcetypes.PLAIN_TEXT, v1.ExternalRelation, func() {
+					env.Access(ociartifact.New("ghcr.io/acme/added:v1"))
+				})
+				env.Reference("ref", COMP2, VERSION)
+			})
+			env.ComponentVersion(COMP2, VERSION, func() {
+				env.Label("purpose", "test")
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("shows no differences", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("diff", "components", ARCH1+"//"+COMP+":"+VERSION, ARCH1+"//"+COMP+":"+VERSION))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+comparing test.de/x:v1 with test.de/x:v1
+  no differences
+`))
+	})
+
+	It("shows differences", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("diff", "components", ARCH1+"//"+COMP+":"+VERSION, ARCH2+"//"+COMP+":"+VERSION))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+comparing test.de/x:v1 with test.de/x:v1
+  resource "name"="image": access changed: {"imageReference":"ghcr.io/acme/image:v1","type":"ociArtifact"} -> {"imageReference":"registry.acme.org/acme/image:v1","type":"ociArtifact"}
+  resource "name"="added": added
+`))
+	})
+
+	It("shows differences recursively", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("diff", "components", "-r", ARCH1+"//"+COMP+":"+VERSION, ARCH2+"//"+COMP+":"+VERSION))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+comparing test.de/x:v1 with test.de/x:v1
+  resource "name"="image": access changed: {"imageReference":"ghcr.io/acme/image:v1","type":"ociArtifact"} -> {"imageReference":"registry.acme.org/acme/image:v1","type":"ociArtifact"}
+  resource "name"="added": added
+  comparing test.de/y:v1 with test.de/y:v1
+    component: label purpose added: {"name":"purpose","value":"test"}
+`))
+	})
+
+	It("shows differences as yaml", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("diff", "components", "-o", "yaml", ARCH1+"//"+COMP+":"+VERSION, ARCH2+"//"+COMP+":"+VERSION))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+---
+changes:
+- change: changed
+  element: '"name"="image"'
+  field: access
+  kind: resource
+  new:
+    imageReference: registry.acme.org/acme/image:v1
+    type: ociArtifact
+  old:
+    imageReference: ghcr.io/acme/image:v1
+    type: ociArtifact
+- change: added
+  element: '"name"="added"'
+  kind: resource
+componentVersion: test.de/x:v1
+equivalent: false
+hashEqual: false
+other: test.de/x:v1
+`))
+	})
+
+	It("rejects wrong number of arguments", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("diff", "components", ARCH1+"//"+COMP+":"+VERSION)).To(HaveOccurred())
+	})
+})
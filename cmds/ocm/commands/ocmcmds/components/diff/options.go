@@ -0,0 +1,42 @@
+package diff
+
+import (
+	"github.com/mandelsoft/goutils/optionutils"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/ocmutils/diff"
+	"ocm.software/ocm/cmds/ocm/common/options"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+var _ options.Options = (*Option)(nil)
+
+type Option struct {
+	Recursive bool
+}
+
+func NewOption() *Option {
+	return &Option{}
+}
+
+func (o *Option) ApplyTo(opts *diff.Options) {
+	optionutils.ApplyOption(&o.Recursive, &opts.Recursive)
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&o.Recursive, "recursive", "r", false, "compare referenced component versions, also")
+}
+
+func (o *Option) Usage() string {
+	s := `
+If the option <code>--recursive</code> is given, the component versions
+referenced by both compared component versions (by the same reference
+identity) are compared, also.
+`
+	return s
+}
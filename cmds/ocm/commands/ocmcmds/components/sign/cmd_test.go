@@ -2,6 +2,8 @@ package sign_test
 
 import (
 	"bytes"
+	"crypto"
+	"net/url"
 	"os"
 
 	. "github.com/mandelsoft/goutils/testutils"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
+	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/datacontext"
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/ocm/compdesc"
@@ -22,6 +25,9 @@ import (
 	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
 	"ocm.software/ocm/api/ocm/tools/signing"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/kms"
+	"ocm.software/ocm/api/tech/signing/kms/gcp"
+	"ocm.software/ocm/api/tech/signing/kms/kmstest"
 	"ocm.software/ocm/api/tech/signing/signutils"
 	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/accessobj"
@@ -325,6 +331,103 @@ successfully signed github.com/mandelsoft/ref:v1 (digest SHA-256:3d1bf98adce0632
 		})
 	})
 
+	Context("kms key", func() {
+		const KEY = "projects/ocm/locations/global/keyRings/release/cryptoKeys/signing/cryptoKeyVersions/1"
+
+		var server *kmstest.Server
+		var ref string
+
+		BeforeEach(func() {
+			server = kmstest.NewServer()
+			server.AddKey(KEY, priv.(crypto.Signer), kms.RSA_PKCS1V15)
+			env.CredentialsContext().SetCredentialsForConsumer(gcp.GetConsumerId(server.URL, KEY),
+				credentials.CredentialsFromList(gcp.ATTR_TOKEN, kmstest.TOKEN))
+			ref = "kms://gcp/" + KEY + "?endpoint=" + url.QueryEscape(server.URL)
+
+			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+				env.Component(COMPONENTA, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_TEXT, "testdata")
+						})
+					})
+				})
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("signs with key reference", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.CatchOutput(buf).Execute("sign", "components", "-s", SIGNATURE, "--keyref", ref, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(ContainSubstring("successfully signed github.com/mandelsoft/test:v1"))
+			Expect(server.Requests()).To(Equal(1))
+
+			buf.Reset()
+			MustBeSuccessful(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(ContainSubstring("successfully verified github.com/mandelsoft/test:v1"))
+		})
+
+		It("uses key reference only for named signature", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.CatchOutput(buf).Execute("sign", "components", "-s", SIGNATURE, "-K", PRIVKEY, "--keyref", "other="+ref, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(ContainSubstring("successfully signed github.com/mandelsoft/test:v1"))
+			Expect(server.Requests()).To(Equal(0))
+
+			buf.Reset()
+			MustBeSuccessful(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(ContainSubstring("successfully verified github.com/mandelsoft/test:v1"))
+		})
+
+		It("rejects unsupported algorithm", func() {
+			buf := bytes.NewBuffer(nil)
+			ExpectError(env.CatchOutput(buf).Execute("sign", "components", "-s", SIGNATURE, "-S", "Ed25519", "--keyref", SIGNATURE+"="+ref, "--repo", ARCH, COMPONENTA+":"+VERSION)).
+				To(MatchError(`signature algorithm "Ed25519" not supported for KMS keys`))
+		})
+	})
+
+	Context("countersign", func() {
+		BeforeEach(func() {
+			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+				env.ComponentVersion(COMPONENTA, VERSION, func() {
+					env.Provider(PROVIDER)
+				})
+			})
+		})
+
+		It("countersigns signature", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.CatchOutput(buf).Execute("sign", "components", "-s", SIGNATURE, "-K", PRIVKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+
+			buf.Reset()
+			MustBeSuccessful(env.CatchOutput(buf).Execute("sign", "components", "-s", "approval", "-K", PRIVKEY, "--countersign", SIGNATURE, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(ContainSubstring("successfully signed github.com/mandelsoft/test:v1"))
+
+			buf.Reset()
+			MustBeSuccessful(env.CatchOutput(buf).Execute("verify", "components", "-s", "approval", "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(ContainSubstring("successfully verified github.com/mandelsoft/test:v1"))
+
+			repo := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+			defer Close(repo, "repo")
+			cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+			defer Close(cv, "cv")
+
+			sig := cv.GetDescriptor().Signatures.GetByName("approval")
+			Expect(sig).NotTo(BeNil())
+			Expect(sig.Countersigns).To(Equal(SIGNATURE))
+			Expect(sig.Digest.NormalisationAlgorithm).To(Equal(metav1.CountersignatureNormalisation))
+		})
+
+		It("fails for unknown signature", func() {
+			buf := bytes.NewBuffer(nil)
+			ExpectError(env.CatchOutput(buf).Execute("sign", "components", "-s", "approval", "-K", PRIVKEY, "--countersign", SIGNATURE, "--repo", ARCH, COMPONENTA+":"+VERSION)).
+				To(MatchError(ContainSubstring(`signature "test" not found`)))
+		})
+	})
+
 	It("keyless verification", func() {
 		buf := bytes.NewBuffer(nil)
 
@@ -1,11 +1,15 @@
 package transfer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/maputils"
+	"github.com/mandelsoft/vfs/pkg/layerfs"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/readonlyfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -14,6 +18,7 @@ import (
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/spiff"
 	common "ocm.software/ocm/api/utils/misc"
@@ -22,9 +27,12 @@ import (
 	"ocm.software/ocm/cmds/ocm/commands/common/options/formatoption"
 	ocmcommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/dryrunoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/journaloption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/omitaccesstypeoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/overwriteoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/referrersoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/rscbyvalueoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/scriptoption"
@@ -52,6 +60,7 @@ type Command struct {
 	TargetName          string
 	BOMFile             string
 	DisableBlobHandlers bool
+	Progress            bool
 }
 
 // NewCommand creates a new ctf command.
@@ -68,8 +77,12 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 		srcbyvalueoption.New(),
 		omitaccesstypeoption.New(),
 		stoponexistingoption.New(),
+		journaloption.New(),
 		uploaderoption.New(ctx.OCMContext()),
+		referrersoption.New(),
 		scriptoption.New(),
+		dryrunoption.New("print the transfer plan without modifying the target", false),
+		output.OutputOptions(planOutputs),
 	)}, utils.Names(Names, names...)...)
 }
 
@@ -82,11 +95,26 @@ func (o *Command) ForName(name string) *cobra.Command {
 Transfer all component versions specified to the given target repository.
 If only a component (instead of a component version) is specified all versions
 are transferred.
+
+With the option <code>--dry-run</code> nothing is transferred. Instead,
+the transfer plan is shown: the component versions to be created, overwritten,
+updated or skipped, and the resources and sources to be copied by value,
+together with the blob upload handler used for the target and the estimated
+size.
+
+With the option <code>--progress</code> the transfer progress of the
+resources and sources copied by value is shown. On a terminal, a progress bar
+is shown for every artifact actually transferred, otherwise, progress lines are
+printed for every 25% of an artifact blob. At the end a summary with the
+number of transferred component versions and artifacts, the copied bytes and
+the number of retries is printed.
 `,
 		Example: `
 $ ocm transfer components -t tgz ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.17.0 ./ctf.tgz
 $ ocm transfer components --latest -t tgz --repo OCIRegistry::ghcr.io/open-component-model/ocm ocm.software/ocmcli ./ctf.tgz
 $ ocm transfer components --latest --copy-resources --type directory ghcr.io/open-component-model/ocm//ocm.software/ocmcli ./ctf
+$ ocm transfer components --progress --copy-resources ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.17.0 ./ctf
+$ ocm transfer components --dry-run -o yaml --recursive ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.17.0 ghcr.io/acme/ocm
 `,
 		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
 	}
@@ -96,6 +124,7 @@ func (o *Command) AddFlags(fs *pflag.FlagSet) {
 	o.BaseCommand.AddFlags(fs)
 	fs.StringVarP(&o.BOMFile, "bom-file", "B", "", "file name to write the component version BOM")
 	fs.BoolVarP(&o.DisableBlobHandlers, "disable-uploads", "", false, "disable standard upload handlers for transport")
+	fs.BoolVarP(&o.Progress, "progress", "", false, "show transfer progress of artifacts and a final summary")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -104,6 +133,12 @@ func (o *Command) Complete(args []string) error {
 		return fmt.Errorf("a repository or at least one argument that defines the reference is required")
 	}
 	o.TargetName = args[len(args)-1]
+	if output.From(o).OutputMode != "" && !dryrunoption.From(o).DryRun {
+		return fmt.Errorf("--output only usable for dry-run mode")
+	}
+	if o.Progress && dryrunoption.From(o).DryRun {
+		return fmt.Errorf("--progress not usable for dry-run mode")
+	}
 	return nil
 }
 
@@ -126,7 +161,15 @@ func (o *Command) Run() error {
 		return err
 	}
 
-	target, err := ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), o.Context.FileSystem())
+	var fs vfs.FileSystem = o.Context.FileSystem()
+	dryrun := dryrunoption.From(o).DryRun
+	if dryrun {
+		// the target must not be modified in dry-run mode, therefore
+		// file based targets are accessed via a read-only base with
+		// an in-memory overlay.
+		fs = layerfs.New(memoryfs.New(), readonlyfs.New(fs))
+	}
+	target, err := ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), fs)
 	if err != nil {
 		return err
 	}
@@ -142,14 +185,28 @@ func (o *Command) Run() error {
 		return err
 	}
 	hdlr := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository, comphdlr.OptionsFor(o))
-	err = utils.HandleOutput(&action{
+	if dryrun {
+		return utils.HandleOutput(&planAction{
+			cmd:     o,
+			target:  target,
+			handler: thdlr,
+			closure: transfer.TransportClosure{},
+			plan:    &transfer.Plan{},
+		}, hdlr, utils.StringElemSpecs(o.Refs...)...)
+	}
+	a := &action{
 		cmd:     o,
 		printer: common.NewPrinter(o.Context.StdOut()),
 		target:  target,
 		handler: thdlr,
 		closure: transfer.TransportClosure{},
 		errors:  errors.ErrListf("transfer errors"),
-	}, hdlr, utils.StringElemSpecs(o.Refs...)...)
+	}
+	if o.Progress {
+		a.progress = newProgressRenderer(o.Context.StdOut())
+		a.printer = common.NewPrinter(a.progress)
+	}
+	err = utils.HandleOutput(a, hdlr, utils.StringElemSpecs(o.Refs...)...)
 	if err != nil {
 		return err
 	}
@@ -159,12 +216,13 @@ func (o *Command) Run() error {
 /////////////////////////////////////////////////////////////////////////////
 
 type action struct {
-	cmd     *Command
-	printer common.Printer
-	target  ocm.Repository
-	handler transferhandler.TransferHandler
-	closure transfer.TransportClosure
-	errors  *errors.ErrorList
+	cmd      *Command
+	printer  common.Printer
+	target   ocm.Repository
+	handler  transferhandler.TransferHandler
+	closure  transfer.TransportClosure
+	errors   *errors.ErrorList
+	progress *progressRenderer
 }
 
 var _ output.Output = (*action)(nil)
@@ -178,7 +236,11 @@ func (a *action) Add(e interface{}) error {
 	if err != nil {
 		return errors.Wrapf(err, "cannot transfer component version %s/%s", o.ComponentVersion.GetName(), o.ComponentVersion.GetVersion())
 	}
-	err = transfer.TransferVersion(a.printer, a.closure, sub, a.target, h)
+	ctx := common.WithPrinter(context.Background(), a.printer)
+	if a.progress != nil {
+		ctx = progress.WithHandler(ctx, a.progress)
+	}
+	err = transfer.TransferVersionWithContext(ctx, a.closure, sub, a.target, h)
 	sub.Close()
 	a.errors.Add(err)
 	if err != nil {
@@ -193,6 +255,9 @@ func (a *action) Close() error {
 
 func (a *action) Out() error {
 	a.printer.Printf("%d versions transferred\n", len(a.closure))
+	if a.progress != nil {
+		a.progress.Summary()
+	}
 	if a.errors.Result() != nil {
 		sum := "Error summary:"
 		for _, e := range a.errors.Entries() {
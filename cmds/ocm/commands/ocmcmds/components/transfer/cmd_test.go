@@ -16,7 +16,9 @@ import (
 	"ocm.software/ocm/api/config/extensions/config"
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
+	ocictf "ocm.software/ocm/api/oci/extensions/repositories/ctf"
 	"ocm.software/ocm/api/ocm"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
@@ -24,10 +26,12 @@ import (
 	ctfocm "ocm.software/ocm/api/ocm/extensions/repositories/ctf"
 	ocmutils "ocm.software/ocm/api/ocm/ocmutils"
 	handlercfg "ocm.software/ocm/api/ocm/tools/transfer/transferhandler/config"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
 	"ocm.software/ocm/api/utils"
 	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/accessobj"
 	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/referrersoption"
 )
 
 const (
@@ -204,6 +208,119 @@ transferring version "github.com/mandelsoft/test2:v1"...
 `))
 	})
 
+	It("resumes transfer with journal", func() {
+		JOURNAL := "/tmp/journal.json"
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--journal", JOURNAL, "--copy-resources", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
+		Expect(env.FileExists(JOURNAL)).To(BeTrue())
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--resume", JOURNAL, "--copy-resources", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test2:v1"...
+  version "github.com/mandelsoft/test2:v1" already transferred according to journal -> skip
+1 versions transferred
+`))
+	})
+
+	It("fails resuming transfer without journal", func() {
+		buf := bytes.NewBuffer(nil)
+		ExpectError(env.CatchOutput(buf).Execute("transfer", "components", "--resume", "/tmp/journal.json", ARCH, OUT)).To(MatchError(`transfer journal "/tmp/journal.json" not found`))
+	})
+
+	It("plans transfer with --dry-run", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", "--copy-resources", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+COMPONENT                   VERSION ELEMENT                       ACTION UPLOADER         MESSAGE     SIZE
+github.com/mandelsoft/test2 v1                                    create                           9 bytes
+github.com/mandelsoft/test2 v1      resource otherdate[plainText] copy                             9 bytes
+github.com/mandelsoft/test  v1                                    create                          35 bytes
+github.com/mandelsoft/test  v1      resource testdata[plainText]  copy                             8 bytes
+github.com/mandelsoft/test  v1      resource value[ociImage]      copy   ocm/ociArtifacts         15 bytes
+github.com/mandelsoft/test  v1      resource ref[ociImage]        copy   ocm/ociArtifacts         12 bytes
+2 versions planned, estimated size 44 bytes
+`))
+		Expect(env.FileExists(OUT)).To(BeFalse())
+	})
+
+	It("plans transfer with --dry-run as yaml", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", ARCH, ARCH, OUT)).To(Succeed())
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", "-o", "yaml", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+---
+action: create
+component: github.com/mandelsoft/test2
+resources:
+- accessType: localBlob
+  action: copy
+  identity:
+    name: otherdate
+  kind: resource
+  size: 9
+  type: plainText
+version: v1
+---
+action: skip
+component: github.com/mandelsoft/test
+message: already present
+version: v1
+`))
+	})
+
+	It("keeps existing target untouched with --dry-run", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", ARCH, ARCH, OUT)).To(Succeed())
+		index := Must(env.ReadFile(OUT + "/" + ocictf.ArtifactIndexFileName))
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", "--copy-resources", "--overwrite", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
+		Expect(Must(env.ReadFile(OUT + "/" + ocictf.ArtifactIndexFileName))).To(Equal(index))
+
+		tgt := Must(ctfocm.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt, "target")
+		Expect(Must(tgt.ComponentLister().GetComponents("", true))).To(ConsistOf(COMPONENT))
+	})
+
+	It("enables referrers for the transfer", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--referrer-types", "application/vnd.dev.cosign.artifact.sig.v1+json", ARCH, ARCH, OUT)).To(Succeed())
+		Expect(referrersattr.Get(env.OCMContext())).To(BeNil())
+
+		opts := &standard.Options{}
+		MustBeSuccessful((&referrersoption.Option{ArtifactTypes: []string{"application/vnd.dev.cosign.artifact.sig.v1+json"}}).ApplyTransferOption(opts))
+		Expect(opts.GetReferrers()).To(Equal(&referrersattr.Attribute{ArtifactTypes: []string{"application/vnd.dev.cosign.artifact.sig.v1+json"}}))
+	})
+
+	It("rejects output mode without --dry-run", func() {
+		buf := bytes.NewBuffer(nil)
+		ExpectError(env.CatchOutput(buf).Execute("transfer", "components", "-o", "yaml", ARCH, OUT)).To(MatchError(`--output only usable for dry-run mode`))
+	})
+
+	It("transfers ctf with --progress", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--progress", "--copy-resources", ARCH, ARCH, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0 testdata[plainText]...
+  github.com/mandelsoft/test:v1 resource testdata[plainText]: 100% 8 bytes/8 bytes
+...resource 1 value[ociImage](ocm/value:v2.0)...
+  github.com/mandelsoft/test:v1 resource value[ociImage]: 100% 628 bytes/628 bytes
+...resource 2 ref[ociImage](ocm/ref:v2.0)...
+  github.com/mandelsoft/test:v1 resource ref[ociImage]: 100% 635 bytes/635 bytes
+...adding component version...
+1 versions transferred
+transfer summary:
+  component versions: 1 transferred, 0 failed
+  artifacts:          3 copied, 0 failed (1.24 KiB)
+  retries:            0
+`))
+		Expect(env.DirExists(OUT)).To(BeTrue())
+	})
+
 	It("transfers ctf to tgz with type option", func() {
 		buf := bytes.NewBuffer(nil)
 		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--type", accessio.FormatTGZ.String(), ARCH, ARCH, OUT)).To(Succeed())
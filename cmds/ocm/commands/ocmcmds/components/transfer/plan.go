@@ -0,0 +1,130 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"ocm.software/ocm/cmds/ocm/common/output"
+	"ocm.software/ocm/cmds/ocm/common/processing"
+)
+
+var planOutputs = output.NewOutputs(getPlanRegular).AddManifestOutputs()
+
+// planTable hides the sort fields of the table output, the plan
+// is always shown in transfer order (and the sort flag would
+// conflict with the script file option).
+type planTable struct {
+	output.Output
+}
+
+func getPlanRegular(opts *output.Options) output.Output {
+	return &planTable{output.NewProcessingTableOutput(opts,
+		processing.Explode(explodePlan).Map(mapPlanOutput),
+		"COMPONENT", "VERSION", "ELEMENT", "ACTION", "UPLOADER", "MESSAGE", "-SIZE")}
+}
+
+type planRow struct {
+	version  *transfer.VersionPlan
+	artifact *transfer.ArtifactPlan
+}
+
+func explodePlan(e interface{}) []interface{} {
+	v := e.(output.Manifest).AsManifest().(*transfer.VersionPlan)
+	result := []interface{}{&planRow{version: v}}
+	for _, a := range v.Resources {
+		result = append(result, &planRow{version: v, artifact: a})
+	}
+	for _, a := range v.Sources {
+		result = append(result, &planRow{version: v, artifact: a})
+	}
+	return result
+}
+
+func mapPlanOutput(e interface{}) interface{} {
+	r := e.(*planRow)
+	if r.artifact == nil {
+		return []string{r.version.Component, r.version.Version, "", r.version.Action, "", r.version.Message, sizeString(r.version.Size())}
+	}
+	a := r.artifact
+	name := a.Identity.Get(metav1.SystemIdentityName)
+	if len(a.Identity) > 1 {
+		name = a.Identity.String()
+	}
+	return []string{r.version.Component, r.version.Version,
+		fmt.Sprintf("%s %s[%s]", a.Kind, name, a.Type), a.Action, a.Uploader, a.Message, sizeString(a.Size)}
+}
+
+func sizeString(size int64) string {
+	if size < 0 {
+		return "unknown"
+	}
+	return utils.BytesString(uint64(size), 2)
+}
+
+/////////////////////////////////////////////////////////////////////////////
+
+// planAction determines the transfer plan for the selected
+// component versions without modifying the target repository.
+type planAction struct {
+	cmd     *Command
+	target  ocm.Repository
+	handler transferhandler.TransferHandler
+	closure transfer.TransportClosure
+	plan    *transfer.Plan
+}
+
+var _ output.Output = (*planAction)(nil)
+
+func (a *planAction) Add(e interface{}) error {
+	o, ok := e.(*comphdlr.Object)
+	if !ok {
+		return fmt.Errorf("object of type %T is not a valid comphdlr.Object", e)
+	}
+	sub, h, err := a.handler.TransferVersion(o.Repository, nil, compdesc.NewComponentReference("", o.ComponentVersion.GetName(), o.ComponentVersion.GetVersion(), nil), a.target)
+	if err != nil {
+		return errors.Wrapf(err, "cannot plan transfer of component version %s/%s", o.ComponentVersion.GetName(), o.ComponentVersion.GetVersion())
+	}
+	if sub == nil {
+		return nil
+	}
+	defer sub.Close()
+	plan, err := transfer.PlanVersion(context.Background(), a.closure, sub, a.target, h)
+	if err != nil {
+		return errors.Wrapf(err, "cannot plan transfer of component version %s/%s", o.ComponentVersion.GetName(), o.ComponentVersion.GetVersion())
+	}
+	a.plan.Versions = append(a.plan.Versions, plan.Versions...)
+	return nil
+}
+
+func (a *planAction) Close() error {
+	return nil
+}
+
+func (a *planAction) Out() error {
+	opts := output.From(a.cmd)
+	for _, v := range a.plan.Versions {
+		if err := opts.Output.Add(output.AsManifest(v)); err != nil {
+			return err
+		}
+	}
+	if err := opts.Output.Close(); err != nil {
+		return err
+	}
+	if err := opts.Output.Out(); err != nil {
+		return err
+	}
+	if opts.OutputMode == "" {
+		out.Outf(a.cmd.Context, "%d versions planned, estimated size %s\n", len(a.plan.Versions), sizeString(a.plan.Size()))
+	}
+	return nil
+}
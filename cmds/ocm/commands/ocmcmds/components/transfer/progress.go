@@ -0,0 +1,212 @@
+package transfer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/tools/transfer/progress"
+	"ocm.software/ocm/api/utils"
+)
+
+const (
+	barWidth = 30
+	// unknownSizeStep is the number of bytes between two progress lines
+	// for artifacts with unknown size on a non-terminal output.
+	unknownSizeStep = 64 * utils.MEBIBYTE
+)
+
+// progressRenderer renders the progress events of a transfer.
+// It is used as writer for the regular transfer output, to be able
+// to keep the progress bars of the actually transferred artifacts
+// below the regular output on a terminal.
+// On other outputs, only the start of a transfer, progress milestones
+// (every 25%) and retries are reported as regular lines.
+type progressRenderer struct {
+	lock    sync.Mutex
+	out     io.Writer
+	tty     bool
+	summary *progress.Summary
+	partial []byte
+	bars    []*progressBar
+	drawn   int
+}
+
+type progressBar struct {
+	key    string
+	label  string
+	copied int64
+	total  int64
+	step   int64
+}
+
+var _ progress.Handler = (*progressRenderer)(nil)
+
+func newProgressRenderer(out io.Writer) *progressRenderer {
+	tty := false
+	if f, ok := out.(*os.File); ok {
+		tty = term.IsTerminal(int(f.Fd()))
+	}
+	return &progressRenderer{out: out, tty: tty, summary: progress.NewSummary()}
+}
+
+func (r *progressRenderer) Write(data []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.tty {
+		return r.out.Write(data)
+	}
+	r.partial = append(r.partial, data...)
+	idx := bytes.LastIndexByte(r.partial, '\n')
+	if idx < 0 {
+		return len(data), nil
+	}
+	r.clear()
+	_, err := r.out.Write(r.partial[:idx+1])
+	r.partial = r.partial[idx+1:]
+	r.draw()
+	return len(data), err
+}
+
+func (r *progressRenderer) HandleEvent(e progress.Event) {
+	r.summary.HandleEvent(e)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	switch ev := e.(type) {
+	case *progress.ArtifactStarted:
+		b := &progressBar{key: artifactKey(&ev.ArtifactEvent), label: artifactLabel(&ev.ArtifactEvent), total: ev.Total}
+		b.step = unknownSizeStep
+		if ev.Total > 0 {
+			b.step = (ev.Total + 3) / 4
+		}
+		r.bars = append(r.bars, b)
+		r.update()
+	case *progress.ArtifactProgress:
+		b := r.find(artifactKey(&ev.ArtifactEvent))
+		if b == nil {
+			return
+		}
+		last := b.copied
+		b.copied = ev.Copied
+		b.total = ev.Total
+		if !r.tty && ev.Copied/b.step > last/b.step {
+			r.printf("  %s\n", b)
+		}
+		r.update()
+	case *progress.ArtifactFinished:
+		key := artifactKey(&ev.ArtifactEvent)
+		for i, b := range r.bars {
+			if b.key == key {
+				r.bars = append(r.bars[:i], r.bars[i+1:]...)
+				break
+			}
+		}
+		if ev.Error != nil {
+			r.printf("  %s failed: %s\n", artifactLabel(&ev.ArtifactEvent), ev.Error)
+		} else {
+			r.update()
+		}
+	case *progress.Retry:
+		if b := r.find(artifactKey(&ev.ArtifactEvent)); b != nil {
+			b.copied = 0
+		}
+		r.printf("  %s: retry %d after error: %s\n", artifactLabel(&ev.ArtifactEvent), ev.Attempt, ev.Error)
+	}
+}
+
+// Summary prints the final summary of the transfer.
+func (r *progressRenderer) Summary() {
+	s := r.summary.Get()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.bars = nil
+	r.printf("transfer summary:\n")
+	r.printf("  component versions: %d transferred, %d failed\n", s.Versions, s.FailedVersions)
+	r.printf("  artifacts:          %d copied, %d failed (%s)\n", s.Artifacts, s.FailedArtifacts, utils.BytesString(uint64(s.Bytes), 2))
+	r.printf("  retries:            %d\n", s.Retries)
+}
+
+func (r *progressRenderer) find(key string) *progressBar {
+	for _, b := range r.bars {
+		if b.key == key {
+			return b
+		}
+	}
+	return nil
+}
+
+// printf prints a regular line, keeping the progress bars below.
+func (r *progressRenderer) printf(msg string, args ...interface{}) {
+	r.clear()
+	fmt.Fprintf(r.out, msg, args...)
+	r.draw()
+}
+
+// update redraws the progress bars on a terminal.
+func (r *progressRenderer) update() {
+	if r.tty {
+		r.clear()
+		r.draw()
+	}
+}
+
+func (r *progressRenderer) clear() {
+	if r.tty && r.drawn > 0 {
+		fmt.Fprintf(r.out, "\x1b[%dA\x1b[J", r.drawn)
+		r.drawn = 0
+	}
+}
+
+func (r *progressRenderer) draw() {
+	if !r.tty || len(r.partial) > 0 {
+		return
+	}
+	for _, b := range r.bars {
+		fmt.Fprintf(r.out, "%s\n", b.Bar())
+	}
+	r.drawn = len(r.bars)
+}
+
+func (b *progressBar) Bar() string {
+	if b.total <= 0 {
+		return fmt.Sprintf("%s [%s] %s", b.label, strings.Repeat("?", barWidth), utils.BytesString(uint64(b.copied), 2))
+	}
+	n := int(b.copied * barWidth / b.total)
+	if n > barWidth {
+		n = barWidth
+	}
+	return fmt.Sprintf("%s [%s%s] %s", b.label, strings.Repeat("=", n), strings.Repeat(" ", barWidth-n), b.amount())
+}
+
+func (b *progressBar) String() string {
+	return fmt.Sprintf("%s: %s", b.label, b.amount())
+}
+
+func (b *progressBar) amount() string {
+	if b.total <= 0 {
+		return utils.BytesString(uint64(b.copied), 2)
+	}
+	return fmt.Sprintf("%3d%% %s/%s", b.copied*100/b.total, utils.BytesString(uint64(b.copied), 2), utils.BytesString(uint64(b.total), 2))
+}
+
+func artifactKey(e *progress.ArtifactEvent) string {
+	return fmt.Sprintf("%s/%s/%d", e.GetComponentVersion(), e.Kind, e.Index)
+}
+
+func artifactLabel(e *progress.ArtifactEvent) string {
+	name := e.Identity.Get(metav1.SystemIdentityName)
+	if len(e.Identity) > 1 {
+		name = e.Identity.String()
+	}
+	return fmt.Sprintf("%s %s %s[%s]", e.GetComponentVersion(), e.Kind, name, e.Type)
+}
@@ -214,6 +214,169 @@ successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
 			CheckStore(store, common.NewNameVersion(COMPONENTA, VERSION))
 			CheckStore(store, common.NewNameVersion(COMPONENTB, VERSION))
 		})
+
+		It("verifies incrementally", func() {
+			Prepare()
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--incremental", "--verified", VERIFIED_FILE, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1]
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1]
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1]
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:${test}[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:${rb0}[genericBlobDigest/v1]
+successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
+`, substitutions))
+
+			buf.Reset()
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--incremental", "--verified", VERIFIED_FILE, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1] (already verified)
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1] (already verified)
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1] (already verified)
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:${test}[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:${rb0}[genericBlobDigest/v1] (already verified)
+successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
+`, substitutions))
+		})
+
+		It("ignores recorded descriptors with other digest", func() {
+			Prepare()
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--incremental", "--verified", VERIFIED_FILE, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+
+			store := Must(NewVerifiedStore(VERIFIED_FILE, env.FileSystem()))
+			cd := store.Get(common.NewNameVersion(COMPONENTA, VERSION))
+			Expect(cd).NotTo(BeNil())
+			cd.Provider.Name = "acme.org"
+			MustBeSuccessful(store.Save())
+
+			buf.Reset()
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--incremental", "--verified", VERIFIED_FILE, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1]
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1]
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1]
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:${test}[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:${rb0}[genericBlobDigest/v1] (already verified)
+successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
+`, substitutions))
+		})
+
+		It("re-digests resources with changed access", func() {
+			Prepare()
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--incremental", "--verified", VERIFIED_FILE, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+
+			store := Must(NewVerifiedStore(VERIFIED_FILE, env.FileSystem()))
+			cd := store.Get(common.NewNameVersion(COMPONENTA, VERSION))
+			Expect(cd).NotTo(BeNil())
+			cd.Resources[2].Access = ociartifact.New(oci.StandardOCIRef(OCIHOST+".alias", OCINAMESPACE, OCIVERSION))
+			MustBeSuccessful(store.Save())
+
+			buf.Reset()
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--incremental", "--verified", VERIFIED_FILE, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1] (already verified)
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1] (already verified)
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1]
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:${test}[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:${rb0}[genericBlobDigest/v1] (already verified)
+successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
+`, substitutions))
+		})
+	})
+
+	Context("verification policy", func() {
+		const POLICY = "/tmp/policy.yaml"
+
+		It("reports satisfied rules", func() {
+			Prepare()
+
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), POLICY, []byte(`
+rules:
+- name: signed
+  components:
+  - github.com/mandelsoft/ref
+  signatures:
+  - test
+`), os.ModePerm))
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--policy", POLICY, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1]
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1]
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1]
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:${test}[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:${rb0}[genericBlobDigest/v1]
+  policy rule "signed": satisfied
+successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
+`, substitutions))
+		})
+
+		It("reports violated rules", func() {
+			Prepare()
+
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), POLICY, []byte(`
+rules:
+- name: two of three
+  components:
+  - github.com/mandelsoft/ref
+  signatures:
+  - test
+  - other
+  - third
+  required: 2
+`), os.ModePerm))
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--policy", POLICY, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(MatchError(ContainSubstring(`rule "two of three": 1 of 2 required signatures valid`)))
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1]
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1]
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1]
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:${test}[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:${rb0}[genericBlobDigest/v1]
+  policy rule "two of three": violated: 1 of 2 required signatures valid
+failed verifying signature of github.com/mandelsoft/ref:v1: github.com/mandelsoft/ref:v1: verification policy violated for github.com/mandelsoft/ref:v1: rule "two of three": 1 of 2 required signatures valid
+finished with 1 error(s)
+`, substitutions))
+		})
+
+		It("rejects invalid policies", func() {
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), POLICY, []byte(`
+rules:
+- name: invalid
+  signatures:
+  - test
+  required: 2
+`), os.ModePerm))
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--policy", POLICY, "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(MatchError(ContainSubstring("required signature count 2 exceeds number of signatures 1")))
+		})
 	})
 })
 
@@ -18,6 +18,7 @@ import (
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/omitaccesstypeoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/overwriteoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/referrersoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/rscbyvalueoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/scriptoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/skipupdateoption"
@@ -55,6 +56,7 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 		omitaccesstypeoption.New(),
 		stoponexistingoption.New(),
 		uploaderoption.New(ctx.OCMContext()),
+		referrersoption.New(),
 		scriptoption.New(),
 	)}, utils.Names(Names, names...)...)
 }
@@ -53,6 +53,32 @@ set pubsub spec "test" for repository "ctf"
 		Expect(raw).To(YAMLEqual(spec))
 	})
 
+	It("sets webhook pubsub", func() {
+		var buf bytes.Buffer
+
+		spec := `{"type":"webhook","url":"https://hooks.acme.org/ocm"}`
+		MustBeSuccessful(env.CatchOutput(&buf).Execute("set", "pubsub", ARCH, spec))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+set pubsub spec "webhook" for repository "ctf"
+`))
+
+		repo := Must(ctf.Open(env, ctf.ACC_WRITABLE, ARCH, 0o600, env))
+		defer Close(repo)
+		raw := Must(pubsub.SpecForRepo(repo))
+		Expect(raw).To(YAMLEqual(spec))
+		Expect(raw.Describe(env.OCMContext())).To(Equal("webhook https://hooks.acme.org/ocm"))
+	})
+
+	It("sets directory pubsub", func() {
+		var buf bytes.Buffer
+
+		spec := `{"type":"directory","path":"/var/spool/ocm"}`
+		MustBeSuccessful(env.CatchOutput(&buf).Execute("set", "pubsub", ARCH, spec))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+set pubsub spec "directory" for repository "ctf"
+`))
+	})
+
 	It("removes pubsub for non-existing", func() {
 		var buf bytes.Buffer
 
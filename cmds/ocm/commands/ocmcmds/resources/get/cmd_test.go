@@ -111,6 +111,72 @@ COMPONENT    NAME     VERSION IDENTITY TYPE      RELATION
 		})
 	})
 
+	Context("template output", func() {
+		BeforeEach(func() {
+			env.ComponentArchive(ARCH, accessio.FormatDirectory, COMP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "testdata")
+				})
+				env.Resource("moredata", "", "PlainText", metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "moredata")
+				})
+			})
+		})
+
+		It("lists resources with jsonpath", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("get", "resources", ARCH, "-o", "jsonpath={.items[*].element.name}")).To(Succeed())
+			Expect(buf.String()).To(Equal("testdata moredata\n"))
+		})
+
+		It("lists resources with jsonpath without braces", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("get", "resources", ARCH, "-o", "jsonpath=.items[0].context[0]")).To(Succeed())
+			Expect(buf.String()).To(Equal("test.de/x:v1\n"))
+		})
+
+		It("lists resources with go template", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("get", "resources", ARCH, "-o", `go-template={{range .items}}{{.element.name}}:{{.element.access.type | upper}}{{"\n"}}{{end}}`)).To(Succeed())
+			Expect(buf.String()).To(Equal("testdata:LOCALBLOB\nmoredata:LOCALBLOB\n"))
+		})
+
+		It("lists resources with custom columns", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("get", "resources", ARCH, "-o", "custom-columns=NAME:.element.name,TYPE:.element.type,LABELS:.element.labels")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(
+				`
+NAME     TYPE      LABELS
+testdata PlainText <none>
+moredata PlainText <none>
+`))
+		})
+
+		It("lists resources with custom columns using unions", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("get", "resources", ARCH, "-o", "custom-columns=NAME:.element.name,INFO:.element['type','relation']")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(
+				`
+NAME     INFO
+testdata PlainText local
+moredata PlainText local
+`))
+		})
+
+		It("rejects missing template", func() {
+			ExpectError(env.Execute("get", "resources", ARCH, "-o", "jsonpath")).To(MatchError(`output mode "jsonpath": "template" required`))
+		})
+
+		It("rejects invalid column specification", func() {
+			ExpectError(env.Execute("get", "resources", ARCH, "-o", "custom-columns=NAME")).To(MatchError(`output mode "custom-columns": column specification "NAME" is invalid`))
+		})
+
+		It("rejects parameter for regular output mode", func() {
+			ExpectError(env.Execute("get", "resources", ARCH, "-o", "yaml=x")).To(MatchError(`output mode "yaml" does not accept a parameter`))
+		})
+	})
+
 	Context("ctf", func() {
 		It("lists single resource in ctf file", func() {
 			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
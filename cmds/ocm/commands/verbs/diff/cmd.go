@@ -0,0 +1,19 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	components "ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/diff"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+// NewCommand creates a new command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "compare elements in OCM repositories",
+	}, verbs.Diff)
+	cmd.AddCommand(components.NewCommand(ctx))
+	return cmd
+}
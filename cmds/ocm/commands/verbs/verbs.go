@@ -5,6 +5,7 @@ const (
 	Set       = "set"
 	List      = "list"
 	Check     = "check"
+	Diff      = "diff"
 	Describe  = "describe"
 	Hash      = "hash"
 	Add       = "add"
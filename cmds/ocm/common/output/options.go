@@ -42,8 +42,11 @@ type Options struct {
 	allColumns bool
 	sort       []string
 
-	Outputs          Outputs
-	OutputMode       string
+	Outputs    Outputs
+	OutputMode string
+	// OutputParameter is the parameter given for a parameterized
+	// output mode (<mode>=<parameter>).
+	OutputParameter  string
 	Output           Output
 	Sort             []string
 	StatusCheck      StatusCheckFunction
@@ -151,11 +154,24 @@ func (o *Options) Configure(ctx clictx.Context) error {
 		return err
 	}
 
+	if o.Outputs[o.OutputMode] == nil {
+		if mode, param, ok := strings.Cut(o.OutputMode, "="); ok {
+			o.OutputMode = mode
+			o.OutputParameter = param
+		}
+	}
 	if f := o.Outputs[o.OutputMode]; f == nil {
 		return errors.ErrInvalid("output mode", o.OutputMode)
 	} else {
 		o.Output = f(o)
 	}
+	if p, ok := o.Output.(ParameterizedOutput); ok {
+		if err := p.SetParameter(o.OutputParameter); err != nil {
+			return errors.Wrapf(err, "output mode %q", o.OutputMode)
+		}
+	} else if o.OutputParameter != "" {
+		return errors.Newf("output mode %q does not accept a parameter", o.OutputMode)
+	}
 
 	var avail sliceutils.OrderedSlice[string]
 
@@ -201,6 +217,24 @@ func (o *Options) Usage() string {
 With the option <code>--output</code> the output mode can be selected.
 The following modes are supported:
 ` + listformat.FormatList(o.OutputMode, utils.StringMapKeys(o.Outputs)...)
+		if o.Outputs[MODE_JSONPATH] != nil {
+			s += `
+The modes <code>` + MODE_JSONPATH + `</code>, <code>` + MODE_GOTEMPLATE + `</code> and
+<code>` + MODE_CUSTOM_COLUMNS + `</code> require a parameter given with the
+output mode (<code>&lt;mode>=&lt;parameter></code>):
+- <code>` + MODE_JSONPATH + `=&lt;expression></code>: a JSONPath template
+  (like <code>{.items[*].element.name}</code>)
+- <code>` + MODE_GOTEMPLATE + `=&lt;template></code>: a Go template
+  (like <code>{{range .items}}{{.element.name}} {{end}}</code>)
+- <code>` + MODE_CUSTOM_COLUMNS + `=&lt;header>:&lt;expression>,...</code>: a table
+  with columns described by JSONPath expressions evaluated for every element
+  (like <code>NAME:.element.name</code>)
+
+The templates are evaluated against the same elements shown by the
+<code>json</code> output mode. JSONPath and Go templates work on the complete
+list (field <code>items</code>).
+`
+		}
 	}
 	return s
 }
@@ -285,7 +285,7 @@ func (this Outputs) AddManifestOutputs() Outputs {
 	this["JSON"] = func(opts *Options) Output {
 		return &JSONOutput{NewManifestOutput(opts), false}
 	}
-	return this
+	return this.addTemplateOutputs(func(*Options) processing.ProcessChain { return nil })
 }
 
 func (this Outputs) AddChainedManifestOutputs(chain ChainFunction) Outputs {
@@ -298,6 +298,22 @@ func (this Outputs) AddChainedManifestOutputs(chain ChainFunction) Outputs {
 	this["JSON"] = func(opts *Options) Output {
 		return NewProcessingJSONOutput(opts, chain(opts), false)
 	}
+	return this.addTemplateOutputs(chain)
+}
+
+// addTemplateOutputs adds the parameterized output modes
+// evaluating templates against the elements serialized
+// by the manifest outputs.
+func (this Outputs) addTemplateOutputs(chain ChainFunction) Outputs {
+	this[MODE_JSONPATH] = func(opts *Options) Output {
+		return NewProcessingJSONPathOutput(opts, chain(opts))
+	}
+	this[MODE_GOTEMPLATE] = func(opts *Options) Output {
+		return NewProcessingGoTemplateOutput(opts, chain(opts))
+	}
+	this[MODE_CUSTOM_COLUMNS] = func(opts *Options) Output {
+		return NewProcessingCustomColumnsOutput(opts, chain(opts))
+	}
 	return this
 }
 
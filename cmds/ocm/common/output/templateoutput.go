@@ -0,0 +1,242 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/mandelsoft/goutils/errors"
+	"k8s.io/client-go/util/jsonpath"
+
+	"ocm.software/ocm/cmds/ocm/common/processing"
+)
+
+const (
+	MODE_JSONPATH       = "jsonpath"
+	MODE_GOTEMPLATE     = "go-template"
+	MODE_CUSTOM_COLUMNS = "custom-columns"
+)
+
+// ParameterizedOutput is an optional interface for outputs
+// configured by a parameter given together with the output
+// mode (<mode>=<parameter>).
+type ParameterizedOutput interface {
+	Output
+	SetParameter(p string) error
+}
+
+// Generic provides the generic (JSON) representation of an element
+// as it is serialized by the json and yaml outputs.
+func Generic(elem interface{}) (interface{}, error) {
+	if m, ok := elem.(Manifest); ok {
+		elem = m.AsManifest()
+	}
+	data, err := json.Marshal(elem)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	if err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// TemplateFunction executes a template for the given generic
+// data and writes the result to the given buffer.
+type TemplateFunction func(buf *bytes.Buffer, data interface{}) error
+
+// TemplateProcessingOutput evaluates a template against the
+// list of elements (field items), like it is serialized by the
+// json output.
+type TemplateProcessingOutput struct {
+	ElementOutput
+	parse    func(p string) (TemplateFunction, error)
+	template TemplateFunction
+}
+
+var _ ParameterizedOutput = (*TemplateProcessingOutput)(nil)
+
+func NewProcessingJSONPathOutput(opts *Options, chain processing.ProcessChain) *TemplateProcessingOutput {
+	return (&TemplateProcessingOutput{}).new(opts, chain, parseJSONPath)
+}
+
+func NewProcessingGoTemplateOutput(opts *Options, chain processing.ProcessChain) *TemplateProcessingOutput {
+	return (&TemplateProcessingOutput{}).new(opts, chain, parseGoTemplate)
+}
+
+func (this *TemplateProcessingOutput) new(opts *Options, chain processing.ProcessChain, parse func(p string) (TemplateFunction, error)) *TemplateProcessingOutput {
+	this.ElementOutput.new(opts, chain)
+	this.parse = parse
+	return this
+}
+
+func (this *TemplateProcessingOutput) SetParameter(p string) error {
+	if p == "" {
+		return errors.ErrRequired("template")
+	}
+	t, err := this.parse(p)
+	if err != nil {
+		return err
+	}
+	this.template = t
+	return nil
+}
+
+func (this *TemplateProcessingOutput) Out() error {
+	items := []interface{}{}
+	i := this.Elems.Iterator()
+	for i.HasNext() {
+		elem, err := Generic(i.Next())
+		if err != nil {
+			return err
+		}
+		items = append(items, elem)
+	}
+	var buf bytes.Buffer
+	err := this.template(&buf, map[string]interface{}{"items": items})
+	if err != nil {
+		return err
+	}
+	if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	this.Write(buf.Bytes())
+	return this.ElementOutput.Out()
+}
+
+func parseJSONPath(p string) (TemplateFunction, error) {
+	if !strings.Contains(p, "{") {
+		p = "{" + p + "}"
+	}
+	t := jsonpath.New("output").AllowMissingKeys(true)
+	err := t.Parse(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid jsonpath expression")
+	}
+	return func(buf *bytes.Buffer, data interface{}) error {
+		return t.Execute(buf, data)
+	}, nil
+}
+
+func parseGoTemplate(p string) (TemplateFunction, error) {
+	t, err := template.New("output").Funcs(sprig.HermeticTxtFuncMap()).Parse(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid go template")
+	}
+	return func(buf *bytes.Buffer, data interface{}) error {
+		return t.Execute(buf, data)
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type column struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// CustomColumnsProcessingOutput provides a table with columns
+// described by jsonpath expressions evaluated for every element
+// (<header>:<expression>,...).
+type CustomColumnsProcessingOutput struct {
+	ElementOutput
+	columns []column
+}
+
+var _ ParameterizedOutput = (*CustomColumnsProcessingOutput)(nil)
+
+func NewProcessingCustomColumnsOutput(opts *Options, chain processing.ProcessChain) *CustomColumnsProcessingOutput {
+	return (&CustomColumnsProcessingOutput{}).new(opts, chain)
+}
+
+func (this *CustomColumnsProcessingOutput) new(opts *Options, chain processing.ProcessChain) *CustomColumnsProcessingOutput {
+	this.ElementOutput.new(opts, chain)
+	return this
+}
+
+func (this *CustomColumnsProcessingOutput) SetParameter(p string) error {
+	if p == "" {
+		return errors.ErrRequired("column specification")
+	}
+	this.columns = nil
+	for _, spec := range splitColumns(p) {
+		header, expr, ok := strings.Cut(spec, ":")
+		if !ok || header == "" || expr == "" {
+			return errors.ErrInvalid("column specification", spec)
+		}
+		if !strings.Contains(expr, "{") {
+			expr = "{" + expr + "}"
+		}
+		path := jsonpath.New(header).AllowMissingKeys(true)
+		err := path.Parse(expr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid jsonpath expression for column %q", header)
+		}
+		this.columns = append(this.columns, column{header, path})
+	}
+	return nil
+}
+
+// splitColumns splits a column specification at commas, which are
+// not part of a quoted string or enclosed in brackets or braces of
+// a jsonpath expression.
+func splitColumns(p string) []string {
+	var specs []string
+	var quote rune
+	depth := 0
+	start := 0
+	for i, c := range p {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth <= 0:
+			specs = append(specs, p[start:i])
+			start = i + 1
+		}
+	}
+	return append(specs, p[start:])
+}
+
+func (this *CustomColumnsProcessingOutput) Out() error {
+	var header []string
+	for _, c := range this.columns {
+		header = append(header, c.header)
+	}
+	lines := [][]string{header}
+
+	i := this.Elems.Iterator()
+	for i.HasNext() {
+		elem, err := Generic(i.Next())
+		if err != nil {
+			return err
+		}
+		var line []string
+		for _, c := range this.columns {
+			var buf bytes.Buffer
+			err := c.path.Execute(&buf, elem)
+			if err != nil {
+				return errors.Wrapf(err, "column %q", c.header)
+			}
+			v := buf.String()
+			if v == "" {
+				v = "<none>"
+			}
+			line = append(line, v)
+		}
+		lines = append(lines, line)
+	}
+	FormatTable(this.Context, "", lines)
+	return this.ElementOutput.Out()
+}